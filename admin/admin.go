@@ -5,29 +5,58 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/rockholla/go-google-lib/admin/calls"
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	dirv1 "google.golang.org/api/admin/directory/v1"
 	"google.golang.org/api/option"
 )
 
+// service is the name of the library in the events it emits
+const service = "admin"
+
 // Interface represents functionality for Admin
 type Interface interface {
 	Initialize(credentialsJSON string, domain string, adminUsername string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentialsJSON string, domain string, adminUsername string, log logger.Interface) error
 	EnsureGroup(name string, description string) (*dirv1.Group, error)
+	EnsureGroupCtx(ctx context.Context, name string, description string) (*dirv1.Group, error)
 	DeleteGroup(name string) error
+	DeleteGroupCtx(ctx context.Context, name string) error
 	EnsureMembership(group string, member string) (*dirv1.Member, error)
+	EnsureMembershipCtx(ctx context.Context, group string, member string) (*dirv1.Member, error)
 	DeleteMembership(group string, member string) error
+	DeleteMembershipCtx(ctx context.Context, group string, member string) error
 }
 
 // Admin wraps google-provided apis for interacting with google.golang.org/api/admin/*
 type Admin struct {
-	log    logger.Interface
-	DirV1  *dirv1.Service
-	Calls  *Calls
+	log   logger.Interface
+	DirV1 *dirv1.Service
+	Calls *Calls
+	Retry *retry.Policy
+	// ClientOptions are applied when constructing the underlying api client, though its credentials are always the
+	// domain-wide delegated ones provided to Initialize
+	ClientOptions []option.ClientOption
+	// Transport is the base http transport api requests, and the token requests for the delegated credentials, are
+	// sent through, e.g. a recorder.Recorder to record or replay them
+	Transport http.RoundTripper
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
 	domain string
 }
 
@@ -44,17 +73,24 @@ type Calls struct {
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (a *Admin) Initialize(credentialsJSON string, domain string, adminUsername string, log logger.Interface) error {
+	return a.InitializeCtx(context.Background(), credentialsJSON, domain, adminUsername, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (a *Admin) InitializeCtx(ctx context.Context, credentialsJSON string, domain string, adminUsername string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	a.log = log
+	if a.Retry == nil {
+		a.Retry = retry.DefaultPolicy()
+	}
 	a.Calls = &Calls{
-		GroupsInsert:  &calls.GroupsInsertCall{},
-		GroupsUpdate:  &calls.GroupsUpdateCall{},
-		GroupsGet:     &calls.GroupsGetCall{},
-		GroupsDelete:  &calls.GroupsDeleteCall{},
-		MembersGet:    &calls.MembersGetCall{},
-		MembersInsert: &calls.MembersInsertCall{},
-		MembersDelete: &calls.MembersDeleteCall{},
+		GroupsInsert:  &calls.GroupsInsertCall{Retry: a.Retry, Telemetry: a.Telemetry},
+		GroupsUpdate:  &calls.GroupsUpdateCall{Retry: a.Retry, Telemetry: a.Telemetry},
+		GroupsGet:     &calls.GroupsGetCall{Retry: a.Retry, Telemetry: a.Telemetry},
+		GroupsDelete:  &calls.GroupsDeleteCall{Retry: a.Retry, Telemetry: a.Telemetry},
+		MembersGet:    &calls.MembersGetCall{Retry: a.Retry, Telemetry: a.Telemetry},
+		MembersInsert: &calls.MembersInsertCall{Retry: a.Retry, Telemetry: a.Telemetry},
+		MembersDelete: &calls.MembersDeleteCall{Retry: a.Retry, Telemetry: a.Telemetry},
 	}
 	a.domain = domain
 	if credentialsJSON == "" {
@@ -66,8 +102,12 @@ func (a *Admin) Initialize(credentialsJSON string, domain string, adminUsername
 	}
 	config.Subject = fmt.Sprintf("%s@%s", adminUsername, domain)
 	a.log.Info("For Google admin and directory operations: impersonating %s", config.Subject)
+	if a.Transport != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: a.Transport})
+	}
 	client := config.Client(ctx)
-	if a.DirV1, err = dirv1.NewService(ctx, option.WithHTTPClient(client)); err != nil {
+	clientOptions := append([]option.ClientOption{}, a.ClientOptions...)
+	if a.DirV1, err = dirv1.NewService(ctx, append(clientOptions, option.WithHTTPClient(client))...); err != nil {
 		return err
 	}
 	return nil
@@ -75,7 +115,12 @@ func (a *Admin) Initialize(credentialsJSON string, domain string, adminUsername
 
 // EnsureGroup will make sure that a particular group exists in Google admin
 func (a *Admin) EnsureGroup(name string, description string) (*dirv1.Group, error) {
-	ctx := context.Background()
+	return a.EnsureGroupCtx(context.Background(), name, description)
+}
+
+// EnsureGroupCtx is EnsureGroup, using the provided context for the underlying api calls
+func (a *Admin) EnsureGroupCtx(ctx context.Context, name string, description string) (group *dirv1.Group, err error) {
+	defer events.Start(a.Events, service, "EnsureGroup", events.ActionEnsure, "", name).Done(&err)
 	email := name
 	if !strings.Contains(email, "@") {
 		email = fmt.Sprintf("%s@%s", name, a.domain)
@@ -88,23 +133,32 @@ func (a *Admin) EnsureGroup(name string, description string) (*dirv1.Group, erro
 	a.log.InfoPart("Ensuring that Google group %s exists...", email)
 	groupsService := dirv1.NewGroupsService(a.DirV1)
 	groupsGetCall := groupsService.Get(email).Context(ctx)
-	existingGroup, err := a.Calls.GroupsGet.Do(groupsGetCall)
-	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "notfound") {
+	existingGroup, err := a.Calls.GroupsGet.Do(telemetry.WithResource(ctx, "", name), groupsGetCall)
+	if err != nil && !googleerrors.IsNotFound(err) {
 		a.log.InfoPart("error\n")
 		return nil, err
 	}
+	change := plan.Change{Service: service, Method: "EnsureGroup", Resource: email, Action: plan.ActionCreate, After: apiGroup}
+	if existingGroup != nil {
+		change.Action = plan.ActionUpdate
+		change.Before = existingGroup
+	}
+	if a.DryRun.Record(change) {
+		a.log.InfoPart("would be %sd\n", change.Action)
+		return apiGroup, nil
+	}
 	if existingGroup == nil {
 		a.log.InfoPart("creating...")
-		groupsInsertCall := groupsService.Insert(apiGroup)
-		_, err = a.Calls.GroupsInsert.Do(groupsInsertCall)
+		groupsInsertCall := groupsService.Insert(apiGroup).Context(ctx)
+		_, err = a.Calls.GroupsInsert.Do(telemetry.WithResource(ctx, "", name), groupsInsertCall)
 		if err != nil {
 			a.log.InfoPart("error\n")
 			return nil, err
 		}
 	} else {
 		a.log.InfoPart("updating...")
-		groupsUpdateCall := groupsService.Update(email, apiGroup)
-		_, err = a.Calls.GroupsUpdate.Do(groupsUpdateCall)
+		groupsUpdateCall := groupsService.Update(email, apiGroup).Context(ctx)
+		_, err = a.Calls.GroupsUpdate.Do(telemetry.WithResource(ctx, "", name), groupsUpdateCall)
 		if err != nil {
 			a.log.InfoPart("error\n")
 			return nil, err
@@ -116,7 +170,12 @@ func (a *Admin) EnsureGroup(name string, description string) (*dirv1.Group, erro
 
 // EnsureMembership will make sure that a member is part of a group in Google admin
 func (a *Admin) EnsureMembership(group string, member string) (*dirv1.Member, error) {
-	ctx := context.Background()
+	return a.EnsureMembershipCtx(context.Background(), group, member)
+}
+
+// EnsureMembershipCtx is EnsureMembership, using the provided context for the underlying api calls
+func (a *Admin) EnsureMembershipCtx(ctx context.Context, group string, member string) (membership *dirv1.Member, err error) {
+	defer events.Start(a.Events, service, "EnsureMembership", events.ActionEnsure, "", group).Done(&err)
 	groupEmail := group
 	if !strings.Contains(groupEmail, "@") {
 		groupEmail = fmt.Sprintf("%s@%s", group, a.domain)
@@ -128,17 +187,22 @@ func (a *Admin) EnsureMembership(group string, member string) (*dirv1.Member, er
 	a.log.InfoPart("Ensuring that %s is a member of Google group %s...", memberEmail, groupEmail)
 	membersService := dirv1.NewMembersService(a.DirV1)
 	membersGetCall := membersService.Get(groupEmail, memberEmail).Context(ctx)
-	existingMember, err := a.Calls.MembersGet.Do(membersGetCall)
-	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "notfound") {
+	existingMember, err := a.Calls.MembersGet.Do(telemetry.WithResource(ctx, "", group), membersGetCall)
+	if err != nil && !googleerrors.IsNotFound(err) {
 		a.log.InfoPart("error\n")
 		return nil, err
 	}
 	if existingMember == nil {
-		a.log.InfoPart("adding...")
-		membersInsertCall := membersService.Insert(groupEmail, &dirv1.Member{
+		newMember := &dirv1.Member{
 			Email: memberEmail,
-		}).Context(ctx)
-		newMember, err := a.Calls.MembersInsert.Do(membersInsertCall)
+		}
+		if a.DryRun.Record(plan.Change{Service: service, Method: "EnsureMembership", Resource: groupEmail + "/" + memberEmail, Action: plan.ActionCreate, After: newMember}) {
+			a.log.InfoPart("would be added\n")
+			return newMember, nil
+		}
+		a.log.InfoPart("adding...")
+		membersInsertCall := membersService.Insert(groupEmail, newMember).Context(ctx)
+		newMember, err := a.Calls.MembersInsert.Do(telemetry.WithResource(ctx, "", group), membersInsertCall)
 		if err != nil {
 			a.log.InfoPart("error\n")
 			return nil, err
@@ -152,16 +216,33 @@ func (a *Admin) EnsureMembership(group string, member string) (*dirv1.Member, er
 
 // DeleteGroup will delete a Google group
 func (a *Admin) DeleteGroup(name string) error {
-	ctx := context.Background()
+	return a.DeleteGroupCtx(context.Background(), name)
+}
+
+// DeleteGroupCtx is DeleteGroup, using the provided context for the underlying api calls
+func (a *Admin) DeleteGroupCtx(ctx context.Context, name string) (err error) {
+	defer events.Start(a.Events, service, "DeleteGroup", events.ActionDelete, "", name).Done(&err)
 	email := name
 	if !strings.Contains(email, "@") {
 		email = fmt.Sprintf("%s@%s", name, a.domain)
 	}
 	a.log.Info("Ensuring that group %s is deleted", email)
 	groupsService := dirv1.NewGroupsService(a.DirV1)
+	if a.DryRun != nil {
+		groupsGetCall := groupsService.Get(email).Context(ctx)
+		existingGroup, err := a.Calls.GroupsGet.Do(telemetry.WithResource(ctx, "", name), groupsGetCall)
+		if err != nil {
+			if googleerrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		a.DryRun.Record(plan.Change{Service: service, Method: "DeleteGroup", Resource: email, Action: plan.ActionDelete, Before: existingGroup})
+		return nil
+	}
 	groupsDeleteCall := groupsService.Delete(email).Context(ctx)
-	err := a.Calls.GroupsDelete.Do(groupsDeleteCall)
-	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "notfound") {
+	err = a.Calls.GroupsDelete.Do(telemetry.WithResource(ctx, "", name), groupsDeleteCall)
+	if err != nil && !googleerrors.IsNotFound(err) {
 		return err
 	}
 	return nil
@@ -169,7 +250,12 @@ func (a *Admin) DeleteGroup(name string) error {
 
 // DeleteMembership will remove a member from a Google group
 func (a *Admin) DeleteMembership(group string, member string) error {
-	ctx := context.Background()
+	return a.DeleteMembershipCtx(context.Background(), group, member)
+}
+
+// DeleteMembershipCtx is DeleteMembership, using the provided context for the underlying api calls
+func (a *Admin) DeleteMembershipCtx(ctx context.Context, group string, member string) (err error) {
+	defer events.Start(a.Events, service, "DeleteMembership", events.ActionDelete, "", group).Done(&err)
 	groupEmail := group
 	if !strings.Contains(groupEmail, "@") {
 		groupEmail = fmt.Sprintf("%s@%s", group, a.domain)
@@ -181,15 +267,19 @@ func (a *Admin) DeleteMembership(group string, member string) error {
 	a.log.InfoPart("Ensuring member %s is removed from group %s...", memberEmail, groupEmail)
 	membersService := dirv1.NewMembersService(a.DirV1)
 	membersGetCall := membersService.Get(groupEmail, memberEmail).Context(ctx)
-	existingMember, err := a.Calls.MembersGet.Do(membersGetCall)
-	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "notfound") {
+	existingMember, err := a.Calls.MembersGet.Do(telemetry.WithResource(ctx, "", group), membersGetCall)
+	if err != nil && !googleerrors.IsNotFound(err) {
 		a.log.InfoPart("error\n")
 		return nil
 	}
 	if existingMember != nil {
+		if a.DryRun.Record(plan.Change{Service: service, Method: "DeleteMembership", Resource: groupEmail + "/" + memberEmail, Action: plan.ActionDelete, Before: existingMember}) {
+			a.log.InfoPart("would be removed\n")
+			return nil
+		}
 		a.log.InfoPart("removing...")
 		membersDeleteCall := membersService.Delete(groupEmail, memberEmail).Context(ctx)
-		err := a.Calls.MembersDelete.Do(membersDeleteCall)
+		err := a.Calls.MembersDelete.Do(telemetry.WithResource(ctx, "", group), membersDeleteCall)
 		if err != nil {
 			a.log.InfoPart("error\n")
 			return err
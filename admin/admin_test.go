@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"context"
 	"testing"
 
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
@@ -34,15 +35,15 @@ type membersGetMock struct{}
 type membersInsertMock struct{}
 type membersDeleteMock struct{}
 
-func (c *groupsInsertMock) Do(call *dirv1.GroupsInsertCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
+func (c *groupsInsertMock) Do(ctx context.Context, call *dirv1.GroupsInsertCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
 	return testAPIGroup, nil
 }
 
-func (c *groupsUpdateMock) Do(call *dirv1.GroupsUpdateCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
+func (c *groupsUpdateMock) Do(ctx context.Context, call *dirv1.GroupsUpdateCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
 	return testAPIGroup, nil
 }
 
-func (c *groupsGetMock) Do(call *dirv1.GroupsGetCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
+func (c *groupsGetMock) Do(ctx context.Context, call *dirv1.GroupsGetCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
 	if triggerGroupNotFound {
 		triggerGroupNotFound = false
 		return nil, nil
@@ -50,11 +51,11 @@ func (c *groupsGetMock) Do(call *dirv1.GroupsGetCall, opts ...googleapi.CallOpti
 	return testAPIGroup, nil
 }
 
-func (c *groupsDeleteMock) Do(call *dirv1.GroupsDeleteCall, opts ...googleapi.CallOption) error {
+func (c *groupsDeleteMock) Do(ctx context.Context, call *dirv1.GroupsDeleteCall, opts ...googleapi.CallOption) error {
 	return nil
 }
 
-func (c *membersGetMock) Do(call *dirv1.MembersGetCall, opts ...googleapi.CallOption) (*dirv1.Member, error) {
+func (c *membersGetMock) Do(ctx context.Context, call *dirv1.MembersGetCall, opts ...googleapi.CallOption) (*dirv1.Member, error) {
 	if triggerMemberNotFound {
 		triggerMemberNotFound = false
 		return nil, nil
@@ -62,11 +63,11 @@ func (c *membersGetMock) Do(call *dirv1.MembersGetCall, opts ...googleapi.CallOp
 	return testAPIMember, nil
 }
 
-func (c *membersInsertMock) Do(call *dirv1.MembersInsertCall, opts ...googleapi.CallOption) (*dirv1.Member, error) {
+func (c *membersInsertMock) Do(ctx context.Context, call *dirv1.MembersInsertCall, opts ...googleapi.CallOption) (*dirv1.Member, error) {
 	return testAPIMember, nil
 }
 
-func (c *membersDeleteMock) Do(call *dirv1.MembersDeleteCall, opts ...googleapi.CallOption) error {
+func (c *membersDeleteMock) Do(ctx context.Context, call *dirv1.MembersDeleteCall, opts ...googleapi.CallOption) error {
 	return nil
 }
 
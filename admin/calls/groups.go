@@ -2,58 +2,102 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	dirv1 "google.golang.org/api/admin/directory/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
+// service is the name of the library in the telemetry of its calls
+const service = "admin"
+
 // GroupsInsertCallInterface is an interface to a call to insert a group into Google admin
 type GroupsInsertCallInterface interface {
-	Do(call *dirv1.GroupsInsertCall, opts ...googleapi.CallOption) (*dirv1.Group, error)
+	Do(ctx context.Context, call *dirv1.GroupsInsertCall, opts ...googleapi.CallOption) (*dirv1.Group, error)
 }
 
 // GroupsUpdateCallInterface is an interface to a call to update a group into Google admin
 type GroupsUpdateCallInterface interface {
-	Do(call *dirv1.GroupsUpdateCall, opts ...googleapi.CallOption) (*dirv1.Group, error)
+	Do(ctx context.Context, call *dirv1.GroupsUpdateCall, opts ...googleapi.CallOption) (*dirv1.Group, error)
 }
 
 // GroupsGetCallInterface is an interface to a call to get a single group in Google admin
 type GroupsGetCallInterface interface {
-	Do(call *dirv1.GroupsGetCall, opts ...googleapi.CallOption) (*dirv1.Group, error)
+	Do(ctx context.Context, call *dirv1.GroupsGetCall, opts ...googleapi.CallOption) (*dirv1.Group, error)
 }
 
 // GroupsDeleteCallInterface is an interface to a call to delete a single group in Google admin
 type GroupsDeleteCallInterface interface {
-	Do(call *dirv1.GroupsDeleteCall, opts ...googleapi.CallOption) error
+	Do(ctx context.Context, call *dirv1.GroupsDeleteCall, opts ...googleapi.CallOption) error
 }
 
 // GroupsInsertCall is the default implementation for GroupsInsertCallInterface
-type GroupsInsertCall struct{}
+type GroupsInsertCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // GroupsUpdateCall is the default implementation for GroupsUpdateCallInterface
-type GroupsUpdateCall struct{}
+type GroupsUpdateCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // GroupsGetCall is the default implementation for GroupsGetCallInterface
-type GroupsGetCall struct{}
+type GroupsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // GroupsDeleteCall is the default implementation for GroupsDeleteCallInterface
-type GroupsDeleteCall struct{}
+type GroupsDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *GroupsInsertCall) Do(call *dirv1.GroupsInsertCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
-	return call.Do(opts...)
+func (c *GroupsInsertCall) Do(ctx context.Context, call *dirv1.GroupsInsertCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
+	var result *dirv1.Group
+	err := c.Telemetry.Do(ctx, service, "GroupsInsert", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *GroupsUpdateCall) Do(call *dirv1.GroupsUpdateCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
-	return call.Do(opts...)
+func (c *GroupsUpdateCall) Do(ctx context.Context, call *dirv1.GroupsUpdateCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
+	var result *dirv1.Group
+	err := c.Telemetry.Do(ctx, service, "GroupsUpdate", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *GroupsGetCall) Do(call *dirv1.GroupsGetCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
-	return call.Do(opts...)
+func (c *GroupsGetCall) Do(ctx context.Context, call *dirv1.GroupsGetCall, opts ...googleapi.CallOption) (*dirv1.Group, error) {
+	var result *dirv1.Group
+	err := c.Telemetry.Do(ctx, service, "GroupsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *GroupsDeleteCall) Do(call *dirv1.GroupsDeleteCall, opts ...googleapi.CallOption) error {
-	return call.Do(opts...)
+func (c *GroupsDeleteCall) Do(ctx context.Context, call *dirv1.GroupsDeleteCall, opts ...googleapi.CallOption) error {
+	return c.Telemetry.Do(ctx, service, "GroupsDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() error {
+			return call.Context(ctx).Do(opts...)
+		})
+	})
 }
@@ -1,45 +1,76 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	dirv1 "google.golang.org/api/admin/directory/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // MembersGetCallInterface is an interface to a call to get a member of a group in Google admin
 type MembersGetCallInterface interface {
-	Do(call *dirv1.MembersGetCall, opts ...googleapi.CallOption) (*dirv1.Member, error)
+	Do(ctx context.Context, call *dirv1.MembersGetCall, opts ...googleapi.CallOption) (*dirv1.Member, error)
 }
 
 // MembersInsertCallInterface is an interface to a call to insert a member into a group in Google admin
 type MembersInsertCallInterface interface {
-	Do(call *dirv1.MembersInsertCall, opts ...googleapi.CallOption) (*dirv1.Member, error)
+	Do(ctx context.Context, call *dirv1.MembersInsertCall, opts ...googleapi.CallOption) (*dirv1.Member, error)
 }
 
 // MembersDeleteCallInterface is an interface to a call to delete a member in Google admin
 type MembersDeleteCallInterface interface {
-	Do(call *dirv1.MembersDeleteCall, opts ...googleapi.CallOption) error
+	Do(ctx context.Context, call *dirv1.MembersDeleteCall, opts ...googleapi.CallOption) error
 }
 
 // MembersGetCall is the default implementation for MembersGetCallInterface
-type MembersGetCall struct{}
+type MembersGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // MembersInsertCall is the default implementation for MembersInsertCallInterface
-type MembersInsertCall struct{}
+type MembersInsertCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // MembersDeleteCall is the default implementation for MembersDeleteCallInterface
-type MembersDeleteCall struct{}
+type MembersDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *MembersGetCall) Do(call *dirv1.MembersGetCall, opts ...googleapi.CallOption) (*dirv1.Member, error) {
-	return call.Do(opts...)
+func (c *MembersGetCall) Do(ctx context.Context, call *dirv1.MembersGetCall, opts ...googleapi.CallOption) (*dirv1.Member, error) {
+	var result *dirv1.Member
+	err := c.Telemetry.Do(ctx, service, "MembersGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *MembersInsertCall) Do(call *dirv1.MembersInsertCall, opts ...googleapi.CallOption) (*dirv1.Member, error) {
-	return call.Do(opts...)
+func (c *MembersInsertCall) Do(ctx context.Context, call *dirv1.MembersInsertCall, opts ...googleapi.CallOption) (*dirv1.Member, error) {
+	var result *dirv1.Member
+	err := c.Telemetry.Do(ctx, service, "MembersInsert", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *MembersDeleteCall) Do(call *dirv1.MembersDeleteCall, opts ...googleapi.CallOption) error {
-	return call.Do(opts...)
+func (c *MembersDeleteCall) Do(ctx context.Context, call *dirv1.MembersDeleteCall, opts ...googleapi.CallOption) error {
+	return c.Telemetry.Do(ctx, service, "MembersDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() error {
+			return call.Context(ctx).Do(opts...)
+		})
+	})
 }
@@ -2,32 +2,59 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/cloudbilling/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
+// service is the name of the library in the telemetry of its calls
+const service = "cloudbilling"
+
 // BillingAccountsGetIAMPolicyCallInterface is an interface to a call to get the IAM policy for a billing account
 type BillingAccountsGetIAMPolicyCallInterface interface {
-	Do(call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
+	Do(ctx context.Context, call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
 }
 
 // BillingAccountsSetIAMPolicyCallInterface is an interface to a call to set the IAM policy for a billing account
 type BillingAccountsSetIAMPolicyCallInterface interface {
-	Do(call *v1.BillingAccountsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
+	Do(ctx context.Context, call *v1.BillingAccountsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
 }
 
 // BillingAccountsGetIAMPolicyCall is the default implementation for BillingAccountsGetIAMPolicyCallInterface
-type BillingAccountsGetIAMPolicyCall struct{}
+type BillingAccountsGetIAMPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // BillingAccountsSetIAMPolicyCall is the default implementation for BillingAccountsSetIAMPolicyCallInterface
-type BillingAccountsSetIAMPolicyCall struct{}
+type BillingAccountsSetIAMPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *BillingAccountsGetIAMPolicyCall) Do(call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
-	return call.Do(opts...)
+func (c *BillingAccountsGetIAMPolicyCall) Do(ctx context.Context, call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+	var result *v1.Policy
+	err := c.Telemetry.Do(ctx, service, "BillingAccountsGetIAMPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *BillingAccountsSetIAMPolicyCall) Do(call *v1.BillingAccountsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
-	return call.Do(opts...)
+func (c *BillingAccountsSetIAMPolicyCall) Do(ctx context.Context, call *v1.BillingAccountsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+	var result *v1.Policy
+	err := c.Telemetry.Do(ctx, service, "BillingAccountsSetIAMPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -1,19 +1,33 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/cloudbilling/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // ProjectsUpdateBillingInfoCallInterface is an interface to a call to update project billing info
 type ProjectsUpdateBillingInfoCallInterface interface {
-	Do(call *v1.ProjectsUpdateBillingInfoCall, opts ...googleapi.CallOption) (*v1.ProjectBillingInfo, error)
+	Do(ctx context.Context, call *v1.ProjectsUpdateBillingInfoCall, opts ...googleapi.CallOption) (*v1.ProjectBillingInfo, error)
 }
 
 // ProjectsUpdateBillingInfoCall is the default implementation for ProjectsUpdateBillingInfoCallInterface
-type ProjectsUpdateBillingInfoCall struct{}
+type ProjectsUpdateBillingInfoCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsUpdateBillingInfoCall) Do(call *v1.ProjectsUpdateBillingInfoCall, opts ...googleapi.CallOption) (*v1.ProjectBillingInfo, error) {
-	return call.Do(opts...)
+func (c *ProjectsUpdateBillingInfoCall) Do(ctx context.Context, call *v1.ProjectsUpdateBillingInfoCall, opts ...googleapi.CallOption) (*v1.ProjectBillingInfo, error) {
+	var result *v1.ProjectBillingInfo
+	err := c.Telemetry.Do(ctx, service, "ProjectsUpdateBillingInfo", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
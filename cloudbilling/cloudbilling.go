@@ -4,27 +4,64 @@ package cloudbilling
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 
 	"github.com/rockholla/go-google-lib/cloudbilling/calls"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	v1 "google.golang.org/api/cloudbilling/v1"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
+// service is the name of the library in the events it emits
+const service = "cloudbilling"
+
 // Interface represents functionality for CloudBilling
 type Interface interface {
 	Initialize(credentials string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error
 	SetProjectBillingAccount(projectID string, billingAccountID string) (string, error)
+	SetProjectBillingAccountCtx(ctx context.Context, projectID string, billingAccountID string) (string, error)
 	EnsureRoles(billingAccount string, member string, roles []string) error
+	EnsureRolesCtx(ctx context.Context, billingAccount string, member string, roles []string) error
+	EnsureRolesWithCondition(billingAccount string, member string, roles []string, condition *iampolicy.Condition) error
+	EnsureRolesWithConditionCtx(ctx context.Context, billingAccount string, member string, roles []string, condition *iampolicy.Condition) error
 	RemoveRoles(billingAccount string, member string, roles []string) error
+	RemoveRolesCtx(ctx context.Context, billingAccount string, member string, roles []string) error
+	RemoveRolesWithCondition(billingAccount string, member string, roles []string, condition *iampolicy.Condition) error
+	RemoveRolesWithConditionCtx(ctx context.Context, billingAccount string, member string, roles []string, condition *iampolicy.Condition) error
 }
 
 // CloudBilling wraps google-provided apis for interacting with google.golang.org/api/cloudbilling/*
 type CloudBilling struct {
-	log   logger.Interface
-	V1    *v1.APIService
-	Calls *Calls
+	log           logger.Interface
+	V1            *v1.APIService
+	Calls         *Calls
+	Retry         *retry.Policy
+	ClientOptions []option.ClientOption
+	// Endpoint overrides where api requests are sent, e.g. to a local emulator or fake
+	Endpoint string
+	// Insecure sends api requests without credentials, for an Endpoint that doesn't expect them
+	Insecure bool
+	// Transport is the base http transport api requests are sent through, underneath authentication, e.g. a
+	// recorder.Recorder to record or replay them
+	Transport http.RoundTripper
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
+	// ConflictRetry is how iam policy updates that conflict with a concurrent change to the policy are retried, from a
+	// fresh read of it, iampolicy.DefaultRetryPolicy() when not set
+	ConflictRetry *retry.Policy
 }
 
 // Calls are interfaces for making the actual calls to various underlying apis
@@ -36,37 +73,68 @@ type Calls struct {
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (cb *CloudBilling) Initialize(credentials string, log logger.Interface) error {
+	return cb.InitializeCtx(context.Background(), credentials, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (cb *CloudBilling) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	cb.log = log
+	if cb.Retry == nil {
+		cb.Retry = retry.DefaultPolicy()
+	}
+	if cb.ConflictRetry == nil {
+		cb.ConflictRetry = iampolicy.DefaultRetryPolicy()
+	}
 	cb.Calls = &Calls{
-		ProjectsUpdateBillingInfo:   &calls.ProjectsUpdateBillingInfoCall{},
-		BillingAccountsGetIAMPolicy: &calls.BillingAccountsGetIAMPolicyCall{},
-		BillingAccountsSetIAMPolicy: &calls.BillingAccountsSetIAMPolicyCall{},
+		ProjectsUpdateBillingInfo:   &calls.ProjectsUpdateBillingInfoCall{Retry: cb.Retry, Telemetry: cb.Telemetry},
+		BillingAccountsGetIAMPolicy: &calls.BillingAccountsGetIAMPolicyCall{Retry: cb.Retry, Telemetry: cb.Telemetry},
+		BillingAccountsSetIAMPolicy: &calls.BillingAccountsSetIAMPolicyCall{Retry: cb.Retry, Telemetry: cb.Telemetry},
 	}
-	if credentials != "" {
-		if cb.V1, err = v1.NewService(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-	} else {
-		if cb.V1, err = v1.NewService(ctx); err != nil {
+	clientOptions := append([]option.ClientOption{}, cb.ClientOptions...)
+	if credentials != "" && !cb.Insecure {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if cb.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(cb.Endpoint))
+	}
+	if cb.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication())
+	}
+	if cb.Transport != nil {
+		transport, err := htransport.NewTransport(ctx, cb.Transport, append([]option.ClientOption{option.WithScopes(v1.CloudPlatformScope)}, clientOptions...)...)
+		if err != nil {
 			return err
 		}
+		clientOptions = append(clientOptions, option.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	if cb.V1, err = v1.NewService(ctx, clientOptions...); err != nil {
+		return err
 	}
 	return nil
 }
 
-// SetProjectBillingAccount will update the billing account attached to a project, returns billing account name
+// SetProjectBillingAccount will update the billing account attached to a project, returns billing account name, which
+// is the name it would have in a dry run
 func (cb *CloudBilling) SetProjectBillingAccount(projectID string, billingAccountID string) (string, error) {
-	ctx := context.Background()
-	cb.log.Info("Assigning billing account ID %s to project %s", billingAccountID, projectID)
-	projectsService := v1.NewProjectsService(cb.V1)
-	updateBillingInfoCall := projectsService.UpdateBillingInfo(fmt.Sprintf("projects/%s", projectID), &v1.ProjectBillingInfo{
+	return cb.SetProjectBillingAccountCtx(context.Background(), projectID, billingAccountID)
+}
+
+// SetProjectBillingAccountCtx is SetProjectBillingAccount, using the provided context for the underlying api calls
+func (cb *CloudBilling) SetProjectBillingAccountCtx(ctx context.Context, projectID string, billingAccountID string) (name string, err error) {
+	defer events.Start(cb.Events, service, "SetProjectBillingAccount", events.ActionUpdate, projectID, billingAccountID).Done(&err)
+	billingInfo := &v1.ProjectBillingInfo{
 		Name:               fmt.Sprintf("projects/%s/billlingInfo", projectID),
 		BillingAccountName: fmt.Sprintf("billingAccounts/%s", billingAccountID),
 		BillingEnabled:     true,
-	}).Context(ctx)
-	result, err := cb.Calls.ProjectsUpdateBillingInfo.Do(updateBillingInfoCall)
+	}
+	if cb.DryRun.Record(plan.Change{Service: service, Method: "SetProjectBillingAccount", Project: projectID, Resource: billingAccountID, Action: plan.ActionUpdate, After: billingInfo}) {
+		return billingInfo.BillingAccountName, nil
+	}
+	cb.log.Info("Assigning billing account ID %s to project %s", billingAccountID, projectID)
+	projectsService := v1.NewProjectsService(cb.V1)
+	updateBillingInfoCall := projectsService.UpdateBillingInfo(fmt.Sprintf("projects/%s", projectID), billingInfo).Context(ctx)
+	result, err := cb.Calls.ProjectsUpdateBillingInfo.Do(telemetry.WithResource(ctx, projectID, billingAccountID), updateBillingInfoCall)
 	if err != nil {
 		return "", err
 	}
@@ -75,96 +143,66 @@ func (cb *CloudBilling) SetProjectBillingAccount(projectID string, billingAccoun
 
 // EnsureRoles makes sure that a particular member has the supplied roles on the billing account
 func (cb *CloudBilling) EnsureRoles(billingAccount string, member string, roles []string) error {
-	ctx := context.Background()
-	if matched, _ := regexp.Match("^billingAccounts\\/", []byte(billingAccount)); !matched {
-		billingAccount = fmt.Sprintf("billingAccounts/%s", billingAccount)
-	}
-	cb.log.Info("Ensuring member %s has roles in %s:", member, billingAccount)
-	billingAccountsService := v1.NewBillingAccountsService(cb.V1)
-	billingAccountGetPolicyCall := billingAccountsService.GetIamPolicy(billingAccount).Context(ctx)
-	policy, err := cb.Calls.BillingAccountsGetIAMPolicy.Do(billingAccountGetPolicyCall)
-	if err != nil {
-		return err
-	}
-	for _, role := range roles {
-		cb.log.ListItem(role)
-		binding := getExistingRoleBinding(policy.Bindings, role)
-		if binding == nil {
-			policy.Bindings = append(policy.Bindings, &v1.Binding{
-				Members: []string{member},
-				Role:    role,
-			})
-		} else if !memberExistsInRoleBinding(binding, member) {
-			binding.Members = append(binding.Members, member)
-		}
-	}
-	setIAMPolicyRequest := &v1.SetIamPolicyRequest{
-		Policy: policy,
-	}
-	billingAccountSetPolicyCall := billingAccountsService.SetIamPolicy(billingAccount, setIAMPolicyRequest).Context(ctx)
-	_, err = cb.Calls.BillingAccountsSetIAMPolicy.Do(billingAccountSetPolicyCall)
-	if err != nil {
-		return err
-	}
-	return nil
+	return cb.EnsureRolesCtx(context.Background(), billingAccount, member, roles)
+}
+
+// EnsureRolesCtx is EnsureRoles, using the provided context for the underlying api calls
+func (cb *CloudBilling) EnsureRolesCtx(ctx context.Context, billingAccount string, member string, roles []string) (err error) {
+	defer events.Start(cb.Events, service, "EnsureRoles", events.ActionUpdate, "", billingAccount).Done(&err)
+	return cb.ensureRoles(ctx, "EnsureRoles", billingAccount, member, roles, nil)
+}
+
+// EnsureRolesWithCondition makes sure that a particular member has the supplied roles on the billing account, granted
+// only when the condition is met
+func (cb *CloudBilling) EnsureRolesWithCondition(billingAccount string, member string, roles []string, condition *iampolicy.Condition) error {
+	return cb.EnsureRolesWithConditionCtx(context.Background(), billingAccount, member, roles, condition)
+}
+
+// EnsureRolesWithConditionCtx is EnsureRolesWithCondition, using the provided context for the underlying api calls
+func (cb *CloudBilling) EnsureRolesWithConditionCtx(ctx context.Context, billingAccount string, member string, roles []string, condition *iampolicy.Condition) (err error) {
+	defer events.Start(cb.Events, service, "EnsureRolesWithCondition", events.ActionUpdate, "", billingAccount).Done(&err)
+	return cb.ensureRoles(ctx, "EnsureRolesWithCondition", billingAccount, member, roles, condition)
+}
+
+func (cb *CloudBilling) ensureRoles(ctx context.Context, method string, billingAccount string, member string, roles []string, condition *iampolicy.Condition) error {
+	billingAccount = billingAccountName(billingAccount)
+	return cb.updateRoles(ctx, method, billingAccount, fmt.Sprintf("Ensuring member %s has roles in %s", member, billingAccount), condition, roles,
+		func(role string) iampolicy.Mutation { return iampolicy.AddMember(role, member, condition) })
 }
 
 // RemoveRoles makes sure that a particular member is removed from a role or roles on the billing account
 func (cb *CloudBilling) RemoveRoles(billingAccount string, member string, roles []string) error {
-	ctx := context.Background()
-	if matched, _ := regexp.Match("^billingAccounts\\/", []byte(billingAccount)); !matched {
-		billingAccount = fmt.Sprintf("billingAccounts/%s", billingAccount)
-	}
-	cb.log.Info("Ensuring member %s has roles in %s:", member, billingAccount)
-	billingAccountsService := v1.NewBillingAccountsService(cb.V1)
-	billingAccountGetPolicyCall := billingAccountsService.GetIamPolicy(billingAccount).Context(ctx)
-	policy, err := cb.Calls.BillingAccountsGetIAMPolicy.Do(billingAccountGetPolicyCall)
-	if err != nil {
-		return err
-	}
-	changed := false
-	for _, role := range roles {
-		cb.log.ListItem(role)
-		binding := getExistingRoleBinding(policy.Bindings, role)
-		if binding != nil {
-			newBindingMembers := []string{}
-			for _, bindingMember := range binding.Members {
-				if bindingMember == member {
-					changed = true
-					continue
-				}
-				newBindingMembers = append(newBindingMembers, bindingMember)
-			}
-			binding.Members = newBindingMembers
-		}
-	}
-	setIAMPolicyRequest := &v1.SetIamPolicyRequest{
-		Policy: policy,
-	}
-	if changed {
-		billingAccountSetPolicyCall := billingAccountsService.SetIamPolicy(billingAccount, setIAMPolicyRequest).Context(ctx)
-		_, err = cb.Calls.BillingAccountsSetIAMPolicy.Do(billingAccountSetPolicyCall)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return cb.RemoveRolesCtx(context.Background(), billingAccount, member, roles)
 }
 
-func getExistingRoleBinding(bindings []*v1.Binding, role string) *v1.Binding {
-	for _, binding := range bindings {
-		if binding.Role == role {
-			return binding
-		}
-	}
-	return nil
+// RemoveRolesCtx is RemoveRoles, using the provided context for the underlying api calls
+func (cb *CloudBilling) RemoveRolesCtx(ctx context.Context, billingAccount string, member string, roles []string) (err error) {
+	defer events.Start(cb.Events, service, "RemoveRoles", events.ActionUpdate, "", billingAccount).Done(&err)
+	return cb.removeRoles(ctx, "RemoveRoles", billingAccount, member, roles, nil)
 }
 
-func memberExistsInRoleBinding(binding *v1.Binding, member string) bool {
-	for _, bindingMember := range binding.Members {
-		if bindingMember == member {
-			return true
-		}
+// RemoveRolesWithCondition makes sure that a particular member is removed from a role or roles granted with the
+// condition on the billing account, leaving the roles granted without it or with other conditions
+func (cb *CloudBilling) RemoveRolesWithCondition(billingAccount string, member string, roles []string, condition *iampolicy.Condition) error {
+	return cb.RemoveRolesWithConditionCtx(context.Background(), billingAccount, member, roles, condition)
+}
+
+// RemoveRolesWithConditionCtx is RemoveRolesWithCondition, using the provided context for the underlying api calls
+func (cb *CloudBilling) RemoveRolesWithConditionCtx(ctx context.Context, billingAccount string, member string, roles []string, condition *iampolicy.Condition) (err error) {
+	defer events.Start(cb.Events, service, "RemoveRolesWithCondition", events.ActionUpdate, "", billingAccount).Done(&err)
+	return cb.removeRoles(ctx, "RemoveRolesWithCondition", billingAccount, member, roles, condition)
+}
+
+func (cb *CloudBilling) removeRoles(ctx context.Context, method string, billingAccount string, member string, roles []string, condition *iampolicy.Condition) error {
+	billingAccount = billingAccountName(billingAccount)
+	return cb.updateRoles(ctx, method, billingAccount, fmt.Sprintf("Ensuring roles for member %s are removed in %s", member, billingAccount), condition, roles,
+		func(role string) iampolicy.Mutation { return iampolicy.RemoveMember(role, member, condition) })
+}
+
+// billingAccountName will return the billing account in the form billingAccounts/{id}
+func billingAccountName(billingAccount string) string {
+	if matched, _ := regexp.Match("^billingAccounts\\/", []byte(billingAccount)); !matched {
+		return fmt.Sprintf("billingAccounts/%s", billingAccount)
 	}
-	return false
+	return billingAccount
 }
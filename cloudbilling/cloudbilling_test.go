@@ -1,6 +1,7 @@
 package cloudbilling
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -28,19 +29,19 @@ type billingAccountsGetIAMPolicyExistingRoleMock struct{}
 type billingAccountsSetIAMPolicyMock struct{}
 
 // Do is the mock for default projectsUpdateBillingInfoMock
-func (c *projectsUpdateBillingInfoMock) Do(call *v1.ProjectsUpdateBillingInfoCall, opts ...googleapi.CallOption) (*v1.ProjectBillingInfo, error) {
+func (c *projectsUpdateBillingInfoMock) Do(ctx context.Context, call *v1.ProjectsUpdateBillingInfoCall, opts ...googleapi.CallOption) (*v1.ProjectBillingInfo, error) {
 	return &v1.ProjectBillingInfo{
 		BillingAccountName: testBillingAccountName,
 	}, nil
 }
 
 // Do is the mock for default billingAccountsGetIAMPolicy
-func (c *billingAccountsGetIAMPolicyMock) Do(call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *billingAccountsGetIAMPolicyMock) Do(ctx context.Context, call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	return &v1.Policy{}, nil
 }
 
 // Do is the mock for billingAccountsGetIamPolicy that includes an existing member in role
-func (c *billingAccountsGetIAMPolicyExistingMemberMock) Do(call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *billingAccountsGetIAMPolicyExistingMemberMock) Do(ctx context.Context, call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	var bindings []*v1.Binding
 	bindings = append(bindings, &v1.Binding{
 		Role: testRole,
@@ -54,7 +55,7 @@ func (c *billingAccountsGetIAMPolicyExistingMemberMock) Do(call *v1.BillingAccou
 }
 
 // Do is the mock for billingAccountsGetIAMPolicy that includes an existing member in role
-func (c *billingAccountsGetIAMPolicyExistingRoleMock) Do(call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *billingAccountsGetIAMPolicyExistingRoleMock) Do(ctx context.Context, call *v1.BillingAccountsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	var bindings []*v1.Binding
 	bindings = append(bindings, &v1.Binding{
 		Role:    testRole,
@@ -66,7 +67,7 @@ func (c *billingAccountsGetIAMPolicyExistingRoleMock) Do(call *v1.BillingAccount
 }
 
 // Do is the mock for default billingAccountsSetIAMPolicy
-func (c *billingAccountsSetIAMPolicyMock) Do(call *v1.BillingAccountsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *billingAccountsSetIAMPolicyMock) Do(ctx context.Context, call *v1.BillingAccountsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	return &v1.Policy{}, nil
 }
 
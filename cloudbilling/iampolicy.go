@@ -0,0 +1,75 @@
+package cloudbilling
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/cloudbilling/v1"
+)
+
+// policyUpdater will return the updater for the iam policies of billing accounts
+func (cb *CloudBilling) policyUpdater() *iampolicy.Updater {
+	return &iampolicy.Updater{Retry: cb.ConflictRetry, DryRun: cb.DryRun != nil}
+}
+
+// updateRoles will log the message, along with the condition if not nil, and each of the roles, then update the
+// policy of the billing account with the mutation for each role, adding the change to the policy to a dry run
+func (cb *CloudBilling) updateRoles(ctx context.Context, method string, billingAccount string, message string, condition *iampolicy.Condition, roles []string, mutation func(role string) iampolicy.Mutation) error {
+	if condition != nil {
+		message = fmt.Sprintf("%s %s", message, condition)
+	}
+	cb.log.Info("%s:", message)
+	mutations := []iampolicy.Mutation{}
+	for _, role := range roles {
+		cb.log.ListItem(role)
+		mutations = append(mutations, mutation(role))
+	}
+	result, err := cb.policyUpdater().Update(ctx, &billingAccountPolicy{cb: cb, billingAccount: billingAccount}, mutations...)
+	if err != nil {
+		return err
+	}
+	cb.DryRun.Record(result.Changes(plan.Change{Service: service, Method: method, Resource: billingAccount, Action: plan.ActionUpdate})...)
+	return nil
+}
+
+// billingAccountPolicy is the iam policy of a billing account
+type billingAccountPolicy struct {
+	cb             *CloudBilling
+	billingAccount string
+}
+
+func (b *billingAccountPolicy) GetPolicy(ctx context.Context) (*iampolicy.Policy, error) {
+	billingAccountsService := v1.NewBillingAccountsService(b.cb.V1)
+	billingAccountGetPolicyCall := billingAccountsService.GetIamPolicy(b.billingAccount).OptionsRequestedPolicyVersion(iampolicy.PolicyVersion).Context(ctx)
+	policy, err := b.cb.Calls.BillingAccountsGetIAMPolicy.Do(telemetry.WithResource(ctx, "", b.billingAccount), billingAccountGetPolicyCall)
+	if err != nil {
+		return nil, err
+	}
+	result := &iampolicy.Policy{Version: policy.Version, Etag: policy.Etag}
+	for _, binding := range policy.Bindings {
+		converted := &iampolicy.Binding{Role: binding.Role, Members: binding.Members}
+		if binding.Condition != nil {
+			converted.Condition = &iampolicy.Condition{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+		}
+		result.Bindings = append(result.Bindings, converted)
+	}
+	return result, nil
+}
+
+func (b *billingAccountPolicy) SetPolicy(ctx context.Context, policy *iampolicy.Policy) error {
+	billingAccountPolicy := &v1.Policy{Version: policy.Version, Etag: policy.Etag}
+	for _, binding := range policy.Bindings {
+		converted := &v1.Binding{Role: binding.Role, Members: binding.Members}
+		if binding.Condition != nil {
+			converted.Condition = &v1.Expr{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+		}
+		billingAccountPolicy.Bindings = append(billingAccountPolicy.Bindings, converted)
+	}
+	billingAccountsService := v1.NewBillingAccountsService(b.cb.V1)
+	billingAccountSetPolicyCall := billingAccountsService.SetIamPolicy(b.billingAccount, &v1.SetIamPolicyRequest{Policy: billingAccountPolicy}).Context(ctx)
+	_, err := b.cb.Calls.BillingAccountsSetIAMPolicy.Do(telemetry.WithResource(ctx, "", b.billingAccount), billingAccountSetPolicyCall)
+	return err
+}
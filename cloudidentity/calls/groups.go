@@ -1,32 +1,59 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1beta1 "google.golang.org/api/cloudidentity/v1beta1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
+// service is the name of the library in the telemetry of its calls
+const service = "cloudidentity"
+
 // GroupCreateCallInterface is an interface to a call to create a cloud identity group
 type GroupCreateCallInterface interface {
-	Do(call *v1beta1.GroupsCreateCall, opts ...googleapi.CallOption) (*v1beta1.Operation, error)
+	Do(ctx context.Context, call *v1beta1.GroupsCreateCall, opts ...googleapi.CallOption) (*v1beta1.Operation, error)
 }
 
 // GroupLookupCallInterface is an interface to a call to create a cloud identity group
 type GroupLookupCallInterface interface {
-	Do(call *v1beta1.GroupsLookupCall, opts ...googleapi.CallOption) (*v1beta1.LookupGroupNameResponse, error)
+	Do(ctx context.Context, call *v1beta1.GroupsLookupCall, opts ...googleapi.CallOption) (*v1beta1.LookupGroupNameResponse, error)
 }
 
 // GroupCreateCall is the default implementation for GroupCreateCallInterface
-type GroupCreateCall struct{}
+type GroupCreateCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // GroupLookupCall is the default implementation for GroupLookupCallInterface
-type GroupLookupCall struct{}
+type GroupLookupCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *GroupCreateCall) Do(call *v1beta1.GroupsCreateCall, opts ...googleapi.CallOption) (*v1beta1.Operation, error) {
-	return call.Do(opts...)
+func (c *GroupCreateCall) Do(ctx context.Context, call *v1beta1.GroupsCreateCall, opts ...googleapi.CallOption) (*v1beta1.Operation, error) {
+	var result *v1beta1.Operation
+	err := c.Telemetry.Do(ctx, service, "GroupCreate", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *GroupLookupCall) Do(call *v1beta1.GroupsLookupCall, opts ...googleapi.CallOption) (*v1beta1.LookupGroupNameResponse, error) {
-	return call.Do(opts...)
+func (c *GroupLookupCall) Do(ctx context.Context, call *v1beta1.GroupsLookupCall, opts ...googleapi.CallOption) (*v1beta1.LookupGroupNameResponse, error) {
+	var result *v1beta1.LookupGroupNameResponse
+	err := c.Telemetry.Do(ctx, service, "GroupLookup", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -4,27 +4,52 @@ package cloudidentity
 import (
 	"context"
 	"fmt"
-	"strings"
+	"net/http"
 
 	"github.com/rockholla/go-google-lib/cloudidentity/calls"
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	v1beta1 "google.golang.org/api/cloudidentity/v1beta1"
 	"google.golang.org/api/option"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	htransport "google.golang.org/api/transport/http"
 )
 
+// service is the name of the library in the events it emits
+const service = "cloudidentity"
+
 // Interface represents functionality for CloudBilling
 type Interface interface {
 	Initialize(impersonateServiceAccountEmail string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, impersonateServiceAccountEmail string, log logger.Interface) error
 	EnsureGroup(name string, domain string, customerID string) (*v1beta1.Group, error)
+	EnsureGroupCtx(ctx context.Context, name string, domain string, customerID string) (*v1beta1.Group, error)
 }
 
 // CloudIdentity wraps google-provided apis for interacting with google.golang.org/api/cloudbilling/*
 type CloudIdentity struct {
-	log     logger.Interface
-	V1Beta1 *v1beta1.Service
-	Calls   *Calls
+	log           logger.Interface
+	V1Beta1       *v1beta1.Service
+	Calls         *Calls
+	Retry         *retry.Policy
+	ClientOptions []option.ClientOption
+	// Endpoint overrides where api requests are sent, e.g. to a local emulator or fake
+	Endpoint string
+	// Insecure sends api requests without credentials, for an Endpoint that doesn't expect them
+	Insecure bool
+	// Transport is the base http transport api requests are sent through, underneath authentication, e.g. a
+	// recorder.Recorder to record or replay them
+	Transport http.RoundTripper
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
 }
 
 // Calls are interfaces for making the actual calls to various underlying apis
@@ -35,28 +60,51 @@ type Calls struct {
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (ci *CloudIdentity) Initialize(impersonateServiceAccountEmail string, log logger.Interface) error {
+	return ci.InitializeCtx(context.Background(), impersonateServiceAccountEmail, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (ci *CloudIdentity) InitializeCtx(ctx context.Context, impersonateServiceAccountEmail string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	ci.log = log
+	if ci.Retry == nil {
+		ci.Retry = retry.DefaultPolicy()
+	}
 	ci.Calls = &Calls{
-		GroupCreate: &calls.GroupCreateCall{},
-		GroupLookup: &calls.GroupLookupCall{},
+		GroupCreate: &calls.GroupCreateCall{Retry: ci.Retry, Telemetry: ci.Telemetry},
+		GroupLookup: &calls.GroupLookupCall{Retry: ci.Retry, Telemetry: ci.Telemetry},
 	}
-	if impersonateServiceAccountEmail != "" {
-		if ci.V1Beta1, err = v1beta1.NewService(ctx, option.ImpersonateCredentials(impersonateServiceAccountEmail)); err != nil {
-			return err
-		}
-	} else {
-		if ci.V1Beta1, err = v1beta1.NewService(ctx); err != nil {
+	clientOptions := append([]option.ClientOption{}, ci.ClientOptions...)
+	if impersonateServiceAccountEmail != "" && !ci.Insecure {
+		clientOptions = append(clientOptions, option.ImpersonateCredentials(impersonateServiceAccountEmail))
+	}
+	if ci.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(ci.Endpoint))
+	}
+	if ci.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication())
+	}
+	if ci.Transport != nil {
+		transport, err := htransport.NewTransport(ctx, ci.Transport, append([]option.ClientOption{option.WithScopes(v1beta1.CloudIdentityGroupsScope)}, clientOptions...)...)
+		if err != nil {
 			return err
 		}
+		clientOptions = append(clientOptions, option.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	if ci.V1Beta1, err = v1beta1.NewService(ctx, clientOptions...); err != nil {
+		return err
 	}
 	return nil
 }
 
 // EnsureGroup will make sure that a cloud identity group exists
 func (ci *CloudIdentity) EnsureGroup(name string, domain string, customerID string) (*v1beta1.Group, error) {
-	ctx := context.Background()
+	return ci.EnsureGroupCtx(context.Background(), name, domain, customerID)
+}
+
+// EnsureGroupCtx is EnsureGroup, using the provided context for the underlying api calls
+func (ci *CloudIdentity) EnsureGroupCtx(ctx context.Context, name string, domain string, customerID string) (ensured *v1beta1.Group, err error) {
+	defer events.Start(ci.Events, service, "EnsureGroup", events.ActionEnsure, "", name).Done(&err)
 	groupsService := v1beta1.NewGroupsService(ci.V1Beta1)
 	groupKeyID := fmt.Sprintf("%s@%s", name, domain)
 	fullCustomerID := fmt.Sprintf("customers/%s", customerID)
@@ -71,13 +119,12 @@ func (ci *CloudIdentity) EnsureGroup(name string, domain string, customerID stri
 			"cloudidentity.googleapis.com/groups.discussion_forum": "",
 		},
 	}
+	if ci.DryRun != nil {
+		return ci.planGroup(ctx, name, group)
+	}
 	groupCreateCall := groupsService.Create(group).Context(ctx).InitialGroupConfig("WITH_INITIAL_OWNER")
-	if _, err := ci.Calls.GroupCreate.Do(groupCreateCall); err != nil {
-		if s, ok := status.FromError(err); ok {
-			if s.Code() != codes.AlreadyExists {
-				return nil, err
-			}
-		} else if !strings.Contains(err.Error(), "alreadyExists") {
+	if _, err := ci.Calls.GroupCreate.Do(telemetry.WithResource(ctx, "", name), groupCreateCall); err != nil {
+		if !googleerrors.IsAlreadyExists(err) {
 			return nil, err
 		}
 		ci.log.InfoPart("already exists\n")
@@ -85,10 +132,30 @@ func (ci *CloudIdentity) EnsureGroup(name string, domain string, customerID stri
 		ci.log.InfoPart("created\n")
 	}
 	groupLookupCall := groupsService.Lookup().Context(ctx).GroupKeyId(groupKeyID)
-	lookupResponse, err := ci.Calls.GroupLookup.Do(groupLookupCall)
+	lookupResponse, err := ci.Calls.GroupLookup.Do(telemetry.WithResource(ctx, "", name), groupLookupCall)
 	if err != nil {
 		return nil, err
 	}
 	group.Name = lookupResponse.Name
 	return group, nil
 }
+
+// planGroup will add the creation of the group to the dry run if it doesn't exist, returning the group as it is or
+// would be
+func (ci *CloudIdentity) planGroup(ctx context.Context, name string, group *v1beta1.Group) (*v1beta1.Group, error) {
+	groupsService := v1beta1.NewGroupsService(ci.V1Beta1)
+	groupLookupCall := groupsService.Lookup().Context(ctx).GroupKeyId(group.GroupKey.Id)
+	lookupResponse, err := ci.Calls.GroupLookup.Do(telemetry.WithResource(ctx, "", name), groupLookupCall)
+	if err == nil {
+		ci.log.InfoPart("already exists\n")
+		group.Name = lookupResponse.Name
+		return group, nil
+	}
+	if !googleerrors.IsNotFound(err) {
+		ci.log.InfoPart("\n")
+		return nil, err
+	}
+	ci.DryRun.Record(plan.Change{Service: service, Method: "EnsureGroup", Resource: group.GroupKey.Id, Action: plan.ActionCreate, After: group})
+	ci.log.InfoPart("would be created\n")
+	return group, nil
+}
@@ -1,7 +1,8 @@
 package cloudidentity
 
 import (
-	"errors"
+	"context"
+	"net/http"
 	"testing"
 
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
@@ -30,7 +31,7 @@ var groupAlreadyExistsErrorRaw = `Error: googleapi: got HTTP response code 409 w
 type groupCreateMock struct{}
 type groupLookupMock struct{}
 
-func (c *groupCreateMock) Do(call *v1beta1.GroupsCreateCall, opts ...googleapi.CallOption) (*v1beta1.Operation, error) {
+func (c *groupCreateMock) Do(ctx context.Context, call *v1beta1.GroupsCreateCall, opts ...googleapi.CallOption) (*v1beta1.Operation, error) {
 	if triggerGroupAlreadyExists {
 		triggerGroupAlreadyExists = false
 		st := status.New(codes.AlreadyExists, "alreadyExists")
@@ -38,12 +39,12 @@ func (c *groupCreateMock) Do(call *v1beta1.GroupsCreateCall, opts ...googleapi.C
 	}
 	if triggerGroupAlreadyExistsRaw {
 		triggerGroupAlreadyExistsRaw = false
-		return nil, errors.New(groupAlreadyExistsErrorRaw)
+		return nil, &googleapi.Error{Code: http.StatusConflict, Body: groupAlreadyExistsErrorRaw}
 	}
 	return &v1beta1.Operation{}, nil
 }
 
-func (c *groupLookupMock) Do(call *v1beta1.GroupsLookupCall, opts ...googleapi.CallOption) (*v1beta1.LookupGroupNameResponse, error) {
+func (c *groupLookupMock) Do(ctx context.Context, call *v1beta1.GroupsLookupCall, opts ...googleapi.CallOption) (*v1beta1.LookupGroupNameResponse, error) {
 	return &v1beta1.LookupGroupNameResponse{
 		Name: testGroupName,
 	}, nil
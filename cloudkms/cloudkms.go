@@ -5,19 +5,34 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 
 	v1 "cloud.google.com/go/kms/apiv1"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	gax "github.com/googleapis/gax-go/v2"
 	"google.golang.org/api/option"
 	v1objects "google.golang.org/genproto/googleapis/cloud/kms/v1"
+	"google.golang.org/grpc"
 )
 
+// service is the name of the library in the events it emits
+const service = "cloudkms"
+
+// noClientRetry turns off the kms client's own retries, so that Retry is the only policy retrying a call
+var noClientRetry = gax.WithRetry(func() gax.Retryer { return nil })
+
 // Interface represents functionality for DeploymentManager
 type Interface interface {
 	Initialize(credentials string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error
 	Encrypt(key *CryptoKey, data string) (string, error)
+	EncryptCtx(ctx context.Context, key *CryptoKey, data string) (string, error)
 	Decrypt(key *CryptoKey, data string) (string, error)
+	DecryptCtx(ctx context.Context, key *CryptoKey, data string) (string, error)
+	Close() error
 }
 
 // ClientInterface represents the underlying kms api client
@@ -28,8 +43,18 @@ type ClientInterface interface {
 
 // CloudKMS is a wrapper around the google-provided sdks/apis for google.golang.org/api/cloudkms/*
 type CloudKMS struct {
-	log logger.Interface
-	V1  ClientInterface
+	log           logger.Interface
+	V1            ClientInterface
+	Retry         *retry.Policy
+	ClientOptions []option.ClientOption
+	// Endpoint overrides the host:port api requests are sent to, e.g. a local emulator or fake
+	Endpoint string
+	// Insecure sends api requests without credentials or TLS, for an Endpoint that doesn't expect them
+	Insecure bool
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
 }
 
 // CryptoKey represents an encryption key within a project, location, and key ring
@@ -42,29 +67,51 @@ type CryptoKey struct {
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (kms *CloudKMS) Initialize(credentials string, log logger.Interface) error {
+	return kms.InitializeCtx(context.Background(), credentials, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (kms *CloudKMS) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	kms.log = log
-	if credentials != "" {
-		if kms.V1, err = v1.NewKeyManagementClient(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-	} else {
-		if kms.V1, err = v1.NewKeyManagementClient(ctx); err != nil {
-			return err
-		}
+	if kms.Retry == nil {
+		kms.Retry = retry.DefaultPolicy()
+	}
+	clientOptions := append([]option.ClientOption{}, kms.ClientOptions...)
+	if credentials != "" && !kms.Insecure {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if kms.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(kms.Endpoint))
+	}
+	if kms.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication(), option.WithGRPCDialOption(grpc.WithInsecure()))
+	}
+	if kms.V1, err = v1.NewKeyManagementClient(ctx, clientOptions...); err != nil {
+		return err
 	}
 	return nil
 }
 
 // Encrypt will receive data in and encrypt with a designated KMS crypto key
 func (kms *CloudKMS) Encrypt(key *CryptoKey, data string) (string, error) {
-	ctx := context.Background()
+	return kms.EncryptCtx(context.Background(), key, data)
+}
+
+// EncryptCtx is Encrypt, using the provided context for the underlying api calls
+func (kms *CloudKMS) EncryptCtx(ctx context.Context, key *CryptoKey, data string) (encrypted string, err error) {
+	defer events.Start(kms.Events, service, "Encrypt", events.Action("encrypt"), "", key.Name).Done(&err)
 	request := &v1objects.EncryptRequest{
 		Name:      fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s", key.ProjectID, key.Location, key.KeyRing, key.Name),
 		Plaintext: []byte(data),
 	}
-	response, err := kms.V1.Encrypt(ctx, request)
+	var response *v1objects.EncryptResponse
+	err = kms.Telemetry.Call(ctx, service, "Encrypt", "", key.Name, func(ctx context.Context) error {
+		return kms.Retry.Do(ctx, func() (err error) {
+			response, err = kms.V1.Encrypt(ctx, request, noClientRetry)
+			return err
+		})
+	})
 	if err != nil {
 		return "", err
 	}
@@ -73,7 +120,12 @@ func (kms *CloudKMS) Encrypt(key *CryptoKey, data string) (string, error) {
 
 // Decrypt will receive data in and decrypt with a designated KMS crypto key
 func (kms *CloudKMS) Decrypt(key *CryptoKey, data string) (string, error) {
-	ctx := context.Background()
+	return kms.DecryptCtx(context.Background(), key, data)
+}
+
+// DecryptCtx is Decrypt, using the provided context for the underlying api calls
+func (kms *CloudKMS) DecryptCtx(ctx context.Context, key *CryptoKey, data string) (decrypted string, err error) {
+	defer events.Start(kms.Events, service, "Decrypt", events.Action("decrypt"), "", key.Name).Done(&err)
 	decoded, err := base64.StdEncoding.DecodeString(data)
 	if err != nil {
 		return "", err
@@ -82,9 +134,23 @@ func (kms *CloudKMS) Decrypt(key *CryptoKey, data string) (string, error) {
 		Name:       fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s", key.ProjectID, key.Location, key.KeyRing, key.Name),
 		Ciphertext: decoded,
 	}
-	response, err := kms.V1.Decrypt(ctx, request)
+	var response *v1objects.DecryptResponse
+	err = kms.Telemetry.Call(ctx, service, "Decrypt", "", key.Name, func(ctx context.Context) error {
+		return kms.Retry.Do(ctx, func() (err error) {
+			response, err = kms.V1.Decrypt(ctx, request, noClientRetry)
+			return err
+		})
+	})
 	if err != nil {
 		return "", err
 	}
 	return string(response.Plaintext), nil
 }
+
+// Close will release the underlying api client's connections
+func (kms *CloudKMS) Close() error {
+	if closer, ok := kms.V1.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -18,6 +18,24 @@ func (c *ClientMock) Decrypt(ctx context.Context, req *v1objects.DecryptRequest,
 	return &v1objects.DecryptResponse{}, nil
 }
 
+type contextKey string
+
+type ClientContextMock struct {
+	values []interface{}
+	opts   [][]gax.CallOption
+}
+
+func (c *ClientContextMock) Encrypt(ctx context.Context, req *v1objects.EncryptRequest, opts ...gax.CallOption) (*v1objects.EncryptResponse, error) {
+	c.values = append(c.values, ctx.Value(contextKey("test")))
+	c.opts = append(c.opts, opts)
+	return &v1objects.EncryptResponse{}, nil
+}
+func (c *ClientContextMock) Decrypt(ctx context.Context, req *v1objects.DecryptRequest, opts ...gax.CallOption) (*v1objects.DecryptResponse, error) {
+	c.values = append(c.values, ctx.Value(contextKey("test")))
+	c.opts = append(c.opts, opts)
+	return &v1objects.DecryptResponse{}, nil
+}
+
 func TestEncryptDecrypt(t *testing.T) {
 	kms := &CloudKMS{}
 	err := kms.Initialize("", loggermock.GetLogMock())
@@ -40,3 +58,42 @@ func TestEncryptDecrypt(t *testing.T) {
 		t.Errorf("Got unexpected error from kms.Decrypt(): %s", err)
 	}
 }
+
+func TestEncryptDecryptCtx(t *testing.T) {
+	kms := &CloudKMS{}
+	err := kms.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudkms.Initialize(): %s", err)
+	}
+	client := &ClientContextMock{}
+	kms.V1 = client
+	cryptoKey := &CryptoKey{
+		ProjectID: "test-project",
+		Location:  "us-central1",
+		KeyRing:   "test-keyring",
+		Name:      "test-encryption-key",
+	}
+	ctx := context.WithValue(context.Background(), contextKey("test"), "value")
+	encrypted, err := kms.EncryptCtx(ctx, cryptoKey, "some data")
+	if err != nil {
+		t.Errorf("Got unexpected error from kms.EncryptCtx(): %s", err)
+	}
+	_, err = kms.DecryptCtx(ctx, cryptoKey, encrypted)
+	if err != nil {
+		t.Errorf("Got unexpected error from kms.DecryptCtx(): %s", err)
+	}
+	for _, value := range client.values {
+		if value != "value" {
+			t.Errorf("Expected the provided context to be passed to the kms client, but got value: %v", value)
+		}
+	}
+	for _, opts := range client.opts {
+		settings := &gax.CallSettings{}
+		for _, opt := range opts {
+			opt.Resolve(settings)
+		}
+		if settings.Retry == nil || settings.Retry() != nil {
+			t.Errorf("Expected the kms client's own retries to be turned off, so that only the library's policy retries")
+		}
+	}
+}
@@ -2,72 +2,336 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
 	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
+// service is the name of the library in the telemetry of its calls
+const service = "cloudresourcemanager"
+
 // FoldersSearchCallInterface is an interface to a call to search for a folder
 type FoldersSearchCallInterface interface {
-	Do(call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error)
+	Do(ctx context.Context, call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error)
+}
+
+// FoldersGetCallInterface is an interface to a call to get a folder
+type FoldersGetCallInterface interface {
+	Do(ctx context.Context, call *v2beta1.FoldersGetCall, opts ...googleapi.CallOption) (*v2beta1.Folder, error)
 }
 
 // FoldersCreateCallInterface is an interface to a call to create a folder
 type FoldersCreateCallInterface interface {
-	Do(call *v2beta1.FoldersCreateCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error)
+	Do(ctx context.Context, call *v2beta1.FoldersCreateCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error)
+}
+
+// FoldersMoveCallInterface is an interface to a call to move a folder to another parent
+type FoldersMoveCallInterface interface {
+	Do(ctx context.Context, call *v2beta1.FoldersMoveCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error)
+}
+
+// FoldersPatchCallInterface is an interface to a call to update a folder
+type FoldersPatchCallInterface interface {
+	Do(ctx context.Context, call *v2beta1.FoldersPatchCall, opts ...googleapi.CallOption) (*v2beta1.Folder, error)
+}
+
+// FoldersDeleteCallInterface is an interface to a call to delete a folder
+type FoldersDeleteCallInterface interface {
+	Do(ctx context.Context, call *v2beta1.FoldersDeleteCall, opts ...googleapi.CallOption) (*v2beta1.Folder, error)
+}
+
+// FoldersUndeleteCallInterface is an interface to a call to undelete a folder
+type FoldersUndeleteCallInterface interface {
+	Do(ctx context.Context, call *v2beta1.FoldersUndeleteCall, opts ...googleapi.CallOption) (*v2beta1.Folder, error)
 }
 
 // FoldersGetIAMPolicyCallInterface is an interface to a call to get the iam policy for a folder
 type FoldersGetIAMPolicyCallInterface interface {
-	Do(call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error)
+	Do(ctx context.Context, call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error)
 }
 
 // FoldersSetIAMPolicyCallInterface is an interface to a call to set the iam policy for a folder
 type FoldersSetIAMPolicyCallInterface interface {
-	Do(call *v2beta1.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error)
+	Do(ctx context.Context, call *v2beta1.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error)
 }
 
 // FoldersSetOrgPolicyCallInterface is an interface to a call to set an org policy constraint on a folder
 type FoldersSetOrgPolicyCallInterface interface {
-	Do(call *v1.FoldersSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
+	Do(ctx context.Context, call *v1.FoldersSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
+}
+
+// FoldersGetOrgPolicyCallInterface is an interface to a call to get an org policy constraint of a folder
+type FoldersGetOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.FoldersGetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
+}
+
+// FoldersGetEffectiveOrgPolicyCallInterface is an interface to a call to get the effective org policy constraint of a folder
+type FoldersGetEffectiveOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.FoldersGetEffectiveOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
+}
+
+// FoldersListOrgPoliciesCallInterface is an interface to a call to list the org policies set on a folder
+type FoldersListOrgPoliciesCallInterface interface {
+	Do(ctx context.Context, call *v1.FoldersListOrgPoliciesCall, opts ...googleapi.CallOption) (*v1.ListOrgPoliciesResponse, error)
+}
+
+// FoldersClearOrgPolicyCallInterface is an interface to a call to clear an org policy constraint of a folder
+type FoldersClearOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.FoldersClearOrgPolicyCall, opts ...googleapi.CallOption) (*v1.Empty, error)
 }
 
 // FoldersSearchCall is the default implementation for FoldersSearchCallInterface
-type FoldersSearchCall struct{}
+type FoldersSearchCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersGetCall is the default implementation for FoldersGetCallInterface
+type FoldersGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // FoldersCreateCall is the default implementation for FoldersCreateCallInterface
-type FoldersCreateCall struct{}
+type FoldersCreateCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersMoveCall is the default implementation for FoldersMoveCallInterface
+type FoldersMoveCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersPatchCall is the default implementation for FoldersPatchCallInterface
+type FoldersPatchCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersDeleteCall is the default implementation for FoldersDeleteCallInterface
+type FoldersDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersUndeleteCall is the default implementation for FoldersUndeleteCallInterface
+type FoldersUndeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // FoldersGetIAMPolicyCall is the default implementation for FoldersGetIAMPolicyCallInterface
-type FoldersGetIAMPolicyCall struct{}
+type FoldersGetIAMPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // FoldersSetIAMPolicyCall is the default implementation for FoldersSetIAMPolicyCallInterface
-type FoldersSetIAMPolicyCall struct{}
+type FoldersSetIAMPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // FoldersSetOrgPolicyCall is the default implementation for FoldersSetOrgPolicyCallInterface
-type FoldersSetOrgPolicyCall struct{}
+type FoldersSetOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersGetOrgPolicyCall is the default implementation for FoldersGetOrgPolicyCallInterface
+type FoldersGetOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersGetEffectiveOrgPolicyCall is the default implementation for FoldersGetEffectiveOrgPolicyCallInterface
+type FoldersGetEffectiveOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersListOrgPoliciesCall is the default implementation for FoldersListOrgPoliciesCallInterface
+type FoldersListOrgPoliciesCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// FoldersClearOrgPolicyCall is the default implementation for FoldersClearOrgPolicyCallInterface
+type FoldersClearOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersSearchCall) Do(ctx context.Context, call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error) {
+	var result *v2beta1.SearchFoldersResponse
+	err := c.Telemetry.Do(ctx, service, "FoldersSearch", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersGetCall) Do(ctx context.Context, call *v2beta1.FoldersGetCall, opts ...googleapi.CallOption) (*v2beta1.Folder, error) {
+	var result *v2beta1.Folder
+	err := c.Telemetry.Do(ctx, service, "FoldersGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersCreateCall) Do(ctx context.Context, call *v2beta1.FoldersCreateCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error) {
+	var result *v2beta1.Operation
+	err := c.Telemetry.Do(ctx, service, "FoldersCreate", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersMoveCall) Do(ctx context.Context, call *v2beta1.FoldersMoveCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error) {
+	var result *v2beta1.Operation
+	err := c.Telemetry.Do(ctx, service, "FoldersMove", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersPatchCall) Do(ctx context.Context, call *v2beta1.FoldersPatchCall, opts ...googleapi.CallOption) (*v2beta1.Folder, error) {
+	var result *v2beta1.Folder
+	err := c.Telemetry.Do(ctx, service, "FoldersPatch", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersDeleteCall) Do(ctx context.Context, call *v2beta1.FoldersDeleteCall, opts ...googleapi.CallOption) (*v2beta1.Folder, error) {
+	var result *v2beta1.Folder
+	err := c.Telemetry.Do(ctx, service, "FoldersDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersUndeleteCall) Do(ctx context.Context, call *v2beta1.FoldersUndeleteCall, opts ...googleapi.CallOption) (*v2beta1.Folder, error) {
+	var result *v2beta1.Folder
+	err := c.Telemetry.Do(ctx, service, "FoldersUndelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersGetIAMPolicyCall) Do(ctx context.Context, call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
+	var result *v2beta1.Policy
+	err := c.Telemetry.Do(ctx, service, "FoldersGetIAMPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *FoldersSetIAMPolicyCall) Do(ctx context.Context, call *v2beta1.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
+	var result *v2beta1.Policy
+	err := c.Telemetry.Do(ctx, service, "FoldersSetIAMPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *FoldersSearchCall) Do(call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error) {
-	return call.Do(opts...)
+func (c *FoldersSetOrgPolicyCall) Do(ctx context.Context, call *v1.FoldersSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "FoldersSetOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *FoldersCreateCall) Do(call *v2beta1.FoldersCreateCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error) {
-	return call.Do(opts...)
+func (c *FoldersGetOrgPolicyCall) Do(ctx context.Context, call *v1.FoldersGetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "FoldersGetOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *FoldersGetIAMPolicyCall) Do(call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
-	return call.Do(opts...)
+func (c *FoldersGetEffectiveOrgPolicyCall) Do(ctx context.Context, call *v1.FoldersGetEffectiveOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "FoldersGetEffectiveOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *FoldersSetIAMPolicyCall) Do(call *v2beta1.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
-	return call.Do(opts...)
+func (c *FoldersListOrgPoliciesCall) Do(ctx context.Context, call *v1.FoldersListOrgPoliciesCall, opts ...googleapi.CallOption) (*v1.ListOrgPoliciesResponse, error) {
+	var result *v1.ListOrgPoliciesResponse
+	err := c.Telemetry.Do(ctx, service, "FoldersListOrgPolicies", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *FoldersSetOrgPolicyCall) Do(call *v1.FoldersSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
-	return call.Do(opts...)
+func (c *FoldersClearOrgPolicyCall) Do(ctx context.Context, call *v1.FoldersClearOrgPolicyCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
+	var result *v1.Empty
+	err := c.Telemetry.Do(ctx, service, "FoldersClearOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -0,0 +1,79 @@
+package calls
+
+import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	googleapi "google.golang.org/api/googleapi"
+)
+
+// LiensCreateCallInterface is an interface to a call to create a lien
+type LiensCreateCallInterface interface {
+	Do(ctx context.Context, call *v1.LiensCreateCall, opts ...googleapi.CallOption) (*v1.Lien, error)
+}
+
+// LiensListCallInterface is an interface to a call to list the liens of a resource
+type LiensListCallInterface interface {
+	Do(ctx context.Context, call *v1.LiensListCall, opts ...googleapi.CallOption) (*v1.ListLiensResponse, error)
+}
+
+// LiensDeleteCallInterface is an interface to a call to delete a lien
+type LiensDeleteCallInterface interface {
+	Do(ctx context.Context, call *v1.LiensDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error)
+}
+
+// LiensCreateCall is the default implementation for LiensCreateCallInterface
+type LiensCreateCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// LiensListCall is the default implementation for LiensListCallInterface
+type LiensListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// LiensDeleteCall is the default implementation for LiensDeleteCallInterface
+type LiensDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *LiensCreateCall) Do(ctx context.Context, call *v1.LiensCreateCall, opts ...googleapi.CallOption) (*v1.Lien, error) {
+	var result *v1.Lien
+	err := c.Telemetry.Do(ctx, service, "LiensCreate", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *LiensListCall) Do(ctx context.Context, call *v1.LiensListCall, opts ...googleapi.CallOption) (*v1.ListLiensResponse, error) {
+	var result *v1.ListLiensResponse
+	err := c.Telemetry.Do(ctx, service, "LiensList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *LiensDeleteCall) Do(ctx context.Context, call *v1.LiensDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
+	var result *v1.Empty
+	err := c.Telemetry.Do(ctx, service, "LiensDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
@@ -0,0 +1,58 @@
+package calls
+
+import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+	v3 "google.golang.org/api/cloudresourcemanager/v3"
+	googleapi "google.golang.org/api/googleapi"
+)
+
+// OperationsGetCallInterface is an interface to a call to get the state of a long running operation
+type OperationsGetCallInterface interface {
+	Do(ctx context.Context, call *v2beta1.OperationsGetCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error)
+}
+
+// V3OperationsGetCallInterface is an interface to a call to get the state of a long running operation started
+// through the v3 api, e.g. a project move
+type V3OperationsGetCallInterface interface {
+	Do(ctx context.Context, call *v3.OperationsGetCall, opts ...googleapi.CallOption) (*v3.Operation, error)
+}
+
+// OperationsGetCall is the default implementation for OperationsGetCallInterface
+type OperationsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// V3OperationsGetCall is the default implementation for V3OperationsGetCallInterface
+type V3OperationsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *OperationsGetCall) Do(ctx context.Context, call *v2beta1.OperationsGetCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error) {
+	var result *v2beta1.Operation
+	err := c.Telemetry.Do(ctx, service, "OperationsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *V3OperationsGetCall) Do(ctx context.Context, call *v3.OperationsGetCall, opts ...googleapi.CallOption) (*v3.Operation, error) {
+	var result *v3.Operation
+	err := c.Telemetry.Do(ctx, service, "V3OperationsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
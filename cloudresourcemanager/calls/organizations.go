@@ -1,32 +1,171 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // OrganizationsGetIAMPolicyCallInterface is an interface to a call to get the iam policy for a organization
 type OrganizationsGetIAMPolicyCallInterface interface {
-	Do(call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
+	Do(ctx context.Context, call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
 }
 
 // OrganizationsSetIAMPolicyCallInterface is an interface to a call to set the iam policy for a organization
 type OrganizationsSetIAMPolicyCallInterface interface {
-	Do(call *v1.OrganizationsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
+	Do(ctx context.Context, call *v1.OrganizationsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
+}
+
+// OrganizationsGetOrgPolicyCallInterface is an interface to a call to get an org policy constraint of an organization
+type OrganizationsGetOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.OrganizationsGetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
+}
+
+// OrganizationsGetEffectiveOrgPolicyCallInterface is an interface to a call to get the effective org policy constraint of an organization
+type OrganizationsGetEffectiveOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.OrganizationsGetEffectiveOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
+}
+
+// OrganizationsListOrgPoliciesCallInterface is an interface to a call to list the org policies set on an organization
+type OrganizationsListOrgPoliciesCallInterface interface {
+	Do(ctx context.Context, call *v1.OrganizationsListOrgPoliciesCall, opts ...googleapi.CallOption) (*v1.ListOrgPoliciesResponse, error)
+}
+
+// OrganizationsClearOrgPolicyCallInterface is an interface to a call to clear an org policy constraint of an organization
+type OrganizationsClearOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.OrganizationsClearOrgPolicyCall, opts ...googleapi.CallOption) (*v1.Empty, error)
+}
+
+// OrganizationsSetOrgPolicyCallInterface is an interface to a call to set an org policy constraint on an organization
+type OrganizationsSetOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.OrganizationsSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
 }
 
 // OrganizationsGetIAMPolicyCall is the default implementation for OrganizationsGetIAMPolicyCallInterface
-type OrganizationsGetIAMPolicyCall struct{}
+type OrganizationsGetIAMPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // OrganizationsSetIAMPolicyCall is the default implementation for OrganizationsSetIAMPolicyCallInterface
-type OrganizationsSetIAMPolicyCall struct{}
+type OrganizationsSetIAMPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// OrganizationsGetOrgPolicyCall is the default implementation for OrganizationsGetOrgPolicyCallInterface
+type OrganizationsGetOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// OrganizationsGetEffectiveOrgPolicyCall is the default implementation for OrganizationsGetEffectiveOrgPolicyCallInterface
+type OrganizationsGetEffectiveOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// OrganizationsListOrgPoliciesCall is the default implementation for OrganizationsListOrgPoliciesCallInterface
+type OrganizationsListOrgPoliciesCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// OrganizationsClearOrgPolicyCall is the default implementation for OrganizationsClearOrgPolicyCallInterface
+type OrganizationsClearOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// OrganizationsSetOrgPolicyCall is the default implementation for OrganizationsSetOrgPolicyCallInterface
+type OrganizationsSetOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *OrganizationsGetIAMPolicyCall) Do(ctx context.Context, call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+	var result *v1.Policy
+	err := c.Telemetry.Do(ctx, service, "OrganizationsGetIAMPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *OrganizationsSetIAMPolicyCall) Do(ctx context.Context, call *v1.OrganizationsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+	var result *v1.Policy
+	err := c.Telemetry.Do(ctx, service, "OrganizationsSetIAMPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *OrganizationsGetOrgPolicyCall) Do(ctx context.Context, call *v1.OrganizationsGetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "OrganizationsGetOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *OrganizationsGetEffectiveOrgPolicyCall) Do(ctx context.Context, call *v1.OrganizationsGetEffectiveOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "OrganizationsGetEffectiveOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *OrganizationsListOrgPoliciesCall) Do(ctx context.Context, call *v1.OrganizationsListOrgPoliciesCall, opts ...googleapi.CallOption) (*v1.ListOrgPoliciesResponse, error) {
+	var result *v1.ListOrgPoliciesResponse
+	err := c.Telemetry.Do(ctx, service, "OrganizationsListOrgPolicies", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *OrganizationsGetIAMPolicyCall) Do(call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
-	return call.Do(opts...)
+func (c *OrganizationsClearOrgPolicyCall) Do(ctx context.Context, call *v1.OrganizationsClearOrgPolicyCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
+	var result *v1.Empty
+	err := c.Telemetry.Do(ctx, service, "OrganizationsClearOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *OrganizationsSetIAMPolicyCall) Do(call *v1.OrganizationsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
-	return call.Do(opts...)
+func (c *OrganizationsSetOrgPolicyCall) Do(ctx context.Context, call *v1.OrganizationsSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "OrganizationsSetOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
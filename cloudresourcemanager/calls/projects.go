@@ -1,98 +1,357 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	v3 "google.golang.org/api/cloudresourcemanager/v3"
 	googleapi "google.golang.org/api/googleapi"
 	suv1 "google.golang.org/api/serviceusage/v1"
 )
 
 // ProjectsListCallInterface is an interface to a call to list projects
 type ProjectsListCallInterface interface {
-	Do(call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error)
+	Do(ctx context.Context, call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error)
 }
 
 // ProjectsGetCallInterface is an interface to a call to get a single project
 type ProjectsGetCallInterface interface {
-	Do(call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error)
+	Do(ctx context.Context, call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error)
 }
 
 // ProjectsCreateCallInterface is an interface to a call to create a project
 type ProjectsCreateCallInterface interface {
-	Do(call *v1.ProjectsCreateCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.ProjectsCreateCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // ProjectsDeleteCallInterface is an interface to a call to delete a project
 type ProjectsDeleteCallInterface interface {
-	Do(call *v1.ProjectsDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error)
+	Do(ctx context.Context, call *v1.ProjectsDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error)
+}
+
+// ProjectsUpdateCallInterface is an interface to a call to update a project, e.g. its labels
+type ProjectsUpdateCallInterface interface {
+	Do(ctx context.Context, call *v1.ProjectsUpdateCall, opts ...googleapi.CallOption) (*v1.Project, error)
+}
+
+// ProjectsUndeleteCallInterface is an interface to a call to undelete a project
+type ProjectsUndeleteCallInterface interface {
+	Do(ctx context.Context, call *v1.ProjectsUndeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error)
+}
+
+// ProjectsMoveCallInterface is an interface to a call to move a project to another parent
+type ProjectsMoveCallInterface interface {
+	Do(ctx context.Context, call *v3.ProjectsMoveCall, opts ...googleapi.CallOption) (*v3.Operation, error)
 }
 
 // ProjectsGetIAMPolicyCallInterface is an interface to a call to get the iam policy for a project
 type ProjectsGetIAMPolicyCallInterface interface {
-	Do(call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
+	Do(ctx context.Context, call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
 }
 
 // ProjectsSetIAMPolicyCallInterface is an interface to a call to set the iam policy for a project
 type ProjectsSetIAMPolicyCallInterface interface {
-	Do(call *v1.ProjectsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
+	Do(ctx context.Context, call *v1.ProjectsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error)
 }
 
 // ServiceEnableCallInterface is an interface to call to enable a service/api on a project
 type ServiceEnableCallInterface interface {
-	Do(call *suv1.ServicesEnableCall, opts ...googleapi.CallOption) (*suv1.Operation, error)
+	Do(ctx context.Context, call *suv1.ServicesEnableCall, opts ...googleapi.CallOption) (*suv1.Operation, error)
+}
+
+// ProjectsGetOrgPolicyCallInterface is an interface to a call to get an org policy constraint of a project
+type ProjectsGetOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.ProjectsGetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
+}
+
+// ProjectsGetEffectiveOrgPolicyCallInterface is an interface to a call to get the effective org policy constraint of a project
+type ProjectsGetEffectiveOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.ProjectsGetEffectiveOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
+}
+
+// ProjectsListOrgPoliciesCallInterface is an interface to a call to list the org policies set on a project
+type ProjectsListOrgPoliciesCallInterface interface {
+	Do(ctx context.Context, call *v1.ProjectsListOrgPoliciesCall, opts ...googleapi.CallOption) (*v1.ListOrgPoliciesResponse, error)
+}
+
+// ProjectsClearOrgPolicyCallInterface is an interface to a call to clear an org policy constraint of a project
+type ProjectsClearOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.ProjectsClearOrgPolicyCall, opts ...googleapi.CallOption) (*v1.Empty, error)
+}
+
+// ProjectsSetOrgPolicyCallInterface is an interface to a call to set an org policy constraint on a project
+type ProjectsSetOrgPolicyCallInterface interface {
+	Do(ctx context.Context, call *v1.ProjectsSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error)
 }
 
 // ProjectsListCall is the default implementation for ProjectsListCallInterface
-type ProjectsListCall struct{}
+type ProjectsListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ProjectsGetCall is the default implementation for ProjectsGetCallInterface
-type ProjectsGetCall struct{}
+type ProjectsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ProjectsCreateCall is the default implementation for ProjectsCreateCallInterface
-type ProjectsCreateCall struct{}
+type ProjectsCreateCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ProjectsDeleteCall is the default implementation for ProjectsDeleteCallInterface
-type ProjectsDeleteCall struct{}
+type ProjectsDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ProjectsUpdateCall is the default implementation for ProjectsUpdateCallInterface
+type ProjectsUpdateCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ProjectsUndeleteCall is the default implementation for ProjectsUndeleteCallInterface
+type ProjectsUndeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ProjectsMoveCall is the default implementation for ProjectsMoveCallInterface
+type ProjectsMoveCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ProjectsGetIAMPolicyCall is the default implementation for ProjectsGetIAMPolicyCallInterface
-type ProjectsGetIAMPolicyCall struct{}
+type ProjectsGetIAMPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ProjectsSetIAMPolicyCall is the default implementation for ProjectsSetIAMPolicyCallInterface
-type ProjectsSetIAMPolicyCall struct{}
+type ProjectsSetIAMPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ServiceEnableCall is the default implementation for ServiceEnableCallInterface
-type ServiceEnableCall struct{}
+type ServiceEnableCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ProjectsGetOrgPolicyCall is the default implementation for ProjectsGetOrgPolicyCallInterface
+type ProjectsGetOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ProjectsGetEffectiveOrgPolicyCall is the default implementation for ProjectsGetEffectiveOrgPolicyCallInterface
+type ProjectsGetEffectiveOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ProjectsListOrgPoliciesCall is the default implementation for ProjectsListOrgPoliciesCallInterface
+type ProjectsListOrgPoliciesCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ProjectsClearOrgPolicyCall is the default implementation for ProjectsClearOrgPolicyCallInterface
+type ProjectsClearOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ProjectsSetOrgPolicyCall is the default implementation for ProjectsSetOrgPolicyCallInterface
+type ProjectsSetOrgPolicyCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ProjectsListCall) Do(ctx context.Context, call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
+	var result *v1.ListProjectsResponse
+	err := c.Telemetry.Do(ctx, service, "ProjectsList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ProjectsGetCall) Do(ctx context.Context, call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error) {
+	var result *v1.Project
+	err := c.Telemetry.Do(ctx, service, "ProjectsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ProjectsCreateCall) Do(ctx context.Context, call *v1.ProjectsCreateCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "ProjectsCreate", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ProjectsDeleteCall) Do(ctx context.Context, call *v1.ProjectsDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
+	var result *v1.Empty
+	err := c.Telemetry.Do(ctx, service, "ProjectsDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ProjectsUpdateCall) Do(ctx context.Context, call *v1.ProjectsUpdateCall, opts ...googleapi.CallOption) (*v1.Project, error) {
+	var result *v1.Project
+	err := c.Telemetry.Do(ctx, service, "ProjectsUpdate", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ProjectsUndeleteCall) Do(ctx context.Context, call *v1.ProjectsUndeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
+	var result *v1.Empty
+	err := c.Telemetry.Do(ctx, service, "ProjectsUndelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ProjectsMoveCall) Do(ctx context.Context, call *v3.ProjectsMoveCall, opts ...googleapi.CallOption) (*v3.Operation, error) {
+	var result *v3.Operation
+	err := c.Telemetry.Do(ctx, service, "ProjectsMove", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ProjectsGetIAMPolicyCall) Do(ctx context.Context, call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+	var result *v1.Policy
+	err := c.Telemetry.Do(ctx, service, "ProjectsGetIAMPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsListCall) Do(call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
-	return call.Do(opts...)
+func (c *ProjectsSetIAMPolicyCall) Do(ctx context.Context, call *v1.ProjectsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+	var result *v1.Policy
+	err := c.Telemetry.Do(ctx, service, "ProjectsSetIAMPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsGetCall) Do(call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error) {
-	return call.Do(opts...)
+func (c *ServiceEnableCall) Do(ctx context.Context, call *suv1.ServicesEnableCall, opts ...googleapi.CallOption) (*suv1.Operation, error) {
+	var result *suv1.Operation
+	err := c.Telemetry.Do(ctx, service, "ServiceEnable", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsCreateCall) Do(call *v1.ProjectsCreateCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *ProjectsGetOrgPolicyCall) Do(ctx context.Context, call *v1.ProjectsGetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "ProjectsGetOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsDeleteCall) Do(call *v1.ProjectsDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
-	return call.Do(opts...)
+func (c *ProjectsGetEffectiveOrgPolicyCall) Do(ctx context.Context, call *v1.ProjectsGetEffectiveOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "ProjectsGetEffectiveOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsGetIAMPolicyCall) Do(call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
-	return call.Do(opts...)
+func (c *ProjectsListOrgPoliciesCall) Do(ctx context.Context, call *v1.ProjectsListOrgPoliciesCall, opts ...googleapi.CallOption) (*v1.ListOrgPoliciesResponse, error) {
+	var result *v1.ListOrgPoliciesResponse
+	err := c.Telemetry.Do(ctx, service, "ProjectsListOrgPolicies", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsSetIAMPolicyCall) Do(call *v1.ProjectsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
-	return call.Do(opts...)
+func (c *ProjectsClearOrgPolicyCall) Do(ctx context.Context, call *v1.ProjectsClearOrgPolicyCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
+	var result *v1.Empty
+	err := c.Telemetry.Do(ctx, service, "ProjectsClearOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ServiceEnableCall) Do(call *suv1.ServicesEnableCall, opts ...googleapi.CallOption) (*suv1.Operation, error) {
-	return call.Do(opts...)
+func (c *ProjectsSetOrgPolicyCall) Do(ctx context.Context, call *v1.ProjectsSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+	var result *v1.OrgPolicy
+	err := c.Telemetry.Do(ctx, service, "ProjectsSetOrgPolicy", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
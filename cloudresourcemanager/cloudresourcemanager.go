@@ -3,100 +3,310 @@ package cloudresourcemanager
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/rockholla/go-google-lib/cloudresourcemanager/calls"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
 	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+	v3 "google.golang.org/api/cloudresourcemanager/v3"
 	"google.golang.org/api/option"
 	suv1 "google.golang.org/api/serviceusage/v1"
+	htransport "google.golang.org/api/transport/http"
 )
 
+// service is the name of the library in the events it emits
+const service = "cloudresourcemanager"
+
 // Interface represents functionality for CloudResourceManager
 type Interface interface {
 	Initialize(credentials string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error
 	GetFolder(displayName string, parent string) (string, error)
+	GetFolderCtx(ctx context.Context, displayName string, parent string) (string, error)
 	EnsureFolder(displayName string, parent string) (string, error)
+	EnsureFolderCtx(ctx context.Context, displayName string, parent string) (string, error)
+	EnsureFolderPath(parent string, path string) ([]string, error)
+	EnsureFolderPathCtx(ctx context.Context, parent string, path string) ([]string, error)
+	ResolveFolderPath(folder string) (string, string, error)
+	ResolveFolderPathCtx(ctx context.Context, folder string) (string, string, error)
+	ListFolders(parent string, recursive bool) ([]*v2beta1.Folder, error)
+	ListFoldersCtx(ctx context.Context, parent string, recursive bool) ([]*v2beta1.Folder, error)
+	MoveFolder(folder string, parent string) error
+	MoveFolderCtx(ctx context.Context, folder string, parent string) error
+	RenameFolder(folder string, displayName string) error
+	RenameFolderCtx(ctx context.Context, folder string, displayName string) error
+	DeleteFolder(folder string, recursive bool) error
+	DeleteFolderCtx(ctx context.Context, folder string, recursive bool) error
+	UndeleteFolder(folder string) error
+	UndeleteFolderCtx(ctx context.Context, folder string) error
 	EnsureFolderRoles(folder string, member string, roles []string) error
+	EnsureFolderRolesCtx(ctx context.Context, folder string, member string, roles []string) error
+	EnsureFolderRolesWithCondition(folder string, member string, roles []string, condition *iampolicy.Condition) error
+	EnsureFolderRolesWithConditionCtx(ctx context.Context, folder string, member string, roles []string, condition *iampolicy.Condition) error
+	RemoveFolderRoles(folder string, member string, roles []string) ([]string, error)
+	RemoveFolderRolesCtx(ctx context.Context, folder string, member string, roles []string) ([]string, error)
+	RemoveFolderRolesWithCondition(folder string, member string, roles []string, condition *iampolicy.Condition) ([]string, error)
+	RemoveFolderRolesWithConditionCtx(ctx context.Context, folder string, member string, roles []string, condition *iampolicy.Condition) ([]string, error)
+	RemoveFolderMember(folder string, member string) ([]string, error)
+	RemoveFolderMemberCtx(ctx context.Context, folder string, member string) ([]string, error)
+	DiffFolderPolicy(folder string, spec *iampolicy.Spec) ([]plan.Change, error)
+	DiffFolderPolicyCtx(ctx context.Context, folder string, spec *iampolicy.Spec) ([]plan.Change, error)
+	ReconcileFolderPolicy(folder string, spec *iampolicy.Spec) ([]plan.Change, error)
+	ReconcileFolderPolicyCtx(ctx context.Context, folder string, spec *iampolicy.Spec) ([]plan.Change, error)
 	SetFolderOrgPolicy(folder string, policy *v1.OrgPolicy) error
+	SetFolderOrgPolicyCtx(ctx context.Context, folder string, policy *v1.OrgPolicy) error
 	GetProject(name string, parent string) (*v1.Project, error)
+	GetProjectCtx(ctx context.Context, name string, parent string) (*v1.Project, error)
 	DeleteProject(id string) error
+	DeleteProjectCtx(ctx context.Context, id string) error
 	GetProjectByID(id string) (*v1.Project, error)
+	GetProjectByIDCtx(ctx context.Context, id string) (*v1.Project, error)
+	MoveProject(id string, parent string) error
+	MoveProjectCtx(ctx context.Context, id string, parent string) error
+	UndeleteProject(id string) error
+	UndeleteProjectCtx(ctx context.Context, id string) error
+	SetProjectLabels(id string, labels map[string]string) error
+	SetProjectLabelsCtx(ctx context.Context, id string, labels map[string]string) error
+	MergeProjectLabels(id string, labels map[string]string) error
+	MergeProjectLabelsCtx(ctx context.Context, id string, labels map[string]string) error
+	CreateProjectLien(id string, reason string, origin string) (*v1.Lien, error)
+	CreateProjectLienCtx(ctx context.Context, id string, reason string, origin string) (*v1.Lien, error)
+	ListProjectLiens(id string) ([]*v1.Lien, error)
+	ListProjectLiensCtx(ctx context.Context, id string) ([]*v1.Lien, error)
+	RemoveProjectLien(id string, lien string) error
+	RemoveProjectLienCtx(ctx context.Context, id string, lien string) error
 	EnsureProject(name string, parent string) (string, int64, error)
+	EnsureProjectCtx(ctx context.Context, name string, parent string) (string, int64, error)
 	EnableProjectServices(projectID string, services []string) error
+	EnableProjectServicesCtx(ctx context.Context, projectID string, services []string) error
 	EnsureProjectRoles(project string, member string, roles []string) error
+	EnsureProjectRolesCtx(ctx context.Context, project string, member string, roles []string) error
+	EnsureProjectRolesWithCondition(project string, member string, roles []string, condition *iampolicy.Condition) error
+	EnsureProjectRolesWithConditionCtx(ctx context.Context, project string, member string, roles []string, condition *iampolicy.Condition) error
+	RemoveProjectRoles(project string, member string, roles []string) ([]string, error)
+	RemoveProjectRolesCtx(ctx context.Context, project string, member string, roles []string) ([]string, error)
+	RemoveProjectRolesWithCondition(project string, member string, roles []string, condition *iampolicy.Condition) ([]string, error)
+	RemoveProjectRolesWithConditionCtx(ctx context.Context, project string, member string, roles []string, condition *iampolicy.Condition) ([]string, error)
+	RemoveProjectMember(project string, member string) ([]string, error)
+	RemoveProjectMemberCtx(ctx context.Context, project string, member string) ([]string, error)
+	DiffProjectPolicy(project string, spec *iampolicy.Spec) ([]plan.Change, error)
+	DiffProjectPolicyCtx(ctx context.Context, project string, spec *iampolicy.Spec) ([]plan.Change, error)
+	ReconcileProjectPolicy(project string, spec *iampolicy.Spec) ([]plan.Change, error)
+	ReconcileProjectPolicyCtx(ctx context.Context, project string, spec *iampolicy.Spec) ([]plan.Change, error)
 	EnsureOrganizationRoles(organization string, member string, roles []string) error
+	EnsureOrganizationRolesCtx(ctx context.Context, organization string, member string, roles []string) error
+	EnsureOrganizationRolesWithCondition(organization string, member string, roles []string, condition *iampolicy.Condition) error
+	EnsureOrganizationRolesWithConditionCtx(ctx context.Context, organization string, member string, roles []string, condition *iampolicy.Condition) error
 	RemoveOrganizationRoles(organization string, member string, roles []string) error
+	RemoveOrganizationRolesCtx(ctx context.Context, organization string, member string, roles []string) error
+	RemoveOrganizationRolesWithCondition(organization string, member string, roles []string, condition *iampolicy.Condition) error
+	RemoveOrganizationRolesWithConditionCtx(ctx context.Context, organization string, member string, roles []string, condition *iampolicy.Condition) error
+	RemoveOrganizationMember(organization string, member string) ([]string, error)
+	RemoveOrganizationMemberCtx(ctx context.Context, organization string, member string) ([]string, error)
+	DiffOrganizationPolicy(organization string, spec *iampolicy.Spec) ([]plan.Change, error)
+	DiffOrganizationPolicyCtx(ctx context.Context, organization string, spec *iampolicy.Spec) ([]plan.Change, error)
+	ReconcileOrganizationPolicy(organization string, spec *iampolicy.Spec) ([]plan.Change, error)
+	ReconcileOrganizationPolicyCtx(ctx context.Context, organization string, spec *iampolicy.Spec) ([]plan.Change, error)
+	GetOrgPolicy(resource string, constraint string) (*v1.OrgPolicy, error)
+	GetOrgPolicyCtx(ctx context.Context, resource string, constraint string) (*v1.OrgPolicy, error)
+	GetEffectiveOrgPolicy(resource string, constraint string) (*v1.OrgPolicy, error)
+	GetEffectiveOrgPolicyCtx(ctx context.Context, resource string, constraint string) (*v1.OrgPolicy, error)
+	ListOrgPolicies(resource string) ([]*v1.OrgPolicy, error)
+	ListOrgPoliciesCtx(ctx context.Context, resource string) ([]*v1.OrgPolicy, error)
+	SetOrgPolicy(resource string, policy *v1.OrgPolicy) error
+	SetOrgPolicyCtx(ctx context.Context, resource string, policy *v1.OrgPolicy) error
+	EnsureOrgPolicy(resource string, policy *v1.OrgPolicy) (bool, error)
+	EnsureOrgPolicyCtx(ctx context.Context, resource string, policy *v1.OrgPolicy) (bool, error)
+	ClearOrgPolicy(resource string, constraint string) error
+	ClearOrgPolicyCtx(ctx context.Context, resource string, constraint string) error
 }
 
 // CloudResourceManager wraps google-provided apis for interacting with google.golang.org/api/cloudresourcemanager/*
 type CloudResourceManager struct {
-	log     logger.Interface
-	V1      *v1.Service
-	V2Beta1 *v2beta1.Service
-	SUV1    *suv1.Service
-	Calls   *Calls
+	log           logger.Interface
+	V1            *v1.Service
+	V2Beta1       *v2beta1.Service
+	V3            *v3.Service
+	SUV1          *suv1.Service
+	Calls         *Calls
+	Retry         *retry.Policy
+	ClientOptions []option.ClientOption
+	// Endpoint overrides where cloud resource manager api requests are sent, e.g. to a local emulator or fake
+	Endpoint string
+	// ServiceUsageEndpoint overrides where the service usage api requests enabling project services are sent, which
+	// is a different api to Endpoint's
+	ServiceUsageEndpoint string
+	// Insecure sends api requests without credentials, for an Endpoint that doesn't expect them
+	Insecure bool
+	// Transport is the base http transport api requests are sent through, underneath authentication, e.g. a
+	// recorder.Recorder to record or replay them
+	Transport http.RoundTripper
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
+	// ConflictRetry is how iam policy updates that conflict with a concurrent change to the policy are retried, from a
+	// fresh read of it, iampolicy.DefaultRetryPolicy() when not set
+	ConflictRetry *retry.Policy
+	// OperationPollSeconds is how long to wait between checks of a pending operation, e.g. a folder move, 3 when not
+	// set
+	OperationPollSeconds int64
+	// OperationTimeoutSeconds is how long to wait in total for an operation to finish, 600 when not set, a negative
+	// value meaning no limit
+	OperationTimeoutSeconds int64
 }
 
 // Calls are interfaces for making the actual calls to various underlying apis
 type Calls struct {
-	FoldersSearch             calls.FoldersSearchCallInterface
-	FoldersCreate             calls.FoldersCreateCallInterface
-	FoldersGetIAMPolicy       calls.FoldersGetIAMPolicyCallInterface
-	FoldersSetIAMPolicy       calls.FoldersSetIAMPolicyCallInterface
-	FoldersSetOrgPolicy       calls.FoldersSetOrgPolicyCallInterface
-	ProjectsList              calls.ProjectsListCallInterface
-	ProjectsGet               calls.ProjectsGetCallInterface
-	ProjectsCreate            calls.ProjectsCreateCallInterface
-	ProjectsDelete            calls.ProjectsDeleteCallInterface
-	ProjectsGetIAMPolicy      calls.ProjectsGetIAMPolicyCallInterface
-	ProjectsSetIAMPolicy      calls.ProjectsSetIAMPolicyCallInterface
-	ServiceEnable             calls.ServiceEnableCallInterface
-	OrganizationsGetIAMPolicy calls.OrganizationsGetIAMPolicyCallInterface
-	OrganizationsSetIAMPolicy calls.OrganizationsSetIAMPolicyCallInterface
+	FoldersSearch                      calls.FoldersSearchCallInterface
+	FoldersGet                         calls.FoldersGetCallInterface
+	FoldersCreate                      calls.FoldersCreateCallInterface
+	FoldersMove                        calls.FoldersMoveCallInterface
+	FoldersPatch                       calls.FoldersPatchCallInterface
+	FoldersDelete                      calls.FoldersDeleteCallInterface
+	FoldersUndelete                    calls.FoldersUndeleteCallInterface
+	FoldersGetIAMPolicy                calls.FoldersGetIAMPolicyCallInterface
+	FoldersSetIAMPolicy                calls.FoldersSetIAMPolicyCallInterface
+	FoldersSetOrgPolicy                calls.FoldersSetOrgPolicyCallInterface
+	FoldersGetOrgPolicy                calls.FoldersGetOrgPolicyCallInterface
+	FoldersGetEffectiveOrgPolicy       calls.FoldersGetEffectiveOrgPolicyCallInterface
+	FoldersListOrgPolicies             calls.FoldersListOrgPoliciesCallInterface
+	FoldersClearOrgPolicy              calls.FoldersClearOrgPolicyCallInterface
+	ProjectsList                       calls.ProjectsListCallInterface
+	ProjectsGet                        calls.ProjectsGetCallInterface
+	ProjectsCreate                     calls.ProjectsCreateCallInterface
+	ProjectsDelete                     calls.ProjectsDeleteCallInterface
+	ProjectsUpdate                     calls.ProjectsUpdateCallInterface
+	ProjectsUndelete                   calls.ProjectsUndeleteCallInterface
+	ProjectsMove                       calls.ProjectsMoveCallInterface
+	ProjectsGetIAMPolicy               calls.ProjectsGetIAMPolicyCallInterface
+	ProjectsSetIAMPolicy               calls.ProjectsSetIAMPolicyCallInterface
+	ProjectsGetOrgPolicy               calls.ProjectsGetOrgPolicyCallInterface
+	ProjectsGetEffectiveOrgPolicy      calls.ProjectsGetEffectiveOrgPolicyCallInterface
+	ProjectsListOrgPolicies            calls.ProjectsListOrgPoliciesCallInterface
+	ProjectsSetOrgPolicy               calls.ProjectsSetOrgPolicyCallInterface
+	ProjectsClearOrgPolicy             calls.ProjectsClearOrgPolicyCallInterface
+	LiensCreate                        calls.LiensCreateCallInterface
+	LiensList                          calls.LiensListCallInterface
+	LiensDelete                        calls.LiensDeleteCallInterface
+	ServiceEnable                      calls.ServiceEnableCallInterface
+	OrganizationsGetIAMPolicy          calls.OrganizationsGetIAMPolicyCallInterface
+	OrganizationsSetIAMPolicy          calls.OrganizationsSetIAMPolicyCallInterface
+	OrganizationsGetOrgPolicy          calls.OrganizationsGetOrgPolicyCallInterface
+	OrganizationsGetEffectiveOrgPolicy calls.OrganizationsGetEffectiveOrgPolicyCallInterface
+	OrganizationsListOrgPolicies       calls.OrganizationsListOrgPoliciesCallInterface
+	OrganizationsSetOrgPolicy          calls.OrganizationsSetOrgPolicyCallInterface
+	OrganizationsClearOrgPolicy        calls.OrganizationsClearOrgPolicyCallInterface
+	OperationsGet                      calls.OperationsGetCallInterface
+	V3OperationsGet                    calls.V3OperationsGetCallInterface
 }
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (crm *CloudResourceManager) Initialize(credentials string, log logger.Interface) error {
+	return crm.InitializeCtx(context.Background(), credentials, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (crm *CloudResourceManager) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	crm.log = log
+	if crm.Retry == nil {
+		crm.Retry = retry.DefaultPolicy()
+	}
+	if crm.ConflictRetry == nil {
+		crm.ConflictRetry = iampolicy.DefaultRetryPolicy()
+	}
+	if crm.OperationPollSeconds == 0 {
+		crm.OperationPollSeconds = 3
+	}
+	if crm.OperationTimeoutSeconds == 0 {
+		crm.OperationTimeoutSeconds = 600
+	}
 	crm.Calls = &Calls{
-		FoldersSearch:             &calls.FoldersSearchCall{},
-		FoldersCreate:             &calls.FoldersCreateCall{},
-		FoldersGetIAMPolicy:       &calls.FoldersGetIAMPolicyCall{},
-		FoldersSetIAMPolicy:       &calls.FoldersSetIAMPolicyCall{},
-		FoldersSetOrgPolicy:       &calls.FoldersSetOrgPolicyCall{},
-		ProjectsList:              &calls.ProjectsListCall{},
-		ProjectsGet:               &calls.ProjectsGetCall{},
-		ProjectsCreate:            &calls.ProjectsCreateCall{},
-		ProjectsDelete:            &calls.ProjectsDeleteCall{},
-		ProjectsGetIAMPolicy:      &calls.ProjectsGetIAMPolicyCall{},
-		ProjectsSetIAMPolicy:      &calls.ProjectsSetIAMPolicyCall{},
-		ServiceEnable:             &calls.ServiceEnableCall{},
-		OrganizationsGetIAMPolicy: &calls.OrganizationsGetIAMPolicyCall{},
-		OrganizationsSetIAMPolicy: &calls.OrganizationsSetIAMPolicyCall{},
+		FoldersSearch:                      &calls.FoldersSearchCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersGet:                         &calls.FoldersGetCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersCreate:                      &calls.FoldersCreateCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersMove:                        &calls.FoldersMoveCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersPatch:                       &calls.FoldersPatchCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersDelete:                      &calls.FoldersDeleteCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersUndelete:                    &calls.FoldersUndeleteCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersGetIAMPolicy:                &calls.FoldersGetIAMPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersSetIAMPolicy:                &calls.FoldersSetIAMPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersSetOrgPolicy:                &calls.FoldersSetOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersGetOrgPolicy:                &calls.FoldersGetOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersGetEffectiveOrgPolicy:       &calls.FoldersGetEffectiveOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersListOrgPolicies:             &calls.FoldersListOrgPoliciesCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		FoldersClearOrgPolicy:              &calls.FoldersClearOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsList:                       &calls.ProjectsListCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsGet:                        &calls.ProjectsGetCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsCreate:                     &calls.ProjectsCreateCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsDelete:                     &calls.ProjectsDeleteCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsUpdate:                     &calls.ProjectsUpdateCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsUndelete:                   &calls.ProjectsUndeleteCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsMove:                       &calls.ProjectsMoveCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsGetIAMPolicy:               &calls.ProjectsGetIAMPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsSetIAMPolicy:               &calls.ProjectsSetIAMPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsGetOrgPolicy:               &calls.ProjectsGetOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsGetEffectiveOrgPolicy:      &calls.ProjectsGetEffectiveOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsListOrgPolicies:            &calls.ProjectsListOrgPoliciesCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsSetOrgPolicy:               &calls.ProjectsSetOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ProjectsClearOrgPolicy:             &calls.ProjectsClearOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		LiensCreate:                        &calls.LiensCreateCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		LiensList:                          &calls.LiensListCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		LiensDelete:                        &calls.LiensDeleteCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		ServiceEnable:                      &calls.ServiceEnableCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		OrganizationsGetIAMPolicy:          &calls.OrganizationsGetIAMPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		OrganizationsSetIAMPolicy:          &calls.OrganizationsSetIAMPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		OrganizationsGetOrgPolicy:          &calls.OrganizationsGetOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		OrganizationsGetEffectiveOrgPolicy: &calls.OrganizationsGetEffectiveOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		OrganizationsListOrgPolicies:       &calls.OrganizationsListOrgPoliciesCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		OrganizationsSetOrgPolicy:          &calls.OrganizationsSetOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		OrganizationsClearOrgPolicy:        &calls.OrganizationsClearOrgPolicyCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		OperationsGet:                      &calls.OperationsGetCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
+		V3OperationsGet:                    &calls.V3OperationsGetCall{Retry: crm.Retry, Telemetry: crm.Telemetry},
 	}
-	if credentials != "" {
-		if crm.V1, err = v1.NewService(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-		if crm.V2Beta1, err = v2beta1.NewService(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-		if crm.SUV1, err = suv1.NewService(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-	} else {
-		if crm.V1, err = v1.NewService(ctx); err != nil {
-			return err
-		}
-		if crm.V2Beta1, err = v2beta1.NewService(ctx); err != nil {
-			return err
-		}
-		if crm.SUV1, err = suv1.NewService(ctx); err != nil {
+	clientOptions := append([]option.ClientOption{}, crm.ClientOptions...)
+	if credentials != "" && !crm.Insecure {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if crm.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication())
+	}
+	if crm.Transport != nil {
+		transport, err := htransport.NewTransport(ctx, crm.Transport, append([]option.ClientOption{option.WithScopes(v1.CloudPlatformScope)}, clientOptions...)...)
+		if err != nil {
 			return err
 		}
+		clientOptions = append(clientOptions, option.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	crmOptions := append([]option.ClientOption{}, clientOptions...)
+	if crm.Endpoint != "" {
+		crmOptions = append(crmOptions, option.WithEndpoint(crm.Endpoint))
+	}
+	if crm.V1, err = v1.NewService(ctx, crmOptions...); err != nil {
+		return err
+	}
+	if crm.V2Beta1, err = v2beta1.NewService(ctx, crmOptions...); err != nil {
+		return err
+	}
+	if crm.V3, err = v3.NewService(ctx, crmOptions...); err != nil {
+		return err
+	}
+	serviceUsageOptions := append([]option.ClientOption{}, clientOptions...)
+	if crm.ServiceUsageEndpoint != "" {
+		serviceUsageOptions = append(serviceUsageOptions, option.WithEndpoint(crm.ServiceUsageEndpoint))
+	}
+	if crm.SUV1, err = suv1.NewService(ctx, serviceUsageOptions...); err != nil {
+		return err
 	}
 	return nil
 }
@@ -27,4 +27,28 @@ func TestInitialize(t *testing.T) {
 	if err != nil {
 		t.Errorf("Got unexpected error during cloudresourcemanager.Initialize() with explicit credentials: %s", err)
 	}
+	crm = &CloudResourceManager{OperationTimeoutSeconds: -1}
+	if err = crm.Initialize("", loggermock.GetLogMock()); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.Initialize() without an operation timeout: %s", err)
+	}
+	if crm.OperationPollSeconds != 3 || crm.OperationTimeoutSeconds != -1 {
+		t.Errorf("Expected cloudresourcemanager.Initialize() to keep no operation timeout with the default poll, got %d and %d", crm.OperationPollSeconds, crm.OperationTimeoutSeconds)
+	}
+}
+
+func TestInitializeEndpoints(t *testing.T) {
+	crm := &CloudResourceManager{Endpoint: "http://localhost:8080/", Insecure: true}
+	if err := crm.Initialize("", loggermock.GetLogMock()); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.Initialize() with an endpoint: %s", err)
+	}
+	if crm.V1.BasePath != "http://localhost:8080/" || crm.SUV1.BasePath != "https://serviceusage.googleapis.com/" {
+		t.Errorf("Expected only the cloud resource manager apis to use the endpoint, got %s and %s", crm.V1.BasePath, crm.SUV1.BasePath)
+	}
+	crm.ServiceUsageEndpoint = "http://localhost:8081/"
+	if err := crm.Initialize("", loggermock.GetLogMock()); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.Initialize() with a service usage endpoint: %s", err)
+	}
+	if crm.SUV1.BasePath != "http://localhost:8081/" {
+		t.Errorf("Expected the service usage api to use its endpoint, got %s", crm.SUV1.BasePath)
+	}
 }
@@ -2,49 +2,71 @@ package cloudresourcemanager
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
 	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
 )
 
 // GetFolder returns an existing folder name, blank if none found
 func (crm *CloudResourceManager) GetFolder(displayName string, parent string) (string, error) {
-	ctx := context.Background()
-	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
-	query := fmt.Sprintf("displayName=%s AND lifecycleState=ACTIVE", displayName)
+	return crm.GetFolderCtx(context.Background(), displayName, parent)
+}
+
+// GetFolderCtx is GetFolder, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) GetFolderCtx(ctx context.Context, displayName string, parent string) (folder string, err error) {
+	defer events.Start(crm.Events, service, "GetFolder", events.ActionRead, "", displayName).Done(&err)
+	return crm.findFolder(ctx, displayName, parent)
+}
+
+// findFolder is GetFolderCtx without its event, for use within other methods. The search matches display names
+// loosely, so only a folder with exactly the display name, and parent if there is one, is returned.
+func (crm *CloudResourceManager) findFolder(ctx context.Context, displayName string, parent string) (string, error) {
+	query := fmt.Sprintf("displayName=%q AND lifecycleState=ACTIVE", displayName)
 	if parent != "" {
 		query = fmt.Sprintf("%s AND parent=%s", query, parent)
 	}
-	folderSearchRequest := &v2beta1.SearchFoldersRequest{
-		PageSize: 1,
-		Query:    query,
-	}
-	folderSearchCall := foldersService.Search(folderSearchRequest).Context(ctx)
-	folderSearchResponse, err := crm.Calls.FoldersSearch.Do(folderSearchCall)
+	folders, err := crm.searchFolders(ctx, query, displayName)
 	if err != nil {
 		return "", err
 	}
-	if len(folderSearchResponse.Folders) == 0 {
-		return "", nil
+	for _, folder := range folders {
+		if folder.DisplayName == displayName && (parent == "" || folder.Parent == parent) {
+			return folder.Name, nil
+		}
 	}
-	return folderSearchResponse.Folders[0].Name, nil
+	return "", nil
 }
 
 // EnsureFolder will make sure that a folder exists, creates it if it doesn't already exist, nothing if it does,
-// returns either new or existing folder name
+// returns either new or existing folder name. A dry run returns a blank name for a folder that would be created.
 func (crm *CloudResourceManager) EnsureFolder(displayName string, parent string) (string, error) {
+	return crm.EnsureFolderCtx(context.Background(), displayName, parent)
+}
+
+// EnsureFolderCtx is EnsureFolder, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) EnsureFolderCtx(ctx context.Context, displayName string, parent string) (folderName string, err error) {
+	defer events.Start(crm.Events, service, "EnsureFolder", events.ActionEnsure, "", displayName).Done(&err)
+	return crm.ensureFolder(ctx, displayName, parent)
+}
+
+// ensureFolder is EnsureFolderCtx without its event, for use within other methods
+func (crm *CloudResourceManager) ensureFolder(ctx context.Context, displayName string, parent string) (folderName string, err error) {
 	crm.log.InfoPart("Ensuring that folder %s exists", displayName)
 	if parent != "" {
 		crm.log.InfoPart(" in %s...", parent)
 	}
-	ctx := context.Background()
 	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
-	name, err := crm.GetFolder(displayName, parent)
+	name, err := crm.findFolder(ctx, displayName, parent)
 	if err != nil {
 		crm.log.InfoPart("\n")
 		return "", err
@@ -53,100 +75,517 @@ func (crm *CloudResourceManager) EnsureFolder(displayName string, parent string)
 		crm.log.InfoPart("already exists\n")
 		return name, nil
 	}
-	crm.log.InfoPart("creating\n")
 	folder := &v2beta1.Folder{
 		DisplayName: displayName,
+		Parent:      parent,
 	}
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "EnsureFolder", Resource: displayName, Action: plan.ActionCreate, After: folder}) {
+		crm.log.InfoPart("would be created\n")
+		return "", nil
+	}
+	crm.log.InfoPart("creating\n")
 	folderCreateCall := foldersService.Create(folder).Context(ctx).Parent(parent)
-	folderCreateOperation, err := crm.Calls.FoldersCreate.Do(folderCreateCall)
+	folderCreateOperation, err := crm.Calls.FoldersCreate.Do(telemetry.WithResource(ctx, "", displayName), folderCreateCall)
 	if err != nil {
 		return "", err
 	}
 	if folderCreateOperation.Error != nil {
 		return "", errors.New(folderCreateOperation.Error.Message)
 	}
+	// the folder shows up in searches once created, waited for like an operation
+	waitCtx := ctx
+	if crm.OperationTimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, time.Duration(crm.OperationTimeoutSeconds)*time.Second)
+		defer cancel()
+	}
 	for name == "" {
-		name, err = crm.GetFolder(displayName, parent)
+		name, err = crm.findFolder(waitCtx, displayName, parent)
 		if err != nil {
+			if ctx.Err() == nil && waitCtx.Err() != nil {
+				return "", fmt.Errorf("error waiting for folder %s to be created: %s", displayName, waitCtx.Err().Error())
+			}
 			return "", err
 		}
 		if name == "" {
-			time.Sleep(3 * time.Second)
+			select {
+			case <-waitCtx.Done():
+				if ctx.Err() != nil {
+					return "", ctx.Err()
+				}
+				return "", fmt.Errorf("error waiting for folder %s to be created: %s", displayName, waitCtx.Err().Error())
+			case <-time.After(time.Duration(crm.OperationPollSeconds) * time.Second):
+			}
 		}
 	}
 	return name, nil
 }
 
-// EnsureFolderRoles makes sure that a particular member has the supplied roles on the folder
-func (crm *CloudResourceManager) EnsureFolderRoles(folder string, member string, roles []string) error {
-	ctx := context.Background()
-	if matched, _ := regexp.Match("^folders\\/", []byte(folder)); !matched {
-		folder = fmt.Sprintf("folders/%s", folder)
+// EnsureFolderPath will make sure that each folder in the path of display names, e.g. eng/platform/prod, exists,
+// nested in the one before it and the first in the parent, creating those that don't, and returns the names of all of
+// them, in the order of the path. A dry run returns a blank name for each folder that would be created.
+func (crm *CloudResourceManager) EnsureFolderPath(parent string, path string) ([]string, error) {
+	return crm.EnsureFolderPathCtx(context.Background(), parent, path)
+}
+
+// EnsureFolderPathCtx is EnsureFolderPath, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) EnsureFolderPathCtx(ctx context.Context, parent string, path string) (folders []string, err error) {
+	defer events.Start(crm.Events, service, "EnsureFolderPath", events.ActionEnsure, "", path).Done(&err)
+	displayNames := folderPathDisplayNames(path)
+	if parent == "" || len(displayNames) == 0 {
+		return nil, fmt.Errorf("Expecting a parent and a path of folder display names, e.g. organizations/1234 and eng/platform/prod, but got: %q and %q", parent, path)
+	}
+	folders = []string{}
+	for _, displayName := range displayNames {
+		if parent == "" {
+			// a dry run, in which the folder above would be created, so this one would be too
+			crm.log.Info("Folder %s would be created", displayName)
+			crm.DryRun.Record(plan.Change{Service: service, Method: "EnsureFolderPath", Resource: displayName, Action: plan.ActionCreate, After: &v2beta1.Folder{DisplayName: displayName}})
+			folders = append(folders, "")
+			continue
+		}
+		name, err := crm.ensureFolder(ctx, displayName, parent)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, name)
+		parent = name
 	}
-	crm.log.Info("Ensuring member %s has roles in %s:", member, folder)
+	return folders, nil
+}
+
+// ResolveFolderPath will return the organization the folder is in, and the path of display names from it to the
+// folder, e.g. organizations/1234 and eng/platform/prod for the folder EnsureFolderPath("organizations/1234",
+// "eng/platform/prod") returned last
+func (crm *CloudResourceManager) ResolveFolderPath(folder string) (string, string, error) {
+	return crm.ResolveFolderPathCtx(context.Background(), folder)
+}
+
+// ResolveFolderPathCtx is ResolveFolderPath, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) ResolveFolderPathCtx(ctx context.Context, folder string) (organization string, path string, err error) {
+	defer events.Start(crm.Events, service, "ResolveFolderPath", events.ActionRead, "", folder).Done(&err)
+	folder = folderName(folder)
 	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
-	folderGetPolicyCall := foldersService.GetIamPolicy(folder, &v2beta1.GetIamPolicyRequest{}).Context(ctx)
-	policy, err := crm.Calls.FoldersGetIAMPolicy.Do(folderGetPolicyCall)
+	displayNames := []string{}
+	for current := folder; strings.HasPrefix(current, "folders/"); {
+		folderGetCall := foldersService.Get(current).Context(ctx)
+		existing, err := crm.Calls.FoldersGet.Do(telemetry.WithResource(ctx, "", current), folderGetCall)
+		if err != nil {
+			return "", "", err
+		}
+		displayNames = append([]string{existing.DisplayName}, displayNames...)
+		current = existing.Parent
+		organization = current
+	}
+	return organization, strings.Join(displayNames, "/"), nil
+}
+
+// ListFolders will return the active folders in the parent, an organization or folder, and when recursive, the
+// folders nested in them too, breadth first
+func (crm *CloudResourceManager) ListFolders(parent string, recursive bool) ([]*v2beta1.Folder, error) {
+	return crm.ListFoldersCtx(context.Background(), parent, recursive)
+}
+
+// ListFoldersCtx is ListFolders, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) ListFoldersCtx(ctx context.Context, parent string, recursive bool) (folders []*v2beta1.Folder, err error) {
+	defer events.Start(crm.Events, service, "ListFolders", events.ActionRead, "", parent).Done(&err)
+	folders = []*v2beta1.Folder{}
+	parents := []string{parent}
+	for len(parents) > 0 {
+		children, err := crm.searchFolders(ctx, fmt.Sprintf("parent=%s AND lifecycleState=ACTIVE", parents[0]), parents[0])
+		if err != nil {
+			return nil, err
+		}
+		parents = parents[1:]
+		folders = append(folders, children...)
+		if recursive {
+			for _, child := range children {
+				parents = append(parents, child.Name)
+			}
+		}
+	}
+	return folders, nil
+}
+
+// searchFolders will return all of the folders matching the query, following the pages of results
+func (crm *CloudResourceManager) searchFolders(ctx context.Context, query string, resource string) ([]*v2beta1.Folder, error) {
+	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
+	folders := []*v2beta1.Folder{}
+	pageToken := ""
+	for {
+		folderSearchRequest := &v2beta1.SearchFoldersRequest{
+			Query:     query,
+			PageToken: pageToken,
+		}
+		folderSearchCall := foldersService.Search(folderSearchRequest).Context(ctx)
+		folderSearchResponse, err := crm.Calls.FoldersSearch.Do(telemetry.WithResource(ctx, "", resource), folderSearchCall)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, folderSearchResponse.Folders...)
+		if folderSearchResponse.NextPageToken == "" {
+			return folders, nil
+		}
+		pageToken = folderSearchResponse.NextPageToken
+	}
+}
+
+// folderPathDisplayNames will return the display names in the path, ignoring leading, trailing and repeated slashes
+func folderPathDisplayNames(path string) []string {
+	displayNames := []string{}
+	for _, displayName := range strings.Split(path, "/") {
+		if displayName != "" {
+			displayNames = append(displayNames, displayName)
+		}
+	}
+	return displayNames
+}
+
+// FolderNotEmptyError is returned when deleting a folder that still has active folders or projects in it, without
+// recursing into them
+type FolderNotEmptyError struct {
+	Folder   string
+	Folders  []string
+	Projects []string
+}
+
+// Error returns a message listing what's still in the folder
+func (e *FolderNotEmptyError) Error() string {
+	return fmt.Sprintf("folder %s isn't empty, it has %d folder(s) %v and %d project(s) %v in it", e.Folder, len(e.Folders), e.Folders, len(e.Projects), e.Projects)
+}
+
+// MoveFolder will move a folder to another parent, an organization or folder, nothing if it's already in it, waiting
+// for the move to finish
+func (crm *CloudResourceManager) MoveFolder(folder string, parent string) error {
+	return crm.MoveFolderCtx(context.Background(), folder, parent)
+}
+
+// MoveFolderCtx is MoveFolder, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) MoveFolderCtx(ctx context.Context, folder string, parent string) (err error) {
+	defer events.Start(crm.Events, service, "MoveFolder", events.ActionUpdate, "", folder).Done(&err)
+	folder = folderName(folder)
+	crm.log.InfoPart("Moving folder %s to %s...", folder, parent)
+	existing, err := crm.getFolder(ctx, folder)
 	if err != nil {
+		crm.log.InfoPart("error\n")
 		return err
 	}
-	for _, role := range roles {
-		crm.log.ListItem(role)
-		binding := getExistingFolderRoleBinding(policy.Bindings, role)
-		if binding == nil {
-			policy.Bindings = append(policy.Bindings, &v2beta1.Binding{
-				Members: []string{member},
-				Role:    role,
-			})
-		} else if !memberExistsInFolderRoleBinding(binding, member) {
-			binding.Members = append(binding.Members, member)
-		}
+	if existing.Parent == parent {
+		crm.log.InfoPart("already there\n")
+		return nil
 	}
-	setIAMPolicyRequest := &v2beta1.SetIamPolicyRequest{
-		Policy: policy,
+	moved := *existing
+	moved.Parent = parent
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "MoveFolder", Resource: folder, Action: plan.ActionUpdate, Before: existing, After: &moved}) {
+		crm.log.InfoPart("would be moved\n")
+		return nil
 	}
-	folderSetPolicyCall := foldersService.SetIamPolicy(folder, setIAMPolicyRequest).Context(ctx)
-	_, err = crm.Calls.FoldersSetIAMPolicy.Do(folderSetPolicyCall)
+	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
+	folderMoveCall := foldersService.Move(folder, &v2beta1.MoveFolderRequest{DestinationParent: parent}).Context(ctx)
+	folderMoveOperation, err := crm.Calls.FoldersMove.Do(telemetry.WithResource(ctx, "", folder), folderMoveCall)
 	if err != nil {
+		crm.log.InfoPart("error\n")
 		return err
 	}
+	if err = crm.waitForOperation(ctx, folderMoveOperation, folder); err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	crm.log.InfoPart("done\n")
 	return nil
 }
 
-// SetFolderOrgPolicy will set a particular org policy on a folder
-func (crm *CloudResourceManager) SetFolderOrgPolicy(folder string, policy *v1.OrgPolicy) error {
-	ctx := context.Background()
-	if matched, _ := regexp.Match("^folders\\/", []byte(folder)); !matched {
-		folder = fmt.Sprintf("folders/%s", folder)
+// RenameFolder will update the display name of a folder, nothing if it already has it
+func (crm *CloudResourceManager) RenameFolder(folder string, displayName string) error {
+	return crm.RenameFolderCtx(context.Background(), folder, displayName)
+}
+
+// RenameFolderCtx is RenameFolder, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) RenameFolderCtx(ctx context.Context, folder string, displayName string) (err error) {
+	defer events.Start(crm.Events, service, "RenameFolder", events.ActionUpdate, "", folder).Done(&err)
+	folder = folderName(folder)
+	crm.log.InfoPart("Renaming folder %s to %s...", folder, displayName)
+	existing, err := crm.getFolder(ctx, folder)
+	if err != nil {
+		crm.log.InfoPart("error\n")
+		return err
 	}
-	policyBytes, _ := json.Marshal(policy)
-	crm.log.Info("Ensuring org policy is set on folder %s: %s", folder, string(policyBytes))
-	foldersService := v1.NewFoldersService(crm.V1)
-	setOrgPolicyRequest := &v1.SetOrgPolicyRequest{
-		Policy: policy,
+	if existing.DisplayName == displayName {
+		crm.log.InfoPart("already named that\n")
+		return nil
 	}
-	folderSetPolicyCall := foldersService.SetOrgPolicy(folder, setOrgPolicyRequest).Context(ctx)
-	_, err := crm.Calls.FoldersSetOrgPolicy.Do(folderSetPolicyCall)
-	if err != nil {
+	renamed := *existing
+	renamed.DisplayName = displayName
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "RenameFolder", Resource: folder, Action: plan.ActionUpdate, Before: existing, After: &renamed}) {
+		crm.log.InfoPart("would be renamed\n")
+		return nil
+	}
+	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
+	folderPatchCall := foldersService.Patch(folder, &v2beta1.Folder{DisplayName: displayName}).UpdateMask("display_name").Context(ctx)
+	if _, err = crm.Calls.FoldersPatch.Do(telemetry.WithResource(ctx, "", folder), folderPatchCall); err != nil {
+		crm.log.InfoPart("error\n")
 		return err
 	}
+	crm.log.InfoPart("done\n")
 	return nil
 }
 
-func getExistingFolderRoleBinding(bindings []*v2beta1.Binding, role string) *v2beta1.Binding {
-	for _, binding := range bindings {
-		if binding.Role == role {
-			return binding
+// DeleteFolder will delete a folder, nothing if it doesn't exist or is already deleted. A folder with active folders
+// or projects in it isn't deleted, returning a *FolderNotEmptyError, unless recursive, in which case everything in it
+// is deleted first.
+func (crm *CloudResourceManager) DeleteFolder(folder string, recursive bool) error {
+	return crm.DeleteFolderCtx(context.Background(), folder, recursive)
+}
+
+// DeleteFolderCtx is DeleteFolder, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) DeleteFolderCtx(ctx context.Context, folder string, recursive bool) (err error) {
+	defer events.Start(crm.Events, service, "DeleteFolder", events.ActionDelete, "", folder).Done(&err)
+	return crm.deleteFolder(ctx, folder, recursive)
+}
+
+// deleteFolder is DeleteFolderCtx without its event, for use within other methods
+func (crm *CloudResourceManager) deleteFolder(ctx context.Context, folder string, recursive bool) error {
+	folder = folderName(folder)
+	existing, err := crm.getFolder(ctx, folder)
+	if googleerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error determining if folder to delete exists: %s", err)
+	}
+	if existing.LifecycleState != "ACTIVE" {
+		return nil
+	}
+	folders, err := crm.searchFolders(ctx, fmt.Sprintf("parent=%s AND lifecycleState=ACTIVE", folder), folder)
+	if err != nil {
+		return err
+	}
+	projects, err := crm.listFolderProjects(ctx, folder)
+	if err != nil {
+		return err
+	}
+	if (len(folders) > 0 || len(projects) > 0) && !recursive {
+		notEmpty := &FolderNotEmptyError{Folder: folder, Folders: []string{}, Projects: []string{}}
+		for _, child := range folders {
+			notEmpty.Folders = append(notEmpty.Folders, child.Name)
+		}
+		for _, project := range projects {
+			notEmpty.Projects = append(notEmpty.Projects, project.ProjectId)
+		}
+		return notEmpty
+	}
+	for _, child := range folders {
+		if err = crm.deleteFolder(ctx, child.Name, true); err != nil {
+			return err
 		}
 	}
+	for _, project := range projects {
+		if err = crm.deleteProject(ctx, project.ProjectId); err != nil {
+			return err
+		}
+	}
+	crm.log.InfoPart("Deleting folder %s...", folder)
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "DeleteFolder", Resource: folder, Action: plan.ActionDelete, Before: existing}) {
+		crm.log.InfoPart("would be deleted\n")
+		return nil
+	}
+	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
+	folderDeleteCall := foldersService.Delete(folder).Context(ctx)
+	if _, err = crm.Calls.FoldersDelete.Do(telemetry.WithResource(ctx, "", folder), folderDeleteCall); err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	crm.log.InfoPart("done\n")
 	return nil
 }
 
-func memberExistsInFolderRoleBinding(binding *v2beta1.Binding, member string) bool {
-	for _, bindingMember := range binding.Members {
-		if bindingMember == member {
-			return true
+// UndeleteFolder will restore a deleted folder that's still within its recovery period, nothing if it's active
+func (crm *CloudResourceManager) UndeleteFolder(folder string) error {
+	return crm.UndeleteFolderCtx(context.Background(), folder)
+}
+
+// UndeleteFolderCtx is UndeleteFolder, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) UndeleteFolderCtx(ctx context.Context, folder string) (err error) {
+	defer events.Start(crm.Events, service, "UndeleteFolder", events.ActionUpdate, "", folder).Done(&err)
+	folder = folderName(folder)
+	crm.log.InfoPart("Undeleting folder %s...", folder)
+	existing, err := crm.getFolder(ctx, folder)
+	if err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	if existing.LifecycleState == "ACTIVE" {
+		crm.log.InfoPart("already active\n")
+		return nil
+	}
+	undeleted := *existing
+	undeleted.LifecycleState = "ACTIVE"
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "UndeleteFolder", Resource: folder, Action: plan.ActionUpdate, Before: existing, After: &undeleted}) {
+		crm.log.InfoPart("would be undeleted\n")
+		return nil
+	}
+	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
+	folderUndeleteCall := foldersService.Undelete(folder, &v2beta1.UndeleteFolderRequest{}).Context(ctx)
+	if _, err = crm.Calls.FoldersUndelete.Do(telemetry.WithResource(ctx, "", folder), folderUndeleteCall); err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	crm.log.InfoPart("done\n")
+	return nil
+}
+
+// getFolder will get the folder by its name, whatever its lifecycle state
+func (crm *CloudResourceManager) getFolder(ctx context.Context, folder string) (*v2beta1.Folder, error) {
+	foldersService := v2beta1.NewFoldersService(crm.V2Beta1)
+	folderGetCall := foldersService.Get(folder).Context(ctx)
+	return crm.Calls.FoldersGet.Do(telemetry.WithResource(ctx, "", folder), folderGetCall)
+}
+
+// listFolderProjects will return the active projects directly in the folder, following the pages of results
+func (crm *CloudResourceManager) listFolderProjects(ctx context.Context, folder string) ([]*v1.Project, error) {
+	projectsService := v1.NewProjectsService(crm.V1)
+	filter := fmt.Sprintf("parent.type:folder parent.id:%s lifecycleState:ACTIVE", strings.TrimPrefix(folder, "folders/"))
+	projectsListCall := projectsService.List().Filter(filter).Context(ctx)
+	projects := []*v1.Project{}
+	for {
+		listProjectsResponse, err := crm.Calls.ProjectsList.Do(telemetry.WithResource(ctx, "", folder), projectsListCall)
+		if err != nil {
+			return nil, err
 		}
+		projects = append(projects, listProjectsResponse.Projects...)
+		if listProjectsResponse.NextPageToken == "" {
+			return projects, nil
+		}
+		projectsListCall = projectsListCall.PageToken(listProjectsResponse.NextPageToken)
+	}
+}
+
+// folderName will make sure a folder ID or name is a name, e.g. folders/1234
+func folderName(folder string) string {
+	if matched, _ := regexp.Match("^folders\\/", []byte(folder)); !matched {
+		return fmt.Sprintf("folders/%s", folder)
 	}
-	return false
+	return folder
+}
+
+// EnsureFolderRoles makes sure that a particular member has the supplied roles on the folder
+func (crm *CloudResourceManager) EnsureFolderRoles(folder string, member string, roles []string) error {
+	return crm.EnsureFolderRolesCtx(context.Background(), folder, member, roles)
+}
+
+// EnsureFolderRolesCtx is EnsureFolderRoles, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) EnsureFolderRolesCtx(ctx context.Context, folder string, member string, roles []string) (err error) {
+	defer events.Start(crm.Events, service, "EnsureFolderRoles", events.ActionUpdate, "", folder).Done(&err)
+	return crm.ensureFolderRoles(ctx, "EnsureFolderRoles", folder, member, roles, nil)
+}
+
+// EnsureFolderRolesWithCondition makes sure that a particular member has the supplied roles on the folder, granted
+// only when the condition is met
+func (crm *CloudResourceManager) EnsureFolderRolesWithCondition(folder string, member string, roles []string, condition *iampolicy.Condition) error {
+	return crm.EnsureFolderRolesWithConditionCtx(context.Background(), folder, member, roles, condition)
+}
+
+// EnsureFolderRolesWithConditionCtx is EnsureFolderRolesWithCondition, using the provided context for the underlying
+// api calls
+func (crm *CloudResourceManager) EnsureFolderRolesWithConditionCtx(ctx context.Context, folder string, member string, roles []string, condition *iampolicy.Condition) (err error) {
+	defer events.Start(crm.Events, service, "EnsureFolderRolesWithCondition", events.ActionUpdate, "", folder).Done(&err)
+	return crm.ensureFolderRoles(ctx, "EnsureFolderRolesWithCondition", folder, member, roles, condition)
+}
+
+func (crm *CloudResourceManager) ensureFolderRoles(ctx context.Context, method string, folder string, member string, roles []string, condition *iampolicy.Condition) error {
+	folder = folderName(folder)
+	_, err := crm.updateRoles(ctx, &folderPolicy{crm: crm, folder: folder},
+		plan.Change{Service: service, Method: method, Resource: folder, Action: plan.ActionUpdate},
+		fmt.Sprintf("Ensuring member %s has roles in %s", member, folder), condition, roles,
+		func(role string) iampolicy.Mutation { return iampolicy.AddMember(role, member, condition) })
+	return err
+}
+
+// RemoveFolderRoles makes sure that a particular member is removed from the supplied roles on the folder, returning
+// the roles it was removed from
+func (crm *CloudResourceManager) RemoveFolderRoles(folder string, member string, roles []string) ([]string, error) {
+	return crm.RemoveFolderRolesCtx(context.Background(), folder, member, roles)
+}
+
+// RemoveFolderRolesCtx is RemoveFolderRoles, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) RemoveFolderRolesCtx(ctx context.Context, folder string, member string, roles []string) (removed []string, err error) {
+	defer events.Start(crm.Events, service, "RemoveFolderRoles", events.ActionUpdate, "", folder).Done(&err)
+	return crm.removeFolderRoles(ctx, "RemoveFolderRoles", folder, member, roles, nil)
+}
+
+// RemoveFolderRolesWithCondition makes sure that a particular member is removed from the supplied roles granted with
+// the condition on the folder, leaving the roles granted without it or with other conditions, returning the roles it
+// was removed from
+func (crm *CloudResourceManager) RemoveFolderRolesWithCondition(folder string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	return crm.RemoveFolderRolesWithConditionCtx(context.Background(), folder, member, roles, condition)
+}
+
+// RemoveFolderRolesWithConditionCtx is RemoveFolderRolesWithCondition, using the provided context for the
+// underlying api calls
+func (crm *CloudResourceManager) RemoveFolderRolesWithConditionCtx(ctx context.Context, folder string, member string, roles []string, condition *iampolicy.Condition) (removed []string, err error) {
+	defer events.Start(crm.Events, service, "RemoveFolderRolesWithCondition", events.ActionUpdate, "", folder).Done(&err)
+	return crm.removeFolderRoles(ctx, "RemoveFolderRolesWithCondition", folder, member, roles, condition)
+}
+
+func (crm *CloudResourceManager) removeFolderRoles(ctx context.Context, method string, folder string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	folder = folderName(folder)
+	result, err := crm.updateRoles(ctx, &folderPolicy{crm: crm, folder: folder},
+		plan.Change{Service: service, Method: method, Resource: folder, Action: plan.ActionUpdate},
+		fmt.Sprintf("Ensuring roles for member %s are removed in %s", member, folder), condition, roles,
+		func(role string) iampolicy.Mutation { return iampolicy.RemoveMember(role, member, condition) })
+	if err != nil {
+		return nil, err
+	}
+	return result.Removed(member), nil
+}
+
+// RemoveFolderMember makes sure that a particular member is removed from every role on the folder, with or without
+// a condition, returning the roles it was removed from
+func (crm *CloudResourceManager) RemoveFolderMember(folder string, member string) ([]string, error) {
+	return crm.RemoveFolderMemberCtx(context.Background(), folder, member)
+}
+
+// RemoveFolderMemberCtx is RemoveFolderMember, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) RemoveFolderMemberCtx(ctx context.Context, folder string, member string) (removed []string, err error) {
+	defer events.Start(crm.Events, service, "RemoveFolderMember", events.ActionUpdate, "", folder).Done(&err)
+	folder = folderName(folder)
+	return crm.removeMember(ctx, &folderPolicy{crm: crm, folder: folder}, plan.Change{Service: service, Method: "RemoveFolderMember", Resource: folder, Action: plan.ActionUpdate}, member)
+}
+
+// DiffFolderPolicy will return how the iam policy of the folder differs from the spec, without changing it, as the
+// change that reconciling it would make, or no changes at all when it matches
+func (crm *CloudResourceManager) DiffFolderPolicy(folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return crm.DiffFolderPolicyCtx(context.Background(), folder, spec)
+}
+
+// DiffFolderPolicyCtx is DiffFolderPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) DiffFolderPolicyCtx(ctx context.Context, folder string, spec *iampolicy.Spec) (changes []plan.Change, err error) {
+	defer events.Start(crm.Events, service, "DiffFolderPolicy", events.ActionRead, "", folder).Done(&err)
+	folder = folderName(folder)
+	return crm.reconcile(ctx, &folderPolicy{crm: crm, folder: folder}, plan.Change{Service: service, Method: "DiffFolderPolicy", Resource: folder, Action: plan.ActionUpdate}, spec, true)
+}
+
+// ReconcileFolderPolicy will bring the iam policy of the folder in line with the spec, logging how it differed
+// from it, and return the change it made, or no changes at all when it already matched
+func (crm *CloudResourceManager) ReconcileFolderPolicy(folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return crm.ReconcileFolderPolicyCtx(context.Background(), folder, spec)
+}
+
+// ReconcileFolderPolicyCtx is ReconcileFolderPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) ReconcileFolderPolicyCtx(ctx context.Context, folder string, spec *iampolicy.Spec) (changes []plan.Change, err error) {
+	defer events.Start(crm.Events, service, "ReconcileFolderPolicy", events.ActionUpdate, "", folder).Done(&err)
+	folder = folderName(folder)
+	return crm.reconcile(ctx, &folderPolicy{crm: crm, folder: folder}, plan.Change{Service: service, Method: "ReconcileFolderPolicy", Resource: folder, Action: plan.ActionUpdate}, spec, false)
+}
+
+// SetFolderOrgPolicy will set a particular org policy on a folder
+func (crm *CloudResourceManager) SetFolderOrgPolicy(folder string, policy *v1.OrgPolicy) error {
+	return crm.SetFolderOrgPolicyCtx(context.Background(), folder, policy)
+}
+
+// SetFolderOrgPolicyCtx is SetFolderOrgPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) SetFolderOrgPolicyCtx(ctx context.Context, folder string, policy *v1.OrgPolicy) (err error) {
+	defer events.Start(crm.Events, service, "SetFolderOrgPolicy", events.ActionUpdate, "", folder).Done(&err)
+	folder = folderName(folder)
+	return crm.setOrgPolicy(ctx, "SetFolderOrgPolicy", &folderOrgPolicies{crm: crm, folder: folder}, folder, policy, nil)
 }
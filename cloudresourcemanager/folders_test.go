@@ -1,9 +1,19 @@
 package cloudresourcemanager
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
 	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
@@ -12,6 +22,7 @@ import (
 
 const (
 	testFolderName            = "folders/tests"
+	testFolderParent          = "folders/000000000"
 	testFolderNameDoesntExist = "folders/new"
 )
 
@@ -28,12 +39,18 @@ type foldersGetIAMPolicyExistingMemberMock struct{}
 type foldersGetIAMPolicyExistingRoleMock struct{}
 type foldersSetIAMPolicyMock struct{}
 type foldersSetOrgPolicyMock struct{}
+type foldersIAMPolicyConflictMock struct {
+	gets int
+	sets int
+}
 
 // Do is the mock for default foldersSearchMock
-func (c *foldersSearchMock) Do(call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error) {
+func (c *foldersSearchMock) Do(ctx context.Context, call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error) {
 	var folders []*v2beta1.Folder
 	folders = append(folders, &v2beta1.Folder{
-		Name: testFolderName,
+		Name:        testFolderName,
+		DisplayName: testFolderName,
+		Parent:      testFolderParent,
 	})
 	return &v2beta1.SearchFoldersResponse{
 		Folders: folders,
@@ -41,7 +58,7 @@ func (c *foldersSearchMock) Do(call *v2beta1.FoldersSearchCall, opts ...googleap
 }
 
 // Do is the mock for foldersSearch that doesn't include any results
-func (c *foldersSearchMockNoResults) Do(call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error) {
+func (c *foldersSearchMockNoResults) Do(ctx context.Context, call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error) {
 	var folders []*v2beta1.Folder
 	return &v2beta1.SearchFoldersResponse{
 		Folders: folders,
@@ -49,13 +66,15 @@ func (c *foldersSearchMockNoResults) Do(call *v2beta1.FoldersSearchCall, opts ..
 }
 
 // Do is the mock for foldersSearch that will return nothing on the first time, will return a result on the second
-func (c *foldersSearchNoResultThenResult) Do(call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error) {
+func (c *foldersSearchNoResultThenResult) Do(ctx context.Context, call *v2beta1.FoldersSearchCall, opts ...googleapi.CallOption) (*v2beta1.SearchFoldersResponse, error) {
 	var folders []*v2beta1.Folder
 	if searchCount == 0 {
 		searchCount++
 	} else {
 		folders = append(folders, &v2beta1.Folder{
-			Name: testFolderName,
+			Name:        testFolderName,
+			DisplayName: testFolderName,
+			Parent:      testFolderParent,
 		})
 		searchCount = 0
 	}
@@ -65,19 +84,19 @@ func (c *foldersSearchNoResultThenResult) Do(call *v2beta1.FoldersSearchCall, op
 }
 
 // Do is the mock for default foldersCreateMock
-func (c *foldersCreateMock) Do(call *v2beta1.FoldersCreateCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error) {
+func (c *foldersCreateMock) Do(ctx context.Context, call *v2beta1.FoldersCreateCall, opts ...googleapi.CallOption) (*v2beta1.Operation, error) {
 	return &v2beta1.Operation{
 		Error: nil,
 	}, nil
 }
 
 // Do is the mock for default foldersGetIAMPolicy
-func (c *foldersGetIAMPolicyMock) Do(call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
+func (c *foldersGetIAMPolicyMock) Do(ctx context.Context, call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
 	return &v2beta1.Policy{}, nil
 }
 
 // Do is the mock for foldersGetIamPolicy that includes an existing member in role
-func (c *foldersGetIAMPolicyExistingMemberMock) Do(call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
+func (c *foldersGetIAMPolicyExistingMemberMock) Do(ctx context.Context, call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
 	var bindings []*v2beta1.Binding
 	bindings = append(bindings, &v2beta1.Binding{
 		Role: testRole,
@@ -91,7 +110,7 @@ func (c *foldersGetIAMPolicyExistingMemberMock) Do(call *v2beta1.FoldersGetIamPo
 }
 
 // Do is the mock for foldersGetIAMPolicy that includes an existing member in role
-func (c *foldersGetIAMPolicyExistingRoleMock) Do(call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
+func (c *foldersGetIAMPolicyExistingRoleMock) Do(ctx context.Context, call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
 	var bindings []*v2beta1.Binding
 	bindings = append(bindings, &v2beta1.Binding{
 		Role:    testRole,
@@ -103,11 +122,31 @@ func (c *foldersGetIAMPolicyExistingRoleMock) Do(call *v2beta1.FoldersGetIamPoli
 }
 
 // Do is the mock for default foldersSetIAMPolicy
-func (c *foldersSetIAMPolicyMock) Do(call *v2beta1.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
+func (c *foldersSetIAMPolicyMock) Do(ctx context.Context, call *v2beta1.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
 	return &v2beta1.Policy{}, nil
 }
 
-func (c *foldersSetOrgPolicyMock) Do(call *v1.FoldersSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
+// Do is the mock for foldersGetIAMPolicy whose policy changes concurrently with the first set
+func (c *foldersIAMPolicyConflictMock) Do(ctx context.Context, call *v2beta1.FoldersGetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
+	c.gets++
+	return &v2beta1.Policy{Etag: strings.Repeat("etag", c.gets)}, nil
+}
+
+// foldersSetIAMPolicyConflictMock is the mock for foldersSetIAMPolicy whose first set conflicts
+type foldersSetIAMPolicyConflictMock struct {
+	*foldersIAMPolicyConflictMock
+}
+
+// Do is the mock for foldersSetIAMPolicy whose first set conflicts
+func (c *foldersSetIAMPolicyConflictMock) Do(ctx context.Context, call *v2beta1.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*v2beta1.Policy, error) {
+	c.sets++
+	if c.sets == 1 {
+		return nil, &googleapi.Error{Code: http.StatusConflict, Message: "There were concurrent policy changes."}
+	}
+	return &v2beta1.Policy{}, nil
+}
+
+func (c *foldersSetOrgPolicyMock) Do(ctx context.Context, call *v1.FoldersSetOrgPolicyCall, opts ...googleapi.CallOption) (*v1.OrgPolicy, error) {
 	return &v1.OrgPolicy{}, nil
 }
 
@@ -158,7 +197,7 @@ func TestEnsureFolderAlreadyExists(t *testing.T) {
 		t.Errorf("Got unexpected error during cloudresourcemanager.Initialize() with blank credentials: %s", err)
 	}
 	setFoldersCallMockDefaults(crm)
-	name, err := crm.EnsureFolder(testFolderName, "folders/000000000")
+	name, err := crm.EnsureFolder(testFolderName, testFolderParent)
 	if err != nil {
 		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureFolder() for folder that already exists: %s", err)
 	}
@@ -175,7 +214,8 @@ func TestEnsureFolderDoesntExist(t *testing.T) {
 	}
 	setFoldersCallMockDefaults(crm)
 	crm.Calls.FoldersSearch = &foldersSearchNoResultThenResult{}
-	name, err := crm.EnsureFolder(testFolderName, "folders/11111111")
+	crm.OperationPollSeconds = 0
+	name, err := crm.EnsureFolder(testFolderName, testFolderParent)
 	if err != nil {
 		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureFolder() for folder that doesn't exist: %s", err)
 	}
@@ -238,6 +278,41 @@ func TestEnsureFolderRolesExistingRoleWithoutMember(t *testing.T) {
 	}
 }
 
+func TestEnsureFolderRolesConflict(t *testing.T) {
+	crm := &CloudResourceManager{ConflictRetry: &retry.Policy{MaxAttempts: 3}}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error for cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setFoldersCallMockDefaults(crm)
+	policyMock := &foldersIAMPolicyConflictMock{}
+	crm.Calls.FoldersGetIAMPolicy = policyMock
+	crm.Calls.FoldersSetIAMPolicy = &foldersSetIAMPolicyConflictMock{policyMock}
+	err = crm.EnsureFolderRoles(testFolderName, testMember, []string{testRole})
+	if err != nil {
+		t.Errorf("Got unexpected error for cloudresourcemanager.EnsureFolderRoles() with a conflicting set: %s", err)
+	}
+	if policyMock.gets != 2 || policyMock.sets != 2 {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolderRoles() to read the policy again and retry a conflicting set, got %d reads and %d sets", policyMock.gets, policyMock.sets)
+	}
+}
+
+func TestEnsureFolderRolesConflictExhausted(t *testing.T) {
+	crm := &CloudResourceManager{ConflictRetry: retry.NoRetry()}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error for cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setFoldersCallMockDefaults(crm)
+	policyMock := &foldersIAMPolicyConflictMock{}
+	crm.Calls.FoldersGetIAMPolicy = policyMock
+	crm.Calls.FoldersSetIAMPolicy = &foldersSetIAMPolicyConflictMock{policyMock}
+	err = crm.EnsureFolderRoles(testFolderName, testMember, []string{testRole})
+	if err == nil {
+		t.Errorf("Expected an error from cloudresourcemanager.EnsureFolderRoles() once its conflict retries are exhausted")
+	}
+}
+
 func TestSetFolderOrgPolicy(t *testing.T) {
 	crm := &CloudResourceManager{}
 	err := crm.Initialize("", loggermock.GetLogMock())
@@ -250,3 +325,359 @@ func TestSetFolderOrgPolicy(t *testing.T) {
 		t.Errorf("Got unexpected error for cloudresourcemanager.TestSetFolderOrgPolicy(): %s", err)
 	}
 }
+
+func TestEnsureFolderCtxCanceled(t *testing.T) {
+	crm := &CloudResourceManager{}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setFoldersCallMockDefaults(crm)
+	crm.Calls.FoldersSearch = &foldersSearchMockNoResults{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = crm.EnsureFolderCtx(ctx, testFolderNameDoesntExist, "folders/11111111")
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled from cloudresourcemanager.EnsureFolderCtx() with a canceled context, got: %v", err)
+	}
+}
+
+func TestEnsureFolderTimeout(t *testing.T) {
+	crm := &CloudResourceManager{OperationTimeoutSeconds: 1}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setFoldersCallMockDefaults(crm)
+	crm.Calls.FoldersSearch = &foldersSearchMockNoResults{}
+	crm.OperationPollSeconds = 0
+	_, err = crm.EnsureFolder(testFolderNameDoesntExist, testFolderParent)
+	if err == nil || !strings.Contains(err.Error(), "error waiting for folder") {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolder() to give up on a created folder that never shows up, got: %v", err)
+	}
+}
+
+// folderServer serves a hierarchy of folders, one per page of search results, and the projects in them, keeping the
+// folders created and the folders and projects deleted
+type folderServer struct {
+	folders  []*v2beta1.Folder
+	projects []*v1.Project
+	created  []string
+	deleted  []string
+	moves    map[string]string
+	searches int
+}
+
+func (s *folderServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/v2/folders:search":
+		request := &v2beta1.SearchFoldersRequest{}
+		json.NewDecoder(r.Body).Decode(request)
+		s.searches++
+		matches := []*v2beta1.Folder{}
+		for _, folder := range s.folders {
+			if folderMatches(folder, request.Query) {
+				matches = append(matches, folder)
+			}
+		}
+		page, _ := strconv.Atoi(request.PageToken)
+		response := &v2beta1.SearchFoldersResponse{}
+		if page < len(matches) {
+			response.Folders = matches[page : page+1]
+		}
+		if page+1 < len(matches) {
+			response.NextPageToken = strconv.Itoa(page + 1)
+		}
+		json.NewEncoder(w).Encode(response)
+	case r.URL.Path == "/v2/folders" && r.Method == http.MethodPost:
+		folder := &v2beta1.Folder{}
+		json.NewDecoder(r.Body).Decode(folder)
+		folder.Name = fmt.Sprintf("folders/%d", len(s.folders)+1)
+		folder.Parent = r.URL.Query().Get("parent")
+		folder.LifecycleState = "ACTIVE"
+		s.folders = append(s.folders, folder)
+		s.created = append(s.created, folder.DisplayName)
+		json.NewEncoder(w).Encode(&v2beta1.Operation{Done: true})
+	case strings.HasPrefix(r.URL.Path, "/v2/folders/"):
+		name := strings.TrimPrefix(r.URL.Path, "/v2/")
+		action := ""
+		if parts := strings.SplitN(name, ":", 2); len(parts) == 2 {
+			name, action = parts[0], parts[1]
+		}
+		folder := s.folder(name)
+		if folder == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch {
+		case action == "move":
+			request := &v2beta1.MoveFolderRequest{}
+			json.NewDecoder(r.Body).Decode(request)
+			if s.moves == nil {
+				s.moves = map[string]string{}
+			}
+			s.moves[name] = request.DestinationParent
+			json.NewEncoder(w).Encode(&v2beta1.Operation{Name: "operations/move" + strings.TrimPrefix(name, "folders/")})
+			return
+		case action == "undelete":
+			folder.LifecycleState = "ACTIVE"
+		case r.Method == http.MethodPatch:
+			patch := &v2beta1.Folder{}
+			json.NewDecoder(r.Body).Decode(patch)
+			if r.URL.Query().Get("updateMask") == "display_name" {
+				folder.DisplayName = patch.DisplayName
+			}
+		case r.Method == http.MethodDelete:
+			folder.LifecycleState = "DELETE_REQUESTED"
+			s.deleted = append(s.deleted, name)
+		}
+		json.NewEncoder(w).Encode(folder)
+	case strings.HasPrefix(r.URL.Path, "/v1/operations/move"):
+		// the move finishes on the first check of its operation
+		name := "folders/" + strings.TrimPrefix(r.URL.Path, "/v1/operations/move")
+		s.folder(name).Parent = s.moves[name]
+		json.NewEncoder(w).Encode(&v2beta1.Operation{Name: strings.TrimPrefix(r.URL.Path, "/v1/"), Done: true})
+	case r.URL.Path == "/v1/liens":
+		json.NewEncoder(w).Encode(&v1.ListLiensResponse{})
+	case r.URL.Path == "/v1/projects":
+		response := &v1.ListProjectsResponse{Projects: []*v1.Project{}}
+		for _, project := range s.projects {
+			if project.LifecycleState == "ACTIVE" && strings.Contains(r.URL.Query().Get("filter"), "parent.id:"+project.Parent.Id+" ") {
+				response.Projects = append(response.Projects, project)
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	case strings.HasPrefix(r.URL.Path, "/v1/projects/"):
+		for _, project := range s.projects {
+			if project.ProjectId == strings.TrimPrefix(r.URL.Path, "/v1/projects/") {
+				if r.Method == http.MethodDelete {
+					project.LifecycleState = "DELETE_REQUESTED"
+					s.deleted = append(s.deleted, "projects/"+project.ProjectId)
+					json.NewEncoder(w).Encode(&v1.Empty{})
+					return
+				}
+				json.NewEncoder(w).Encode(project)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// folder will return the folder with the name, nil if there isn't one
+func (s *folderServer) folder(name string) *v2beta1.Folder {
+	for _, folder := range s.folders {
+		if folder.Name == name {
+			return folder
+		}
+	}
+	return nil
+}
+
+// folderMatches will return whether the folder matches each "field=value" of the search query
+// folderMatches will match a folder to a search query the way google does, ignoring the case of the display name
+func folderMatches(folder *v2beta1.Folder, query string) bool {
+	for _, condition := range strings.Split(query, " AND ") {
+		parts := strings.SplitN(condition, "=", 2)
+		if parts[0] == "displayName" {
+			displayName, err := strconv.Unquote(parts[1])
+			if err != nil || !strings.EqualFold(folder.DisplayName, displayName) {
+				return false
+			}
+			continue
+		}
+		values := map[string]string{"parent": folder.Parent, "lifecycleState": folder.LifecycleState}
+		if values[parts[0]] != parts[1] {
+			return false
+		}
+	}
+	return true
+}
+
+func getFolderServerCloudResourceManager(t *testing.T, folders ...*v2beta1.Folder) (*CloudResourceManager, *folderServer) {
+	folderServer := &folderServer{folders: folders}
+	server := httptest.NewServer(folderServer)
+	t.Cleanup(server.Close)
+	crm := &CloudResourceManager{Endpoint: server.URL, Insecure: true}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.Initialize() with a test endpoint: %s", err)
+	}
+	return crm, folderServer
+}
+
+func TestGetFolderExactMatch(t *testing.T) {
+	crm, server := getFolderServerCloudResourceManager(t,
+		&v2beta1.Folder{Name: "folders/1", DisplayName: "Platform Team", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+		&v2beta1.Folder{Name: "folders/2", DisplayName: "platform team", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+	)
+	name, err := crm.GetFolder("platform team", testOrganizationName)
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.GetFolder(): %s", err)
+	}
+	if name != "folders/2" || server.searches != 2 {
+		t.Errorf("Expected the folder with exactly the display name, from the second page of cloudresourcemanager.GetFolder() results, got %s from %d searches", name, server.searches)
+	}
+	if name, err := crm.GetFolder("platform", testOrganizationName); err != nil || name != "" {
+		t.Errorf("Expected no folder from cloudresourcemanager.GetFolder() for only part of a display name, got %s: %v", name, err)
+	}
+}
+
+func TestEnsureFolderPath(t *testing.T) {
+	crm, server := getFolderServerCloudResourceManager(t,
+		&v2beta1.Folder{Name: "folders/eng", DisplayName: "eng", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+	)
+	folders, err := crm.EnsureFolderPath(testOrganizationName, "/eng/platform/prod/")
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.EnsureFolderPath(): %s", err)
+	}
+	if len(folders) != 3 || folders[0] != "folders/eng" || !reflect.DeepEqual(server.created, []string{"platform", "prod"}) {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolderPath() to resolve eng and create the levels below it, got %v, created %v", folders, server.created)
+	}
+	organization, path, err := crm.ResolveFolderPath(folders[2])
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.ResolveFolderPath(): %s", err)
+	}
+	if organization != testOrganizationName || path != "eng/platform/prod" {
+		t.Errorf("Expected cloudresourcemanager.ResolveFolderPath() to return the path the folder was ensured with, got %s and %s", organization, path)
+	}
+	again, err := crm.EnsureFolderPath(testOrganizationName, "eng/platform/prod")
+	if err != nil || !reflect.DeepEqual(again, folders) || len(server.created) != 2 {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolderPath() to resolve an existing path without creating anything, got %v", again)
+	}
+	if _, err := crm.EnsureFolderPath(testOrganizationName, "/"); err == nil {
+		t.Errorf("Expected an error from cloudresourcemanager.EnsureFolderPath() without any display names")
+	}
+}
+
+func TestEnsureFolderPathDryRun(t *testing.T) {
+	crm, server := getFolderServerCloudResourceManager(t)
+	crm.DryRun = plan.New()
+	folders, err := crm.EnsureFolderPath(testOrganizationName, "eng/platform")
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.EnsureFolderPath() in a dry run: %s", err)
+	}
+	if !reflect.DeepEqual(folders, []string{"", ""}) || len(server.created) != 0 || len(crm.DryRun.Changes()) != 2 {
+		t.Errorf("Expected a dry run cloudresourcemanager.EnsureFolderPath() to plan creating both folders, got %v", crm.DryRun.Changes())
+	}
+}
+
+func TestListFolders(t *testing.T) {
+	crm, server := getFolderServerCloudResourceManager(t,
+		&v2beta1.Folder{Name: "folders/1", DisplayName: "eng", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+		&v2beta1.Folder{Name: "folders/2", DisplayName: "ops", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+		&v2beta1.Folder{Name: "folders/3", DisplayName: "platform", Parent: "folders/1", LifecycleState: "ACTIVE"},
+		&v2beta1.Folder{Name: "folders/4", DisplayName: "old", Parent: "folders/1", LifecycleState: "DELETE_REQUESTED"},
+		&v2beta1.Folder{Name: "folders/5", DisplayName: "prod", Parent: "folders/3", LifecycleState: "ACTIVE"},
+	)
+	folders, err := crm.ListFolders(testOrganizationName, false)
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.ListFolders(): %s", err)
+	}
+	if len(folders) != 2 || server.searches != 2 {
+		t.Errorf("Expected both pages of the organization's folders from cloudresourcemanager.ListFolders(), got %d from %d searches", len(folders), server.searches)
+	}
+	folders, err = crm.ListFolders(testOrganizationName, true)
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.ListFolders() recursively: %s", err)
+	}
+	names := []string{}
+	for _, folder := range folders {
+		names = append(names, folder.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"folders/1", "folders/2", "folders/3", "folders/5"}) {
+		t.Errorf("Expected the active folders, breadth first, from a recursive cloudresourcemanager.ListFolders(), got %v", names)
+	}
+}
+
+func TestMoveAndRenameFolder(t *testing.T) {
+	crm, server := getFolderServerCloudResourceManager(t,
+		&v2beta1.Folder{Name: "folders/1", DisplayName: "eng", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+		&v2beta1.Folder{Name: "folders/2", DisplayName: "ops", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+	)
+	crm.OperationPollSeconds = 0
+	if err := crm.MoveFolder("2", "folders/1"); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.MoveFolder(): %s", err)
+	}
+	if server.folders[1].Parent != "folders/1" {
+		t.Errorf("Expected cloudresourcemanager.MoveFolder() to wait for the folder to be moved, got parent %s", server.folders[1].Parent)
+	}
+	if err := crm.RenameFolder("folders/2", "operations"); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.RenameFolder(): %s", err)
+	}
+	organization, path, err := crm.ResolveFolderPath("folders/2")
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.ResolveFolderPath(): %s", err)
+	}
+	if organization != testOrganizationName || path != "eng/operations" {
+		t.Errorf("Expected the folder to be moved and renamed, got %s and %s", organization, path)
+	}
+	server.moves = nil
+	if err := crm.MoveFolder("folders/2", "folders/1"); err != nil || server.moves != nil {
+		t.Errorf("Expected cloudresourcemanager.MoveFolder() not to move a folder already in the parent, got %v", err)
+	}
+}
+
+func TestMoveFolderDryRun(t *testing.T) {
+	crm, server := getFolderServerCloudResourceManager(t,
+		&v2beta1.Folder{Name: "folders/1", DisplayName: "eng", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+	)
+	crm.DryRun = plan.New()
+	if err := crm.MoveFolder("folders/1", "folders/2"); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.MoveFolder() in a dry run: %s", err)
+	}
+	changes := crm.DryRun.Changes()
+	if len(changes) != 1 || changes[0].After.(*v2beta1.Folder).Parent != "folders/2" || server.moves != nil {
+		t.Errorf("Expected a dry run cloudresourcemanager.MoveFolder() to plan the move without making it, got %v", changes)
+	}
+}
+
+func TestDeleteFolder(t *testing.T) {
+	crm, server := getFolderServerCloudResourceManager(t,
+		&v2beta1.Folder{Name: "folders/1", DisplayName: "eng", Parent: testOrganizationName, LifecycleState: "ACTIVE"},
+		&v2beta1.Folder{Name: "folders/2", DisplayName: "platform", Parent: "folders/1", LifecycleState: "ACTIVE"},
+	)
+	server.projects = []*v1.Project{
+		{ProjectId: "platform-1", LifecycleState: "ACTIVE", Parent: &v1.ResourceId{Type: "folder", Id: "2"}},
+	}
+	err := crm.DeleteFolder("folders/1", false)
+	notEmpty, ok := err.(*FolderNotEmptyError)
+	if !ok || !reflect.DeepEqual(notEmpty.Folders, []string{"folders/2"}) || len(server.deleted) != 0 {
+		t.Fatalf("Expected a *FolderNotEmptyError from cloudresourcemanager.DeleteFolder() for a folder with a folder in it, got %v", err)
+	}
+	if _, ok := crm.DeleteFolder("2", false).(*FolderNotEmptyError); !ok {
+		t.Errorf("Expected a *FolderNotEmptyError from cloudresourcemanager.DeleteFolder() for a folder with a project in it")
+	}
+	sink := &events.Memory{}
+	crm.Events = sink
+	if err := crm.DeleteFolder("folders/1", true); err != nil {
+		t.Fatalf("Got unexpected error during a recursive cloudresourcemanager.DeleteFolder(): %s", err)
+	}
+	if emitted := sink.Events(); len(emitted) != 1 || emitted[0].Method != "DeleteFolder" || emitted[0].Resource != "folders/1" {
+		t.Errorf("Expected a single event from a recursive cloudresourcemanager.DeleteFolder(), got %v", emitted)
+	}
+	crm.Events = nil
+	if !reflect.DeepEqual(server.deleted, []string{"projects/platform-1", "folders/2", "folders/1"}) {
+		t.Errorf("Expected a recursive cloudresourcemanager.DeleteFolder() to delete what's in the folder first, got %v", server.deleted)
+	}
+	if err := crm.DeleteFolder("folders/1", false); err != nil || len(server.deleted) != 3 {
+		t.Errorf("Expected cloudresourcemanager.DeleteFolder() to do nothing for a deleted folder, got %v", err)
+	}
+	if err := crm.DeleteFolder("folders/missing", false); err != nil {
+		t.Errorf("Expected cloudresourcemanager.DeleteFolder() to do nothing for a folder that doesn't exist, got %s", err)
+	}
+	if err := crm.UndeleteFolder("folders/1"); err != nil || server.folders[0].LifecycleState != "ACTIVE" {
+		t.Errorf("Expected cloudresourcemanager.UndeleteFolder() to restore the folder, got %v", err)
+	}
+}
+
+func TestWaitForOperationError(t *testing.T) {
+	crm := &CloudResourceManager{}
+	err := crm.waitForOperation(context.Background(), &v2beta1.Operation{Name: "operations/1", Done: true, Error: &v2beta1.Status{Code: 9, Message: "folder has too many children"}}, "folders/1")
+	if operationError, ok := err.(*OperationError); !ok || operationError.Code != 9 {
+		t.Errorf("Expected an *OperationError from a finished operation with an error, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package cloudresourcemanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+)
+
+// policyUpdater will return the updater for the iam policies of projects, folders and organizations
+func (crm *CloudResourceManager) policyUpdater() *iampolicy.Updater {
+	return &iampolicy.Updater{Retry: crm.ConflictRetry, DryRun: crm.DryRun != nil}
+}
+
+// updateRoles will log the message, along with the condition if not nil, and each of the roles, then update the
+// policy of the resource with the mutation for each role
+func (crm *CloudResourceManager) updateRoles(ctx context.Context, resource iampolicy.Resource, change plan.Change, message string, condition *iampolicy.Condition, roles []string, mutation func(role string) iampolicy.Mutation) (*iampolicy.Result, error) {
+	if condition != nil {
+		message = fmt.Sprintf("%s %s", message, condition)
+	}
+	crm.log.Info("%s:", message)
+	mutations := []iampolicy.Mutation{}
+	for _, role := range roles {
+		crm.log.ListItem(role)
+		mutations = append(mutations, mutation(role))
+	}
+	return crm.updatePolicy(ctx, resource, change, mutations...)
+}
+
+// removeMember will remove the member from every role on the resource, logging the roles it was removed from
+func (crm *CloudResourceManager) removeMember(ctx context.Context, resource iampolicy.Resource, change plan.Change, member string) ([]string, error) {
+	crm.log.Info("Ensuring member %s is removed from all roles in %s:", member, change.Resource)
+	result, err := crm.updatePolicy(ctx, resource, change, iampolicy.RemoveMemberFromAll(member))
+	if err != nil {
+		return nil, err
+	}
+	removed := result.Removed(member)
+	for _, role := range removed {
+		crm.log.ListItem(role)
+	}
+	return removed, nil
+}
+
+// reconcile will bring the policy of the resource in line with the spec, logging how the live policy differs from it,
+// and return the change, if any. Only the diff is computed when diffOnly, without setting the policy or adding the
+// change to a dry run.
+func (crm *CloudResourceManager) reconcile(ctx context.Context, resource iampolicy.Resource, change plan.Change, spec *iampolicy.Spec, diffOnly bool) ([]plan.Change, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	updater := crm.policyUpdater()
+	updater.DryRun = updater.DryRun || diffOnly
+	result, err := updater.Update(ctx, resource, spec.Mutations()...)
+	if err != nil {
+		return nil, err
+	}
+	diff := result.Diff()
+	if len(diff) == 0 {
+		crm.log.Info("IAM policy of %s matches its spec", change.Resource)
+		return nil, nil
+	}
+	crm.log.Info("IAM policy of %s differs from its spec:", change.Resource)
+	for _, line := range diff {
+		crm.log.ListItem(line)
+	}
+	changes := result.Changes(change)
+	if !diffOnly {
+		crm.DryRun.Record(changes...)
+	}
+	return changes, nil
+}
+
+// updatePolicy will update the policy of the resource with the mutations, adding the change to the policy to a dry
+// run
+func (crm *CloudResourceManager) updatePolicy(ctx context.Context, resource iampolicy.Resource, change plan.Change, mutations ...iampolicy.Mutation) (*iampolicy.Result, error) {
+	result, err := crm.policyUpdater().Update(ctx, resource, mutations...)
+	if err != nil {
+		return nil, err
+	}
+	crm.DryRun.Record(result.Changes(change)...)
+	return result, nil
+}
+
+// projectPolicy is the iam policy of a project
+type projectPolicy struct {
+	crm     *CloudResourceManager
+	project string
+}
+
+func (p *projectPolicy) GetPolicy(ctx context.Context) (*iampolicy.Policy, error) {
+	projectsService := v1.NewProjectsService(p.crm.V1)
+	projectPolicyGetCall := projectsService.GetIamPolicy(p.project, &v1.GetIamPolicyRequest{Options: &v1.GetPolicyOptions{RequestedPolicyVersion: iampolicy.PolicyVersion}}).Context(ctx)
+	policy, err := p.crm.Calls.ProjectsGetIAMPolicy.Do(telemetry.WithResource(ctx, p.project, p.project), projectPolicyGetCall)
+	if err != nil {
+		return nil, err
+	}
+	return fromV1Policy(policy), nil
+}
+
+func (p *projectPolicy) SetPolicy(ctx context.Context, policy *iampolicy.Policy) error {
+	projectsService := v1.NewProjectsService(p.crm.V1)
+	projectSetPolicyCall := projectsService.SetIamPolicy(p.project, &v1.SetIamPolicyRequest{Policy: toV1Policy(policy)}).Context(ctx)
+	_, err := p.crm.Calls.ProjectsSetIAMPolicy.Do(telemetry.WithResource(ctx, p.project, p.project), projectSetPolicyCall)
+	return err
+}
+
+// organizationPolicy is the iam policy of an organization
+type organizationPolicy struct {
+	crm          *CloudResourceManager
+	organization string
+}
+
+func (o *organizationPolicy) GetPolicy(ctx context.Context) (*iampolicy.Policy, error) {
+	organizationsService := v1.NewOrganizationsService(o.crm.V1)
+	organizationPolicyGetCall := organizationsService.GetIamPolicy(o.organization, &v1.GetIamPolicyRequest{Options: &v1.GetPolicyOptions{RequestedPolicyVersion: iampolicy.PolicyVersion}}).Context(ctx)
+	policy, err := o.crm.Calls.OrganizationsGetIAMPolicy.Do(telemetry.WithResource(ctx, "", o.organization), organizationPolicyGetCall)
+	if err != nil {
+		return nil, err
+	}
+	return fromV1Policy(policy), nil
+}
+
+func (o *organizationPolicy) SetPolicy(ctx context.Context, policy *iampolicy.Policy) error {
+	organizationsService := v1.NewOrganizationsService(o.crm.V1)
+	organizationSetPolicyCall := organizationsService.SetIamPolicy(o.organization, &v1.SetIamPolicyRequest{Policy: toV1Policy(policy)}).Context(ctx)
+	_, err := o.crm.Calls.OrganizationsSetIAMPolicy.Do(telemetry.WithResource(ctx, "", o.organization), organizationSetPolicyCall)
+	return err
+}
+
+// folderPolicy is the iam policy of a folder
+type folderPolicy struct {
+	crm    *CloudResourceManager
+	folder string
+}
+
+func (f *folderPolicy) GetPolicy(ctx context.Context) (*iampolicy.Policy, error) {
+	foldersService := v2beta1.NewFoldersService(f.crm.V2Beta1)
+	folderGetPolicyCall := foldersService.GetIamPolicy(f.folder, &v2beta1.GetIamPolicyRequest{Options: &v2beta1.GetPolicyOptions{RequestedPolicyVersion: iampolicy.PolicyVersion}}).Context(ctx)
+	policy, err := f.crm.Calls.FoldersGetIAMPolicy.Do(telemetry.WithResource(ctx, "", f.folder), folderGetPolicyCall)
+	if err != nil {
+		return nil, err
+	}
+	return fromFolderPolicy(policy), nil
+}
+
+func (f *folderPolicy) SetPolicy(ctx context.Context, policy *iampolicy.Policy) error {
+	foldersService := v2beta1.NewFoldersService(f.crm.V2Beta1)
+	folderSetPolicyCall := foldersService.SetIamPolicy(f.folder, &v2beta1.SetIamPolicyRequest{Policy: toFolderPolicy(policy)}).Context(ctx)
+	_, err := f.crm.Calls.FoldersSetIAMPolicy.Do(telemetry.WithResource(ctx, "", f.folder), folderSetPolicyCall)
+	return err
+}
+
+// fromV1Policy will convert the iam policy of a project or organization
+func fromV1Policy(policy *v1.Policy) *iampolicy.Policy {
+	result := &iampolicy.Policy{Version: policy.Version, Etag: policy.Etag}
+	for _, binding := range policy.Bindings {
+		b := &iampolicy.Binding{Role: binding.Role, Members: binding.Members}
+		if binding.Condition != nil {
+			b.Condition = &iampolicy.Condition{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+		}
+		result.Bindings = append(result.Bindings, b)
+	}
+	return result
+}
+
+// toV1Policy will convert to the iam policy of a project or organization
+func toV1Policy(policy *iampolicy.Policy) *v1.Policy {
+	result := &v1.Policy{Version: policy.Version, Etag: policy.Etag}
+	for _, binding := range policy.Bindings {
+		b := &v1.Binding{Role: binding.Role, Members: binding.Members}
+		if binding.Condition != nil {
+			b.Condition = &v1.Expr{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+		}
+		result.Bindings = append(result.Bindings, b)
+	}
+	return result
+}
+
+// fromFolderPolicy will convert the iam policy of a folder
+func fromFolderPolicy(policy *v2beta1.Policy) *iampolicy.Policy {
+	result := &iampolicy.Policy{Version: policy.Version, Etag: policy.Etag}
+	for _, binding := range policy.Bindings {
+		b := &iampolicy.Binding{Role: binding.Role, Members: binding.Members}
+		if binding.Condition != nil {
+			b.Condition = &iampolicy.Condition{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+		}
+		result.Bindings = append(result.Bindings, b)
+	}
+	return result
+}
+
+// toFolderPolicy will convert to the iam policy of a folder
+func toFolderPolicy(policy *iampolicy.Policy) *v2beta1.Policy {
+	result := &v2beta1.Policy{Version: policy.Version, Etag: policy.Etag}
+	for _, binding := range policy.Bindings {
+		b := &v2beta1.Binding{Role: binding.Role, Members: binding.Members}
+		if binding.Condition != nil {
+			b.Condition = &v2beta1.Expr{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+		}
+		result.Bindings = append(result.Bindings, b)
+	}
+	return result
+}
@@ -0,0 +1,258 @@
+package cloudresourcemanager
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
+	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+)
+
+var testCondition = &iampolicy.Condition{
+	Title:       "expires",
+	Description: "Expires at the start of 2030",
+	Expression:  "request.time < timestamp('2030-01-01T00:00:00Z')",
+}
+
+// policyServer serves an iam policy, keeping the requests made to get and set it
+type policyServer struct {
+	policy *v1.Policy
+	gets   []*v1.GetIamPolicyRequest
+	sets   []*v1.SetIamPolicyRequest
+}
+
+func (s *policyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case strings.HasSuffix(r.URL.Path, ":getIamPolicy"):
+		request := &v1.GetIamPolicyRequest{}
+		json.NewDecoder(r.Body).Decode(request)
+		s.gets = append(s.gets, request)
+	case strings.HasSuffix(r.URL.Path, ":setIamPolicy"):
+		request := &v1.SetIamPolicyRequest{}
+		json.NewDecoder(r.Body).Decode(request)
+		s.sets = append(s.sets, request)
+		s.policy = request.Policy
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(s.policy)
+}
+
+func getPolicyServerCloudResourceManager(t *testing.T, policy *v1.Policy) (*CloudResourceManager, *policyServer) {
+	policyServer := &policyServer{policy: policy}
+	server := httptest.NewServer(policyServer)
+	t.Cleanup(server.Close)
+	crm := &CloudResourceManager{Endpoint: server.URL, Insecure: true}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.Initialize() with a test endpoint: %s", err)
+	}
+	return crm, policyServer
+}
+
+func TestEnsureProjectRolesWithCondition(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Version: 1, Etag: "etag", Bindings: []*v1.Binding{
+		{Role: testRole, Members: []string{"user:existing@go-google-lib.tests"}},
+	}})
+	err := crm.EnsureProjectRolesWithCondition(testProjectID, testMember, []string{testRole}, testCondition)
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.EnsureProjectRolesWithCondition(): %s", err)
+	}
+	if len(server.gets) != 1 || server.gets[0].Options == nil || server.gets[0].Options.RequestedPolicyVersion != 3 {
+		t.Errorf("Expected cloudresourcemanager.EnsureProjectRolesWithCondition() to read the policy with version 3")
+	}
+	if len(server.sets) != 1 {
+		t.Fatalf("Expected cloudresourcemanager.EnsureProjectRolesWithCondition() to set the policy once, got %d", len(server.sets))
+	}
+	policy := server.sets[0].Policy
+	if policy.Version != 3 || policy.Etag != "etag" {
+		t.Errorf("Expected the policy to be set as version 3 with the etag it was read with, got version %d and etag %q", policy.Version, policy.Etag)
+	}
+	if len(policy.Bindings) != 2 || policy.Bindings[0].Condition != nil {
+		t.Fatalf("Expected the condition in a binding of its own alongside the existing one, got %d bindings", len(policy.Bindings))
+	}
+	binding := policy.Bindings[1]
+	if binding.Role != testRole || len(binding.Members) != 1 || binding.Members[0] != testMember || binding.Condition == nil ||
+		binding.Condition.Title != testCondition.Title || binding.Condition.Description != testCondition.Description || binding.Condition.Expression != testCondition.Expression {
+		t.Errorf("Got unexpected conditional binding from cloudresourcemanager.EnsureProjectRolesWithCondition(): %v", binding)
+	}
+}
+
+func TestEnsureProjectRolesPreservesConditions(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Version: 3, Bindings: []*v1.Binding{
+		{Role: testRole, Members: []string{testMember}, Condition: &v1.Expr{Title: testCondition.Title, Expression: testCondition.Expression}},
+	}})
+	err := crm.EnsureProjectRoles(testProjectID, testMember, []string{testRole})
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.EnsureProjectRoles(): %s", err)
+	}
+	if len(server.sets) != 1 {
+		t.Fatalf("Expected cloudresourcemanager.EnsureProjectRoles() to grant the role without the condition")
+	}
+	policy := server.sets[0].Policy
+	if policy.Version != 3 || len(policy.Bindings) != 2 || policy.Bindings[0].Condition == nil || policy.Bindings[0].Condition.Expression != testCondition.Expression {
+		t.Errorf("Expected cloudresourcemanager.EnsureProjectRoles() to preserve the existing conditional binding")
+	}
+}
+
+func TestRemoveOrganizationRolesWithCondition(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Version: 3, Bindings: []*v1.Binding{
+		{Role: testRole, Members: []string{testMember}},
+		{Role: testRole, Members: []string{testMember}, Condition: &v1.Expr{Title: testCondition.Title, Description: testCondition.Description, Expression: testCondition.Expression}},
+	}})
+	err := crm.RemoveOrganizationRolesWithCondition(testOrganizationName, testMember, []string{testRole}, testCondition)
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.RemoveOrganizationRolesWithCondition(): %s", err)
+	}
+	if len(server.sets) != 1 {
+		t.Fatalf("Expected cloudresourcemanager.RemoveOrganizationRolesWithCondition() to set the policy once, got %d", len(server.sets))
+	}
+	bindings := server.sets[0].Policy.Bindings
+	if len(bindings) != 1 || bindings[0].Condition != nil {
+		t.Errorf("Expected only the conditional binding to be removed by cloudresourcemanager.RemoveOrganizationRolesWithCondition()")
+	}
+}
+
+func TestRemoveProjectRoles(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Bindings: []*v1.Binding{
+		{Role: testRole, Members: []string{testMember, "user:other@go-google-lib.tests"}},
+		{Role: "role2", Members: []string{testMember}},
+		{Role: "role3", Members: []string{testMember}},
+	}})
+	removed, err := crm.RemoveProjectRoles(testProjectID, testMember, []string{testRole, "role2", "role4"})
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.RemoveProjectRoles(): %s", err)
+	}
+	if !reflect.DeepEqual(removed, []string{testRole, "role2"}) {
+		t.Errorf("Expected cloudresourcemanager.RemoveProjectRoles() to report the roles the member was removed from, got %v", removed)
+	}
+	if len(server.sets) != 1 || len(server.sets[0].Policy.Bindings) != 2 {
+		t.Errorf("Expected cloudresourcemanager.RemoveProjectRoles() to set the policy without the member's role2 binding")
+	}
+}
+
+func TestRemoveProjectRolesUnchanged(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Bindings: []*v1.Binding{
+		{Role: testRole, Members: []string{"user:other@go-google-lib.tests"}},
+	}})
+	removed, err := crm.RemoveProjectRoles(testProjectID, testMember, []string{testRole})
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.RemoveProjectRoles(): %s", err)
+	}
+	if len(removed) != 0 || len(server.sets) != 0 {
+		t.Errorf("Expected cloudresourcemanager.RemoveProjectRoles() not to set a policy the member isn't in, got %v removed", removed)
+	}
+}
+
+func TestRemoveFolderMember(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Version: 3, Bindings: []*v1.Binding{
+		{Role: testRole, Members: []string{testMember}},
+		{Role: "role2", Members: []string{testMember}, Condition: &v1.Expr{Expression: testCondition.Expression}},
+		{Role: "role3", Members: []string{"user:other@go-google-lib.tests"}},
+	}})
+	removed, err := crm.RemoveFolderMember(testFolderName, testMember)
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.RemoveFolderMember(): %s", err)
+	}
+	expected := []string{testRole, "role2 if " + testCondition.Expression}
+	if !reflect.DeepEqual(removed, expected) {
+		t.Errorf("Expected cloudresourcemanager.RemoveFolderMember() to report removing the member from %v, got %v", expected, removed)
+	}
+	if len(server.sets) != 1 || len(server.sets[0].Policy.Bindings) != 1 || server.sets[0].Policy.Bindings[0].Role != "role3" {
+		t.Errorf("Expected cloudresourcemanager.RemoveFolderMember() to leave only the bindings of other members")
+	}
+}
+
+func TestRemoveOrganizationMemberDryRun(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Bindings: []*v1.Binding{
+		{Role: testRole, Members: []string{testMember}},
+	}})
+	crm.DryRun = plan.New()
+	removed, err := crm.RemoveOrganizationMember(testOrganizationName, testMember)
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.RemoveOrganizationMember() in a dry run: %s", err)
+	}
+	if !reflect.DeepEqual(removed, []string{testRole}) || len(server.sets) != 0 {
+		t.Errorf("Expected a dry run cloudresourcemanager.RemoveOrganizationMember() to report the roles without setting the policy")
+	}
+	if changes := crm.DryRun.Changes(); len(changes) != 1 || changes[0].Method != "RemoveOrganizationMember" {
+		t.Errorf("Expected the removal in the plan of a dry run cloudresourcemanager.RemoveOrganizationMember(), got %v", changes)
+	}
+}
+
+func TestReconcileProjectPolicy(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Etag: "etag", Bindings: []*v1.Binding{
+		{Role: "roles/owner", Members: []string{testMember, "user:unmanaged@go-google-lib.tests"}},
+		{Role: "roles/viewer", Members: []string{"user:unmanaged@go-google-lib.tests"}},
+	}})
+	spec := &iampolicy.Spec{Bindings: []*iampolicy.SpecBinding{
+		{Role: "roles/owner", Members: []string{testMember}, Authoritative: true},
+		{Role: "roles/viewer", Members: []string{testMember}},
+	}}
+	changes, err := crm.ReconcileProjectPolicy(testProjectID, spec)
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.ReconcileProjectPolicy(): %s", err)
+	}
+	expected := plan.Roles{"roles/owner": {testMember}, "roles/viewer": {"user:unmanaged@go-google-lib.tests", testMember}}
+	if len(changes) != 1 || !reflect.DeepEqual(changes[0].After, expected) {
+		t.Fatalf("Expected the reconciled roles in the change from cloudresourcemanager.ReconcileProjectPolicy(), got %v", changes)
+	}
+	if len(server.sets) != 1 || server.sets[0].Policy.Etag != "etag" {
+		t.Errorf("Expected cloudresourcemanager.ReconcileProjectPolicy() to set the policy once, with the etag it was read with")
+	}
+	changes, err = crm.ReconcileProjectPolicy(testProjectID, spec)
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.ReconcileProjectPolicy() with a reconciled policy: %s", err)
+	}
+	if len(changes) != 0 || len(server.sets) != 1 {
+		t.Errorf("Expected cloudresourcemanager.ReconcileProjectPolicy() not to change a policy matching its spec, got %v", changes)
+	}
+}
+
+func TestDiffFolderPolicy(t *testing.T) {
+	crm, server := getPolicyServerCloudResourceManager(t, &v1.Policy{Bindings: []*v1.Binding{
+		{Role: "roles/owner", Members: []string{"user:unmanaged@go-google-lib.tests"}},
+	}})
+	crm.DryRun = plan.New()
+	spec := &iampolicy.Spec{Bindings: []*iampolicy.SpecBinding{{Role: "roles/owner", Authoritative: true}}}
+	changes, err := crm.DiffFolderPolicy(testFolderName, spec)
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.DiffFolderPolicy(): %s", err)
+	}
+	if len(changes) != 1 || len(changes[0].After.(plan.Roles)["roles/owner"]) != 0 {
+		t.Errorf("Expected the drift of the authoritative role from cloudresourcemanager.DiffFolderPolicy(), got %v", changes)
+	}
+	if len(server.sets) != 0 || len(crm.DryRun.Changes()) != 0 {
+		t.Errorf("Expected cloudresourcemanager.DiffFolderPolicy() neither to set the policy nor plan the change")
+	}
+	if _, err := crm.DiffFolderPolicy(testFolderName, nil); err == nil {
+		t.Errorf("Expected an error from cloudresourcemanager.DiffFolderPolicy() without a spec")
+	}
+}
+
+func TestFolderPolicyConversion(t *testing.T) {
+	folderPolicy := &v2beta1.Policy{Version: 3, Etag: "etag", Bindings: []*v2beta1.Binding{
+		{Role: testRole, Members: []string{testMember}},
+		{Role: testRole, Members: []string{testMember}, Condition: &v2beta1.Expr{Title: testCondition.Title, Description: testCondition.Description, Expression: testCondition.Expression}},
+	}}
+	policy := fromFolderPolicy(folderPolicy)
+	expected := &iampolicy.Policy{Version: 3, Etag: "etag", Bindings: []*iampolicy.Binding{
+		{Role: testRole, Members: []string{testMember}},
+		{Role: testRole, Members: []string{testMember}, Condition: testCondition},
+	}}
+	if !reflect.DeepEqual(policy, expected) {
+		t.Errorf("Expected fromFolderPolicy() to keep the version, etag and bindings with their conditions, got %v", policy)
+	}
+	if back := toFolderPolicy(policy); !reflect.DeepEqual(back, folderPolicy) {
+		t.Errorf("Expected toFolderPolicy() to convert back to the policy of the folder, got %v", back)
+	}
+}
@@ -0,0 +1,165 @@
+package cloudresourcemanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+// ProjectDeleteRestriction is the restriction of a lien preventing the deletion of the project it's on
+const ProjectDeleteRestriction = "resourcemanager.projects.delete"
+
+// ProjectLienError is returned when deleting a project that has liens on it, which must be removed first. Liens is
+// empty when the liens couldn't be listed, and google refused the deletion instead, Err being the refusal.
+type ProjectLienError struct {
+	Project string
+	Liens   []*v1.Lien
+	Err     error
+}
+
+// Error returns a message listing the liens on the project
+func (e *ProjectLienError) Error() string {
+	if len(e.Liens) == 0 {
+		return fmt.Sprintf("project %s can't be deleted, it has one or more liens on it", e.Project)
+	}
+	liens := []string{}
+	for _, lien := range e.Liens {
+		liens = append(liens, fmt.Sprintf("%s (%s: %s)", lien.Name, lien.Origin, lien.Reason))
+	}
+	return fmt.Sprintf("project %s can't be deleted, it has %d lien(s) on it: %s", e.Project, len(e.Liens), strings.Join(liens, ", "))
+}
+
+// Unwrap returns the error google refused the deletion with, nil if the liens were found before trying
+func (e *ProjectLienError) Unwrap() error {
+	return e.Err
+}
+
+// CreateProjectLien will place a lien on a project preventing it from being deleted, with the reason and origin, e.g.
+// "production project" and "project-vending", returning the lien. Nothing is created if the project already has a lien
+// with the same reason and origin, which is returned instead. A dry run returns the lien that would be created, without
+// a name.
+func (crm *CloudResourceManager) CreateProjectLien(id string, reason string, origin string) (*v1.Lien, error) {
+	return crm.CreateProjectLienCtx(context.Background(), id, reason, origin)
+}
+
+// CreateProjectLienCtx is CreateProjectLien, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) CreateProjectLienCtx(ctx context.Context, id string, reason string, origin string) (lien *v1.Lien, err error) {
+	defer events.Start(crm.Events, service, "CreateProjectLien", events.ActionCreate, id, id).Done(&err)
+	crm.log.InfoPart("Ensuring that project %s has a lien from %s...", id, origin)
+	liens, err := crm.listProjectLiens(ctx, id)
+	if err != nil {
+		crm.log.InfoPart("error\n")
+		return nil, err
+	}
+	for _, existing := range liens {
+		if existing.Reason == reason && existing.Origin == origin {
+			crm.log.InfoPart("already exists\n")
+			return existing, nil
+		}
+	}
+	lien = &v1.Lien{
+		Parent:       fmt.Sprintf("projects/%s", id),
+		Reason:       reason,
+		Origin:       origin,
+		Restrictions: []string{ProjectDeleteRestriction},
+	}
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "CreateProjectLien", Project: id, Resource: id, Action: plan.ActionCreate, After: lien}) {
+		crm.log.InfoPart("would be created\n")
+		return lien, nil
+	}
+	liensService := v1.NewLiensService(crm.V1)
+	lienCreateCall := liensService.Create(lien).Context(ctx)
+	lien, err = crm.Calls.LiensCreate.Do(telemetry.WithResource(ctx, id, id), lienCreateCall)
+	if err != nil {
+		crm.log.InfoPart("error\n")
+		return nil, err
+	}
+	crm.log.InfoPart("created\n")
+	return lien, nil
+}
+
+// ListProjectLiens will return all of the liens on a project
+func (crm *CloudResourceManager) ListProjectLiens(id string) ([]*v1.Lien, error) {
+	return crm.ListProjectLiensCtx(context.Background(), id)
+}
+
+// ListProjectLiensCtx is ListProjectLiens, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) ListProjectLiensCtx(ctx context.Context, id string) (liens []*v1.Lien, err error) {
+	defer events.Start(crm.Events, service, "ListProjectLiens", events.ActionRead, id, id).Done(&err)
+	return crm.listProjectLiens(ctx, id)
+}
+
+// RemoveProjectLien will remove a lien by its name, e.g. liens/1234, nothing if it doesn't exist
+func (crm *CloudResourceManager) RemoveProjectLien(id string, lien string) error {
+	return crm.RemoveProjectLienCtx(context.Background(), id, lien)
+}
+
+// RemoveProjectLienCtx is RemoveProjectLien, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) RemoveProjectLienCtx(ctx context.Context, id string, lien string) (err error) {
+	defer events.Start(crm.Events, service, "RemoveProjectLien", events.ActionDelete, id, lien).Done(&err)
+	crm.log.InfoPart("Removing lien %s from project %s...", lien, id)
+	liens, err := crm.listProjectLiens(ctx, id)
+	if err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	var existing *v1.Lien
+	for _, candidate := range liens {
+		if candidate.Name == lien {
+			existing = candidate
+		}
+	}
+	if existing == nil {
+		crm.log.InfoPart("doesn't exist\n")
+		return nil
+	}
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "RemoveProjectLien", Project: id, Resource: lien, Action: plan.ActionDelete, Before: existing}) {
+		crm.log.InfoPart("would be removed\n")
+		return nil
+	}
+	liensService := v1.NewLiensService(crm.V1)
+	lienDeleteCall := liensService.Delete(lien).Context(ctx)
+	if _, err = crm.Calls.LiensDelete.Do(telemetry.WithResource(ctx, id, lien), lienDeleteCall); err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	crm.log.InfoPart("done\n")
+	return nil
+}
+
+// projectDeleteLiens will return the liens restricting the deletion of the project, out of all of its liens
+func projectDeleteLiens(liens []*v1.Lien) []*v1.Lien {
+	restricting := []*v1.Lien{}
+	for _, lien := range liens {
+		for _, restriction := range lien.Restrictions {
+			if restriction == ProjectDeleteRestriction {
+				restricting = append(restricting, lien)
+				break
+			}
+		}
+	}
+	return restricting
+}
+
+// listProjectLiens will return the liens on the project, following the pages of results
+func (crm *CloudResourceManager) listProjectLiens(ctx context.Context, id string) ([]*v1.Lien, error) {
+	liensService := v1.NewLiensService(crm.V1)
+	liensListCall := liensService.List().Parent(fmt.Sprintf("projects/%s", id)).Context(ctx)
+	liens := []*v1.Lien{}
+	for {
+		listLiensResponse, err := crm.Calls.LiensList.Do(telemetry.WithResource(ctx, id, id), liensListCall)
+		if err != nil {
+			return nil, err
+		}
+		liens = append(liens, listLiensResponse.Liens...)
+		if listLiensResponse.NextPageToken == "" {
+			return liens, nil
+		}
+		liensListCall = liensListCall.PageToken(listLiensResponse.NextPageToken)
+	}
+}
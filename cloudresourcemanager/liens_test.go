@@ -0,0 +1,94 @@
+package cloudresourcemanager
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rockholla/go-google-lib/plan"
+	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	googleapi "google.golang.org/api/googleapi"
+)
+
+type liensListMock struct{}
+
+// Do is the mock for default liensList, a project without liens
+func (c *liensListMock) Do(ctx context.Context, call *v1.LiensListCall, opts ...googleapi.CallOption) (*v1.ListLiensResponse, error) {
+	return &v1.ListLiensResponse{}, nil
+}
+
+func TestProjectLiens(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE"})
+	lien, err := crm.CreateProjectLien(testProjectID, "production project", "project-vending")
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.CreateProjectLien(): %s", err)
+	}
+	if lien.Name == "" || lien.Parent != "projects/"+testProjectID || lien.Restrictions[0] != ProjectDeleteRestriction {
+		t.Errorf("Expected cloudresourcemanager.CreateProjectLien() to create a lien preventing the project's deletion, got %v", lien)
+	}
+	if again, err := crm.CreateProjectLien(testProjectID, "production project", "project-vending"); err != nil || again.Name != lien.Name || len(server.liens) != 1 {
+		t.Errorf("Expected cloudresourcemanager.CreateProjectLien() to return the existing lien with the same reason and origin, got %v", err)
+	}
+	liens, err := crm.ListProjectLiens(testProjectID)
+	if err != nil || len(liens) != 1 {
+		t.Errorf("Expected the lien from cloudresourcemanager.ListProjectLiens(), got %v: %v", liens, err)
+	}
+	err = crm.DeleteProject(testProjectID)
+	if lienError, ok := err.(*ProjectLienError); !ok || len(lienError.Liens) != 1 || server.deletions != 0 {
+		t.Fatalf("Expected a *ProjectLienError from cloudresourcemanager.DeleteProject() for a project with a lien, got %v", err)
+	}
+	if err := crm.RemoveProjectLien(testProjectID, lien.Name); err != nil || len(server.liens) != 0 {
+		t.Fatalf("Expected cloudresourcemanager.RemoveProjectLien() to remove the lien, got %v", err)
+	}
+	if err := crm.DeleteProject(testProjectID); err != nil || server.deletions != 1 {
+		t.Errorf("Expected cloudresourcemanager.DeleteProject() to delete the project once its lien is removed, got %v", err)
+	}
+}
+
+func TestDeleteProjectOtherLiens(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE"})
+	server.liens = []*v1.Lien{{Name: "liens/1", Restrictions: []string{"resourcemanager.projects.move"}}}
+	if err := crm.DeleteProject(testProjectID); err != nil || server.deletions != 1 {
+		t.Errorf("Expected cloudresourcemanager.DeleteProject() to delete a project with only liens not restricting its deletion, got %v", err)
+	}
+}
+
+func TestDeleteProjectLiensUnlisted(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE"})
+	server.liens = []*v1.Lien{{Name: "liens/1", Restrictions: []string{ProjectDeleteRestriction}}}
+	server.hideLiens = true
+	err := crm.DeleteProject(testProjectID)
+	if lienError, ok := err.(*ProjectLienError); !ok || lienError.Project != testProjectID || server.deletions != 0 {
+		t.Errorf("Expected a *ProjectLienError from cloudresourcemanager.DeleteProject() refused because of a lien it couldn't list, got %v", err)
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("Expected the refusal of the deletion within the *ProjectLienError, got %v", err)
+	}
+}
+
+func TestDeleteProjectRefusedWithoutLiens(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE"})
+	server.refuseDeletion = true
+	err := crm.DeleteProject(testProjectID)
+	if _, ok := err.(*ProjectLienError); ok || err == nil {
+		t.Fatalf("Expected the refusal from cloudresourcemanager.DeleteProject() of a project without liens, not a *ProjectLienError, got %v", err)
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("Expected the google error from cloudresourcemanager.DeleteProject() refused for another precondition, got %v", err)
+	}
+}
+
+func TestCreateProjectLienDryRun(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE"})
+	crm.DryRun = plan.New()
+	lien, err := crm.CreateProjectLien(testProjectID, "production project", "project-vending")
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.CreateProjectLien() in a dry run: %s", err)
+	}
+	if lien.Name != "" || len(server.liens) != 0 || len(crm.DryRun.Changes()) != 1 {
+		t.Errorf("Expected a dry run cloudresourcemanager.CreateProjectLien() to plan the lien without creating it, got %v", crm.DryRun.Changes())
+	}
+}
@@ -0,0 +1,104 @@
+package cloudresourcemanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rockholla/go-google-lib/telemetry"
+	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+	v3 "google.golang.org/api/cloudresourcemanager/v3"
+)
+
+// OperationError is returned when a cloud resource manager operation finishes with an error
+type OperationError struct {
+	Operation string
+	Code      int64
+	Message   string
+}
+
+// Error returns the message reported by the operation
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation %s failed: %d: %s", e.Operation, e.Code, e.Message)
+}
+
+// operationState is the state of a long running operation, whichever version of the api started it
+type operationState struct {
+	name string
+	done bool
+	err  *OperationError
+}
+
+// waitForOperation will block until the operation is done, polling every OperationPollSeconds and giving up after
+// OperationTimeoutSeconds, returning an *OperationError if the finished operation reports an error
+func (crm *CloudResourceManager) waitForOperation(ctx context.Context, operation *v2beta1.Operation, resource string) error {
+	operationsService := v2beta1.NewOperationsService(crm.V2Beta1)
+	return crm.pollOperation(ctx, v2beta1OperationState(operation), func(ctx context.Context) (operationState, error) {
+		operationGetCall := operationsService.Get(operation.Name).Context(ctx)
+		latest, err := crm.Calls.OperationsGet.Do(telemetry.WithResource(ctx, "", resource), operationGetCall)
+		if err != nil {
+			return operationState{}, err
+		}
+		return v2beta1OperationState(latest), nil
+	})
+}
+
+// waitForV3Operation is waitForOperation for an operation started through the v3 api
+func (crm *CloudResourceManager) waitForV3Operation(ctx context.Context, operation *v3.Operation, resource string) error {
+	operationsService := v3.NewOperationsService(crm.V3)
+	return crm.pollOperation(ctx, v3OperationState(operation), func(ctx context.Context) (operationState, error) {
+		operationGetCall := operationsService.Get(operation.Name).Context(ctx)
+		latest, err := crm.Calls.V3OperationsGet.Do(telemetry.WithResource(ctx, "", resource), operationGetCall)
+		if err != nil {
+			return operationState{}, err
+		}
+		return v3OperationState(latest), nil
+	})
+}
+
+// pollOperation will get the latest state of the operation until it's done, for waitForOperation and
+// waitForV3Operation
+func (crm *CloudResourceManager) pollOperation(ctx context.Context, operation operationState, get func(ctx context.Context) (operationState, error)) error {
+	if crm.OperationTimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(crm.OperationTimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+	for !operation.done {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting for operation %s: %s", operation.name, ctx.Err().Error())
+		case <-time.After(time.Duration(crm.OperationPollSeconds) * time.Second):
+		}
+		latest, err := get(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("error waiting for operation %s: %s", operation.name, ctx.Err().Error())
+			}
+			return err
+		}
+		operation = latest
+	}
+	if operation.err != nil {
+		return operation.err
+	}
+	return nil
+}
+
+// v2beta1OperationState will return the state of an operation started through the v2beta1 api
+func v2beta1OperationState(operation *v2beta1.Operation) operationState {
+	state := operationState{name: operation.Name, done: operation.Done}
+	if operation.Error != nil {
+		state.err = &OperationError{Operation: operation.Name, Code: operation.Error.Code, Message: operation.Error.Message}
+	}
+	return state
+}
+
+// v3OperationState will return the state of an operation started through the v3 api
+func v3OperationState(operation *v3.Operation) operationState {
+	state := operationState{name: operation.Name, done: operation.Done}
+	if operation.Error != nil {
+		state.err = &OperationError{Operation: operation.Name, Code: operation.Error.Code, Message: operation.Error.Message}
+	}
+	return state
+}
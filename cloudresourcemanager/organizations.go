@@ -5,101 +5,121 @@ import (
 	"fmt"
 	"regexp"
 
-	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
 )
 
 // EnsureOrganizationRoles makes sure that a particular member has the supplied roles on the organization
 func (crm *CloudResourceManager) EnsureOrganizationRoles(organization string, member string, roles []string) error {
-	ctx := context.Background()
-	if matched, _ := regexp.Match("^organizations\\/", []byte(organization)); !matched {
-		organization = fmt.Sprintf("organizations/%s", organization)
-	}
-	crm.log.Info("Ensuring member %s has roles in %s:", member, organization)
-	organizationsService := v1.NewOrganizationsService(crm.V1)
-	organizationPolicyGetCall := organizationsService.GetIamPolicy(organization, &v1.GetIamPolicyRequest{}).Context(ctx)
-	policy, err := crm.Calls.OrganizationsGetIAMPolicy.Do(organizationPolicyGetCall)
-	if err != nil {
-		return err
-	}
-	for _, role := range roles {
-		crm.log.ListItem(role)
-		binding := getExistingOrganizationRoleBinding(policy.Bindings, role)
-		if binding == nil {
-			policy.Bindings = append(policy.Bindings, &v1.Binding{
-				Members: []string{member},
-				Role:    role,
-			})
-		} else if !memberExistsInOrganizationRoleBinding(binding, member) {
-			binding.Members = append(binding.Members, member)
-		}
-	}
-	setIAMPolicyRequest := &v1.SetIamPolicyRequest{
-		Policy: policy,
-	}
-	organizationSetPolicyCall := organizationsService.SetIamPolicy(organization, setIAMPolicyRequest).Context(ctx)
-	_, err = crm.Calls.OrganizationsSetIAMPolicy.Do(organizationSetPolicyCall)
-	if err != nil {
-		return err
-	}
-	return nil
+	return crm.EnsureOrganizationRolesCtx(context.Background(), organization, member, roles)
+}
+
+// EnsureOrganizationRolesCtx is EnsureOrganizationRoles, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) EnsureOrganizationRolesCtx(ctx context.Context, organization string, member string, roles []string) (err error) {
+	defer events.Start(crm.Events, service, "EnsureOrganizationRoles", events.ActionUpdate, "", organization).Done(&err)
+	return crm.ensureOrganizationRoles(ctx, "EnsureOrganizationRoles", organization, member, roles, nil)
+}
+
+// EnsureOrganizationRolesWithCondition makes sure that a particular member has the supplied roles on the
+// organization, granted only when the condition is met
+func (crm *CloudResourceManager) EnsureOrganizationRolesWithCondition(organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	return crm.EnsureOrganizationRolesWithConditionCtx(context.Background(), organization, member, roles, condition)
+}
+
+// EnsureOrganizationRolesWithConditionCtx is EnsureOrganizationRolesWithCondition, using the provided context for the
+// underlying api calls
+func (crm *CloudResourceManager) EnsureOrganizationRolesWithConditionCtx(ctx context.Context, organization string, member string, roles []string, condition *iampolicy.Condition) (err error) {
+	defer events.Start(crm.Events, service, "EnsureOrganizationRolesWithCondition", events.ActionUpdate, "", organization).Done(&err)
+	return crm.ensureOrganizationRoles(ctx, "EnsureOrganizationRolesWithCondition", organization, member, roles, condition)
+}
+
+func (crm *CloudResourceManager) ensureOrganizationRoles(ctx context.Context, method string, organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	organization = organizationName(organization)
+	_, err := crm.updateRoles(ctx, &organizationPolicy{crm: crm, organization: organization},
+		plan.Change{Service: service, Method: method, Resource: organization, Action: plan.ActionUpdate},
+		fmt.Sprintf("Ensuring member %s has roles in %s", member, organization), condition, roles,
+		func(role string) iampolicy.Mutation { return iampolicy.AddMember(role, member, condition) })
+	return err
 }
 
 // RemoveOrganizationRoles if found, removes a role or roles at the organization level for a particular member
 func (crm *CloudResourceManager) RemoveOrganizationRoles(organization string, member string, roles []string) error {
-	ctx := context.Background()
-	if matched, _ := regexp.Match("^organizations\\/", []byte(organization)); !matched {
-		organization = fmt.Sprintf("organizations/%s", organization)
-	}
-	crm.log.Info("Ensuring roles for member %s are removed in %s:", member, organization)
-	organizationsService := v1.NewOrganizationsService(crm.V1)
-	organizationPolicyGetCall := organizationsService.GetIamPolicy(organization, &v1.GetIamPolicyRequest{}).Context(ctx)
-	policy, err := crm.Calls.OrganizationsGetIAMPolicy.Do(organizationPolicyGetCall)
-	if err != nil {
-		return err
-	}
-	changed := false
-	for _, role := range roles {
-		crm.log.ListItem(role)
-		binding := getExistingOrganizationRoleBinding(policy.Bindings, role)
-		if binding != nil {
-			newBindingMembers := []string{}
-			for _, bindingMember := range binding.Members {
-				if bindingMember == member {
-					changed = true
-					continue
-				}
-				newBindingMembers = append(newBindingMembers, bindingMember)
-			}
-			binding.Members = newBindingMembers
-		}
-	}
-	setIAMPolicyRequest := &v1.SetIamPolicyRequest{
-		Policy: policy,
-	}
-	if changed {
-		organizationSetPolicyCall := organizationsService.SetIamPolicy(organization, setIAMPolicyRequest).Context(ctx)
-		_, err = crm.Calls.OrganizationsSetIAMPolicy.Do(organizationSetPolicyCall)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return crm.RemoveOrganizationRolesCtx(context.Background(), organization, member, roles)
 }
 
-func getExistingOrganizationRoleBinding(bindings []*v1.Binding, role string) *v1.Binding {
-	for _, binding := range bindings {
-		if binding.Role == role {
-			return binding
-		}
-	}
-	return nil
+// RemoveOrganizationRolesCtx is RemoveOrganizationRoles, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) RemoveOrganizationRolesCtx(ctx context.Context, organization string, member string, roles []string) (err error) {
+	defer events.Start(crm.Events, service, "RemoveOrganizationRoles", events.ActionUpdate, "", organization).Done(&err)
+	return crm.removeOrganizationRoles(ctx, "RemoveOrganizationRoles", organization, member, roles, nil)
+}
+
+// RemoveOrganizationRolesWithCondition if found, removes a role or roles granted with the condition at the
+// organization level for a particular member, leaving the roles granted without it or with other conditions
+func (crm *CloudResourceManager) RemoveOrganizationRolesWithCondition(organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	return crm.RemoveOrganizationRolesWithConditionCtx(context.Background(), organization, member, roles, condition)
+}
+
+// RemoveOrganizationRolesWithConditionCtx is RemoveOrganizationRolesWithCondition, using the provided context for the
+// underlying api calls
+func (crm *CloudResourceManager) RemoveOrganizationRolesWithConditionCtx(ctx context.Context, organization string, member string, roles []string, condition *iampolicy.Condition) (err error) {
+	defer events.Start(crm.Events, service, "RemoveOrganizationRolesWithCondition", events.ActionUpdate, "", organization).Done(&err)
+	return crm.removeOrganizationRoles(ctx, "RemoveOrganizationRolesWithCondition", organization, member, roles, condition)
+}
+
+func (crm *CloudResourceManager) removeOrganizationRoles(ctx context.Context, method string, organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	organization = organizationName(organization)
+	_, err := crm.updateRoles(ctx, &organizationPolicy{crm: crm, organization: organization},
+		plan.Change{Service: service, Method: method, Resource: organization, Action: plan.ActionUpdate},
+		fmt.Sprintf("Ensuring roles for member %s are removed in %s", member, organization), condition, roles,
+		func(role string) iampolicy.Mutation { return iampolicy.RemoveMember(role, member, condition) })
+	return err
+}
+
+// RemoveOrganizationMember makes sure that a particular member is removed from every role on the organization, with
+// or without a condition, returning the roles it was removed from
+func (crm *CloudResourceManager) RemoveOrganizationMember(organization string, member string) ([]string, error) {
+	return crm.RemoveOrganizationMemberCtx(context.Background(), organization, member)
+}
+
+// RemoveOrganizationMemberCtx is RemoveOrganizationMember, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) RemoveOrganizationMemberCtx(ctx context.Context, organization string, member string) (removed []string, err error) {
+	defer events.Start(crm.Events, service, "RemoveOrganizationMember", events.ActionUpdate, "", organization).Done(&err)
+	organization = organizationName(organization)
+	return crm.removeMember(ctx, &organizationPolicy{crm: crm, organization: organization}, plan.Change{Service: service, Method: "RemoveOrganizationMember", Resource: organization, Action: plan.ActionUpdate}, member)
+}
+
+// DiffOrganizationPolicy will return how the iam policy of the organization differs from the spec, without changing
+// it, as the change that reconciling it would make, or no changes at all when it matches
+func (crm *CloudResourceManager) DiffOrganizationPolicy(organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return crm.DiffOrganizationPolicyCtx(context.Background(), organization, spec)
+}
+
+// DiffOrganizationPolicyCtx is DiffOrganizationPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) DiffOrganizationPolicyCtx(ctx context.Context, organization string, spec *iampolicy.Spec) (changes []plan.Change, err error) {
+	defer events.Start(crm.Events, service, "DiffOrganizationPolicy", events.ActionRead, "", organization).Done(&err)
+	organization = organizationName(organization)
+	return crm.reconcile(ctx, &organizationPolicy{crm: crm, organization: organization}, plan.Change{Service: service, Method: "DiffOrganizationPolicy", Resource: organization, Action: plan.ActionUpdate}, spec, true)
 }
 
-func memberExistsInOrganizationRoleBinding(binding *v1.Binding, member string) bool {
-	for _, bindingMember := range binding.Members {
-		if bindingMember == member {
-			return true
-		}
+// ReconcileOrganizationPolicy will bring the iam policy of the organization in line with the spec, logging how it
+// differed from it, and return the change it made, or no changes at all when it already matched
+func (crm *CloudResourceManager) ReconcileOrganizationPolicy(organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return crm.ReconcileOrganizationPolicyCtx(context.Background(), organization, spec)
+}
+
+// ReconcileOrganizationPolicyCtx is ReconcileOrganizationPolicy, using the provided context for the underlying api
+// calls
+func (crm *CloudResourceManager) ReconcileOrganizationPolicyCtx(ctx context.Context, organization string, spec *iampolicy.Spec) (changes []plan.Change, err error) {
+	defer events.Start(crm.Events, service, "ReconcileOrganizationPolicy", events.ActionUpdate, "", organization).Done(&err)
+	organization = organizationName(organization)
+	return crm.reconcile(ctx, &organizationPolicy{crm: crm, organization: organization}, plan.Change{Service: service, Method: "ReconcileOrganizationPolicy", Resource: organization, Action: plan.ActionUpdate}, spec, false)
+}
+
+// organizationName will return the organization in the form organizations/{id}
+func organizationName(organization string) string {
+	if matched, _ := regexp.Match("^organizations\\/", []byte(organization)); !matched {
+		return fmt.Sprintf("organizations/%s", organization)
 	}
-	return false
+	return organization
 }
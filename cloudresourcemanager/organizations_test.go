@@ -1,6 +1,7 @@
 package cloudresourcemanager
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -19,12 +20,12 @@ type organizationsGetIAMPolicyExistingRoleMock struct{}
 type organizationsSetIAMPolicyMock struct{}
 
 // Do is the mock for default organizationsGetIAMPolicyMock
-func (c *organizationsGetIAMPolicyMock) Do(call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *organizationsGetIAMPolicyMock) Do(ctx context.Context, call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	return &v1.Policy{}, nil
 }
 
 // Do is the mock for organizationsGetIAMPolicyMock that includes an existing member in role
-func (c *organizationsGetIAMPolicyExistingMemberMock) Do(call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *organizationsGetIAMPolicyExistingMemberMock) Do(ctx context.Context, call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	var bindings []*v1.Binding
 	bindings = append(bindings, &v1.Binding{
 		Role: testRole,
@@ -38,7 +39,7 @@ func (c *organizationsGetIAMPolicyExistingMemberMock) Do(call *v1.OrganizationsG
 }
 
 // Do is the mock for organizationsGetIAMPolicyMock that includes an existing member in role
-func (c *organizationsGetIAMPolicyExistingRoleMock) Do(call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *organizationsGetIAMPolicyExistingRoleMock) Do(ctx context.Context, call *v1.OrganizationsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	var bindings []*v1.Binding
 	bindings = append(bindings, &v1.Binding{
 		Role:    testRole,
@@ -50,7 +51,7 @@ func (c *organizationsGetIAMPolicyExistingRoleMock) Do(call *v1.OrganizationsGet
 }
 
 // Do is the mock for default organizationsSetIAMPolicyMock
-func (c *organizationsSetIAMPolicyMock) Do(call *v1.OrganizationsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *organizationsSetIAMPolicyMock) Do(ctx context.Context, call *v1.OrganizationsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	return &v1.Policy{}, nil
 }
 
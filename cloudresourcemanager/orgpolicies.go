@@ -0,0 +1,363 @@
+package cloudresourcemanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+// BooleanOrgPolicy will return an org policy enforcing the boolean constraint, or explicitly not enforcing it, e.g.
+// BooleanOrgPolicy("compute.skipDefaultNetworkCreation", true)
+func BooleanOrgPolicy(constraint string, enforced bool) *v1.OrgPolicy {
+	return &v1.OrgPolicy{
+		Constraint:    constraintName(constraint),
+		BooleanPolicy: &v1.BooleanPolicy{Enforced: enforced, ForceSendFields: []string{"Enforced"}},
+	}
+}
+
+// ListOrgPolicy will return an org policy allowing and denying the values of the list constraint, merged with the
+// policy of the parent when inheritFromParent, e.g.
+// ListOrgPolicy("compute.vmExternalIpAccess", nil, []string{"projects/my-project"}, false)
+func ListOrgPolicy(constraint string, allowed []string, denied []string, inheritFromParent bool) *v1.OrgPolicy {
+	return &v1.OrgPolicy{
+		Constraint: constraintName(constraint),
+		ListPolicy: &v1.ListPolicy{AllowedValues: allowed, DeniedValues: denied, InheritFromParent: inheritFromParent},
+	}
+}
+
+// RestoreDefaultOrgPolicy will return an org policy restoring the default of the constraint, ignoring the policies
+// of parents
+func RestoreDefaultOrgPolicy(constraint string) *v1.OrgPolicy {
+	return &v1.OrgPolicy{Constraint: constraintName(constraint), RestoreDefault: &v1.RestoreDefault{}}
+}
+
+// GetOrgPolicy will return the org policy for the constraint set directly on the resource, the name of an
+// organization, folder or project, e.g. organizations/1234, folders/5678 or projects/my-project. The policy has no
+// list, boolean or restore default policy when none is set.
+func (crm *CloudResourceManager) GetOrgPolicy(resource string, constraint string) (*v1.OrgPolicy, error) {
+	return crm.GetOrgPolicyCtx(context.Background(), resource, constraint)
+}
+
+// GetOrgPolicyCtx is GetOrgPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) GetOrgPolicyCtx(ctx context.Context, resource string, constraint string) (policy *v1.OrgPolicy, err error) {
+	defer events.Start(crm.Events, service, "GetOrgPolicy", events.ActionRead, orgPolicyProject(resource), resource).Done(&err)
+	policies, err := crm.orgPoliciesOf(resource)
+	if err != nil {
+		return nil, err
+	}
+	return policies.get(ctx, constraintName(constraint))
+}
+
+// GetEffectiveOrgPolicy will return the org policy for the constraint in effect on the resource, the name of an
+// organization, folder or project, once the policies of its parents are taken into account
+func (crm *CloudResourceManager) GetEffectiveOrgPolicy(resource string, constraint string) (*v1.OrgPolicy, error) {
+	return crm.GetEffectiveOrgPolicyCtx(context.Background(), resource, constraint)
+}
+
+// GetEffectiveOrgPolicyCtx is GetEffectiveOrgPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) GetEffectiveOrgPolicyCtx(ctx context.Context, resource string, constraint string) (policy *v1.OrgPolicy, err error) {
+	defer events.Start(crm.Events, service, "GetEffectiveOrgPolicy", events.ActionRead, orgPolicyProject(resource), resource).Done(&err)
+	policies, err := crm.orgPoliciesOf(resource)
+	if err != nil {
+		return nil, err
+	}
+	return policies.getEffective(ctx, constraintName(constraint))
+}
+
+// ListOrgPolicies will return all of the org policies set directly on the resource, the name of an organization,
+// folder or project
+func (crm *CloudResourceManager) ListOrgPolicies(resource string) ([]*v1.OrgPolicy, error) {
+	return crm.ListOrgPoliciesCtx(context.Background(), resource)
+}
+
+// ListOrgPoliciesCtx is ListOrgPolicies, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) ListOrgPoliciesCtx(ctx context.Context, resource string) (result []*v1.OrgPolicy, err error) {
+	defer events.Start(crm.Events, service, "ListOrgPolicies", events.ActionRead, orgPolicyProject(resource), resource).Done(&err)
+	policies, err := crm.orgPoliciesOf(resource)
+	if err != nil {
+		return nil, err
+	}
+	result = []*v1.OrgPolicy{}
+	pageToken := ""
+	for {
+		response, err := policies.list(ctx, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, response.Policies...)
+		if response.NextPageToken == "" {
+			return result, nil
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+// SetOrgPolicy will set the org policy on the resource, the name of an organization, folder or project, replacing
+// any policy for the same constraint, regardless of whether it differs
+func (crm *CloudResourceManager) SetOrgPolicy(resource string, policy *v1.OrgPolicy) error {
+	return crm.SetOrgPolicyCtx(context.Background(), resource, policy)
+}
+
+// SetOrgPolicyCtx is SetOrgPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) SetOrgPolicyCtx(ctx context.Context, resource string, policy *v1.OrgPolicy) (err error) {
+	defer events.Start(crm.Events, service, "SetOrgPolicy", events.ActionUpdate, orgPolicyProject(resource), resource).Done(&err)
+	policies, err := crm.orgPoliciesOf(resource)
+	if err != nil {
+		return err
+	}
+	return crm.setOrgPolicy(ctx, "SetOrgPolicy", policies, resource, orgPolicyWithConstraintName(policy), nil)
+}
+
+// EnsureOrgPolicy will make sure the org policy is set on the resource, the name of an organization, folder or
+// project, only setting it when the policy set for the same constraint differs, returning whether it did. Policies
+// are the same when they have the same list, boolean and restore default policies, regardless of the order of their
+// values.
+func (crm *CloudResourceManager) EnsureOrgPolicy(resource string, policy *v1.OrgPolicy) (bool, error) {
+	return crm.EnsureOrgPolicyCtx(context.Background(), resource, policy)
+}
+
+// EnsureOrgPolicyCtx is EnsureOrgPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) EnsureOrgPolicyCtx(ctx context.Context, resource string, policy *v1.OrgPolicy) (changed bool, err error) {
+	defer events.Start(crm.Events, service, "EnsureOrgPolicy", events.ActionEnsure, orgPolicyProject(resource), resource).Done(&err)
+	policies, err := crm.orgPoliciesOf(resource)
+	if err != nil {
+		return false, err
+	}
+	policy = orgPolicyWithConstraintName(policy)
+	existing, err := policies.get(ctx, policy.Constraint)
+	if err != nil {
+		return false, err
+	}
+	if SameOrgPolicy(existing, policy) {
+		crm.log.Info("Org policy %s is already set on %s", policy.Constraint, resource)
+		return false, nil
+	}
+	policy.Etag = existing.Etag
+	if err := crm.setOrgPolicy(ctx, "EnsureOrgPolicy", policies, resource, policy, existing); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ClearOrgPolicy will clear the org policy for the constraint from the resource, the name of an organization, folder
+// or project, so that the policies of its parents, or the default of the constraint, are in effect again
+func (crm *CloudResourceManager) ClearOrgPolicy(resource string, constraint string) error {
+	return crm.ClearOrgPolicyCtx(context.Background(), resource, constraint)
+}
+
+// ClearOrgPolicyCtx is ClearOrgPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) ClearOrgPolicyCtx(ctx context.Context, resource string, constraint string) (err error) {
+	defer events.Start(crm.Events, service, "ClearOrgPolicy", events.ActionDelete, orgPolicyProject(resource), resource).Done(&err)
+	policies, err := crm.orgPoliciesOf(resource)
+	if err != nil {
+		return err
+	}
+	constraint = constraintName(constraint)
+	crm.log.Info("Ensuring org policy %s is cleared from %s", constraint, resource)
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "ClearOrgPolicy", Project: orgPolicyProject(resource), Resource: resource, Action: plan.ActionDelete, Before: &v1.OrgPolicy{Constraint: constraint}}) {
+		return nil
+	}
+	return policies.clear(ctx, constraint)
+}
+
+// SameOrgPolicy will return whether the org policies have the same list, boolean and restore default policies,
+// regardless of the order of their values, their etags, versions and update times
+func SameOrgPolicy(a *v1.OrgPolicy, b *v1.OrgPolicy) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if (a.RestoreDefault == nil) != (b.RestoreDefault == nil) || (a.BooleanPolicy == nil) != (b.BooleanPolicy == nil) || (a.ListPolicy == nil) != (b.ListPolicy == nil) {
+		return false
+	}
+	if a.BooleanPolicy != nil && a.BooleanPolicy.Enforced != b.BooleanPolicy.Enforced {
+		return false
+	}
+	if a.ListPolicy != nil {
+		al, bl := a.ListPolicy, b.ListPolicy
+		return al.AllValues == bl.AllValues && al.InheritFromParent == bl.InheritFromParent && al.SuggestedValue == bl.SuggestedValue &&
+			sameValues(al.AllowedValues, bl.AllowedValues) && sameValues(al.DeniedValues, bl.DeniedValues)
+	}
+	return true
+}
+
+// setOrgPolicy will set the org policy on the resource, existing being the policy it replaces when known, adding the
+// change to a dry run instead of making it
+func (crm *CloudResourceManager) setOrgPolicy(ctx context.Context, method string, policies orgPolicies, resource string, policy *v1.OrgPolicy, existing *v1.OrgPolicy) error {
+	policyBytes, _ := json.Marshal(policy)
+	crm.log.Info("Ensuring org policy is set on %s: %s", resource, string(policyBytes))
+	change := plan.Change{Service: service, Method: method, Project: orgPolicyProject(resource), Resource: resource, Action: plan.ActionUpdate, After: policy}
+	if existing != nil {
+		change.Before = existing
+	}
+	if crm.DryRun.Record(change) {
+		return nil
+	}
+	_, err := policies.set(ctx, policy)
+	return err
+}
+
+// orgPolicies are the org policies of an organization, folder or project, through the calls for its type of resource
+type orgPolicies interface {
+	get(ctx context.Context, constraint string) (*v1.OrgPolicy, error)
+	getEffective(ctx context.Context, constraint string) (*v1.OrgPolicy, error)
+	list(ctx context.Context, pageToken string) (*v1.ListOrgPoliciesResponse, error)
+	set(ctx context.Context, policy *v1.OrgPolicy) (*v1.OrgPolicy, error)
+	clear(ctx context.Context, constraint string) error
+}
+
+// orgPoliciesOf will return the org policies of the resource, by its name, e.g. organizations/1234, folders/5678 or
+// projects/my-project
+func (crm *CloudResourceManager) orgPoliciesOf(resource string) (orgPolicies, error) {
+	switch {
+	case strings.HasPrefix(resource, "organizations/"):
+		return &organizationOrgPolicies{crm: crm, organization: resource}, nil
+	case strings.HasPrefix(resource, "folders/"):
+		return &folderOrgPolicies{crm: crm, folder: resource}, nil
+	case strings.HasPrefix(resource, "projects/"):
+		return &projectOrgPolicies{crm: crm, project: resource}, nil
+	}
+	return nil, fmt.Errorf("%s isn't the name of an organization, folder or project, e.g. organizations/1234, folders/5678 or projects/my-project", resource)
+}
+
+// orgPolicyProject will return the project id of the resource if it's a project, for the events and telemetry of
+// its calls
+func orgPolicyProject(resource string) string {
+	if strings.HasPrefix(resource, "projects/") {
+		return strings.TrimPrefix(resource, "projects/")
+	}
+	return ""
+}
+
+// constraintName will make sure the constraint has its constraints/ prefix, the way the api returns it
+func constraintName(constraint string) string {
+	if strings.HasPrefix(constraint, "constraints/") {
+		return constraint
+	}
+	return fmt.Sprintf("constraints/%s", constraint)
+}
+
+// orgPolicyWithConstraintName will return a copy of the policy with its constraint's prefix
+func orgPolicyWithConstraintName(policy *v1.OrgPolicy) *v1.OrgPolicy {
+	copied := *policy
+	copied.Constraint = constraintName(policy.Constraint)
+	return &copied
+}
+
+func sameValues(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// organizationOrgPolicies are the org policies of an organization
+type organizationOrgPolicies struct {
+	crm          *CloudResourceManager
+	organization string
+}
+
+func (o *organizationOrgPolicies) get(ctx context.Context, constraint string) (*v1.OrgPolicy, error) {
+	call := v1.NewOrganizationsService(o.crm.V1).GetOrgPolicy(o.organization, &v1.GetOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	return o.crm.Calls.OrganizationsGetOrgPolicy.Do(telemetry.WithResource(ctx, "", o.organization), call)
+}
+
+func (o *organizationOrgPolicies) getEffective(ctx context.Context, constraint string) (*v1.OrgPolicy, error) {
+	call := v1.NewOrganizationsService(o.crm.V1).GetEffectiveOrgPolicy(o.organization, &v1.GetEffectiveOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	return o.crm.Calls.OrganizationsGetEffectiveOrgPolicy.Do(telemetry.WithResource(ctx, "", o.organization), call)
+}
+
+func (o *organizationOrgPolicies) list(ctx context.Context, pageToken string) (*v1.ListOrgPoliciesResponse, error) {
+	call := v1.NewOrganizationsService(o.crm.V1).ListOrgPolicies(o.organization, &v1.ListOrgPoliciesRequest{PageToken: pageToken}).Context(ctx)
+	return o.crm.Calls.OrganizationsListOrgPolicies.Do(telemetry.WithResource(ctx, "", o.organization), call)
+}
+
+func (o *organizationOrgPolicies) set(ctx context.Context, policy *v1.OrgPolicy) (*v1.OrgPolicy, error) {
+	call := v1.NewOrganizationsService(o.crm.V1).SetOrgPolicy(o.organization, &v1.SetOrgPolicyRequest{Policy: policy}).Context(ctx)
+	return o.crm.Calls.OrganizationsSetOrgPolicy.Do(telemetry.WithResource(ctx, "", o.organization), call)
+}
+
+func (o *organizationOrgPolicies) clear(ctx context.Context, constraint string) error {
+	call := v1.NewOrganizationsService(o.crm.V1).ClearOrgPolicy(o.organization, &v1.ClearOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	_, err := o.crm.Calls.OrganizationsClearOrgPolicy.Do(telemetry.WithResource(ctx, "", o.organization), call)
+	return err
+}
+
+// folderOrgPolicies are the org policies of a folder
+type folderOrgPolicies struct {
+	crm    *CloudResourceManager
+	folder string
+}
+
+func (f *folderOrgPolicies) get(ctx context.Context, constraint string) (*v1.OrgPolicy, error) {
+	call := v1.NewFoldersService(f.crm.V1).GetOrgPolicy(f.folder, &v1.GetOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	return f.crm.Calls.FoldersGetOrgPolicy.Do(telemetry.WithResource(ctx, "", f.folder), call)
+}
+
+func (f *folderOrgPolicies) getEffective(ctx context.Context, constraint string) (*v1.OrgPolicy, error) {
+	call := v1.NewFoldersService(f.crm.V1).GetEffectiveOrgPolicy(f.folder, &v1.GetEffectiveOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	return f.crm.Calls.FoldersGetEffectiveOrgPolicy.Do(telemetry.WithResource(ctx, "", f.folder), call)
+}
+
+func (f *folderOrgPolicies) list(ctx context.Context, pageToken string) (*v1.ListOrgPoliciesResponse, error) {
+	call := v1.NewFoldersService(f.crm.V1).ListOrgPolicies(f.folder, &v1.ListOrgPoliciesRequest{PageToken: pageToken}).Context(ctx)
+	return f.crm.Calls.FoldersListOrgPolicies.Do(telemetry.WithResource(ctx, "", f.folder), call)
+}
+
+func (f *folderOrgPolicies) set(ctx context.Context, policy *v1.OrgPolicy) (*v1.OrgPolicy, error) {
+	call := v1.NewFoldersService(f.crm.V1).SetOrgPolicy(f.folder, &v1.SetOrgPolicyRequest{Policy: policy}).Context(ctx)
+	return f.crm.Calls.FoldersSetOrgPolicy.Do(telemetry.WithResource(ctx, "", f.folder), call)
+}
+
+func (f *folderOrgPolicies) clear(ctx context.Context, constraint string) error {
+	call := v1.NewFoldersService(f.crm.V1).ClearOrgPolicy(f.folder, &v1.ClearOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	_, err := f.crm.Calls.FoldersClearOrgPolicy.Do(telemetry.WithResource(ctx, "", f.folder), call)
+	return err
+}
+
+// projectOrgPolicies are the org policies of a project
+type projectOrgPolicies struct {
+	crm     *CloudResourceManager
+	project string
+}
+
+func (p *projectOrgPolicies) get(ctx context.Context, constraint string) (*v1.OrgPolicy, error) {
+	call := v1.NewProjectsService(p.crm.V1).GetOrgPolicy(p.project, &v1.GetOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	return p.crm.Calls.ProjectsGetOrgPolicy.Do(telemetry.WithResource(ctx, orgPolicyProject(p.project), p.project), call)
+}
+
+func (p *projectOrgPolicies) getEffective(ctx context.Context, constraint string) (*v1.OrgPolicy, error) {
+	call := v1.NewProjectsService(p.crm.V1).GetEffectiveOrgPolicy(p.project, &v1.GetEffectiveOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	return p.crm.Calls.ProjectsGetEffectiveOrgPolicy.Do(telemetry.WithResource(ctx, orgPolicyProject(p.project), p.project), call)
+}
+
+func (p *projectOrgPolicies) list(ctx context.Context, pageToken string) (*v1.ListOrgPoliciesResponse, error) {
+	call := v1.NewProjectsService(p.crm.V1).ListOrgPolicies(p.project, &v1.ListOrgPoliciesRequest{PageToken: pageToken}).Context(ctx)
+	return p.crm.Calls.ProjectsListOrgPolicies.Do(telemetry.WithResource(ctx, orgPolicyProject(p.project), p.project), call)
+}
+
+func (p *projectOrgPolicies) set(ctx context.Context, policy *v1.OrgPolicy) (*v1.OrgPolicy, error) {
+	call := v1.NewProjectsService(p.crm.V1).SetOrgPolicy(p.project, &v1.SetOrgPolicyRequest{Policy: policy}).Context(ctx)
+	return p.crm.Calls.ProjectsSetOrgPolicy.Do(telemetry.WithResource(ctx, orgPolicyProject(p.project), p.project), call)
+}
+
+func (p *projectOrgPolicies) clear(ctx context.Context, constraint string) error {
+	call := v1.NewProjectsService(p.crm.V1).ClearOrgPolicy(p.project, &v1.ClearOrgPolicyRequest{Constraint: constraint}).Context(ctx)
+	_, err := p.crm.Calls.ProjectsClearOrgPolicy.Do(telemetry.WithResource(ctx, orgPolicyProject(p.project), p.project), call)
+	return err
+}
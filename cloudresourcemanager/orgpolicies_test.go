@@ -0,0 +1,191 @@
+package cloudresourcemanager
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rockholla/go-google-lib/plan"
+	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
+	v1 "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+// orgPolicyServer serves the org policies of resources, keeping the paths of the requests made and the policies set
+type orgPolicyServer struct {
+	policies map[string]*v1.OrgPolicy
+	pages    [][]*v1.OrgPolicy
+	paths    []string
+	sets     []*v1.OrgPolicy
+	clears   []string
+}
+
+func (s *orgPolicyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	s.paths = append(s.paths, r.URL.Path)
+	var response interface{}
+	switch {
+	case strings.HasSuffix(r.URL.Path, ":getOrgPolicy"), strings.HasSuffix(r.URL.Path, ":getEffectiveOrgPolicy"):
+		request := &v1.GetOrgPolicyRequest{}
+		json.NewDecoder(r.Body).Decode(request)
+		policy, ok := s.policies[request.Constraint]
+		if !ok {
+			policy = &v1.OrgPolicy{Constraint: request.Constraint, Etag: "empty"}
+		}
+		response = policy
+	case strings.HasSuffix(r.URL.Path, ":listOrgPolicies"):
+		request := &v1.ListOrgPoliciesRequest{}
+		json.NewDecoder(r.Body).Decode(request)
+		page := 0
+		if request.PageToken != "" {
+			page = 1
+		}
+		list := &v1.ListOrgPoliciesResponse{Policies: s.pages[page]}
+		if page < len(s.pages)-1 {
+			list.NextPageToken = "next"
+		}
+		response = list
+	case strings.HasSuffix(r.URL.Path, ":setOrgPolicy"):
+		request := &v1.SetOrgPolicyRequest{}
+		json.NewDecoder(r.Body).Decode(request)
+		s.sets = append(s.sets, request.Policy)
+		response = request.Policy
+	case strings.HasSuffix(r.URL.Path, ":clearOrgPolicy"):
+		request := &v1.ClearOrgPolicyRequest{}
+		json.NewDecoder(r.Body).Decode(request)
+		s.clears = append(s.clears, request.Constraint)
+		response = map[string]interface{}{}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func getOrgPolicyServerCloudResourceManager(t *testing.T, policies ...*v1.OrgPolicy) (*CloudResourceManager, *orgPolicyServer) {
+	orgPolicyServer := &orgPolicyServer{policies: map[string]*v1.OrgPolicy{}}
+	for _, policy := range policies {
+		orgPolicyServer.policies[policy.Constraint] = policy
+	}
+	server := httptest.NewServer(orgPolicyServer)
+	t.Cleanup(server.Close)
+	crm := &CloudResourceManager{Endpoint: server.URL, Insecure: true}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.Initialize() with a test endpoint: %s", err)
+	}
+	return crm, orgPolicyServer
+}
+
+func TestEnsureOrgPolicy(t *testing.T) {
+	existing := ListOrgPolicy("compute.vmExternalIpAccess", []string{"projects/a", "projects/b"}, nil, false)
+	existing.Etag = "etag"
+	crm, server := getOrgPolicyServerCloudResourceManager(t, existing)
+	changed, err := crm.EnsureOrgPolicy(testFolderName, ListOrgPolicy("constraints/compute.vmExternalIpAccess", []string{"projects/b", "projects/a"}, nil, false))
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.EnsureOrgPolicy(): %s", err)
+	}
+	if changed || len(server.sets) != 0 {
+		t.Errorf("Expected cloudresourcemanager.EnsureOrgPolicy() not to set a policy with the same values in a different order")
+	}
+	changed, err = crm.EnsureOrgPolicy(testFolderName, ListOrgPolicy("compute.vmExternalIpAccess", []string{"projects/a"}, nil, true))
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.EnsureOrgPolicy() with a differing policy: %s", err)
+	}
+	if !changed || len(server.sets) != 1 || server.sets[0].Etag != "etag" || !server.sets[0].ListPolicy.InheritFromParent {
+		t.Errorf("Expected cloudresourcemanager.EnsureOrgPolicy() to set a differing policy with the etag it was read with")
+	}
+	if !strings.HasSuffix(server.paths[len(server.paths)-1], "/v1/"+testFolderName+":setOrgPolicy") {
+		t.Errorf("Expected cloudresourcemanager.EnsureOrgPolicy() to set the policy of the folder, got %s", server.paths[len(server.paths)-1])
+	}
+}
+
+func TestEnsureOrgPolicyBoolean(t *testing.T) {
+	crm, server := getOrgPolicyServerCloudResourceManager(t)
+	changed, err := crm.EnsureOrgPolicy("projects/"+testProjectID, BooleanOrgPolicy("compute.skipDefaultNetworkCreation", false))
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.EnsureOrgPolicy(): %s", err)
+	}
+	if !changed || len(server.sets) != 1 || server.sets[0].BooleanPolicy == nil || server.sets[0].BooleanPolicy.Enforced {
+		t.Errorf("Expected cloudresourcemanager.EnsureOrgPolicy() to explicitly set a boolean policy that isn't enforced when none is set")
+	}
+}
+
+func TestEnsureOrgPolicyDryRun(t *testing.T) {
+	crm, server := getOrgPolicyServerCloudResourceManager(t)
+	crm.DryRun = plan.New()
+	changed, err := crm.EnsureOrgPolicy(testOrganizationName, RestoreDefaultOrgPolicy("compute.vmExternalIpAccess"))
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.EnsureOrgPolicy() in a dry run: %s", err)
+	}
+	if !changed || len(server.sets) != 0 {
+		t.Errorf("Expected a dry run cloudresourcemanager.EnsureOrgPolicy() to report the change without setting the policy")
+	}
+	if changes := crm.DryRun.Changes(); len(changes) != 1 || changes[0].Method != "EnsureOrgPolicy" || changes[0].Before == nil {
+		t.Errorf("Expected the policy before and after in the plan of a dry run cloudresourcemanager.EnsureOrgPolicy(), got %v", changes)
+	}
+}
+
+func TestGetEffectiveOrgPolicy(t *testing.T) {
+	crm, server := getOrgPolicyServerCloudResourceManager(t, BooleanOrgPolicy("compute.skipDefaultNetworkCreation", true))
+	policy, err := crm.GetEffectiveOrgPolicy("projects/"+testProjectID, "compute.skipDefaultNetworkCreation")
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.GetEffectiveOrgPolicy(): %s", err)
+	}
+	if policy.BooleanPolicy == nil || !policy.BooleanPolicy.Enforced || !strings.HasSuffix(server.paths[0], "projects/"+testProjectID+":getEffectiveOrgPolicy") {
+		t.Errorf("Expected the enforced policy of the project from cloudresourcemanager.GetEffectiveOrgPolicy(), got %v from %s", policy, server.paths[0])
+	}
+}
+
+func TestListOrgPolicies(t *testing.T) {
+	crm, server := getOrgPolicyServerCloudResourceManager(t)
+	server.pages = [][]*v1.OrgPolicy{
+		{BooleanOrgPolicy("compute.skipDefaultNetworkCreation", true)},
+		{RestoreDefaultOrgPolicy("compute.vmExternalIpAccess")},
+	}
+	policies, err := crm.ListOrgPolicies(testOrganizationName)
+	if err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.ListOrgPolicies(): %s", err)
+	}
+	if len(policies) != 2 || policies[1].Constraint != "constraints/compute.vmExternalIpAccess" {
+		t.Errorf("Expected the policies of every page from cloudresourcemanager.ListOrgPolicies(), got %d", len(policies))
+	}
+}
+
+func TestClearOrgPolicy(t *testing.T) {
+	crm, server := getOrgPolicyServerCloudResourceManager(t)
+	if err := crm.ClearOrgPolicy(testFolderName, "compute.vmExternalIpAccess"); err != nil {
+		t.Fatalf("Got unexpected error for cloudresourcemanager.ClearOrgPolicy(): %s", err)
+	}
+	if len(server.clears) != 1 || server.clears[0] != "constraints/compute.vmExternalIpAccess" {
+		t.Errorf("Expected cloudresourcemanager.ClearOrgPolicy() to clear the constraint, got %v", server.clears)
+	}
+	if err := crm.ClearOrgPolicy(testProjectID, "compute.vmExternalIpAccess"); err == nil {
+		t.Errorf("Expected an error from cloudresourcemanager.ClearOrgPolicy() for a resource without its type")
+	}
+}
+
+func TestSameOrgPolicy(t *testing.T) {
+	same := [][2]*v1.OrgPolicy{
+		{BooleanOrgPolicy("a", true), {Constraint: "constraints/a", Etag: "etag", BooleanPolicy: &v1.BooleanPolicy{Enforced: true}}},
+		{ListOrgPolicy("a", nil, []string{"x", "y"}, false), ListOrgPolicy("a", []string{}, []string{"y", "x"}, false)},
+		{RestoreDefaultOrgPolicy("a"), RestoreDefaultOrgPolicy("a")},
+	}
+	for _, policies := range same {
+		if !SameOrgPolicy(policies[0], policies[1]) {
+			t.Errorf("Expected cloudresourcemanager.SameOrgPolicy() to be true for %v and %v", policies[0], policies[1])
+		}
+	}
+	different := [][2]*v1.OrgPolicy{
+		{BooleanOrgPolicy("a", true), BooleanOrgPolicy("a", false)},
+		{BooleanOrgPolicy("a", false), {Constraint: "constraints/a"}},
+		{ListOrgPolicy("a", nil, []string{"x"}, false), ListOrgPolicy("a", nil, []string{"x"}, true)},
+		{RestoreDefaultOrgPolicy("a"), ListOrgPolicy("a", nil, nil, true)},
+	}
+	for _, policies := range different {
+		if SameOrgPolicy(policies[0], policies[1]) {
+			t.Errorf("Expected cloudresourcemanager.SameOrgPolicy() to be false for %v and %v", policies[0], policies[1])
+		}
+	}
+}
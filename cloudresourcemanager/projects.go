@@ -8,7 +8,13 @@ import (
 	"strings"
 	"time"
 
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	v3 "google.golang.org/api/cloudresourcemanager/v3"
 	suv1 "google.golang.org/api/serviceusage/v1"
 )
 
@@ -28,29 +34,53 @@ func MakeProjectID(name string, parent string) (string, error) {
 
 // GetProject returns an existing project object, nil if none found
 func (crm *CloudResourceManager) GetProject(name string, parent string) (*v1.Project, error) {
-	ctx := context.Background()
+	return crm.GetProjectCtx(context.Background(), name, parent)
+}
+
+// GetProjectCtx is GetProject, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) GetProjectCtx(ctx context.Context, name string, parent string) (project *v1.Project, err error) {
+	defer events.Start(crm.Events, service, "GetProject", events.ActionRead, "", name).Done(&err)
+	return crm.getProject(ctx, name, parent)
+}
+
+// getProject is GetProjectCtx without its event, for use within other methods
+func (crm *CloudResourceManager) getProject(ctx context.Context, name string, parent string) (project *v1.Project, err error) {
 	parentParts := strings.Split(parent, "/")
 	projectsService := v1.NewProjectsService(crm.V1)
 	projectsListCall := projectsService.List().Context(ctx)
 	filter := fmt.Sprintf("name:%s parent.type:folder parent.id:%s lifecycleState:ACTIVE", name, parentParts[1])
 	projectsListCall = projectsListCall.Filter(filter)
-	listProjectsResponse, err := crm.Calls.ProjectsList.Do(projectsListCall)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(listProjectsResponse.Projects) == 0 {
-		return nil, nil
+	for {
+		listProjectsResponse, err := crm.Calls.ProjectsList.Do(telemetry.WithResource(ctx, "", name), projectsListCall)
+		if err != nil {
+			return nil, err
+		}
+		if len(listProjectsResponse.Projects) > 0 {
+			return listProjectsResponse.Projects[0], nil
+		}
+		if listProjectsResponse.NextPageToken == "" {
+			return nil, nil
+		}
+		projectsListCall = projectsListCall.PageToken(listProjectsResponse.NextPageToken)
 	}
-	return listProjectsResponse.Projects[0], nil
 }
 
 // GetProjectByID gets an existing project object, found by its ID
 func (crm *CloudResourceManager) GetProjectByID(id string) (*v1.Project, error) {
-	ctx := context.Background()
+	return crm.GetProjectByIDCtx(context.Background(), id)
+}
+
+// GetProjectByIDCtx is GetProjectByID, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) GetProjectByIDCtx(ctx context.Context, id string) (project *v1.Project, err error) {
+	defer events.Start(crm.Events, service, "GetProjectByID", events.ActionRead, id, id).Done(&err)
+	return crm.getProjectByID(ctx, id)
+}
+
+// getProjectByID is GetProjectByIDCtx without its event, for use within other methods
+func (crm *CloudResourceManager) getProjectByID(ctx context.Context, id string) (project *v1.Project, err error) {
 	projectsService := v1.NewProjectsService(crm.V1)
 	projectsGetCall := projectsService.Get(id).Context(ctx)
-	project, err := crm.Calls.ProjectsGet.Do(projectsGetCall)
+	project, err = crm.Calls.ProjectsGet.Do(telemetry.WithResource(ctx, id, id), projectsGetCall)
 	if err != nil {
 		return nil, err
 	}
@@ -61,15 +91,21 @@ func (crm *CloudResourceManager) GetProjectByID(id string) (*v1.Project, error)
 }
 
 // EnsureProject will make sure that a project exists, creates it if it doesn't already exist, nothing if it does,
-// returns either new or existing project ID and project number
+// returns either new or existing project ID and project number. A dry run returns the ID a new project would have, and
+// no project number.
 func (crm *CloudResourceManager) EnsureProject(name string, parent string) (string, int64, error) {
+	return crm.EnsureProjectCtx(context.Background(), name, parent)
+}
+
+// EnsureProjectCtx is EnsureProject, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) EnsureProjectCtx(ctx context.Context, name string, parent string) (projectID string, projectNumber int64, err error) {
+	defer events.Start(crm.Events, service, "EnsureProject", events.ActionEnsure, "", name).Done(&err)
 	crm.log.InfoPart("Ensuring that project %s exists", name)
 	if parent != "" {
 		crm.log.InfoPart(" in %s...", parent)
 	}
-	ctx := context.Background()
 	projectsService := v1.NewProjectsService(crm.V1)
-	existingProject, err := crm.GetProject(name, parent)
+	existingProject, err := crm.getProject(ctx, name, parent)
 	if err != nil {
 		crm.log.InfoPart("\n")
 		return "", 0, err
@@ -78,9 +114,9 @@ func (crm *CloudResourceManager) EnsureProject(name string, parent string) (stri
 		crm.log.InfoPart("already exists\n")
 		return existingProject.ProjectId, existingProject.ProjectNumber, nil
 	}
-	crm.log.InfoPart("creating\n")
-	projectID, err := MakeProjectID(name, parent)
+	projectID, err = MakeProjectID(name, parent)
 	if err != nil {
+		crm.log.InfoPart("\n")
 		return "", 0, err
 	}
 	parentParts := strings.Split(parent, "/")
@@ -93,8 +129,13 @@ func (crm *CloudResourceManager) EnsureProject(name string, parent string) (stri
 		Parent:    parentResource,
 		ProjectId: projectID,
 	}
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "EnsureProject", Project: projectID, Resource: name, Action: plan.ActionCreate, After: project}) {
+		crm.log.InfoPart("would be created\n")
+		return projectID, 0, nil
+	}
+	crm.log.InfoPart("creating\n")
 	projectCreateCall := projectsService.Create(project).Context(ctx)
-	projectCreateOperation, err := crm.Calls.ProjectsCreate.Do(projectCreateCall)
+	projectCreateOperation, err := crm.Calls.ProjectsCreate.Do(telemetry.WithResource(ctx, "", name), projectCreateCall)
 	if err != nil {
 		return "", 0, err
 	}
@@ -102,12 +143,16 @@ func (crm *CloudResourceManager) EnsureProject(name string, parent string) (stri
 		return "", 0, errors.New(projectCreateOperation.Error.Message)
 	}
 	for existingProject == nil {
-		existingProject, err = crm.GetProject(name, parent)
+		existingProject, err = crm.getProject(ctx, name, parent)
 		if err != nil {
 			return "", 0, err
 		}
 		if existingProject == nil {
-			time.Sleep(3 * time.Second)
+			select {
+			case <-ctx.Done():
+				return "", 0, ctx.Err()
+			case <-time.After(3 * time.Second):
+			}
 		}
 	}
 	return existingProject.ProjectId, existingProject.ProjectNumber, nil
@@ -115,18 +160,30 @@ func (crm *CloudResourceManager) EnsureProject(name string, parent string) (stri
 
 // EnableProjectServices will enable 1 or many services in a project
 func (crm *CloudResourceManager) EnableProjectServices(projectID string, services []string) error {
-	ctx := context.Background()
+	return crm.EnableProjectServicesCtx(context.Background(), projectID, services)
+}
+
+// EnableProjectServicesCtx is EnableProjectServices, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) EnableProjectServicesCtx(ctx context.Context, projectID string, services []string) (err error) {
+	defer events.Start(crm.Events, service, "EnableProjectServices", events.ActionUpdate, projectID, "").Done(&err)
 	servicesService := suv1.NewServicesService(crm.SUV1)
 	crm.log.Info("Ensuring service APIs are enabled in project %s:", projectID)
-	project, err := crm.GetProjectByID(projectID)
+	project, err := crm.getProjectByID(ctx, projectID)
 	if err != nil {
 		return err
 	}
+	planned := []plan.Change{}
+	for _, serviceName := range services {
+		planned = append(planned, plan.Change{Service: service, Method: "EnableProjectServices", Project: projectID, Resource: serviceName, Action: plan.ActionUpdate})
+	}
+	if crm.DryRun.Record(planned...) {
+		return nil
+	}
 	for _, service := range services {
 		crm.log.ListItem(service)
 		name := fmt.Sprintf("projects/%d/services/%s", project.ProjectNumber, service)
 		serviceEnableCall := servicesService.Enable(name, &suv1.EnableServiceRequest{}).Context(ctx)
-		serviceEnableOperation, err := crm.Calls.ServiceEnable.Do(serviceEnableCall)
+		serviceEnableOperation, err := crm.Calls.ServiceEnable.Do(telemetry.WithResource(ctx, projectID, ""), serviceEnableCall)
 		if err != nil {
 			return err
 		}
@@ -139,83 +196,366 @@ func (crm *CloudResourceManager) EnableProjectServices(projectID string, service
 	return nil
 }
 
+// projectName will make sure a project ID or name is an ID, the way the v1 api takes it, e.g. my-project for
+// projects/my-project
+func projectName(project string) string {
+	if matched, _ := regexp.Match("^projects\\/", []byte(project)); matched {
+		return strings.Replace(project, "projects/", "", 1)
+	}
+	return project
+}
+
 // EnsureProjectRoles makes sure that a particular member has the supplied roles on the project
 func (crm *CloudResourceManager) EnsureProjectRoles(project string, member string, roles []string) error {
-	ctx := context.Background()
-	if matched, _ := regexp.Match("^projects\\/", []byte(project)); matched {
-		project = strings.Replace(project, "projects/", "", 1)
+	return crm.EnsureProjectRolesCtx(context.Background(), project, member, roles)
+}
+
+// EnsureProjectRolesCtx is EnsureProjectRoles, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) EnsureProjectRolesCtx(ctx context.Context, project string, member string, roles []string) (err error) {
+	defer events.Start(crm.Events, service, "EnsureProjectRoles", events.ActionUpdate, project, project).Done(&err)
+	return crm.ensureProjectRoles(ctx, "EnsureProjectRoles", project, member, roles, nil)
+}
+
+// EnsureProjectRolesWithCondition makes sure that a particular member has the supplied roles on the project, granted
+// only when the condition is met
+func (crm *CloudResourceManager) EnsureProjectRolesWithCondition(project string, member string, roles []string, condition *iampolicy.Condition) error {
+	return crm.EnsureProjectRolesWithConditionCtx(context.Background(), project, member, roles, condition)
+}
+
+// EnsureProjectRolesWithConditionCtx is EnsureProjectRolesWithCondition, using the provided context for the
+// underlying api calls
+func (crm *CloudResourceManager) EnsureProjectRolesWithConditionCtx(ctx context.Context, project string, member string, roles []string, condition *iampolicy.Condition) (err error) {
+	defer events.Start(crm.Events, service, "EnsureProjectRolesWithCondition", events.ActionUpdate, project, project).Done(&err)
+	return crm.ensureProjectRoles(ctx, "EnsureProjectRolesWithCondition", project, member, roles, condition)
+}
+
+func (crm *CloudResourceManager) ensureProjectRoles(ctx context.Context, method string, project string, member string, roles []string, condition *iampolicy.Condition) error {
+	project = projectName(project)
+	_, err := crm.updateRoles(ctx, &projectPolicy{crm: crm, project: project},
+		plan.Change{Service: service, Method: method, Project: project, Resource: project, Action: plan.ActionUpdate},
+		fmt.Sprintf("Ensuring member %s has roles in projects/%s", member, project), condition, roles,
+		func(role string) iampolicy.Mutation { return iampolicy.AddMember(role, member, condition) })
+	return err
+}
+
+// RemoveProjectRoles makes sure that a particular member is removed from the supplied roles on the project, returning
+// the roles it was removed from
+func (crm *CloudResourceManager) RemoveProjectRoles(project string, member string, roles []string) ([]string, error) {
+	return crm.RemoveProjectRolesCtx(context.Background(), project, member, roles)
+}
+
+// RemoveProjectRolesCtx is RemoveProjectRoles, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) RemoveProjectRolesCtx(ctx context.Context, project string, member string, roles []string) (removed []string, err error) {
+	defer events.Start(crm.Events, service, "RemoveProjectRoles", events.ActionUpdate, project, project).Done(&err)
+	return crm.removeProjectRoles(ctx, "RemoveProjectRoles", project, member, roles, nil)
+}
+
+// RemoveProjectRolesWithCondition makes sure that a particular member is removed from the supplied roles granted with
+// the condition on the project, leaving the roles granted without it or with other conditions, returning the roles it
+// was removed from
+func (crm *CloudResourceManager) RemoveProjectRolesWithCondition(project string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	return crm.RemoveProjectRolesWithConditionCtx(context.Background(), project, member, roles, condition)
+}
+
+// RemoveProjectRolesWithConditionCtx is RemoveProjectRolesWithCondition, using the provided context for the
+// underlying api calls
+func (crm *CloudResourceManager) RemoveProjectRolesWithConditionCtx(ctx context.Context, project string, member string, roles []string, condition *iampolicy.Condition) (removed []string, err error) {
+	defer events.Start(crm.Events, service, "RemoveProjectRolesWithCondition", events.ActionUpdate, project, project).Done(&err)
+	return crm.removeProjectRoles(ctx, "RemoveProjectRolesWithCondition", project, member, roles, condition)
+}
+
+func (crm *CloudResourceManager) removeProjectRoles(ctx context.Context, method string, project string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	project = projectName(project)
+	result, err := crm.updateRoles(ctx, &projectPolicy{crm: crm, project: project},
+		plan.Change{Service: service, Method: method, Project: project, Resource: project, Action: plan.ActionUpdate},
+		fmt.Sprintf("Ensuring roles for member %s are removed in projects/%s", member, project), condition, roles,
+		func(role string) iampolicy.Mutation { return iampolicy.RemoveMember(role, member, condition) })
+	if err != nil {
+		return nil, err
 	}
-	crm.log.Info("Ensuring member %s has roles in projects/%s:", member, project)
-	projectsService := v1.NewProjectsService(crm.V1)
-	projectPolicyGetCall := projectsService.GetIamPolicy(project, &v1.GetIamPolicyRequest{}).Context(ctx)
-	policy, err := crm.Calls.ProjectsGetIAMPolicy.Do(projectPolicyGetCall)
+	return result.Removed(member), nil
+}
+
+// RemoveProjectMember makes sure that a particular member is removed from every role on the project, with or without
+// a condition, returning the roles it was removed from
+func (crm *CloudResourceManager) RemoveProjectMember(project string, member string) ([]string, error) {
+	return crm.RemoveProjectMemberCtx(context.Background(), project, member)
+}
+
+// RemoveProjectMemberCtx is RemoveProjectMember, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) RemoveProjectMemberCtx(ctx context.Context, project string, member string) (removed []string, err error) {
+	defer events.Start(crm.Events, service, "RemoveProjectMember", events.ActionUpdate, project, project).Done(&err)
+	project = projectName(project)
+	return crm.removeMember(ctx, &projectPolicy{crm: crm, project: project}, plan.Change{Service: service, Method: "RemoveProjectMember", Project: project, Resource: project, Action: plan.ActionUpdate}, member)
+}
+
+// DiffProjectPolicy will return how the iam policy of the project differs from the spec, without changing it, as the
+// change that reconciling it would make, or no changes at all when it matches
+func (crm *CloudResourceManager) DiffProjectPolicy(project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return crm.DiffProjectPolicyCtx(context.Background(), project, spec)
+}
+
+// DiffProjectPolicyCtx is DiffProjectPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) DiffProjectPolicyCtx(ctx context.Context, project string, spec *iampolicy.Spec) (changes []plan.Change, err error) {
+	defer events.Start(crm.Events, service, "DiffProjectPolicy", events.ActionRead, project, project).Done(&err)
+	project = projectName(project)
+	return crm.reconcile(ctx, &projectPolicy{crm: crm, project: project}, plan.Change{Service: service, Method: "DiffProjectPolicy", Project: project, Resource: project, Action: plan.ActionUpdate}, spec, true)
+}
+
+// ReconcileProjectPolicy will bring the iam policy of the project in line with the spec, logging how it differed
+// from it, and return the change it made, or no changes at all when it already matched
+func (crm *CloudResourceManager) ReconcileProjectPolicy(project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return crm.ReconcileProjectPolicyCtx(context.Background(), project, spec)
+}
+
+// ReconcileProjectPolicyCtx is ReconcileProjectPolicy, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) ReconcileProjectPolicyCtx(ctx context.Context, project string, spec *iampolicy.Spec) (changes []plan.Change, err error) {
+	defer events.Start(crm.Events, service, "ReconcileProjectPolicy", events.ActionUpdate, project, project).Done(&err)
+	project = projectName(project)
+	return crm.reconcile(ctx, &projectPolicy{crm: crm, project: project}, plan.Change{Service: service, Method: "ReconcileProjectPolicy", Project: project, Resource: project, Action: plan.ActionUpdate}, spec, false)
+}
+
+// DeleteProject will delete a Google Cloud project ID, returning a *ProjectLienError without deleting it if it has
+// liens on it restricting its deletion
+func (crm *CloudResourceManager) DeleteProject(id string) error {
+	return crm.DeleteProjectCtx(context.Background(), id)
+}
+
+// DeleteProjectCtx is DeleteProject, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) DeleteProjectCtx(ctx context.Context, id string) (err error) {
+	defer events.Start(crm.Events, service, "DeleteProject", events.ActionDelete, id, id).Done(&err)
+	return crm.deleteProject(ctx, id)
+}
+
+// deleteProject is DeleteProjectCtx without its event, for use within other methods
+func (crm *CloudResourceManager) deleteProject(ctx context.Context, id string) error {
+	crm.log.InfoPart("Deleting project %s...", id)
+	existingProject, err := crm.getProjectByID(ctx, id)
 	if err != nil {
-		return err
+		crm.log.InfoPart("error\n")
+		return fmt.Errorf("error determining if project to delete exists: %s", err)
 	}
-	for _, role := range roles {
-		crm.log.ListItem(role)
-		binding := getExistingProjectRoleBinding(policy.Bindings, role)
-		if binding == nil {
-			policy.Bindings = append(policy.Bindings, &v1.Binding{
-				Members: []string{member},
-				Role:    role,
-			})
-		} else if !memberExistsInProjectRoleBinding(binding, member) {
-			binding.Members = append(binding.Members, member)
-		}
+	if existingProject == nil {
+		return nil
+	}
+	// without permission to list the liens, google refusing the deletion below is how they're found instead
+	liens, err := crm.listProjectLiens(ctx, id)
+	if err != nil && !googleerrors.IsPermissionDenied(err) {
+		crm.log.InfoPart("error\n")
+		return fmt.Errorf("error determining if project to delete has liens: %s", err)
 	}
-	setIAMPolicyRequest := &v1.SetIamPolicyRequest{
-		Policy: policy,
+	if liens = projectDeleteLiens(liens); len(liens) > 0 {
+		crm.log.InfoPart("has liens\n")
+		return &ProjectLienError{Project: id, Liens: liens}
+	}
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "DeleteProject", Project: id, Resource: id, Action: plan.ActionDelete, Before: existingProject}) {
+		crm.log.InfoPart("would be deleted\n")
+		return nil
+	}
+	projectsService := v1.NewProjectsService(crm.V1)
+	projectDeleteCall := projectsService.Delete(id).Context(ctx)
+	projectDeleteEmpty, err := crm.Calls.ProjectsDelete.Do(telemetry.WithResource(ctx, id, id), projectDeleteCall)
+	if googleerrors.IsFailedPrecondition(err) {
+		// only a refusal because of liens, found now or not listable, is a lien error
+		liens, listErr := crm.listProjectLiens(ctx, id)
+		if liens = projectDeleteLiens(liens); len(liens) > 0 || googleerrors.IsPermissionDenied(listErr) {
+			crm.log.InfoPart("has liens\n")
+			return &ProjectLienError{Project: id, Liens: liens, Err: err}
+		}
 	}
-	projectSetPolicyCall := projectsService.SetIamPolicy(project, setIAMPolicyRequest).Context(ctx)
-	_, err = crm.Calls.ProjectsSetIAMPolicy.Do(projectSetPolicyCall)
 	if err != nil {
+		crm.log.InfoPart("error\n")
 		return err
 	}
+	if projectDeleteEmpty.ServerResponse.HTTPStatusCode >= 300 || projectDeleteEmpty.ServerResponse.HTTPStatusCode < 200 {
+		crm.log.InfoPart("error\n")
+		return fmt.Errorf("error deleting project: status code %d", projectDeleteEmpty.ServerResponse.HTTPStatusCode)
+	}
+	crm.log.InfoPart("done\n")
 	return nil
 }
 
-// DeleteProject will delete a Google Cloud project ID
-func (crm *CloudResourceManager) DeleteProject(id string) error {
-	crm.log.InfoPart("Deleting project %s...", id)
-	existingProject, err := crm.GetProjectByID(id)
+// MoveProject will move a project to another parent, an organization or folder, e.g. folders/1234, nothing if it's
+// already in it, waiting for the move to finish
+func (crm *CloudResourceManager) MoveProject(id string, parent string) error {
+	return crm.MoveProjectCtx(context.Background(), id, parent)
+}
+
+// MoveProjectCtx is MoveProject, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) MoveProjectCtx(ctx context.Context, id string, parent string) (err error) {
+	defer events.Start(crm.Events, service, "MoveProject", events.ActionUpdate, id, id).Done(&err)
+	crm.log.InfoPart("Moving project %s to %s...", id, parent)
+	existingProject, err := crm.getProjectByID(ctx, id)
 	if err != nil {
 		crm.log.InfoPart("error\n")
-		return fmt.Errorf("error determining if project to delete exists: %s", err)
+		return err
 	}
 	if existingProject == nil {
+		crm.log.InfoPart("error\n")
+		return fmt.Errorf("Expecting project %s to be active to move it", id)
+	}
+	if projectParent(existingProject) == parent {
+		crm.log.InfoPart("already there\n")
 		return nil
 	}
-	ctx := context.Background()
+	parentParts := strings.Split(parent, "/")
+	if len(parentParts) != 2 {
+		crm.log.InfoPart("error\n")
+		return fmt.Errorf("Expecting the parent argument to be like [type]/[ID], e.g. folders/92737276394872, but got: %s", parent)
+	}
+	movedProject := copyProject(existingProject)
+	movedProject.Parent = &v1.ResourceId{Type: strings.TrimSuffix(parentParts[0], "s"), Id: parentParts[1]}
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "MoveProject", Project: id, Resource: id, Action: plan.ActionUpdate, Before: existingProject, After: movedProject}) {
+		crm.log.InfoPart("would be moved\n")
+		return nil
+	}
+	projectsService := v3.NewProjectsService(crm.V3)
+	projectMoveCall := projectsService.Move(fmt.Sprintf("projects/%s", id), &v3.MoveProjectRequest{DestinationParent: parent}).Context(ctx)
+	projectMoveOperation, err := crm.Calls.ProjectsMove.Do(telemetry.WithResource(ctx, id, id), projectMoveCall)
+	if err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	if err = crm.waitForV3Operation(ctx, projectMoveOperation, id); err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	crm.log.InfoPart("done\n")
+	return nil
+}
+
+// UndeleteProject will restore a deleted project that's still within its recovery period, nothing if it's active
+func (crm *CloudResourceManager) UndeleteProject(id string) error {
+	return crm.UndeleteProjectCtx(context.Background(), id)
+}
+
+// UndeleteProjectCtx is UndeleteProject, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) UndeleteProjectCtx(ctx context.Context, id string) (err error) {
+	defer events.Start(crm.Events, service, "UndeleteProject", events.ActionUpdate, id, id).Done(&err)
+	crm.log.InfoPart("Undeleting project %s...", id)
 	projectsService := v1.NewProjectsService(crm.V1)
-	projectDeleteCall := projectsService.Delete(id).Context(ctx)
-	projectDeleteEmpty, err := crm.Calls.ProjectsDelete.Do(projectDeleteCall)
+	projectsGetCall := projectsService.Get(id).Context(ctx)
+	existingProject, err := crm.Calls.ProjectsGet.Do(telemetry.WithResource(ctx, id, id), projectsGetCall)
 	if err != nil {
 		crm.log.InfoPart("error\n")
 		return err
 	}
-	if projectDeleteEmpty.ServerResponse.HTTPStatusCode >= 300 || projectDeleteEmpty.ServerResponse.HTTPStatusCode < 200 {
+	if existingProject.LifecycleState == "ACTIVE" {
+		crm.log.InfoPart("already active\n")
+		return nil
+	}
+	undeletedProject := copyProject(existingProject)
+	undeletedProject.LifecycleState = "ACTIVE"
+	if crm.DryRun.Record(plan.Change{Service: service, Method: "UndeleteProject", Project: id, Resource: id, Action: plan.ActionUpdate, Before: existingProject, After: undeletedProject}) {
+		crm.log.InfoPart("would be undeleted\n")
+		return nil
+	}
+	projectUndeleteCall := projectsService.Undelete(id, &v1.UndeleteProjectRequest{}).Context(ctx)
+	if _, err = crm.Calls.ProjectsUndelete.Do(telemetry.WithResource(ctx, id, id), projectUndeleteCall); err != nil {
 		crm.log.InfoPart("error\n")
-		return fmt.Errorf("error deleting project: status code %d", projectDeleteEmpty.ServerResponse.HTTPStatusCode)
+		return err
 	}
 	crm.log.InfoPart("done\n")
 	return nil
 }
 
-func getExistingProjectRoleBinding(bindings []*v1.Binding, role string) *v1.Binding {
-	for _, binding := range bindings {
-		if binding.Role == role {
-			return binding
+// SetProjectLabels will make the labels of a project exactly the ones supplied, removing any others
+func (crm *CloudResourceManager) SetProjectLabels(id string, labels map[string]string) error {
+	return crm.SetProjectLabelsCtx(context.Background(), id, labels)
+}
+
+// SetProjectLabelsCtx is SetProjectLabels, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) SetProjectLabelsCtx(ctx context.Context, id string, labels map[string]string) (err error) {
+	defer events.Start(crm.Events, service, "SetProjectLabels", events.ActionUpdate, id, id).Done(&err)
+	return crm.updateProjectLabels(ctx, "SetProjectLabels", id, labels, false)
+}
+
+// MergeProjectLabels will add the labels supplied to a project, updating the values of those it already has and
+// leaving its other labels as they are
+func (crm *CloudResourceManager) MergeProjectLabels(id string, labels map[string]string) error {
+	return crm.MergeProjectLabelsCtx(context.Background(), id, labels)
+}
+
+// MergeProjectLabelsCtx is MergeProjectLabels, using the provided context for the underlying api calls
+func (crm *CloudResourceManager) MergeProjectLabelsCtx(ctx context.Context, id string, labels map[string]string) (err error) {
+	defer events.Start(crm.Events, service, "MergeProjectLabels", events.ActionUpdate, id, id).Done(&err)
+	return crm.updateProjectLabels(ctx, "MergeProjectLabels", id, labels, true)
+}
+
+// updateProjectLabels will update the labels of the project to the ones supplied, merged into its existing labels when
+// merge, nothing if they're already the same
+func (crm *CloudResourceManager) updateProjectLabels(ctx context.Context, method string, id string, labels map[string]string, merge bool) error {
+	crm.log.InfoPart("Ensuring labels of project %s...", id)
+	existingProject, err := crm.getProjectByID(ctx, id)
+	if err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	if existingProject == nil {
+		crm.log.InfoPart("error\n")
+		return fmt.Errorf("Expecting project %s to be active to update its labels", id)
+	}
+	updatedProject := copyProject(existingProject)
+	updatedProject.Labels = map[string]string{}
+	if merge {
+		for key, value := range existingProject.Labels {
+			updatedProject.Labels[key] = value
 		}
 	}
+	for key, value := range labels {
+		updatedProject.Labels[key] = value
+	}
+	if sameLabels(updatedProject.Labels, existingProject.Labels) {
+		crm.log.InfoPart("already set\n")
+		return nil
+	}
+	if crm.DryRun.Record(plan.Change{Service: service, Method: method, Project: id, Resource: id, Action: plan.ActionUpdate, Before: existingProject, After: updatedProject}) {
+		crm.log.InfoPart("would be updated\n")
+		return nil
+	}
+	projectsService := v1.NewProjectsService(crm.V1)
+	projectUpdateCall := projectsService.Update(id, updatedProject).Context(ctx)
+	if _, err = crm.Calls.ProjectsUpdate.Do(telemetry.WithResource(ctx, id, id), projectUpdateCall); err != nil {
+		crm.log.InfoPart("error\n")
+		return err
+	}
+	crm.log.InfoPart("updated\n")
 	return nil
 }
 
-func memberExistsInProjectRoleBinding(binding *v1.Binding, member string) bool {
-	for _, bindingMember := range binding.Members {
-		if bindingMember == member {
-			return true
+// sameLabels will return whether both sets of labels have the same keys and values, no labels being the same as empty
+func sameLabels(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if existing, ok := b[key]; !ok || existing != value {
+			return false
+		}
+	}
+	return true
+}
+
+// projectParent will return the name of the parent of the project, e.g. folders/1234, blank if it has none
+func projectParent(project *v1.Project) string {
+	if project.Parent == nil {
+		return ""
+	}
+	return fmt.Sprintf("%ss/%s", project.Parent.Type, project.Parent.Id)
+}
+
+// copyProject will copy the project, along with its parent and labels
+func copyProject(project *v1.Project) *v1.Project {
+	copied := *project
+	if project.Parent != nil {
+		parent := *project.Parent
+		copied.Parent = &parent
+	}
+	if project.Labels != nil {
+		copied.Labels = map[string]string{}
+		for key, value := range project.Labels {
+			copied.Labels[key] = value
 		}
 	}
-	return false
+	return &copied
 }
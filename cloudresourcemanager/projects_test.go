@@ -1,12 +1,21 @@
 package cloudresourcemanager
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/rockholla/go-google-lib/plan"
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	v3 "google.golang.org/api/cloudresourcemanager/v3"
 	googleapi "google.golang.org/api/googleapi"
 	suv1 "google.golang.org/api/serviceusage/v1"
 )
@@ -35,8 +44,12 @@ type projectsGetIAMPolicyExistingRoleMock struct{}
 type projectsSetIAMPolicyMock struct{}
 type serviceEnableMock struct{}
 
+type projectsListPagedMock struct {
+	pages int
+}
+
 // Do is the mock for default projectsListMock
-func (c *projectsListMock) Do(call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
+func (c *projectsListMock) Do(ctx context.Context, call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
 	var projects []*v1.Project
 	projects = append(projects, &v1.Project{
 		Name:          testProjectName,
@@ -54,15 +67,26 @@ func (c *projectsListMock) Do(call *v1.ProjectsListCall, opts ...googleapi.CallO
 }
 
 // Do is the mock for projectsList that doesn't include any results
-func (c *projectsListMockNoResults) Do(call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
+func (c *projectsListMockNoResults) Do(ctx context.Context, call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
 	var projects []*v1.Project
 	return &v1.ListProjectsResponse{
 		Projects: projects,
 	}, nil
 }
 
+// Do is the mock for projectsList that returns an empty first page, then the result on the second page
+func (c *projectsListPagedMock) Do(ctx context.Context, call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
+	c.pages++
+	if c.pages == 1 {
+		return &v1.ListProjectsResponse{
+			NextPageToken: "page-2",
+		}, nil
+	}
+	return (&projectsListMock{}).Do(ctx, call, opts...)
+}
+
 // Do is the mock for projectsList that will return nothing on the first time, will return a result on the second
-func (c *projectsListNoResultThenResult) Do(call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
+func (c *projectsListNoResultThenResult) Do(ctx context.Context, call *v1.ProjectsListCall, opts ...googleapi.CallOption) (*v1.ListProjectsResponse, error) {
 	var projects []*v1.Project
 	if listCount == 0 {
 		listCount++
@@ -85,7 +109,7 @@ func (c *projectsListNoResultThenResult) Do(call *v1.ProjectsListCall, opts ...g
 }
 
 // Do is the mock for default projectsGetMock
-func (c *projectsGetMock) Do(call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error) {
+func (c *projectsGetMock) Do(ctx context.Context, call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error) {
 	return &v1.Project{
 		Name:           testProjectName,
 		ProjectNumber:  testProjectNumber,
@@ -94,14 +118,14 @@ func (c *projectsGetMock) Do(call *v1.ProjectsGetCall, opts ...googleapi.CallOpt
 }
 
 // Do is the mock for default projectsCreateMock
-func (c *projectsCreateMock) Do(call *v1.ProjectsCreateCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *projectsCreateMock) Do(ctx context.Context, call *v1.ProjectsCreateCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{
 		Error: nil,
 	}, nil
 }
 
 // Do is the mock for default projectsDeleteMock
-func (c *projectsDeleteMock) Do(call *v1.ProjectsDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
+func (c *projectsDeleteMock) Do(ctx context.Context, call *v1.ProjectsDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
 	return &v1.Empty{
 		ServerResponse: googleapi.ServerResponse{
 			HTTPStatusCode: 200,
@@ -110,12 +134,12 @@ func (c *projectsDeleteMock) Do(call *v1.ProjectsDeleteCall, opts ...googleapi.C
 }
 
 // Do is the mock for default projectsGetIAMPolicy
-func (c *projectsGetIAMPolicyMock) Do(call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *projectsGetIAMPolicyMock) Do(ctx context.Context, call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	return &v1.Policy{}, nil
 }
 
 // Do is the mock for projectsGetIamPolicy that includes an existing member in role
-func (c *projectsGetIAMPolicyExistingMemberMock) Do(call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *projectsGetIAMPolicyExistingMemberMock) Do(ctx context.Context, call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	var bindings []*v1.Binding
 	bindings = append(bindings, &v1.Binding{
 		Role: testRole,
@@ -129,7 +153,7 @@ func (c *projectsGetIAMPolicyExistingMemberMock) Do(call *v1.ProjectsGetIamPolic
 }
 
 // Do is the mock for projectsGetIAMPolicy that includes an existing member in role
-func (c *projectsGetIAMPolicyExistingRoleMock) Do(call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *projectsGetIAMPolicyExistingRoleMock) Do(ctx context.Context, call *v1.ProjectsGetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	var bindings []*v1.Binding
 	bindings = append(bindings, &v1.Binding{
 		Role:    testRole,
@@ -141,14 +165,35 @@ func (c *projectsGetIAMPolicyExistingRoleMock) Do(call *v1.ProjectsGetIamPolicyC
 }
 
 // Do is the mock for default projectsSetIAMPolicy
-func (c *projectsSetIAMPolicyMock) Do(call *v1.ProjectsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+func (c *projectsSetIAMPolicyMock) Do(ctx context.Context, call *v1.ProjectsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
 	return &v1.Policy{}, nil
 }
 
-func (c *serviceEnableMock) Do(call *suv1.ServicesEnableCall, opts ...googleapi.CallOption) (*suv1.Operation, error) {
+func (c *serviceEnableMock) Do(ctx context.Context, call *suv1.ServicesEnableCall, opts ...googleapi.CallOption) (*suv1.Operation, error) {
 	return &suv1.Operation{}, nil
 }
 
+type projectsCreateUnexpectedMock struct{}
+
+// Do is the mock for projectsCreate that fails, for writes that shouldn't be made
+func (c *projectsCreateUnexpectedMock) Do(ctx context.Context, call *v1.ProjectsCreateCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return nil, errors.New("unexpected project creation")
+}
+
+type projectsSetIAMPolicyUnexpectedMock struct{}
+
+// Do is the mock for projectsSetIAMPolicy that fails, for writes that shouldn't be made
+func (c *projectsSetIAMPolicyUnexpectedMock) Do(ctx context.Context, call *v1.ProjectsSetIamPolicyCall, opts ...googleapi.CallOption) (*v1.Policy, error) {
+	return nil, errors.New("unexpected iam policy update")
+}
+
+type projectsDeleteUnexpectedMock struct{}
+
+// Do is the mock for projectsDelete that fails, for writes that shouldn't be made
+func (c *projectsDeleteUnexpectedMock) Do(ctx context.Context, call *v1.ProjectsDeleteCall, opts ...googleapi.CallOption) (*v1.Empty, error) {
+	return nil, errors.New("unexpected project deletion")
+}
+
 func setProjectsCallMockDefaults(crm *CloudResourceManager) {
 	crm.Calls = &Calls{
 		ProjectsList:         &projectsListMock{},
@@ -158,6 +203,7 @@ func setProjectsCallMockDefaults(crm *CloudResourceManager) {
 		ProjectsGetIAMPolicy: &projectsGetIAMPolicyMock{},
 		ProjectsSetIAMPolicy: &projectsSetIAMPolicyMock{},
 		ServiceEnable:        &serviceEnableMock{},
+		LiensList:            &liensListMock{},
 	}
 }
 
@@ -202,6 +248,23 @@ func TestGetProject(t *testing.T) {
 	}
 }
 
+func TestGetProjectPaged(t *testing.T) {
+	crm := &CloudResourceManager{}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setProjectsCallMockDefaults(crm)
+	crm.Calls.ProjectsList = &projectsListPagedMock{}
+	project, err := crm.GetProject(testProjectName, testProjectParentFolder)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.GetProject() across pages: %s", err)
+	}
+	if project == nil || project.Name != testProjectName {
+		t.Errorf("Didn't get expected project from the second page of cloudresourcemanager.GetProject(): %v", project)
+	}
+}
+
 func TestGetProjectByID(t *testing.T) {
 	crm := &CloudResourceManager{}
 	err := crm.Initialize("", loggermock.GetLogMock())
@@ -354,3 +417,249 @@ func TestDeleteProject(t *testing.T) {
 		t.Errorf("Got unexpected error during cloudresourcemanager.DeleteProject(): %s", err)
 	}
 }
+
+func TestEnsureProjectDryRun(t *testing.T) {
+	crm := &CloudResourceManager{DryRun: plan.New()}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setProjectsCallMockDefaults(crm)
+	crm.Calls.ProjectsList = &projectsListMockNoResults{}
+	crm.Calls.ProjectsCreate = &projectsCreateUnexpectedMock{}
+	projectID, _, err := crm.EnsureProject("new", testProjectParentFolder)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureProject() in a dry run: %s", err)
+	}
+	changes := crm.DryRun.Changes()
+	if len(changes) != 1 || changes[0].Action != plan.ActionCreate || changes[0].Project != projectID {
+		t.Fatalf("Expected the creation of project %s planned by cloudresourcemanager.EnsureProject(), got: %v", projectID, changes)
+	}
+	if project, ok := changes[0].After.(*v1.Project); !ok || project.Name != "new" {
+		t.Errorf("Expected the project to be created planned by cloudresourcemanager.EnsureProject(), got: %v", changes[0].After)
+	}
+}
+
+func TestEnsureProjectRolesDryRun(t *testing.T) {
+	crm := &CloudResourceManager{DryRun: plan.New()}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error for cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setProjectsCallMockDefaults(crm)
+	crm.Calls.ProjectsGetIAMPolicy = &projectsGetIAMPolicyExistingMemberMock{}
+	crm.Calls.ProjectsSetIAMPolicy = &projectsSetIAMPolicyUnexpectedMock{}
+	err = crm.EnsureProjectRoles(testProjectName, testMember, []string{testRole, "role2"})
+	if err != nil {
+		t.Errorf("Got unexpected error for cloudresourcemanager.EnsureProjectRoles() in a dry run: %s", err)
+	}
+	changes := crm.DryRun.Changes()
+	if len(changes) != 1 {
+		t.Fatalf("Expected a single change planned by cloudresourcemanager.EnsureProjectRoles(), got: %v", changes)
+	}
+	if !reflect.DeepEqual(changes[0].Before, plan.Roles{"role2": nil}) || !reflect.DeepEqual(changes[0].After, plan.Roles{"role2": {testMember}}) {
+		t.Errorf("Expected only the missing role planned by cloudresourcemanager.EnsureProjectRoles(), got: %v => %v", changes[0].Before, changes[0].After)
+	}
+}
+
+func TestEnsureProjectRolesDryRunUnchanged(t *testing.T) {
+	crm := &CloudResourceManager{DryRun: plan.New()}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error for cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setProjectsCallMockDefaults(crm)
+	crm.Calls.ProjectsGetIAMPolicy = &projectsGetIAMPolicyExistingMemberMock{}
+	crm.Calls.ProjectsSetIAMPolicy = &projectsSetIAMPolicyUnexpectedMock{}
+	err = crm.EnsureProjectRoles(testProjectName, testMember, []string{testRole})
+	if err != nil {
+		t.Errorf("Got unexpected error for cloudresourcemanager.EnsureProjectRoles() in a dry run: %s", err)
+	}
+	if changes := crm.DryRun.Changes(); len(changes) != 0 {
+		t.Errorf("Expected no changes planned by cloudresourcemanager.EnsureProjectRoles() for existing roles, got: %v", changes)
+	}
+}
+
+func TestDeleteProjectDryRun(t *testing.T) {
+	crm := &CloudResourceManager{DryRun: plan.New()}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.Initialize() with blank credentials: %s", err)
+	}
+	setProjectsCallMockDefaults(crm)
+	crm.Calls.ProjectsDelete = &projectsDeleteUnexpectedMock{}
+	err = crm.DeleteProject(testProjectID)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.DeleteProject() in a dry run: %s", err)
+	}
+	changes := crm.DryRun.Changes()
+	if len(changes) != 1 || changes[0].Action != plan.ActionDelete || changes[0].Before == nil {
+		t.Errorf("Expected the deletion of the existing project planned by cloudresourcemanager.DeleteProject(), got: %v", changes)
+	}
+}
+
+// projectServer serves a project and the liens on it, keeping the updates and deletions made. Like google, it refuses
+// to delete the project while a lien restricts it, and forbids listing the liens if hideLiens is set. It refuses any
+// deletion for another precondition if refuseDeletion is set.
+type projectServer struct {
+	project        *v1.Project
+	liens          []*v1.Lien
+	hideLiens      bool
+	refuseDeletion bool
+	updates        []*v1.Project
+	moving         string
+	deletions      int
+}
+
+func (s *projectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var response interface{}
+	switch {
+	case r.URL.Path == "/v1/projects/"+s.project.ProjectId && r.Method == http.MethodGet:
+		response = s.project
+	case r.URL.Path == "/v1/projects/"+s.project.ProjectId && r.Method == http.MethodPut:
+		update := &v1.Project{}
+		json.NewDecoder(r.Body).Decode(update)
+		s.updates = append(s.updates, update)
+		s.project.Labels = update.Labels
+		response = s.project
+	case r.URL.Path == "/v1/projects/"+s.project.ProjectId && r.Method == http.MethodDelete:
+		if len(projectDeleteLiens(s.liens)) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"code": 400, "message": "A lien to prevent deletion was placed on the project", "status": "FAILED_PRECONDITION"}}`))
+			return
+		}
+		if s.refuseDeletion {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"code": 400, "message": "The project is being updated", "status": "FAILED_PRECONDITION"}}`))
+			return
+		}
+		s.deletions++
+		s.project.LifecycleState = "DELETE_REQUESTED"
+		response = &v1.Empty{}
+	case r.URL.Path == "/v1/projects/"+s.project.ProjectId+":undelete":
+		s.project.LifecycleState = "ACTIVE"
+		response = &v1.Empty{}
+	case r.URL.Path == "/v3/projects/"+s.project.ProjectId+":move":
+		request := &v3.MoveProjectRequest{}
+		json.NewDecoder(r.Body).Decode(request)
+		s.moving = request.DestinationParent
+		response = &v3.Operation{Name: "operations/move"}
+	case r.URL.Path == "/v3/operations/move":
+		// the move finishes on the first check of its operation
+		parts := strings.Split(s.moving, "/")
+		s.project.Parent = &v1.ResourceId{Type: strings.TrimSuffix(parts[0], "s"), Id: parts[1]}
+		response = &v3.Operation{Name: "operations/move", Done: true}
+	case r.URL.Path == "/v1/liens" && r.Method == http.MethodGet:
+		if s.hideLiens {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		response = &v1.ListLiensResponse{Liens: s.liens}
+	case r.URL.Path == "/v1/liens" && r.Method == http.MethodPost:
+		lien := &v1.Lien{}
+		json.NewDecoder(r.Body).Decode(lien)
+		lien.Name = fmt.Sprintf("liens/%d", len(s.liens)+1)
+		s.liens = append(s.liens, lien)
+		response = lien
+	case strings.HasPrefix(r.URL.Path, "/v1/liens/") && r.Method == http.MethodDelete:
+		liens := []*v1.Lien{}
+		for _, lien := range s.liens {
+			if lien.Name != strings.TrimPrefix(r.URL.Path, "/v1/") {
+				liens = append(liens, lien)
+			}
+		}
+		s.liens = liens
+		response = &v1.Empty{}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func getProjectServerCloudResourceManager(t *testing.T, project *v1.Project) (*CloudResourceManager, *projectServer) {
+	projectServer := &projectServer{project: project}
+	server := httptest.NewServer(projectServer)
+	t.Cleanup(server.Close)
+	crm := &CloudResourceManager{Endpoint: server.URL, Insecure: true}
+	err := crm.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.Initialize() with a test endpoint: %s", err)
+	}
+	crm.OperationPollSeconds = 0
+	return crm, projectServer
+}
+
+func TestMoveProject(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE", Parent: &v1.ResourceId{Type: "folder", Id: "1111111111"}})
+	if err := crm.MoveProject(testProjectID, "folders/2222222222"); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.MoveProject(): %s", err)
+	}
+	if server.project.Parent.Id != "2222222222" {
+		t.Errorf("Expected cloudresourcemanager.MoveProject() to wait for the project to be moved, got parent %v", server.project.Parent)
+	}
+	server.moving = ""
+	if err := crm.MoveProject(testProjectID, "folders/2222222222"); err != nil || server.moving != "" {
+		t.Errorf("Expected cloudresourcemanager.MoveProject() not to move a project already in the parent, got %v", err)
+	}
+	if err := crm.MoveProject(testProjectID, "2222222222"); err == nil {
+		t.Errorf("Expected an error from cloudresourcemanager.MoveProject() for a parent without its type")
+	}
+}
+
+type v3OperationsGetErrorMock struct{}
+
+// Do is the mock for v3OperationsGet, an operation that finished with an error
+func (c *v3OperationsGetErrorMock) Do(ctx context.Context, call *v3.OperationsGetCall, opts ...googleapi.CallOption) (*v3.Operation, error) {
+	return &v3.Operation{Name: "operations/move", Done: true, Error: &v3.Status{Code: 9, Message: "project can't be moved"}}, nil
+}
+
+func TestMoveProjectOperationError(t *testing.T) {
+	crm, _ := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE"})
+	crm.Calls.V3OperationsGet = &v3OperationsGetErrorMock{}
+	err := crm.MoveProject(testProjectID, "folders/2222222222")
+	if operationError, ok := err.(*OperationError); !ok || operationError.Code != 9 {
+		t.Errorf("Expected an *OperationError from cloudresourcemanager.MoveProject() for a move that failed, got %v", err)
+	}
+}
+
+func TestUndeleteProject(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "DELETE_REQUESTED"})
+	if err := crm.UndeleteProject(testProjectID); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.UndeleteProject(): %s", err)
+	}
+	if server.project.LifecycleState != "ACTIVE" {
+		t.Errorf("Expected cloudresourcemanager.UndeleteProject() to restore the project, got %s", server.project.LifecycleState)
+	}
+}
+
+func TestProjectLabels(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE", Labels: map[string]string{"team": "platform"}})
+	if err := crm.MergeProjectLabels(testProjectID, map[string]string{"env": "prod"}); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.MergeProjectLabels(): %s", err)
+	}
+	if !reflect.DeepEqual(server.project.Labels, map[string]string{"team": "platform", "env": "prod"}) {
+		t.Errorf("Expected cloudresourcemanager.MergeProjectLabels() to keep the existing labels, got %v", server.project.Labels)
+	}
+	if err := crm.MergeProjectLabels(testProjectID, map[string]string{"env": "prod"}); err != nil || len(server.updates) != 1 {
+		t.Errorf("Expected cloudresourcemanager.MergeProjectLabels() not to update labels it already has, got %v", err)
+	}
+	if err := crm.SetProjectLabels(testProjectID, map[string]string{"env": "dev"}); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.SetProjectLabels(): %s", err)
+	}
+	if !reflect.DeepEqual(server.project.Labels, map[string]string{"env": "dev"}) {
+		t.Errorf("Expected cloudresourcemanager.SetProjectLabels() to replace the existing labels, got %v", server.project.Labels)
+	}
+}
+
+func TestProjectLabelsDryRun(t *testing.T) {
+	crm, server := getProjectServerCloudResourceManager(t, &v1.Project{ProjectId: testProjectID, LifecycleState: "ACTIVE"})
+	crm.DryRun = plan.New()
+	if err := crm.SetProjectLabels(testProjectID, map[string]string{"env": "prod"}); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.SetProjectLabels() in a dry run: %s", err)
+	}
+	if changes := crm.DryRun.Changes(); len(changes) != 1 || len(server.updates) != 0 {
+		t.Errorf("Expected a dry run cloudresourcemanager.SetProjectLabels() to plan the update without making it, got %v", changes)
+	}
+}
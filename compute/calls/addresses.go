@@ -1,32 +1,56 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/compute/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // AddressesListCallInterface is an interface to list all addresses
 type AddressesListCallInterface interface {
-	Do(call *v1.AddressesAggregatedListCall, opts ...googleapi.CallOption) (*v1.AddressAggregatedList, error)
+	Do(ctx context.Context, call *v1.AddressesAggregatedListCall, opts ...googleapi.CallOption) (*v1.AddressAggregatedList, error)
 }
 
 // AddressDeleteCallInterface is an interface to delete an address
 type AddressDeleteCallInterface interface {
-	Do(call *v1.AddressesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.AddressesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // AddressesListCall is the default implementation for AddressesListCallInterface
-type AddressesListCall struct{}
+type AddressesListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // AddressDeleteCall is the default implementation for AddressDeleteCallInterface
-type AddressDeleteCall struct{}
+type AddressDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *AddressesListCall) Do(call *v1.AddressesAggregatedListCall, opts ...googleapi.CallOption) (*v1.AddressAggregatedList, error) {
-	return call.Do(opts...)
+func (c *AddressesListCall) Do(ctx context.Context, call *v1.AddressesAggregatedListCall, opts ...googleapi.CallOption) (*v1.AddressAggregatedList, error) {
+	var result *v1.AddressAggregatedList
+	err := c.Telemetry.Do(ctx, service, "AddressesList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *AddressDeleteCall) Do(call *v1.AddressesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *AddressDeleteCall) Do(ctx context.Context, call *v1.AddressesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "AddressDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
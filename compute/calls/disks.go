@@ -1,32 +1,56 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/compute/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // DisksListCallInterface is an interface to list all disks
 type DisksListCallInterface interface {
-	Do(call *v1.DisksAggregatedListCall, opts ...googleapi.CallOption) (*v1.DiskAggregatedList, error)
+	Do(ctx context.Context, call *v1.DisksAggregatedListCall, opts ...googleapi.CallOption) (*v1.DiskAggregatedList, error)
 }
 
 // DiskDeleteCallInterface is an interface to delete a disk
 type DiskDeleteCallInterface interface {
-	Do(call *v1.DisksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.DisksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // DisksListCall is the default implementation for DisksListCallInterface
-type DisksListCall struct{}
+type DisksListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // DiskDeleteCall is the default implementation for DiskDeleteCallInterface
-type DiskDeleteCall struct{}
+type DiskDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *DisksListCall) Do(call *v1.DisksAggregatedListCall, opts ...googleapi.CallOption) (*v1.DiskAggregatedList, error) {
-	return call.Do(opts...)
+func (c *DisksListCall) Do(ctx context.Context, call *v1.DisksAggregatedListCall, opts ...googleapi.CallOption) (*v1.DiskAggregatedList, error) {
+	var result *v1.DiskAggregatedList
+	err := c.Telemetry.Do(ctx, service, "DisksList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *DiskDeleteCall) Do(call *v1.DisksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *DiskDeleteCall) Do(ctx context.Context, call *v1.DisksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "DiskDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -1,32 +1,56 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/compute/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // FirewallsListCallInterface is an interface to list all disks
 type FirewallsListCallInterface interface {
-	Do(call *v1.FirewallsListCall, opts ...googleapi.CallOption) (*v1.FirewallList, error)
+	Do(ctx context.Context, call *v1.FirewallsListCall, opts ...googleapi.CallOption) (*v1.FirewallList, error)
 }
 
 // FirewallDeleteCallInterface is an interface to delete a disk
 type FirewallDeleteCallInterface interface {
-	Do(call *v1.FirewallsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.FirewallsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // FirewallsListCall is the default implementation for FirewallsListCallInterface
-type FirewallsListCall struct{}
+type FirewallsListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // FirewallDeleteCall is the default implementation for FirewallDeleteCallInterface
-type FirewallDeleteCall struct{}
+type FirewallDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *FirewallsListCall) Do(call *v1.FirewallsListCall, opts ...googleapi.CallOption) (*v1.FirewallList, error) {
-	return call.Do(opts...)
+func (c *FirewallsListCall) Do(ctx context.Context, call *v1.FirewallsListCall, opts ...googleapi.CallOption) (*v1.FirewallList, error) {
+	var result *v1.FirewallList
+	err := c.Telemetry.Do(ctx, service, "FirewallsList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *FirewallDeleteCall) Do(call *v1.FirewallsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *FirewallDeleteCall) Do(ctx context.Context, call *v1.FirewallsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "FirewallDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
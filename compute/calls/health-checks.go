@@ -1,58 +1,102 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/compute/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // HealthChecksListCallInterface is an interface to list all health checks
 type HealthChecksListCallInterface interface {
-	Do(call *v1.HealthChecksAggregatedListCall, opts ...googleapi.CallOption) (*v1.HealthChecksAggregatedList, error)
+	Do(ctx context.Context, call *v1.HealthChecksAggregatedListCall, opts ...googleapi.CallOption) (*v1.HealthChecksAggregatedList, error)
 }
 
 // HealthCheckDeleteCallInterface is an interface to delete a health check
 type HealthCheckDeleteCallInterface interface {
-	Do(call *v1.HealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.HealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // HTTPHealthChecksListCallInterface is an interface to list all http (legacy) health checks
 type HTTPHealthChecksListCallInterface interface {
-	Do(call *v1.HttpHealthChecksListCall, opts ...googleapi.CallOption) (*v1.HttpHealthCheckList, error)
+	Do(ctx context.Context, call *v1.HttpHealthChecksListCall, opts ...googleapi.CallOption) (*v1.HttpHealthCheckList, error)
 }
 
 // HTTPHealthCheckDeleteCallInterface is an interface to delete a http (legacy) health check
 type HTTPHealthCheckDeleteCallInterface interface {
-	Do(call *v1.HttpHealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.HttpHealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // HealthChecksListCall is the default implementation for HealthChecksListCallInterface
-type HealthChecksListCall struct{}
+type HealthChecksListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // HealthCheckDeleteCall is the default implementation for HealthCheckDeleteCallInterface
-type HealthCheckDeleteCall struct{}
+type HealthCheckDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // HTTPHealthChecksListCall is the default implementation for HTTPHealthChecksListCallInterface
-type HTTPHealthChecksListCall struct{}
+type HTTPHealthChecksListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // HTTPHealthCheckDeleteCall is the default implementation for HTTPHealthCheckDeleteCallInterface
-type HTTPHealthCheckDeleteCall struct{}
+type HTTPHealthCheckDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *HealthChecksListCall) Do(call *v1.HealthChecksAggregatedListCall, opts ...googleapi.CallOption) (*v1.HealthChecksAggregatedList, error) {
-	return call.Do(opts...)
+func (c *HealthChecksListCall) Do(ctx context.Context, call *v1.HealthChecksAggregatedListCall, opts ...googleapi.CallOption) (*v1.HealthChecksAggregatedList, error) {
+	var result *v1.HealthChecksAggregatedList
+	err := c.Telemetry.Do(ctx, service, "HealthChecksList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *HealthCheckDeleteCall) Do(call *v1.HealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *HealthCheckDeleteCall) Do(ctx context.Context, call *v1.HealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "HealthCheckDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *HTTPHealthChecksListCall) Do(call *v1.HttpHealthChecksListCall, opts ...googleapi.CallOption) (*v1.HttpHealthCheckList, error) {
-	return call.Do(opts...)
+func (c *HTTPHealthChecksListCall) Do(ctx context.Context, call *v1.HttpHealthChecksListCall, opts ...googleapi.CallOption) (*v1.HttpHealthCheckList, error) {
+	var result *v1.HttpHealthCheckList
+	err := c.Telemetry.Do(ctx, service, "HTTPHealthChecksList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *HTTPHealthCheckDeleteCall) Do(call *v1.HttpHealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *HTTPHealthCheckDeleteCall) Do(ctx context.Context, call *v1.HttpHealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "HTTPHealthCheckDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -0,0 +1,79 @@
+package calls
+
+import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/compute/v1"
+	googleapi "google.golang.org/api/googleapi"
+)
+
+// InstanceGroupManagersListCallInterface is an interface to list all managed instance groups, zonal and regional
+type InstanceGroupManagersListCallInterface interface {
+	Do(ctx context.Context, call *v1.InstanceGroupManagersAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupManagerAggregatedList, error)
+}
+
+// InstanceGroupManagerDeleteCallInterface is an interface to delete a zonal managed instance group
+type InstanceGroupManagerDeleteCallInterface interface {
+	Do(ctx context.Context, call *v1.InstanceGroupManagersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// RegionInstanceGroupManagerDeleteCallInterface is an interface to delete a regional managed instance group
+type RegionInstanceGroupManagerDeleteCallInterface interface {
+	Do(ctx context.Context, call *v1.RegionInstanceGroupManagersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// InstanceGroupManagersListCall is the default implementation for InstanceGroupManagersListCallInterface
+type InstanceGroupManagersListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// InstanceGroupManagerDeleteCall is the default implementation for InstanceGroupManagerDeleteCallInterface
+type InstanceGroupManagerDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// RegionInstanceGroupManagerDeleteCall is the default implementation for RegionInstanceGroupManagerDeleteCallInterface
+type RegionInstanceGroupManagerDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *InstanceGroupManagersListCall) Do(ctx context.Context, call *v1.InstanceGroupManagersAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupManagerAggregatedList, error) {
+	var result *v1.InstanceGroupManagerAggregatedList
+	err := c.Telemetry.Do(ctx, service, "InstanceGroupManagersList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *InstanceGroupManagerDeleteCall) Do(ctx context.Context, call *v1.InstanceGroupManagersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "InstanceGroupManagerDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *RegionInstanceGroupManagerDeleteCall) Do(ctx context.Context, call *v1.RegionInstanceGroupManagersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "RegionInstanceGroupManagerDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
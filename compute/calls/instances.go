@@ -2,71 +2,151 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/compute/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
+// service is the name of the library in the telemetry of its calls
+const service = "compute"
+
 // InstancesAggregatedListCallInterface is an interface to a call to list instances across all zones
 type InstancesAggregatedListCallInterface interface {
-	Do(call *v1.InstancesAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceAggregatedList, error)
+	Do(ctx context.Context, call *v1.InstancesAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceAggregatedList, error)
 }
 
 // InstancesStopCallInterface is an interface to a call to stop instances
 type InstancesStopCallInterface interface {
-	Do(call *v1.InstancesStopCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.InstancesStopCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // InstancesStartCallInterface is an interface to a call to start instances
 type InstancesStartCallInterface interface {
-	Do(call *v1.InstancesStartCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.InstancesStartCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// InstanceDeleteCallInterface is an interface to a call to delete an instance
+type InstanceDeleteCallInterface interface {
+	Do(ctx context.Context, call *v1.InstancesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // ProjectsSetCommonInstanceMetadataCallInterface is an interface to a call to set project-level metadata for instances
 type ProjectsSetCommonInstanceMetadataCallInterface interface {
-	Do(call *v1.ProjectsSetCommonInstanceMetadataCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.ProjectsSetCommonInstanceMetadataCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // ProjectsGetCallInterface is an interface to a call get a compute project object
 type ProjectsGetCallInterface interface {
-	Do(call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error)
+	Do(ctx context.Context, call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error)
 }
 
 // InstancesAggregatedListCall is the default implementation for InstancesAggregatedListCallInterface
-type InstancesAggregatedListCall struct{}
+type InstancesAggregatedListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // InstancesStopCall is the default implementation for InstancesStopCallInterface
-type InstancesStopCall struct{}
+type InstancesStopCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // InstancesStartCall is the default implementation for InstancesStartCallInterface
-type InstancesStartCall struct{}
+type InstancesStartCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// InstanceDeleteCall is the default implementation for InstanceDeleteCallInterface
+type InstanceDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ProjectsSetCommonInstanceMetadataCall is the default implementation for SetCommonInstanceMetadataCallInterface
-type ProjectsSetCommonInstanceMetadataCall struct{}
+type ProjectsSetCommonInstanceMetadataCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ProjectsGetCall is the default implementation for ProjectsGetCallInterface
-type ProjectsGetCall struct{}
+type ProjectsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *InstancesAggregatedListCall) Do(ctx context.Context, call *v1.InstancesAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceAggregatedList, error) {
+	var result *v1.InstanceAggregatedList
+	err := c.Telemetry.Do(ctx, service, "InstancesAggregatedList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *InstancesAggregatedListCall) Do(call *v1.InstancesAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceAggregatedList, error) {
-	return call.Do(opts...)
+func (c *InstancesStopCall) Do(ctx context.Context, call *v1.InstancesStopCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "InstancesStop", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *InstancesStopCall) Do(call *v1.InstancesStopCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *InstancesStartCall) Do(ctx context.Context, call *v1.InstancesStartCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "InstancesStart", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *InstancesStartCall) Do(call *v1.InstancesStartCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *InstanceDeleteCall) Do(ctx context.Context, call *v1.InstancesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "InstanceDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsSetCommonInstanceMetadataCall) Do(call *v1.ProjectsSetCommonInstanceMetadataCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *ProjectsSetCommonInstanceMetadataCall) Do(ctx context.Context, call *v1.ProjectsSetCommonInstanceMetadataCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "ProjectsSetCommonInstanceMetadata", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ProjectsGetCall) Do(call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error) {
-	return call.Do(opts...)
+func (c *ProjectsGetCall) Do(ctx context.Context, call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error) {
+	var result *v1.Project
+	err := c.Telemetry.Do(ctx, service, "ProjectsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -1,123 +1,217 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/compute/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // TargetPoolsListCallInterface is an interface to list all target pools
 type TargetPoolsListCallInterface interface {
-	Do(call *v1.TargetPoolsAggregatedListCall, opts ...googleapi.CallOption) (*v1.TargetPoolAggregatedList, error)
+	Do(ctx context.Context, call *v1.TargetPoolsAggregatedListCall, opts ...googleapi.CallOption) (*v1.TargetPoolAggregatedList, error)
 }
 
 // TargetPoolDeleteCallInterface is an interface to delete a target pool
 type TargetPoolDeleteCallInterface interface {
-	Do(call *v1.TargetPoolsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.TargetPoolsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // BackendServicesListCallInterface is an interface to list all backend services
 type BackendServicesListCallInterface interface {
-	Do(call *v1.BackendServicesAggregatedListCall, opts ...googleapi.CallOption) (*v1.BackendServiceAggregatedList, error)
+	Do(ctx context.Context, call *v1.BackendServicesAggregatedListCall, opts ...googleapi.CallOption) (*v1.BackendServiceAggregatedList, error)
 }
 
 // BackendServiceDeleteCallInterface is an interface to delete a backend service
 type BackendServiceDeleteCallInterface interface {
-	Do(call *v1.BackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.BackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // RegionBackendServiceDeleteCallInterface is an interface to delete a backend service in a region
 type RegionBackendServiceDeleteCallInterface interface {
-	Do(call *v1.RegionBackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.RegionBackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // ForwardingRulesListCallInterface is an interface to list all forwarding rules
 type ForwardingRulesListCallInterface interface {
-	Do(call *v1.ForwardingRulesAggregatedListCall, opts ...googleapi.CallOption) (*v1.ForwardingRuleAggregatedList, error)
+	Do(ctx context.Context, call *v1.ForwardingRulesAggregatedListCall, opts ...googleapi.CallOption) (*v1.ForwardingRuleAggregatedList, error)
 }
 
 // ForwardingRuleDeleteCallInterface is an interface to delete a forwarding rule
 type ForwardingRuleDeleteCallInterface interface {
-	Do(call *v1.ForwardingRulesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.ForwardingRulesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // InstanceGroupsListCallInterface is an interface to list all instance groups
 type InstanceGroupsListCallInterface interface {
-	Do(call *v1.InstanceGroupsAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupAggregatedList, error)
+	Do(ctx context.Context, call *v1.InstanceGroupsAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupAggregatedList, error)
 }
 
 // InstanceGroupDeleteCallInterface is an interface to delete an instance group
 type InstanceGroupDeleteCallInterface interface {
-	Do(call *v1.InstanceGroupsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.InstanceGroupsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // TargetPoolsListCall is the default implementation for TargetPoolsListCallInterface
-type TargetPoolsListCall struct{}
+type TargetPoolsListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // TargetPoolDeleteCall is the default implementation for TargetPoolDeleteCallInterface
-type TargetPoolDeleteCall struct{}
+type TargetPoolDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // BackendServicesListCall is the default implementation for BackendServicesListCallInterface
-type BackendServicesListCall struct{}
+type BackendServicesListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // BackendServiceDeleteCall is the default implementation for BackendServiceDeleteCallInterface
-type BackendServiceDeleteCall struct{}
+type BackendServiceDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // RegionBackendServiceDeleteCall is the default implementation for BackendServiceDeleteCallInterface
-type RegionBackendServiceDeleteCall struct{}
+type RegionBackendServiceDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ForwardingRulesListCall is the default implementation for ForwardingRulesListCallInterface
-type ForwardingRulesListCall struct{}
+type ForwardingRulesListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // ForwardingRuleDeleteCall is the default implementation for ForwardingRuleDeleteCallInterface
-type ForwardingRuleDeleteCall struct{}
+type ForwardingRuleDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // InstanceGroupsListCall is the default implementation for InstanceGroupsListCallInterface
-type InstanceGroupsListCall struct{}
+type InstanceGroupsListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // InstanceGroupDeleteCall is the default implementation for InstanceGroupDeleteCallInterface
-type InstanceGroupDeleteCall struct{}
+type InstanceGroupDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *TargetPoolsListCall) Do(call *v1.TargetPoolsAggregatedListCall, opts ...googleapi.CallOption) (*v1.TargetPoolAggregatedList, error) {
-	return call.Do(opts...)
+func (c *TargetPoolsListCall) Do(ctx context.Context, call *v1.TargetPoolsAggregatedListCall, opts ...googleapi.CallOption) (*v1.TargetPoolAggregatedList, error) {
+	var result *v1.TargetPoolAggregatedList
+	err := c.Telemetry.Do(ctx, service, "TargetPoolsList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *TargetPoolDeleteCall) Do(call *v1.TargetPoolsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *TargetPoolDeleteCall) Do(ctx context.Context, call *v1.TargetPoolsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "TargetPoolDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *BackendServicesListCall) Do(call *v1.BackendServicesAggregatedListCall, opts ...googleapi.CallOption) (*v1.BackendServiceAggregatedList, error) {
-	return call.Do(opts...)
+func (c *BackendServicesListCall) Do(ctx context.Context, call *v1.BackendServicesAggregatedListCall, opts ...googleapi.CallOption) (*v1.BackendServiceAggregatedList, error) {
+	var result *v1.BackendServiceAggregatedList
+	err := c.Telemetry.Do(ctx, service, "BackendServicesList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *BackendServiceDeleteCall) Do(call *v1.BackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *BackendServiceDeleteCall) Do(ctx context.Context, call *v1.BackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "BackendServiceDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *RegionBackendServiceDeleteCall) Do(call *v1.RegionBackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *RegionBackendServiceDeleteCall) Do(ctx context.Context, call *v1.RegionBackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "RegionBackendServiceDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ForwardingRulesListCall) Do(call *v1.ForwardingRulesAggregatedListCall, opts ...googleapi.CallOption) (*v1.ForwardingRuleAggregatedList, error) {
-	return call.Do(opts...)
+func (c *ForwardingRulesListCall) Do(ctx context.Context, call *v1.ForwardingRulesAggregatedListCall, opts ...googleapi.CallOption) (*v1.ForwardingRuleAggregatedList, error) {
+	var result *v1.ForwardingRuleAggregatedList
+	err := c.Telemetry.Do(ctx, service, "ForwardingRulesList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *ForwardingRuleDeleteCall) Do(call *v1.ForwardingRulesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *ForwardingRuleDeleteCall) Do(ctx context.Context, call *v1.ForwardingRulesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "ForwardingRuleDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *InstanceGroupsListCall) Do(call *v1.InstanceGroupsAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupAggregatedList, error) {
-	return call.Do(opts...)
+func (c *InstanceGroupsListCall) Do(ctx context.Context, call *v1.InstanceGroupsAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupAggregatedList, error) {
+	var result *v1.InstanceGroupAggregatedList
+	err := c.Telemetry.Do(ctx, service, "InstanceGroupsList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *InstanceGroupDeleteCall) Do(call *v1.InstanceGroupsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *InstanceGroupDeleteCall) Do(ctx context.Context, call *v1.InstanceGroupsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "InstanceGroupDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -1,32 +1,102 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/compute/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // NetworkGetCallInterface is an interface to get a network
 type NetworkGetCallInterface interface {
-	Do(call *v1.NetworksGetCall, opts ...googleapi.CallOption) (*v1.Network, error)
+	Do(ctx context.Context, call *v1.NetworksGetCall, opts ...googleapi.CallOption) (*v1.Network, error)
 }
 
 // NetworkDeleteCallInterface is an interface to delete a network
 type NetworkDeleteCallInterface interface {
-	Do(call *v1.NetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+	Do(ctx context.Context, call *v1.NetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// SubnetworkDeleteCallInterface is an interface to delete a subnetwork
+type SubnetworkDeleteCallInterface interface {
+	Do(ctx context.Context, call *v1.SubnetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
 }
 
 // NetworkGetCall is the default implementation for NetworkGetCallInterface
-type NetworkGetCall struct{}
+type NetworkGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // NetworkDeleteCall is the default implementation for NetworkDeleteCallInterface
-type NetworkDeleteCall struct{}
+type NetworkDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *NetworkGetCall) Do(call *v1.NetworksGetCall, opts ...googleapi.CallOption) (*v1.Network, error) {
-	return call.Do(opts...)
+func (c *NetworkGetCall) Do(ctx context.Context, call *v1.NetworksGetCall, opts ...googleapi.CallOption) (*v1.Network, error) {
+	var result *v1.Network
+	err := c.Telemetry.Do(ctx, service, "NetworkGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *NetworkDeleteCall) Do(ctx context.Context, call *v1.NetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "NetworkDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// SubnetworkDeleteCall is the default implementation for SubnetworkDeleteCallInterface
+type SubnetworkDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *SubnetworkDeleteCall) Do(ctx context.Context, call *v1.SubnetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "SubnetworkDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// NetworkRemovePeeringCallInterface is an interface to remove a peering from a network
+type NetworkRemovePeeringCallInterface interface {
+	Do(ctx context.Context, call *v1.NetworksRemovePeeringCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// NetworkRemovePeeringCall is the default implementation for NetworkRemovePeeringCallInterface
+type NetworkRemovePeeringCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *NetworkDeleteCall) Do(call *v1.NetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
-	return call.Do(opts...)
+func (c *NetworkRemovePeeringCall) Do(ctx context.Context, call *v1.NetworksRemovePeeringCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "NetworkRemovePeering", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
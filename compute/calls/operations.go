@@ -0,0 +1,148 @@
+package calls
+
+import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/compute/v1"
+	googleapi "google.golang.org/api/googleapi"
+)
+
+// GlobalOperationsWaitCallInterface is an interface to a call to wait on a global operation
+type GlobalOperationsWaitCallInterface interface {
+	Do(ctx context.Context, call *v1.GlobalOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// GlobalOperationsGetCallInterface is an interface to a call to get a global operation
+type GlobalOperationsGetCallInterface interface {
+	Do(ctx context.Context, call *v1.GlobalOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// RegionOperationsWaitCallInterface is an interface to a call to wait on a regional operation
+type RegionOperationsWaitCallInterface interface {
+	Do(ctx context.Context, call *v1.RegionOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// RegionOperationsGetCallInterface is an interface to a call to get a regional operation
+type RegionOperationsGetCallInterface interface {
+	Do(ctx context.Context, call *v1.RegionOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// ZoneOperationsWaitCallInterface is an interface to a call to wait on a zonal operation
+type ZoneOperationsWaitCallInterface interface {
+	Do(ctx context.Context, call *v1.ZoneOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// ZoneOperationsGetCallInterface is an interface to a call to get a zonal operation
+type ZoneOperationsGetCallInterface interface {
+	Do(ctx context.Context, call *v1.ZoneOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// GlobalOperationsWaitCall is the default implementation for GlobalOperationsWaitCallInterface
+type GlobalOperationsWaitCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// GlobalOperationsGetCall is the default implementation for GlobalOperationsGetCallInterface
+type GlobalOperationsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// RegionOperationsWaitCall is the default implementation for RegionOperationsWaitCallInterface
+type RegionOperationsWaitCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// RegionOperationsGetCall is the default implementation for RegionOperationsGetCallInterface
+type RegionOperationsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ZoneOperationsWaitCall is the default implementation for ZoneOperationsWaitCallInterface
+type ZoneOperationsWaitCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// ZoneOperationsGetCall is the default implementation for ZoneOperationsGetCallInterface
+type ZoneOperationsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *GlobalOperationsWaitCall) Do(ctx context.Context, call *v1.GlobalOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "GlobalOperationsWait", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *GlobalOperationsGetCall) Do(ctx context.Context, call *v1.GlobalOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "GlobalOperationsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *RegionOperationsWaitCall) Do(ctx context.Context, call *v1.RegionOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "RegionOperationsWait", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *RegionOperationsGetCall) Do(ctx context.Context, call *v1.RegionOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "RegionOperationsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ZoneOperationsWaitCall) Do(ctx context.Context, call *v1.ZoneOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "ZoneOperationsWait", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *ZoneOperationsGetCall) Do(ctx context.Context, call *v1.ZoneOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "ZoneOperationsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
@@ -1,19 +1,33 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/compute/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // RegionsGetCallInterface is an interface to a call to get a compute region
 type RegionsGetCallInterface interface {
-	Do(call *v1.RegionsGetCall, opts ...googleapi.CallOption) (*v1.Region, error)
+	Do(ctx context.Context, call *v1.RegionsGetCall, opts ...googleapi.CallOption) (*v1.Region, error)
 }
 
 // RegionsGetCall is the default implementation for RegionsGetCallInterface
-type RegionsGetCall struct{}
+type RegionsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *RegionsGetCall) Do(call *v1.RegionsGetCall, opts ...googleapi.CallOption) (*v1.Region, error) {
-	return call.Do(opts...)
+func (c *RegionsGetCall) Do(ctx context.Context, call *v1.RegionsGetCall, opts ...googleapi.CallOption) (*v1.Region, error) {
+	var result *v1.Region
+	err := c.Telemetry.Do(ctx, service, "RegionsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -0,0 +1,102 @@
+package calls
+
+import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/compute/v1"
+	googleapi "google.golang.org/api/googleapi"
+)
+
+// RoutesListCallInterface is an interface to list all routes
+type RoutesListCallInterface interface {
+	Do(ctx context.Context, call *v1.RoutesListCall, opts ...googleapi.CallOption) (*v1.RouteList, error)
+}
+
+// RouteDeleteCallInterface is an interface to delete a route
+type RouteDeleteCallInterface interface {
+	Do(ctx context.Context, call *v1.RoutesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// RoutersAggregatedListCallInterface is an interface to list all routers
+type RoutersAggregatedListCallInterface interface {
+	Do(ctx context.Context, call *v1.RoutersAggregatedListCall, opts ...googleapi.CallOption) (*v1.RouterAggregatedList, error)
+}
+
+// RouterDeleteCallInterface is an interface to delete a router
+type RouterDeleteCallInterface interface {
+	Do(ctx context.Context, call *v1.RoutersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error)
+}
+
+// RoutesListCall is the default implementation for RoutesListCallInterface
+type RoutesListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// RouteDeleteCall is the default implementation for RouteDeleteCallInterface
+type RouteDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// RoutersAggregatedListCall is the default implementation for RoutersAggregatedListCallInterface
+type RoutersAggregatedListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// RouterDeleteCall is the default implementation for RouterDeleteCallInterface
+type RouterDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *RoutesListCall) Do(ctx context.Context, call *v1.RoutesListCall, opts ...googleapi.CallOption) (*v1.RouteList, error) {
+	var result *v1.RouteList
+	err := c.Telemetry.Do(ctx, service, "RoutesList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *RouteDeleteCall) Do(ctx context.Context, call *v1.RoutesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "RouteDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *RoutersAggregatedListCall) Do(ctx context.Context, call *v1.RoutersAggregatedListCall, opts ...googleapi.CallOption) (*v1.RouterAggregatedList, error) {
+	var result *v1.RouterAggregatedList
+	err := c.Telemetry.Do(ctx, service, "RoutersAggregatedList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
+
+// Do performs the call, the default implementation of the interface
+func (c *RouterDeleteCall) Do(ctx context.Context, call *v1.RoutersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	var result *v1.Operation
+	err := c.Telemetry.Do(ctx, service, "RouterDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
+}
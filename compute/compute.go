@@ -4,13 +4,19 @@ package compute
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/rockholla/go-google-lib/compute/calls"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	v1 "google.golang.org/api/compute/v1"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
 // Local utility function to extract a zone string from a zone URL
@@ -19,35 +25,119 @@ func urlZone(url string) string {
 	return string(re.Find([]byte(url)))
 }
 
+// service is the name of the library in the events it emits
+const service = "compute"
+
 // Interface represents functionality for DeploymentManager
 type Interface interface {
 	Initialize(credentials string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error
 	GetRegionZones(projectID string, region string) ([]string, error)
+	GetRegionZonesCtx(ctx context.Context, projectID string, region string) ([]string, error)
+	ForEachInstance(projectID string, fn func(*v1.Instance) error) error
+	ForEachInstanceCtx(ctx context.Context, projectID string, fn func(*v1.Instance) error) error
 	GetInternalIPs(projectID string, network string) ([]*InstanceIP, error)
-	PowerOff(projectID string) error
-	PowerOn(projectID string) error
-	SetCommonInstanceMetadata(projectID string, metadataItems []*v1.MetadataItems) error
+	GetInternalIPsCtx(ctx context.Context, projectID string, network string) ([]*InstanceIP, error)
+	PowerOff(projectID string, opts ...MutateOption) error
+	PowerOffCtx(ctx context.Context, projectID string, opts ...MutateOption) error
+	PowerOn(projectID string, opts ...MutateOption) error
+	PowerOnCtx(ctx context.Context, projectID string, opts ...MutateOption) error
+	DeleteInstance(projectID string, zone string, name string, opts ...MutateOption) error
+	DeleteInstanceCtx(ctx context.Context, projectID string, zone string, name string, opts ...MutateOption) error
+	SetCommonInstanceMetadata(projectID string, metadataItems []*v1.MetadataItems, opts ...MutateOption) error
+	SetCommonInstanceMetadataCtx(ctx context.Context, projectID string, metadataItems []*v1.MetadataItems, opts ...MutateOption) error
 	GetCommonInstanceMetadata(projectID string) ([]*v1.MetadataItems, error)
+	GetCommonInstanceMetadataCtx(ctx context.Context, projectID string) ([]*v1.MetadataItems, error)
+	ForEachTargetPool(projectID string, fn func(*v1.TargetPool) error) error
+	ForEachTargetPoolCtx(ctx context.Context, projectID string, fn func(*v1.TargetPool) error) error
 	GetTargetPools(projectID string) ([]*v1.TargetPool, error)
-	DeleteTargetPool(projectID string, region string, name string) error
-	DeleteForwardingRule(projectID string, region string, name string) error
+	GetTargetPoolsCtx(ctx context.Context, projectID string) ([]*v1.TargetPool, error)
+	DeleteTargetPool(projectID string, region string, name string, opts ...MutateOption) error
+	DeleteTargetPoolCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) error
+	ForEachForwardingRule(projectID string, fn func(*v1.ForwardingRule) error) error
+	ForEachForwardingRuleCtx(ctx context.Context, projectID string, fn func(*v1.ForwardingRule) error) error
+	GetForwardingRules(projectID string) ([]*v1.ForwardingRule, error)
+	GetForwardingRulesCtx(ctx context.Context, projectID string) ([]*v1.ForwardingRule, error)
+	DeleteForwardingRule(projectID string, region string, name string, opts ...MutateOption) error
+	DeleteForwardingRuleCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) error
+	ForEachBackendService(projectID string, fn func(*v1.BackendService) error) error
+	ForEachBackendServiceCtx(ctx context.Context, projectID string, fn func(*v1.BackendService) error) error
 	GetBackendServices(projectID string) ([]*v1.BackendService, error)
-	DeleteBackendService(projectID string, name string) error
-	DeleteRegionBackendService(projectID string, region string, name string) error
+	GetBackendServicesCtx(ctx context.Context, projectID string) ([]*v1.BackendService, error)
+	DeleteBackendService(projectID string, name string, opts ...MutateOption) error
+	DeleteBackendServiceCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) error
+	DeleteRegionBackendService(projectID string, region string, name string, opts ...MutateOption) error
+	DeleteRegionBackendServiceCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) error
+	ForEachHealthCheck(projectID string, fn func(*v1.HealthCheck) error) error
+	ForEachHealthCheckCtx(ctx context.Context, projectID string, fn func(*v1.HealthCheck) error) error
 	GetHealthChecks(projectID string) ([]*v1.HealthCheck, error)
-	DeleteHealthCheck(projectID string, name string) error
+	GetHealthChecksCtx(ctx context.Context, projectID string) ([]*v1.HealthCheck, error)
+	DeleteHealthCheck(projectID string, name string, opts ...MutateOption) error
+	DeleteHealthCheckCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) error
+	ForEachHTTPHealthCheck(projectID string, fn func(*v1.HttpHealthCheck) error) error
+	ForEachHTTPHealthCheckCtx(ctx context.Context, projectID string, fn func(*v1.HttpHealthCheck) error) error
 	GetHTTPHealthChecks(projectID string) ([]*v1.HttpHealthCheck, error)
-	DeleteHTTPHealthCheck(projectID string, name string) error
+	GetHTTPHealthChecksCtx(ctx context.Context, projectID string) ([]*v1.HttpHealthCheck, error)
+	DeleteHTTPHealthCheck(projectID string, name string, opts ...MutateOption) error
+	DeleteHTTPHealthCheckCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) error
+	ForEachDisk(projectID string, fn func(*v1.Disk) error) error
+	ForEachDiskCtx(ctx context.Context, projectID string, fn func(*v1.Disk) error) error
 	GetDisks(projectID string) ([]*v1.Disk, error)
-	DeleteDisk(projectID string, zone string, name string) error
+	GetDisksCtx(ctx context.Context, projectID string) ([]*v1.Disk, error)
+	DeleteDisk(projectID string, zone string, name string, opts ...MutateOption) error
+	DeleteDiskCtx(ctx context.Context, projectID string, zone string, name string, opts ...MutateOption) error
+	ForEachAddress(projectID string, fn func(*v1.Address) error) error
+	ForEachAddressCtx(ctx context.Context, projectID string, fn func(*v1.Address) error) error
 	GetAddresses(projectID string) ([]*v1.Address, error)
-	DeleteAddress(projectID string, region string, name string) error
+	GetAddressesCtx(ctx context.Context, projectID string) ([]*v1.Address, error)
+	DeleteAddress(projectID string, region string, name string, opts ...MutateOption) error
+	DeleteAddressCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) error
+	ForEachFirewall(projectID string, fn func(*v1.Firewall) error) error
+	ForEachFirewallCtx(ctx context.Context, projectID string, fn func(*v1.Firewall) error) error
 	GetFirewalls(projectID string) ([]*v1.Firewall, error)
-	DeleteFirewall(projectID string, name string) error
+	GetFirewallsCtx(ctx context.Context, projectID string) ([]*v1.Firewall, error)
+	DeleteFirewall(projectID string, name string, opts ...MutateOption) error
+	DeleteFirewallCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) error
+	ForEachInstanceGroup(projectID string, fn func(*v1.InstanceGroup) error) error
+	ForEachInstanceGroupCtx(ctx context.Context, projectID string, fn func(*v1.InstanceGroup) error) error
 	GetInstanceGroups(projectID string) ([]*v1.InstanceGroup, error)
-	DeleteInstanceGroup(projectID string, zone string, name string) error
+	GetInstanceGroupsCtx(ctx context.Context, projectID string) ([]*v1.InstanceGroup, error)
+	DeleteInstanceGroup(projectID string, zone string, name string, opts ...MutateOption) error
+	DeleteInstanceGroupCtx(ctx context.Context, projectID string, zone string, name string, opts ...MutateOption) error
+	ForEachInstanceGroupManager(projectID string, fn func(*v1.InstanceGroupManager) error) error
+	ForEachInstanceGroupManagerCtx(ctx context.Context, projectID string, fn func(*v1.InstanceGroupManager) error) error
+	GetInstanceGroupManagers(projectID string) ([]*v1.InstanceGroupManager, error)
+	GetInstanceGroupManagersCtx(ctx context.Context, projectID string) ([]*v1.InstanceGroupManager, error)
+	DeleteInstanceGroupManager(projectID string, zone string, name string, opts ...MutateOption) error
+	DeleteInstanceGroupManagerCtx(ctx context.Context, projectID string, zone string, name string, opts ...MutateOption) error
+	DeleteRegionInstanceGroupManager(projectID string, region string, name string, opts ...MutateOption) error
+	DeleteRegionInstanceGroupManagerCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) error
 	GetNetwork(projectID string, name string) (*v1.Network, error)
-	DeleteNetwork(projectID string, name string) error
+	GetNetworkCtx(ctx context.Context, projectID string, name string) (*v1.Network, error)
+	DeleteSubnetwork(projectID string, region string, name string, opts ...MutateOption) error
+	DeleteSubnetworkCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) error
+	RemoveNetworkPeering(projectID string, network string, name string, opts ...MutateOption) error
+	RemoveNetworkPeeringCtx(ctx context.Context, projectID string, network string, name string, opts ...MutateOption) error
+	DeleteNetwork(projectID string, name string, opts ...MutateOption) error
+	DeleteNetworkCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) error
+	ForEachRoute(projectID string, fn func(*v1.Route) error) error
+	ForEachRouteCtx(ctx context.Context, projectID string, fn func(*v1.Route) error) error
+	GetRoutes(projectID string) ([]*v1.Route, error)
+	GetRoutesCtx(ctx context.Context, projectID string) ([]*v1.Route, error)
+	DeleteRoute(projectID string, name string, opts ...MutateOption) error
+	DeleteRouteCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) error
+	ForEachRouter(projectID string, fn func(*v1.Router) error) error
+	ForEachRouterCtx(ctx context.Context, projectID string, fn func(*v1.Router) error) error
+	GetRouters(projectID string) ([]*v1.Router, error)
+	GetRoutersCtx(ctx context.Context, projectID string) ([]*v1.Router, error)
+	DeleteRouter(projectID string, region string, name string, opts ...MutateOption) error
+	DeleteRouterCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) error
+	GetOperation(projectID string, operation *v1.Operation) (*v1.Operation, error)
+	GetOperationCtx(ctx context.Context, projectID string, operation *v1.Operation) (*v1.Operation, error)
+	WaitForOperation(projectID string, operation *v1.Operation) error
+	WaitForOperationCtx(ctx context.Context, projectID string, operation *v1.Operation) error
+	TeardownNetwork(projectID string, network string, opts TeardownOptions) (*TeardownReport, error)
+	TeardownNetworkCtx(ctx context.Context, projectID string, network string, opts TeardownOptions) (*TeardownReport, error)
 }
 
 // InstanceIP is an IP for a VM instance
@@ -58,9 +148,30 @@ type InstanceIP struct {
 
 // Compute is a wrapper around the google-provided sdks/apis for google.golang.org/api/compute/*
 type Compute struct {
-	log   logger.Interface
-	V1    *v1.Service
-	Calls *Calls
+	log           logger.Interface
+	V1            *v1.Service
+	Calls         *Calls
+	Retry         *retry.Policy
+	ClientOptions []option.ClientOption
+	// Endpoint overrides where api requests are sent, e.g. to a local emulator or fake
+	Endpoint string
+	// Insecure sends api requests without credentials, for an Endpoint that doesn't expect them
+	Insecure bool
+	// Transport is the base http transport api requests are sent through, underneath authentication, e.g. a
+	// recorder.Recorder to record or replay them
+	Transport http.RoundTripper
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
+	// OperationPollSeconds is how long to wait between checks of a pending operation, 5 when not set
+	OperationPollSeconds int64
+	// OperationTimeoutSeconds is how long to wait in total for an operation to finish, 600 when not set, a negative
+	// value meaning no limit
+	OperationTimeoutSeconds int64
 }
 
 type cachedForwardingRule struct {
@@ -74,6 +185,7 @@ type Calls struct {
 	InstancesAggregatedList           calls.InstancesAggregatedListCallInterface
 	InstancesStop                     calls.InstancesStopCallInterface
 	InstancesStart                    calls.InstancesStartCallInterface
+	InstanceDelete                    calls.InstanceDeleteCallInterface
 	ProjectsSetCommonInstanceMetadata calls.ProjectsSetCommonInstanceMetadataCallInterface
 	ProjectsGet                       calls.ProjectsGetCallInterface
 	TargetPoolsList                   calls.TargetPoolsListCallInterface
@@ -95,437 +207,1347 @@ type Calls struct {
 	FirewallDelete                    calls.FirewallDeleteCallInterface
 	InstanceGroupsList                calls.InstanceGroupsListCallInterface
 	InstanceGroupDelete               calls.InstanceGroupDeleteCallInterface
+	InstanceGroupManagersList         calls.InstanceGroupManagersListCallInterface
+	InstanceGroupManagerDelete        calls.InstanceGroupManagerDeleteCallInterface
+	RegionInstanceGroupManagerDelete  calls.RegionInstanceGroupManagerDeleteCallInterface
 	NetworkGet                        calls.NetworkGetCallInterface
 	NetworkDelete                     calls.NetworkDeleteCallInterface
+	SubnetworkDelete                  calls.SubnetworkDeleteCallInterface
+	NetworkRemovePeering              calls.NetworkRemovePeeringCallInterface
+	RoutesList                        calls.RoutesListCallInterface
+	RouteDelete                       calls.RouteDeleteCallInterface
+	RoutersAggregatedList             calls.RoutersAggregatedListCallInterface
+	RouterDelete                      calls.RouterDeleteCallInterface
+	GlobalOperationsWait              calls.GlobalOperationsWaitCallInterface
+	GlobalOperationsGet               calls.GlobalOperationsGetCallInterface
+	RegionOperationsWait              calls.RegionOperationsWaitCallInterface
+	RegionOperationsGet               calls.RegionOperationsGetCallInterface
+	ZoneOperationsWait                calls.ZoneOperationsWaitCallInterface
+	ZoneOperationsGet                 calls.ZoneOperationsGetCallInterface
 }
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (c *Compute) Initialize(credentials string, log logger.Interface) error {
+	return c.InitializeCtx(context.Background(), credentials, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (c *Compute) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	c.log = log
+	if c.Retry == nil {
+		c.Retry = retry.DefaultPolicy()
+	}
 	c.Calls = &Calls{
-		RegionsGet:                        &calls.RegionsGetCall{},
-		InstancesAggregatedList:           &calls.InstancesAggregatedListCall{},
-		InstancesStop:                     &calls.InstancesStopCall{},
-		InstancesStart:                    &calls.InstancesStartCall{},
-		ProjectsSetCommonInstanceMetadata: &calls.ProjectsSetCommonInstanceMetadataCall{},
-		ProjectsGet:                       &calls.ProjectsGetCall{},
-		TargetPoolsList:                   &calls.TargetPoolsListCall{},
-		TargetPoolDelete:                  &calls.TargetPoolDeleteCall{},
-		BackendServicesList:               &calls.BackendServicesListCall{},
-		BackendServiceDelete:              &calls.BackendServiceDeleteCall{},
-		RegionBackendServiceDelete:        &calls.RegionBackendServiceDeleteCall{},
-		ForwardingRulesList:               &calls.ForwardingRulesListCall{},
-		ForwardingRuleDelete:              &calls.ForwardingRuleDeleteCall{},
-		HealthChecksList:                  &calls.HealthChecksListCall{},
-		HealthCheckDelete:                 &calls.HealthCheckDeleteCall{},
-		HTTPHealthChecksList:              &calls.HTTPHealthChecksListCall{},
-		HTTPHealthCheckDelete:             &calls.HTTPHealthCheckDeleteCall{},
-		DisksList:                         &calls.DisksListCall{},
-		DiskDelete:                        &calls.DiskDeleteCall{},
-		AddressesList:                     &calls.AddressesListCall{},
-		AddressDelete:                     &calls.AddressDeleteCall{},
-		FirewallsList:                     &calls.FirewallsListCall{},
-		FirewallDelete:                    &calls.FirewallDeleteCall{},
-		InstanceGroupsList:                &calls.InstanceGroupsListCall{},
-		InstanceGroupDelete:               &calls.InstanceGroupDeleteCall{},
-		NetworkGet:                        &calls.NetworkGetCall{},
-		NetworkDelete:                     &calls.NetworkDeleteCall{},
-	}
-	if credentials != "" {
-		if c.V1, err = v1.NewService(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-	} else {
-		if c.V1, err = v1.NewService(ctx); err != nil {
+		RegionsGet:                        &calls.RegionsGetCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		InstancesAggregatedList:           &calls.InstancesAggregatedListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		InstancesStop:                     &calls.InstancesStopCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		InstancesStart:                    &calls.InstancesStartCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		InstanceDelete:                    &calls.InstanceDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		ProjectsSetCommonInstanceMetadata: &calls.ProjectsSetCommonInstanceMetadataCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		ProjectsGet:                       &calls.ProjectsGetCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		TargetPoolsList:                   &calls.TargetPoolsListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		TargetPoolDelete:                  &calls.TargetPoolDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		BackendServicesList:               &calls.BackendServicesListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		BackendServiceDelete:              &calls.BackendServiceDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		RegionBackendServiceDelete:        &calls.RegionBackendServiceDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		ForwardingRulesList:               &calls.ForwardingRulesListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		ForwardingRuleDelete:              &calls.ForwardingRuleDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		HealthChecksList:                  &calls.HealthChecksListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		HealthCheckDelete:                 &calls.HealthCheckDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		HTTPHealthChecksList:              &calls.HTTPHealthChecksListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		HTTPHealthCheckDelete:             &calls.HTTPHealthCheckDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		DisksList:                         &calls.DisksListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		DiskDelete:                        &calls.DiskDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		AddressesList:                     &calls.AddressesListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		AddressDelete:                     &calls.AddressDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		FirewallsList:                     &calls.FirewallsListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		FirewallDelete:                    &calls.FirewallDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		InstanceGroupsList:                &calls.InstanceGroupsListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		InstanceGroupDelete:               &calls.InstanceGroupDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		InstanceGroupManagersList:         &calls.InstanceGroupManagersListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		InstanceGroupManagerDelete:        &calls.InstanceGroupManagerDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		RegionInstanceGroupManagerDelete:  &calls.RegionInstanceGroupManagerDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		NetworkGet:                        &calls.NetworkGetCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		NetworkDelete:                     &calls.NetworkDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		SubnetworkDelete:                  &calls.SubnetworkDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		NetworkRemovePeering:              &calls.NetworkRemovePeeringCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		RoutesList:                        &calls.RoutesListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		RouteDelete:                       &calls.RouteDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		RoutersAggregatedList:             &calls.RoutersAggregatedListCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		RouterDelete:                      &calls.RouterDeleteCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		GlobalOperationsWait:              &calls.GlobalOperationsWaitCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		GlobalOperationsGet:               &calls.GlobalOperationsGetCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		RegionOperationsWait:              &calls.RegionOperationsWaitCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		RegionOperationsGet:               &calls.RegionOperationsGetCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		ZoneOperationsWait:                &calls.ZoneOperationsWaitCall{Retry: c.Retry, Telemetry: c.Telemetry},
+		ZoneOperationsGet:                 &calls.ZoneOperationsGetCall{Retry: c.Retry, Telemetry: c.Telemetry},
+	}
+	if c.OperationPollSeconds == 0 {
+		c.OperationPollSeconds = 5
+	}
+	if c.OperationTimeoutSeconds == 0 {
+		c.OperationTimeoutSeconds = 600
+	}
+	clientOptions := append([]option.ClientOption{}, c.ClientOptions...)
+	if credentials != "" && !c.Insecure {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if c.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(c.Endpoint))
+	}
+	if c.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication())
+	}
+	if c.Transport != nil {
+		transport, err := htransport.NewTransport(ctx, c.Transport, append([]option.ClientOption{option.WithScopes(v1.CloudPlatformScope)}, clientOptions...)...)
+		if err != nil {
 			return err
 		}
+		clientOptions = append(clientOptions, option.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	if c.V1, err = v1.NewService(ctx, clientOptions...); err != nil {
+		return err
 	}
 	return nil
 }
 
 // GetRegionZones will return a list of zone names available in a region
 func (c *Compute) GetRegionZones(projectID string, region string) ([]string, error) {
-	ctx := context.Background()
+	return c.GetRegionZonesCtx(context.Background(), projectID, region)
+}
+
+// GetRegionZonesCtx is GetRegionZones, using the provided context for the underlying api calls
+func (c *Compute) GetRegionZonesCtx(ctx context.Context, projectID string, region string) (zones []string, err error) {
+	defer events.Start(c.Events, service, "GetRegionZones", events.ActionRead, projectID, region).Done(&err)
 	regionsService := v1.NewRegionsService(c.V1)
 	regionsGetCall := regionsService.Get(projectID, region).Context(ctx)
-	r, err := c.Calls.RegionsGet.Do(regionsGetCall)
+	r, err := c.Calls.RegionsGet.Do(telemetry.WithResource(ctx, projectID, region), regionsGetCall)
 	if err != nil {
 		return []string{}, err
 	}
 	return r.Zones, nil
 }
 
+// ForEachInstance will call fn for every VM instance in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachInstance(projectID string, fn func(*v1.Instance) error) error {
+	return c.ForEachInstanceCtx(context.Background(), projectID, fn)
+}
+
+// ForEachInstanceCtx is ForEachInstance, using the provided context for the underlying api calls
+func (c *Compute) ForEachInstanceCtx(ctx context.Context, projectID string, fn func(*v1.Instance) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachInstance", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachInstance(ctx, projectID, fn)
+}
+
+// forEachInstance is ForEachInstanceCtx without its event, for use within other methods
+func (c *Compute) forEachInstance(ctx context.Context, projectID string, fn func(*v1.Instance) error) error {
+	instancesService := v1.NewInstancesService(c.V1)
+	pageToken := ""
+	for {
+		listCall := instancesService.AggregatedList(projectID).Context(ctx).MaxResults(1000)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.InstancesAggregatedList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, items := range result.Items {
+			for _, instance := range items.Instances {
+				if err := fn(instance); err != nil {
+					return err
+				}
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
+	}
+}
+
 // GetInternalIPs will return a list of InstanceIP objects, which includes the name and internal
 // IP for the VMName on the network interface attached to the specified network name
 func (c *Compute) GetInternalIPs(projectID string, network string) ([]*InstanceIP, error) {
-	ctx := context.Background()
+	return c.GetInternalIPsCtx(context.Background(), projectID, network)
+}
+
+// GetInternalIPsCtx is GetInternalIPs, using the provided context for the underlying api calls
+func (c *Compute) GetInternalIPsCtx(ctx context.Context, projectID string, network string) (ips []*InstanceIP, err error) {
+	defer events.Start(c.Events, service, "GetInternalIPs", events.ActionRead, projectID, network).Done(&err)
 	var result []*InstanceIP
-	instancesService := v1.NewInstancesService(c.V1)
-	instancesListCall := instancesService.AggregatedList(projectID).Context(ctx).MaxResults(1000)
-	instancesListResult, err := c.Calls.InstancesAggregatedList.Do(instancesListCall)
-	if err != nil {
-		return result, err
-	}
-	for _, items := range instancesListResult.Items {
-		for _, instance := range items.Instances {
-			ip := ""
-			for _, networkInterface := range instance.NetworkInterfaces {
-				if strings.Contains(networkInterface.Network, fmt.Sprintf("projects/%s/global/networks/%s", projectID, network)) {
-					ip = networkInterface.NetworkIP
-				}
-			}
-			if ip != "" {
-				result = append(result, &InstanceIP{
-					VMName: instance.Name,
-					IP:     ip,
-				})
+	err = c.forEachInstance(ctx, projectID, func(instance *v1.Instance) error {
+		ip := ""
+		for _, networkInterface := range instance.NetworkInterfaces {
+			if strings.Contains(networkInterface.Network, fmt.Sprintf("projects/%s/global/networks/%s", projectID, network)) {
+				ip = networkInterface.NetworkIP
 			}
 		}
-	}
-	return result, nil
+		if ip != "" {
+			result = append(result, &InstanceIP{
+				VMName: instance.Name,
+				IP:     ip,
+			})
+		}
+		return nil
+	})
+	return result, err
 }
 
 // PowerOff will shut down all instances
-func (c Compute) PowerOff(projectID string) error {
-	ctx := context.Background()
+func (c Compute) PowerOff(projectID string, opts ...MutateOption) error {
+	return c.PowerOffCtx(context.Background(), projectID, opts...)
+}
+
+// PowerOffCtx is PowerOff, using the provided context for the underlying api calls
+func (c Compute) PowerOffCtx(ctx context.Context, projectID string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "PowerOff", events.ActionUpdate, projectID, "").Done(&err)
 	instancesService := v1.NewInstancesService(c.V1)
-	instancesListCall := instancesService.AggregatedList(projectID).Context(ctx).MaxResults(1000)
-	instancesListResult, err := c.Calls.InstancesAggregatedList.Do(instancesListCall)
-	if err != nil {
-		return err
-	}
+	var operations []*v1.Operation
 	// Go through the instances and stop them
-	for _, items := range instancesListResult.Items {
-		for _, instance := range items.Instances {
-			zone := urlZone(instance.Zone)
-			instancesStopCall := instancesService.Stop(projectID, zone, instance.Name).Context(ctx)
-			_, err = c.Calls.InstancesStop.Do(instancesStopCall)
-			if err != nil {
-				return err
-			}
+	err = c.forEachInstance(ctx, projectID, func(instance *v1.Instance) error {
+		if c.DryRun.Record(plan.Change{Service: service, Method: "PowerOff", Project: projectID, Resource: instance.Name, Action: plan.ActionUpdate, Before: instance}) {
+			return nil
 		}
+		zone := urlZone(instance.Zone)
+		instancesStopCall := instancesService.Stop(projectID, zone, instance.Name).Context(ctx)
+		operation, err := c.Calls.InstancesStop.Do(telemetry.WithResource(ctx, projectID, ""), instancesStopCall)
+		operations = append(operations, operation)
+		return err
+	})
+	if err != nil {
+		return err
 	}
-	return nil
+	return c.finishOperations(ctx, projectID, operations, opts)
 }
 
 // PowerOn will start all instances in a project.
-func (c Compute) PowerOn(projectID string) error {
-	ctx := context.Background()
+func (c Compute) PowerOn(projectID string, opts ...MutateOption) error {
+	return c.PowerOnCtx(context.Background(), projectID, opts...)
+}
+
+// PowerOnCtx is PowerOn, using the provided context for the underlying api calls
+func (c Compute) PowerOnCtx(ctx context.Context, projectID string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "PowerOn", events.ActionUpdate, projectID, "").Done(&err)
 	instancesService := v1.NewInstancesService(c.V1)
-	instancesListCall := instancesService.AggregatedList(projectID).Context(ctx).MaxResults(1000)
-	instancesListResult, err := c.Calls.InstancesAggregatedList.Do(instancesListCall)
+	var operations []*v1.Operation
+	// Go through the instances and start them
+	err = c.forEachInstance(ctx, projectID, func(instance *v1.Instance) error {
+		if c.DryRun.Record(plan.Change{Service: service, Method: "PowerOn", Project: projectID, Resource: instance.Name, Action: plan.ActionUpdate, Before: instance}) {
+			return nil
+		}
+		zone := urlZone(instance.Zone)
+		instancesStartCall := instancesService.Start(projectID, zone, instance.Name).Context(ctx)
+		operation, err := c.Calls.InstancesStart.Do(telemetry.WithResource(ctx, projectID, ""), instancesStartCall)
+		operations = append(operations, operation)
+		return err
+	})
 	if err != nil {
 		return err
 	}
-	// Go through the instances and start them
-	for _, items := range instancesListResult.Items {
-		for _, instance := range items.Instances {
-			zone := urlZone(instance.Zone)
-			instancesStartCall := instancesService.Start(projectID, zone, instance.Name).Context(ctx)
-			_, err = c.Calls.InstancesStart.Do(instancesStartCall)
-			if err != nil {
-				return err
-			}
-		}
+	return c.finishOperations(ctx, projectID, operations, opts)
+}
+
+// DeleteInstance will delete a single VM instance
+func (c *Compute) DeleteInstance(projectID string, zone string, name string, opts ...MutateOption) error {
+	return c.DeleteInstanceCtx(context.Background(), projectID, zone, name, opts...)
+}
+
+// DeleteInstanceCtx is DeleteInstance, using the provided context for the underlying api calls
+func (c *Compute) DeleteInstanceCtx(ctx context.Context, projectID string, zone string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteInstance", events.ActionDelete, projectID, name).Done(&err)
+	zone = c.getResourceNameFromURL(zone)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteInstance", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
 	}
-	return nil
+	instancesService := v1.NewInstancesService(c.V1)
+	instancesDeleteCall := instancesService.Delete(projectID, zone, name).Context(ctx)
+	operation, err := c.Calls.InstanceDelete.Do(telemetry.WithResource(ctx, projectID, name), instancesDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
 // SetCommonInstanceMetadata will set project-level metadata to be used by any compute instance
-func (c *Compute) SetCommonInstanceMetadata(projectID string, metadataItems []*v1.MetadataItems) error {
-	ctx := context.Background()
+func (c *Compute) SetCommonInstanceMetadata(projectID string, metadataItems []*v1.MetadataItems, opts ...MutateOption) error {
+	return c.SetCommonInstanceMetadataCtx(context.Background(), projectID, metadataItems, opts...)
+}
+
+// SetCommonInstanceMetadataCtx is SetCommonInstanceMetadata, using the provided context for the underlying api calls
+func (c *Compute) SetCommonInstanceMetadataCtx(ctx context.Context, projectID string, metadataItems []*v1.MetadataItems, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "SetCommonInstanceMetadata", events.ActionUpdate, projectID, "").Done(&err)
 	projectsService := v1.NewProjectsService(c.V1)
 	metadata := &v1.Metadata{
 		Items: metadataItems,
 	}
+	if c.DryRun != nil {
+		existing, err := c.getCommonInstanceMetadata(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		c.DryRun.Record(plan.Change{Service: service, Method: "SetCommonInstanceMetadata", Project: projectID, Action: plan.ActionUpdate, Before: existing, After: metadataItems})
+		return nil
+	}
 	setCommonInstanceMetadataCall := projectsService.SetCommonInstanceMetadata(projectID, metadata).Context(ctx)
-	_, err := c.Calls.ProjectsSetCommonInstanceMetadata.Do(setCommonInstanceMetadataCall)
-	return err
+	operation, err := c.Calls.ProjectsSetCommonInstanceMetadata.Do(telemetry.WithResource(ctx, projectID, ""), setCommonInstanceMetadataCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
 // GetCommonInstanceMetadata will get project-level compute metadata
 func (c *Compute) GetCommonInstanceMetadata(projectID string) ([]*v1.MetadataItems, error) {
-	ctx := context.Background()
+	return c.GetCommonInstanceMetadataCtx(context.Background(), projectID)
+}
+
+// GetCommonInstanceMetadataCtx is GetCommonInstanceMetadata, using the provided context for the underlying api calls
+func (c *Compute) GetCommonInstanceMetadataCtx(ctx context.Context, projectID string) (items []*v1.MetadataItems, err error) {
+	defer events.Start(c.Events, service, "GetCommonInstanceMetadata", events.ActionRead, projectID, "").Done(&err)
+	return c.getCommonInstanceMetadata(ctx, projectID)
+}
+
+// getCommonInstanceMetadata is GetCommonInstanceMetadataCtx without its event, for use within other methods
+func (c *Compute) getCommonInstanceMetadata(ctx context.Context, projectID string) ([]*v1.MetadataItems, error) {
 	projectsService := v1.NewProjectsService(c.V1)
 	getProjectCall := projectsService.Get(projectID).Context(ctx)
-	project, err := c.Calls.ProjectsGet.Do(getProjectCall)
+	project, err := c.Calls.ProjectsGet.Do(telemetry.WithResource(ctx, projectID, ""), getProjectCall)
 	if err != nil {
 		return []*v1.MetadataItems{}, err
 	}
 	return project.CommonInstanceMetadata.Items, nil
 }
 
+// ForEachTargetPool will call fn for every one of the target pools in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachTargetPool(projectID string, fn func(*v1.TargetPool) error) error {
+	return c.ForEachTargetPoolCtx(context.Background(), projectID, fn)
+}
+
+// ForEachTargetPoolCtx is ForEachTargetPool, using the provided context for the underlying api calls
+func (c *Compute) ForEachTargetPoolCtx(ctx context.Context, projectID string, fn func(*v1.TargetPool) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachTargetPool", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachTargetPool(ctx, projectID, fn)
+}
+
+// forEachTargetPool is ForEachTargetPoolCtx without its event, for use within other methods
+func (c *Compute) forEachTargetPool(ctx context.Context, projectID string, fn func(*v1.TargetPool) error) error {
+	targetPoolsService := v1.NewTargetPoolsService(c.V1)
+	pageToken := ""
+	for {
+		listCall := targetPoolsService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.TargetPoolsList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, pool := range item.TargetPools {
+				if err := fn(pool); err != nil {
+					return err
+				}
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
+	}
+}
+
 // GetTargetPools will return a list of all target pools/load balancer using target pools
 func (c *Compute) GetTargetPools(projectID string) ([]*v1.TargetPool, error) {
+	return c.GetTargetPoolsCtx(context.Background(), projectID)
+}
+
+// GetTargetPoolsCtx is GetTargetPools, using the provided context for the underlying api calls
+func (c *Compute) GetTargetPoolsCtx(ctx context.Context, projectID string) (targetPools []*v1.TargetPool, err error) {
+	defer events.Start(c.Events, service, "GetTargetPools", events.ActionRead, projectID, "").Done(&err)
 	var list []*v1.TargetPool
-	var err error
-	ctx := context.Background()
-	targetPoolsService := v1.NewTargetPoolsService(c.V1)
-	targetPoolsListCall := targetPoolsService.AggregatedList(projectID).Context(ctx)
-	result, err := c.Calls.TargetPoolsList.Do(targetPoolsListCall)
-	if err != nil {
-		return list, err
-	}
-	for _, item := range result.Items {
-		for _, pool := range item.TargetPools {
-			list = append(list, pool)
+	err = c.forEachTargetPool(ctx, projectID, func(pool *v1.TargetPool) error {
+		list = append(list, pool)
+		return nil
+	})
+	return list, err
+}
+
+// ForEachBackendService will call fn for every one of the load balancer backend services in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachBackendService(projectID string, fn func(*v1.BackendService) error) error {
+	return c.ForEachBackendServiceCtx(context.Background(), projectID, fn)
+}
+
+// ForEachBackendServiceCtx is ForEachBackendService, using the provided context for the underlying api calls
+func (c *Compute) ForEachBackendServiceCtx(ctx context.Context, projectID string, fn func(*v1.BackendService) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachBackendService", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachBackendService(ctx, projectID, fn)
+}
+
+// forEachBackendService is ForEachBackendServiceCtx without its event, for use within other methods
+func (c *Compute) forEachBackendService(ctx context.Context, projectID string, fn func(*v1.BackendService) error) error {
+	backendServicesService := v1.NewBackendServicesService(c.V1)
+	pageToken := ""
+	for {
+		listCall := backendServicesService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
 		}
+		result, err := c.Calls.BackendServicesList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, backendService := range item.BackendServices {
+				if err := fn(backendService); err != nil {
+					return err
+				}
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
 	}
-	return list, nil
 }
 
 // GetBackendServices will return a list of all load balancer backend services
 func (c *Compute) GetBackendServices(projectID string) ([]*v1.BackendService, error) {
+	return c.GetBackendServicesCtx(context.Background(), projectID)
+}
+
+// GetBackendServicesCtx is GetBackendServices, using the provided context for the underlying api calls
+func (c *Compute) GetBackendServicesCtx(ctx context.Context, projectID string) (backendServices []*v1.BackendService, err error) {
+	defer events.Start(c.Events, service, "GetBackendServices", events.ActionRead, projectID, "").Done(&err)
 	var list []*v1.BackendService
-	var err error
-	ctx := context.Background()
-	backendServicesService := v1.NewBackendServicesService(c.V1)
-	backendServicesListCall := backendServicesService.AggregatedList(projectID).Context(ctx)
-	result, err := c.Calls.BackendServicesList.Do(backendServicesListCall)
+	err = c.forEachBackendService(ctx, projectID, func(backendService *v1.BackendService) error {
+		list = append(list, backendService)
+		return nil
+	})
+	return list, err
+}
+
+// DeleteTargetPool will delete a single load balancer/target pool
+func (c *Compute) DeleteTargetPool(projectID string, region string, name string, opts ...MutateOption) error {
+	return c.DeleteTargetPoolCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteTargetPoolCtx is DeleteTargetPool, using the provided context for the underlying api calls
+func (c *Compute) DeleteTargetPoolCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteTargetPool", events.ActionDelete, projectID, name).Done(&err)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteTargetPool", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
+	targetPoolsService := v1.NewTargetPoolsService(c.V1)
+	targetPoolsDeleteCall := targetPoolsService.Delete(projectID, c.getResourceNameFromURL(region), name).Context(ctx)
+	operation, err := c.Calls.TargetPoolDelete.Do(telemetry.WithResource(ctx, projectID, name), targetPoolsDeleteCall)
 	if err != nil {
-		return list, err
+		return err
 	}
-	for _, item := range result.Items {
-		for _, backendService := range item.BackendServices {
-			list = append(list, backendService)
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// ForEachForwardingRule will call fn for every one of the LB forwarding rules in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachForwardingRule(projectID string, fn func(*v1.ForwardingRule) error) error {
+	return c.ForEachForwardingRuleCtx(context.Background(), projectID, fn)
+}
+
+// ForEachForwardingRuleCtx is ForEachForwardingRule, using the provided context for the underlying api calls
+func (c *Compute) ForEachForwardingRuleCtx(ctx context.Context, projectID string, fn func(*v1.ForwardingRule) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachForwardingRule", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachForwardingRule(ctx, projectID, fn)
+}
+
+// forEachForwardingRule is ForEachForwardingRuleCtx without its event, for use within other methods
+func (c *Compute) forEachForwardingRule(ctx context.Context, projectID string, fn func(*v1.ForwardingRule) error) error {
+	forwardingRulesService := v1.NewForwardingRulesService(c.V1)
+	pageToken := ""
+	for {
+		listCall := forwardingRulesService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.ForwardingRulesList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, rule := range item.ForwardingRules {
+				if err := fn(rule); err != nil {
+					return err
+				}
+			}
 		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
 	}
-	return list, nil
 }
 
-// DeleteTargetPool will delete a single load balancer/target pool
-func (c *Compute) DeleteTargetPool(projectID string, region string, name string) error {
-	var err error
-	ctx := context.Background()
-	targetPoolsService := v1.NewTargetPoolsService(c.V1)
-	targetPoolsDeleteCall := targetPoolsService.Delete(projectID, c.getResourceNameFromURL(region), name).Context(ctx)
-	_, err = c.Calls.TargetPoolDelete.Do(targetPoolsDeleteCall)
-	return err
+// GetForwardingRules will return a list of all LB forwarding rules in a project
+func (c *Compute) GetForwardingRules(projectID string) ([]*v1.ForwardingRule, error) {
+	return c.GetForwardingRulesCtx(context.Background(), projectID)
+}
+
+// GetForwardingRulesCtx is GetForwardingRules, using the provided context for the underlying api calls
+func (c *Compute) GetForwardingRulesCtx(ctx context.Context, projectID string) (forwardingRules []*v1.ForwardingRule, err error) {
+	defer events.Start(c.Events, service, "GetForwardingRules", events.ActionRead, projectID, "").Done(&err)
+	var list []*v1.ForwardingRule
+	err = c.forEachForwardingRule(ctx, projectID, func(rule *v1.ForwardingRule) error {
+		list = append(list, rule)
+		return nil
+	})
+	return list, err
 }
 
 // DeleteForwardingRule will delete an LB forwarding rule
-func (c *Compute) DeleteForwardingRule(projectID string, region string, name string) error {
-	ctx := context.Background()
+func (c *Compute) DeleteForwardingRule(projectID string, region string, name string, opts ...MutateOption) error {
+	return c.DeleteForwardingRuleCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteForwardingRuleCtx is DeleteForwardingRule, using the provided context for the underlying api calls
+func (c *Compute) DeleteForwardingRuleCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteForwardingRule", events.ActionDelete, projectID, name).Done(&err)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteForwardingRule", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	forwardingRulesService := v1.NewForwardingRulesService(c.V1)
 	forwardingRulesDeleteCall := forwardingRulesService.Delete(projectID, c.getResourceNameFromURL(region), name).Context(ctx)
-	if _, err := c.Calls.ForwardingRuleDelete.Do(forwardingRulesDeleteCall); err != nil {
+	operation, err := c.Calls.ForwardingRuleDelete.Do(telemetry.WithResource(ctx, projectID, name), forwardingRulesDeleteCall)
+	if err != nil {
 		return err
 	}
-	return nil
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
 // DeleteBackendService will delete an LB backend service
-func (c *Compute) DeleteBackendService(projectID string, name string) error {
-	ctx := context.Background()
+func (c *Compute) DeleteBackendService(projectID string, name string, opts ...MutateOption) error {
+	return c.DeleteBackendServiceCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteBackendServiceCtx is DeleteBackendService, using the provided context for the underlying api calls
+func (c *Compute) DeleteBackendServiceCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteBackendService", events.ActionDelete, projectID, name).Done(&err)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteBackendService", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	backendServicesService := v1.NewBackendServicesService(c.V1)
 	backendServiceDeleteCall := backendServicesService.Delete(projectID, name).Context(ctx)
-	if _, err := c.Calls.BackendServiceDelete.Do(backendServiceDeleteCall); err != nil {
+	operation, err := c.Calls.BackendServiceDelete.Do(telemetry.WithResource(ctx, projectID, name), backendServiceDeleteCall)
+	if err != nil {
 		return err
 	}
-	return nil
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
 // DeleteRegionBackendService will delete an LB backend service in a region
-func (c *Compute) DeleteRegionBackendService(projectID string, region string, name string) error {
-	ctx := context.Background()
+func (c *Compute) DeleteRegionBackendService(projectID string, region string, name string, opts ...MutateOption) error {
+	return c.DeleteRegionBackendServiceCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteRegionBackendServiceCtx is DeleteRegionBackendService, using the provided context for the underlying api calls
+func (c *Compute) DeleteRegionBackendServiceCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteRegionBackendService", events.ActionDelete, projectID, name).Done(&err)
 	region = c.getResourceNameFromURL(region)
 	name = c.getResourceNameFromURL(name)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteRegionBackendService", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	backendServicesService := v1.NewRegionBackendServicesService(c.V1)
 	backendServiceDeleteCall := backendServicesService.Delete(projectID, region, name).Context(ctx)
-	if _, err := c.Calls.RegionBackendServiceDelete.Do(backendServiceDeleteCall); err != nil {
+	operation, err := c.Calls.RegionBackendServiceDelete.Do(telemetry.WithResource(ctx, projectID, name), backendServiceDeleteCall)
+	if err != nil {
 		return err
 	}
-	return nil
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
-// GetHealthChecks will return a list of all health checks in a project
-func (c *Compute) GetHealthChecks(projectID string) ([]*v1.HealthCheck, error) {
-	var list []*v1.HealthCheck
-	var err error
-	ctx := context.Background()
+// ForEachHealthCheck will call fn for every one of the health checks in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachHealthCheck(projectID string, fn func(*v1.HealthCheck) error) error {
+	return c.ForEachHealthCheckCtx(context.Background(), projectID, fn)
+}
+
+// ForEachHealthCheckCtx is ForEachHealthCheck, using the provided context for the underlying api calls
+func (c *Compute) ForEachHealthCheckCtx(ctx context.Context, projectID string, fn func(*v1.HealthCheck) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachHealthCheck", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachHealthCheck(ctx, projectID, fn)
+}
+
+// forEachHealthCheck is ForEachHealthCheckCtx without its event, for use within other methods
+func (c *Compute) forEachHealthCheck(ctx context.Context, projectID string, fn func(*v1.HealthCheck) error) error {
 	healthChecksService := v1.NewHealthChecksService(c.V1)
-	healthChecksListCall := healthChecksService.AggregatedList(projectID).Context(ctx)
-	result, err := c.Calls.HealthChecksList.Do(healthChecksListCall)
-	if err != nil {
-		return list, err
-	}
-	for _, item := range result.Items {
-		for _, healthCheck := range item.HealthChecks {
-			list = append(list, healthCheck)
+	pageToken := ""
+	for {
+		listCall := healthChecksService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.HealthChecksList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, healthCheck := range item.HealthChecks {
+				if err := fn(healthCheck); err != nil {
+					return err
+				}
+			}
 		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
 	}
-	return list, nil
+}
+
+// GetHealthChecks will return a list of all health checks in a project
+func (c *Compute) GetHealthChecks(projectID string) ([]*v1.HealthCheck, error) {
+	return c.GetHealthChecksCtx(context.Background(), projectID)
+}
+
+// GetHealthChecksCtx is GetHealthChecks, using the provided context for the underlying api calls
+func (c *Compute) GetHealthChecksCtx(ctx context.Context, projectID string) (healthChecks []*v1.HealthCheck, err error) {
+	defer events.Start(c.Events, service, "GetHealthChecks", events.ActionRead, projectID, "").Done(&err)
+	var list []*v1.HealthCheck
+	err = c.forEachHealthCheck(ctx, projectID, func(healthCheck *v1.HealthCheck) error {
+		list = append(list, healthCheck)
+		return nil
+	})
+	return list, err
 }
 
 // DeleteHealthCheck will delete a compute health check
-func (c *Compute) DeleteHealthCheck(projectID string, name string) error {
-	var err error
-	ctx := context.Background()
+func (c *Compute) DeleteHealthCheck(projectID string, name string, opts ...MutateOption) error {
+	return c.DeleteHealthCheckCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteHealthCheckCtx is DeleteHealthCheck, using the provided context for the underlying api calls
+func (c *Compute) DeleteHealthCheckCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteHealthCheck", events.ActionDelete, projectID, name).Done(&err)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteHealthCheck", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	healthChecksService := v1.NewHealthChecksService(c.V1)
 	healthCheckDeleteCall := healthChecksService.Delete(projectID, c.getResourceNameFromURL(name)).Context(ctx)
-	if _, err = c.Calls.HealthCheckDelete.Do(healthCheckDeleteCall); err != nil {
+	operation, err := c.Calls.HealthCheckDelete.Do(telemetry.WithResource(ctx, projectID, name), healthCheckDeleteCall)
+	if err != nil {
 		return err
 	}
-	return nil
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// ForEachHTTPHealthCheck will call fn for every one of the http (legacy) health checks in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachHTTPHealthCheck(projectID string, fn func(*v1.HttpHealthCheck) error) error {
+	return c.ForEachHTTPHealthCheckCtx(context.Background(), projectID, fn)
+}
+
+// ForEachHTTPHealthCheckCtx is ForEachHTTPHealthCheck, using the provided context for the underlying api calls
+func (c *Compute) ForEachHTTPHealthCheckCtx(ctx context.Context, projectID string, fn func(*v1.HttpHealthCheck) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachHTTPHealthCheck", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachHTTPHealthCheck(ctx, projectID, fn)
+}
+
+// forEachHTTPHealthCheck is ForEachHTTPHealthCheckCtx without its event, for use within other methods
+func (c *Compute) forEachHTTPHealthCheck(ctx context.Context, projectID string, fn func(*v1.HttpHealthCheck) error) error {
+	httpHealthChecksService := v1.NewHttpHealthChecksService(c.V1)
+	pageToken := ""
+	for {
+		listCall := httpHealthChecksService.List(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.HTTPHealthChecksList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, healthCheck := range result.Items {
+			if err := fn(healthCheck); err != nil {
+				return err
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
+	}
 }
 
 // GetHTTPHealthChecks will return a list of all http (legacy) health checks in a project
 func (c *Compute) GetHTTPHealthChecks(projectID string) ([]*v1.HttpHealthCheck, error) {
+	return c.GetHTTPHealthChecksCtx(context.Background(), projectID)
+}
+
+// GetHTTPHealthChecksCtx is GetHTTPHealthChecks, using the provided context for the underlying api calls
+func (c *Compute) GetHTTPHealthChecksCtx(ctx context.Context, projectID string) (hTTPHealthChecks []*v1.HttpHealthCheck, err error) {
+	defer events.Start(c.Events, service, "GetHTTPHealthChecks", events.ActionRead, projectID, "").Done(&err)
 	var list []*v1.HttpHealthCheck
-	var err error
-	ctx := context.Background()
-	healthChecksService := v1.NewHttpHealthChecksService(c.V1)
-	healthChecksListCall := healthChecksService.List(projectID).Context(ctx)
-	result, err := c.Calls.HTTPHealthChecksList.Do(healthChecksListCall)
-	if err != nil {
-		return list, err
-	}
-	for _, item := range result.Items {
-		list = append(list, item)
-	}
-	return list, nil
+	err = c.forEachHTTPHealthCheck(ctx, projectID, func(healthCheck *v1.HttpHealthCheck) error {
+		list = append(list, healthCheck)
+		return nil
+	})
+	return list, err
 }
 
 // DeleteHTTPHealthCheck will delete a compute http (legacy) health check
-func (c *Compute) DeleteHTTPHealthCheck(projectID string, name string) error {
-	var err error
-	ctx := context.Background()
+func (c *Compute) DeleteHTTPHealthCheck(projectID string, name string, opts ...MutateOption) error {
+	return c.DeleteHTTPHealthCheckCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteHTTPHealthCheckCtx is DeleteHTTPHealthCheck, using the provided context for the underlying api calls
+func (c *Compute) DeleteHTTPHealthCheckCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteHTTPHealthCheck", events.ActionDelete, projectID, name).Done(&err)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteHTTPHealthCheck", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	healthChecksService := v1.NewHttpHealthChecksService(c.V1)
 	healthCheckDeleteCall := healthChecksService.Delete(projectID, c.getResourceNameFromURL(name)).Context(ctx)
-	if _, err = c.Calls.HTTPHealthCheckDelete.Do(healthCheckDeleteCall); err != nil {
+	operation, err := c.Calls.HTTPHealthCheckDelete.Do(telemetry.WithResource(ctx, projectID, name), healthCheckDeleteCall)
+	if err != nil {
 		return err
 	}
-	return nil
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
-// GetDisks will return a list of all disks
-func (c *Compute) GetDisks(projectID string) ([]*v1.Disk, error) {
-	var list []*v1.Disk
-	ctx := context.Background()
+// ForEachDisk will call fn for every one of the disks in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachDisk(projectID string, fn func(*v1.Disk) error) error {
+	return c.ForEachDiskCtx(context.Background(), projectID, fn)
+}
+
+// ForEachDiskCtx is ForEachDisk, using the provided context for the underlying api calls
+func (c *Compute) ForEachDiskCtx(ctx context.Context, projectID string, fn func(*v1.Disk) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachDisk", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachDisk(ctx, projectID, fn)
+}
+
+// forEachDisk is ForEachDiskCtx without its event, for use within other methods
+func (c *Compute) forEachDisk(ctx context.Context, projectID string, fn func(*v1.Disk) error) error {
 	disksService := v1.NewDisksService(c.V1)
-	disksListCall := disksService.AggregatedList(projectID).Context(ctx)
-	result, err := c.Calls.DisksList.Do(disksListCall)
-	if err != nil {
-		return list, err
-	}
-	for _, item := range result.Items {
-		for _, pool := range item.Disks {
-			list = append(list, pool)
+	pageToken := ""
+	for {
+		listCall := disksService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.DisksList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, disk := range item.Disks {
+				if err := fn(disk); err != nil {
+					return err
+				}
+			}
 		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
 	}
-	return list, nil
+}
+
+// GetDisks will return a list of all disks
+func (c *Compute) GetDisks(projectID string) ([]*v1.Disk, error) {
+	return c.GetDisksCtx(context.Background(), projectID)
+}
+
+// GetDisksCtx is GetDisks, using the provided context for the underlying api calls
+func (c *Compute) GetDisksCtx(ctx context.Context, projectID string) (disks []*v1.Disk, err error) {
+	defer events.Start(c.Events, service, "GetDisks", events.ActionRead, projectID, "").Done(&err)
+	var list []*v1.Disk
+	err = c.forEachDisk(ctx, projectID, func(disk *v1.Disk) error {
+		list = append(list, disk)
+		return nil
+	})
+	return list, err
 }
 
 // DeleteDisk will delete a single disk
-func (c *Compute) DeleteDisk(projectID string, zone string, name string) error {
-	ctx := context.Background()
+func (c *Compute) DeleteDisk(projectID string, zone string, name string, opts ...MutateOption) error {
+	return c.DeleteDiskCtx(context.Background(), projectID, zone, name, opts...)
+}
+
+// DeleteDiskCtx is DeleteDisk, using the provided context for the underlying api calls
+func (c *Compute) DeleteDiskCtx(ctx context.Context, projectID string, zone string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteDisk", events.ActionDelete, projectID, name).Done(&err)
 	zone = c.getResourceNameFromURL(zone)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteDisk", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	disksService := v1.NewDisksService(c.V1)
 	disksDeleteCall := disksService.Delete(projectID, zone, name).Context(ctx)
-	_, err := c.Calls.DiskDelete.Do(disksDeleteCall)
-	return err
+	operation, err := c.Calls.DiskDelete.Do(telemetry.WithResource(ctx, projectID, name), disksDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
-// GetAddresses will return a list of all compute addresses
-func (c *Compute) GetAddresses(projectID string) ([]*v1.Address, error) {
-	var list []*v1.Address
-	ctx := context.Background()
+// ForEachAddress will call fn for every one of the compute addresses in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachAddress(projectID string, fn func(*v1.Address) error) error {
+	return c.ForEachAddressCtx(context.Background(), projectID, fn)
+}
+
+// ForEachAddressCtx is ForEachAddress, using the provided context for the underlying api calls
+func (c *Compute) ForEachAddressCtx(ctx context.Context, projectID string, fn func(*v1.Address) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachAddress", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachAddress(ctx, projectID, fn)
+}
+
+// forEachAddress is ForEachAddressCtx without its event, for use within other methods
+func (c *Compute) forEachAddress(ctx context.Context, projectID string, fn func(*v1.Address) error) error {
 	addressesService := v1.NewAddressesService(c.V1)
-	addressesListCall := addressesService.AggregatedList(projectID).Context(ctx)
-	result, err := c.Calls.AddressesList.Do(addressesListCall)
-	if err != nil {
-		return list, err
-	}
-	for _, item := range result.Items {
-		for _, address := range item.Addresses {
-			list = append(list, address)
+	pageToken := ""
+	for {
+		listCall := addressesService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.AddressesList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, address := range item.Addresses {
+				if err := fn(address); err != nil {
+					return err
+				}
+			}
 		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
 	}
-	return list, nil
+}
+
+// GetAddresses will return a list of all compute addresses
+func (c *Compute) GetAddresses(projectID string) ([]*v1.Address, error) {
+	return c.GetAddressesCtx(context.Background(), projectID)
+}
+
+// GetAddressesCtx is GetAddresses, using the provided context for the underlying api calls
+func (c *Compute) GetAddressesCtx(ctx context.Context, projectID string) (addresses []*v1.Address, err error) {
+	defer events.Start(c.Events, service, "GetAddresses", events.ActionRead, projectID, "").Done(&err)
+	var list []*v1.Address
+	err = c.forEachAddress(ctx, projectID, func(address *v1.Address) error {
+		list = append(list, address)
+		return nil
+	})
+	return list, err
 }
 
 // DeleteAddress will delete a single disk
-func (c *Compute) DeleteAddress(projectID string, region string, name string) error {
-	ctx := context.Background()
+func (c *Compute) DeleteAddress(projectID string, region string, name string, opts ...MutateOption) error {
+	return c.DeleteAddressCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteAddressCtx is DeleteAddress, using the provided context for the underlying api calls
+func (c *Compute) DeleteAddressCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteAddress", events.ActionDelete, projectID, name).Done(&err)
 	region = c.getResourceNameFromURL(region)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteAddress", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	addressesService := v1.NewAddressesService(c.V1)
 	addressesDeleteCall := addressesService.Delete(projectID, region, name).Context(ctx)
-	_, err := c.Calls.AddressDelete.Do(addressesDeleteCall)
-	return err
+	operation, err := c.Calls.AddressDelete.Do(telemetry.WithResource(ctx, projectID, name), addressesDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// ForEachFirewall will call fn for every one of the compute firewall rules in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachFirewall(projectID string, fn func(*v1.Firewall) error) error {
+	return c.ForEachFirewallCtx(context.Background(), projectID, fn)
+}
+
+// ForEachFirewallCtx is ForEachFirewall, using the provided context for the underlying api calls
+func (c *Compute) ForEachFirewallCtx(ctx context.Context, projectID string, fn func(*v1.Firewall) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachFirewall", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachFirewall(ctx, projectID, fn)
+}
+
+// forEachFirewall is ForEachFirewallCtx without its event, for use within other methods
+func (c *Compute) forEachFirewall(ctx context.Context, projectID string, fn func(*v1.Firewall) error) error {
+	firewallsService := v1.NewFirewallsService(c.V1)
+	pageToken := ""
+	for {
+		listCall := firewallsService.List(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.FirewallsList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, firewall := range result.Items {
+			if err := fn(firewall); err != nil {
+				return err
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
+	}
 }
 
 // GetFirewalls will return a list of all compute firewall rules
 func (c *Compute) GetFirewalls(projectID string) ([]*v1.Firewall, error) {
+	return c.GetFirewallsCtx(context.Background(), projectID)
+}
+
+// GetFirewallsCtx is GetFirewalls, using the provided context for the underlying api calls
+func (c *Compute) GetFirewallsCtx(ctx context.Context, projectID string) (firewalls []*v1.Firewall, err error) {
+	defer events.Start(c.Events, service, "GetFirewalls", events.ActionRead, projectID, "").Done(&err)
 	var list []*v1.Firewall
-	ctx := context.Background()
-	firewallsService := v1.NewFirewallsService(c.V1)
-	firewallsListCall := firewallsService.List(projectID).Context(ctx)
-	result, err := c.Calls.FirewallsList.Do(firewallsListCall)
-	if err != nil {
-		return list, err
-	}
-	for _, item := range result.Items {
-		list = append(list, item)
-	}
-	return list, nil
+	err = c.forEachFirewall(ctx, projectID, func(firewall *v1.Firewall) error {
+		list = append(list, firewall)
+		return nil
+	})
+	return list, err
 }
 
 // DeleteFirewall will delete a single firewall
-func (c *Compute) DeleteFirewall(projectID string, name string) error {
-	ctx := context.Background()
+func (c *Compute) DeleteFirewall(projectID string, name string, opts ...MutateOption) error {
+	return c.DeleteFirewallCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteFirewallCtx is DeleteFirewall, using the provided context for the underlying api calls
+func (c *Compute) DeleteFirewallCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteFirewall", events.ActionDelete, projectID, name).Done(&err)
 	name = c.getResourceNameFromURL(name)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteFirewall", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	firewallsService := v1.NewFirewallsService(c.V1)
 	firewallsDeleteCall := firewallsService.Delete(projectID, name).Context(ctx)
-	_, err := c.Calls.FirewallDelete.Do(firewallsDeleteCall)
-	return err
+	operation, err := c.Calls.FirewallDelete.Do(telemetry.WithResource(ctx, projectID, name), firewallsDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// ForEachInstanceGroup will call fn for every one of the compute instance groups in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachInstanceGroup(projectID string, fn func(*v1.InstanceGroup) error) error {
+	return c.ForEachInstanceGroupCtx(context.Background(), projectID, fn)
+}
+
+// ForEachInstanceGroupCtx is ForEachInstanceGroup, using the provided context for the underlying api calls
+func (c *Compute) ForEachInstanceGroupCtx(ctx context.Context, projectID string, fn func(*v1.InstanceGroup) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachInstanceGroup", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachInstanceGroup(ctx, projectID, fn)
+}
+
+// forEachInstanceGroup is ForEachInstanceGroupCtx without its event, for use within other methods
+func (c *Compute) forEachInstanceGroup(ctx context.Context, projectID string, fn func(*v1.InstanceGroup) error) error {
+	instanceGroupsService := v1.NewInstanceGroupsService(c.V1)
+	pageToken := ""
+	for {
+		listCall := instanceGroupsService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.InstanceGroupsList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, instanceGroup := range item.InstanceGroups {
+				if err := fn(instanceGroup); err != nil {
+					return err
+				}
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
+	}
 }
 
 // GetInstanceGroups will return a list of all compute instance groups
 func (c *Compute) GetInstanceGroups(projectID string) ([]*v1.InstanceGroup, error) {
+	return c.GetInstanceGroupsCtx(context.Background(), projectID)
+}
+
+// GetInstanceGroupsCtx is GetInstanceGroups, using the provided context for the underlying api calls
+func (c *Compute) GetInstanceGroupsCtx(ctx context.Context, projectID string) (instanceGroups []*v1.InstanceGroup, err error) {
+	defer events.Start(c.Events, service, "GetInstanceGroups", events.ActionRead, projectID, "").Done(&err)
 	var list []*v1.InstanceGroup
-	ctx := context.Background()
+	err = c.forEachInstanceGroup(ctx, projectID, func(instanceGroup *v1.InstanceGroup) error {
+		list = append(list, instanceGroup)
+		return nil
+	})
+	return list, err
+}
+
+// DeleteInstanceGroup will delete a single instance group
+func (c *Compute) DeleteInstanceGroup(projectID string, zone string, name string, opts ...MutateOption) error {
+	return c.DeleteInstanceGroupCtx(context.Background(), projectID, zone, name, opts...)
+}
+
+// DeleteInstanceGroupCtx is DeleteInstanceGroup, using the provided context for the underlying api calls
+func (c *Compute) DeleteInstanceGroupCtx(ctx context.Context, projectID string, zone string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteInstanceGroup", events.ActionDelete, projectID, name).Done(&err)
+	zone = c.getResourceNameFromURL(zone)
+	name = c.getResourceNameFromURL(name)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteInstanceGroup", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	instanceGroupsService := v1.NewInstanceGroupsService(c.V1)
-	instanceGroupsListCall := instanceGroupsService.AggregatedList(projectID).Context(ctx)
-	result, err := c.Calls.InstanceGroupsList.Do(instanceGroupsListCall)
+	instanceGroupsDeleteCall := instanceGroupsService.Delete(projectID, zone, name).Context(ctx)
+	operation, err := c.Calls.InstanceGroupDelete.Do(telemetry.WithResource(ctx, projectID, name), instanceGroupsDeleteCall)
 	if err != nil {
-		return list, err
+		return err
 	}
-	for _, item := range result.Items {
-		for _, instanceGroup := range item.InstanceGroups {
-			list = append(list, instanceGroup)
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// ForEachInstanceGroupManager will call fn for every one of the managed instance groups in a project, zonal and
+// regional, following all result pages, stopping at and returning the first error returned by fn
+func (c *Compute) ForEachInstanceGroupManager(projectID string, fn func(*v1.InstanceGroupManager) error) error {
+	return c.ForEachInstanceGroupManagerCtx(context.Background(), projectID, fn)
+}
+
+// ForEachInstanceGroupManagerCtx is ForEachInstanceGroupManager, using the provided context for the underlying api calls
+func (c *Compute) ForEachInstanceGroupManagerCtx(ctx context.Context, projectID string, fn func(*v1.InstanceGroupManager) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachInstanceGroupManager", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachInstanceGroupManager(ctx, projectID, fn)
+}
+
+// forEachInstanceGroupManager is ForEachInstanceGroupManagerCtx without its event, for use within other methods
+func (c *Compute) forEachInstanceGroupManager(ctx context.Context, projectID string, fn func(*v1.InstanceGroupManager) error) error {
+	instanceGroupManagersService := v1.NewInstanceGroupManagersService(c.V1)
+	pageToken := ""
+	for {
+		listCall := instanceGroupManagersService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
 		}
+		result, err := c.Calls.InstanceGroupManagersList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, instanceGroupManager := range item.InstanceGroupManagers {
+				if err := fn(instanceGroupManager); err != nil {
+					return err
+				}
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
 	}
-	return list, nil
 }
 
-// DeleteInstanceGroup will delete a single instance group
-func (c *Compute) DeleteInstanceGroup(projectID string, zone string, name string) error {
-	ctx := context.Background()
+// GetInstanceGroupManagers will return a list of all managed instance groups, zonal and regional
+func (c *Compute) GetInstanceGroupManagers(projectID string) ([]*v1.InstanceGroupManager, error) {
+	return c.GetInstanceGroupManagersCtx(context.Background(), projectID)
+}
+
+// GetInstanceGroupManagersCtx is GetInstanceGroupManagers, using the provided context for the underlying api calls
+func (c *Compute) GetInstanceGroupManagersCtx(ctx context.Context, projectID string) (instanceGroupManagers []*v1.InstanceGroupManager, err error) {
+	defer events.Start(c.Events, service, "GetInstanceGroupManagers", events.ActionRead, projectID, "").Done(&err)
+	var list []*v1.InstanceGroupManager
+	err = c.forEachInstanceGroupManager(ctx, projectID, func(instanceGroupManager *v1.InstanceGroupManager) error {
+		list = append(list, instanceGroupManager)
+		return nil
+	})
+	return list, err
+}
+
+// DeleteInstanceGroupManager will delete a zonal managed instance group, along with its instances
+func (c *Compute) DeleteInstanceGroupManager(projectID string, zone string, name string, opts ...MutateOption) error {
+	return c.DeleteInstanceGroupManagerCtx(context.Background(), projectID, zone, name, opts...)
+}
+
+// DeleteInstanceGroupManagerCtx is DeleteInstanceGroupManager, using the provided context for the underlying api calls
+func (c *Compute) DeleteInstanceGroupManagerCtx(ctx context.Context, projectID string, zone string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteInstanceGroupManager", events.ActionDelete, projectID, name).Done(&err)
 	zone = c.getResourceNameFromURL(zone)
 	name = c.getResourceNameFromURL(name)
-	instanceGroupsService := v1.NewInstanceGroupsService(c.V1)
-	instanceGroupsDeleteCall := instanceGroupsService.Delete(projectID, zone, name).Context(ctx)
-	_, err := c.Calls.InstanceGroupDelete.Do(instanceGroupsDeleteCall)
-	return err
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteInstanceGroupManager", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
+	instanceGroupManagersService := v1.NewInstanceGroupManagersService(c.V1)
+	instanceGroupManagerDeleteCall := instanceGroupManagersService.Delete(projectID, zone, name).Context(ctx)
+	operation, err := c.Calls.InstanceGroupManagerDelete.Do(telemetry.WithResource(ctx, projectID, name), instanceGroupManagerDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// DeleteRegionInstanceGroupManager will delete a regional managed instance group, along with its instances
+func (c *Compute) DeleteRegionInstanceGroupManager(projectID string, region string, name string, opts ...MutateOption) error {
+	return c.DeleteRegionInstanceGroupManagerCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteRegionInstanceGroupManagerCtx is DeleteRegionInstanceGroupManager, using the provided context for the underlying
+// api calls
+func (c *Compute) DeleteRegionInstanceGroupManagerCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteRegionInstanceGroupManager", events.ActionDelete, projectID, name).Done(&err)
+	region = c.getResourceNameFromURL(region)
+	name = c.getResourceNameFromURL(name)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteRegionInstanceGroupManager", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
+	instanceGroupManagersService := v1.NewRegionInstanceGroupManagersService(c.V1)
+	instanceGroupManagerDeleteCall := instanceGroupManagersService.Delete(projectID, region, name).Context(ctx)
+	operation, err := c.Calls.RegionInstanceGroupManagerDelete.Do(telemetry.WithResource(ctx, projectID, name), instanceGroupManagerDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
 // GetNetwork will retrieve an existing network in a project
 func (c *Compute) GetNetwork(projectID string, name string) (*v1.Network, error) {
-	ctx := context.Background()
+	return c.GetNetworkCtx(context.Background(), projectID, name)
+}
+
+// GetNetworkCtx is GetNetwork, using the provided context for the underlying api calls
+func (c *Compute) GetNetworkCtx(ctx context.Context, projectID string, name string) (network *v1.Network, err error) {
+	defer events.Start(c.Events, service, "GetNetwork", events.ActionRead, projectID, name).Done(&err)
+	return c.getNetwork(ctx, projectID, name)
+}
+
+// getNetwork is GetNetworkCtx without its event, for use within other methods
+func (c *Compute) getNetwork(ctx context.Context, projectID string, name string) (*v1.Network, error) {
 	networksService := v1.NewNetworksService(c.V1)
 	networkGetCall := networksService.Get(projectID, name).Context(ctx)
-	return c.Calls.NetworkGet.Do(networkGetCall)
+	return c.Calls.NetworkGet.Do(telemetry.WithResource(ctx, projectID, name), networkGetCall)
+}
+
+// DeleteSubnetwork will delete a subnetwork in a region
+func (c *Compute) DeleteSubnetwork(projectID string, region string, name string, opts ...MutateOption) error {
+	return c.DeleteSubnetworkCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteSubnetworkCtx is DeleteSubnetwork, using the provided context for the underlying api calls
+func (c *Compute) DeleteSubnetworkCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteSubnetwork", events.ActionDelete, projectID, name).Done(&err)
+	region = c.getResourceNameFromURL(region)
+	name = c.getResourceNameFromURL(name)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteSubnetwork", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
+	subnetworksService := v1.NewSubnetworksService(c.V1)
+	subnetworkDeleteCall := subnetworksService.Delete(projectID, region, name).Context(ctx)
+	operation, err := c.Calls.SubnetworkDelete.Do(telemetry.WithResource(ctx, projectID, name), subnetworkDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
 // DeleteNetwork will delete a network in a project
-func (c *Compute) DeleteNetwork(projectID string, name string) error {
-	ctx := context.Background()
+func (c *Compute) DeleteNetwork(projectID string, name string, opts ...MutateOption) error {
+	return c.DeleteNetworkCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteNetworkCtx is DeleteNetwork, using the provided context for the underlying api calls
+func (c *Compute) DeleteNetworkCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteNetwork", events.ActionDelete, projectID, name).Done(&err)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteNetwork", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
 	networksService := v1.NewNetworksService(c.V1)
 	networkDeleteCall := networksService.Delete(projectID, name).Context(ctx)
-	_, err := c.Calls.NetworkDelete.Do(networkDeleteCall)
-	return err
+	operation, err := c.Calls.NetworkDelete.Do(telemetry.WithResource(ctx, projectID, name), networkDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// RemoveNetworkPeering will remove a peering, by its name, from a network
+func (c *Compute) RemoveNetworkPeering(projectID string, network string, name string, opts ...MutateOption) error {
+	return c.RemoveNetworkPeeringCtx(context.Background(), projectID, network, name, opts...)
+}
+
+// RemoveNetworkPeeringCtx is RemoveNetworkPeering, using the provided context for the underlying api calls
+func (c *Compute) RemoveNetworkPeeringCtx(ctx context.Context, projectID string, network string, name string, opts ...MutateOption) (err error) {
+	network = c.getResourceNameFromURL(network)
+	defer events.Start(c.Events, service, "RemoveNetworkPeering", events.ActionUpdate, projectID, network).Done(&err)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "RemoveNetworkPeering", Project: projectID, Resource: fmt.Sprintf("%s/%s", network, name), Action: plan.ActionUpdate}) {
+		return nil
+	}
+	networksService := v1.NewNetworksService(c.V1)
+	removePeeringCall := networksService.RemovePeering(projectID, network, &v1.NetworksRemovePeeringRequest{Name: name}).Context(ctx)
+	operation, err := c.Calls.NetworkRemovePeering.Do(telemetry.WithResource(ctx, projectID, network), removePeeringCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// ForEachRoute will call fn for every one of the compute routes in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachRoute(projectID string, fn func(*v1.Route) error) error {
+	return c.ForEachRouteCtx(context.Background(), projectID, fn)
+}
+
+// ForEachRouteCtx is ForEachRoute, using the provided context for the underlying api calls
+func (c *Compute) ForEachRouteCtx(ctx context.Context, projectID string, fn func(*v1.Route) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachRoute", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachRoute(ctx, projectID, fn)
+}
+
+// forEachRoute is ForEachRouteCtx without its event, for use within other methods
+func (c *Compute) forEachRoute(ctx context.Context, projectID string, fn func(*v1.Route) error) error {
+	routesService := v1.NewRoutesService(c.V1)
+	pageToken := ""
+	for {
+		listCall := routesService.List(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.RoutesList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, route := range result.Items {
+			if err := fn(route); err != nil {
+				return err
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
+	}
+}
+
+// GetRoutes will return a list of all compute routes
+func (c *Compute) GetRoutes(projectID string) ([]*v1.Route, error) {
+	return c.GetRoutesCtx(context.Background(), projectID)
+}
+
+// GetRoutesCtx is GetRoutes, using the provided context for the underlying api calls
+func (c *Compute) GetRoutesCtx(ctx context.Context, projectID string) (routes []*v1.Route, err error) {
+	defer events.Start(c.Events, service, "GetRoutes", events.ActionRead, projectID, "").Done(&err)
+	var list []*v1.Route
+	err = c.forEachRoute(ctx, projectID, func(route *v1.Route) error {
+		list = append(list, route)
+		return nil
+	})
+	return list, err
+}
+
+// DeleteRoute will delete a single route
+func (c *Compute) DeleteRoute(projectID string, name string, opts ...MutateOption) error {
+	return c.DeleteRouteCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteRouteCtx is DeleteRoute, using the provided context for the underlying api calls
+func (c *Compute) DeleteRouteCtx(ctx context.Context, projectID string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteRoute", events.ActionDelete, projectID, name).Done(&err)
+	name = c.getResourceNameFromURL(name)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteRoute", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
+	routesService := v1.NewRoutesService(c.V1)
+	routeDeleteCall := routesService.Delete(projectID, name).Context(ctx)
+	operation, err := c.Calls.RouteDelete.Do(telemetry.WithResource(ctx, projectID, name), routeDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
+}
+
+// ForEachRouter will call fn for every one of the compute routers in a project, following all result pages,
+// stopping at and returning the first error returned by fn
+func (c *Compute) ForEachRouter(projectID string, fn func(*v1.Router) error) error {
+	return c.ForEachRouterCtx(context.Background(), projectID, fn)
+}
+
+// ForEachRouterCtx is ForEachRouter, using the provided context for the underlying api calls
+func (c *Compute) ForEachRouterCtx(ctx context.Context, projectID string, fn func(*v1.Router) error) (err error) {
+	defer events.Start(c.Events, service, "ForEachRouter", events.ActionRead, projectID, "").Done(&err)
+	return c.forEachRouter(ctx, projectID, fn)
+}
+
+// forEachRouter is ForEachRouterCtx without its event, for use within other methods
+func (c *Compute) forEachRouter(ctx context.Context, projectID string, fn func(*v1.Router) error) error {
+	routersService := v1.NewRoutersService(c.V1)
+	pageToken := ""
+	for {
+		listCall := routersService.AggregatedList(projectID).Context(ctx)
+		if pageToken != "" {
+			listCall = listCall.PageToken(pageToken)
+		}
+		result, err := c.Calls.RoutersAggregatedList.Do(telemetry.WithResource(ctx, projectID, ""), listCall)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			for _, router := range item.Routers {
+				if err := fn(router); err != nil {
+					return err
+				}
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
+	}
+}
+
+// GetRouters will return a list of all compute routers
+func (c *Compute) GetRouters(projectID string) ([]*v1.Router, error) {
+	return c.GetRoutersCtx(context.Background(), projectID)
+}
+
+// GetRoutersCtx is GetRouters, using the provided context for the underlying api calls
+func (c *Compute) GetRoutersCtx(ctx context.Context, projectID string) (routers []*v1.Router, err error) {
+	defer events.Start(c.Events, service, "GetRouters", events.ActionRead, projectID, "").Done(&err)
+	var list []*v1.Router
+	err = c.forEachRouter(ctx, projectID, func(router *v1.Router) error {
+		list = append(list, router)
+		return nil
+	})
+	return list, err
+}
+
+// DeleteRouter will delete a single router, along with any cloud nat configured on it
+func (c *Compute) DeleteRouter(projectID string, region string, name string, opts ...MutateOption) error {
+	return c.DeleteRouterCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteRouterCtx is DeleteRouter, using the provided context for the underlying api calls
+func (c *Compute) DeleteRouterCtx(ctx context.Context, projectID string, region string, name string, opts ...MutateOption) (err error) {
+	defer events.Start(c.Events, service, "DeleteRouter", events.ActionDelete, projectID, name).Done(&err)
+	region = c.getResourceNameFromURL(region)
+	if c.DryRun.Record(plan.Change{Service: service, Method: "DeleteRouter", Project: projectID, Resource: name, Action: plan.ActionDelete}) {
+		return nil
+	}
+	routersService := v1.NewRoutersService(c.V1)
+	routerDeleteCall := routersService.Delete(projectID, region, name).Context(ctx)
+	operation, err := c.Calls.RouterDelete.Do(telemetry.WithResource(ctx, projectID, name), routerDeleteCall)
+	if err != nil {
+		return err
+	}
+	return c.finishOperations(ctx, projectID, []*v1.Operation{operation}, opts)
 }
 
 func (c *Compute) getResourceNameFromURL(url string) string {
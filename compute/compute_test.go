@@ -1,8 +1,10 @@
 package compute
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
@@ -46,11 +48,14 @@ type firewallsListMock struct{}
 type firewallDeleteMock struct{}
 type instanceGroupsListMock struct{}
 type instanceGroupDeleteMock struct{}
+type instanceGroupManagersListMock struct{}
+type instanceGroupManagerDeleteMock struct{}
+type regionInstanceGroupManagerDeleteMock struct{}
 type networkGetMock struct{}
 type networkDeleteMock struct{}
 
 // Do is the mock for default regionsGetMock
-func (c *regionsGetMock) Do(call *v1.RegionsGetCall, opts ...googleapi.CallOption) (*v1.Region, error) {
+func (c *regionsGetMock) Do(ctx context.Context, call *v1.RegionsGetCall, opts ...googleapi.CallOption) (*v1.Region, error) {
 	if triggerRegionNotFound {
 		triggerRegionNotFound = false
 		return &v1.Region{}, errors.New("NotFound")
@@ -66,7 +71,7 @@ func (c *regionsGetMock) Do(call *v1.RegionsGetCall, opts ...googleapi.CallOptio
 }
 
 // Do is the mock for default instancesAggregatedListMock
-func (c *instancesAggregatedListMock) Do(call *v1.InstancesAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceAggregatedList, error) {
+func (c *instancesAggregatedListMock) Do(ctx context.Context, call *v1.InstancesAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceAggregatedList, error) {
 	return &v1.InstanceAggregatedList{
 		Items: map[string]v1.InstancesScopedList{
 			"us-central1-a": v1.InstancesScopedList{
@@ -116,22 +121,22 @@ func (c *instancesAggregatedListMock) Do(call *v1.InstancesAggregatedListCall, o
 }
 
 // Do is the mock for default instancesStopMock
-func (c *instancesStopMock) Do(call *v1.InstancesStopCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *instancesStopMock) Do(ctx context.Context, call *v1.InstancesStopCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default instancesStartMock
-func (c *instancesStartMock) Do(call *v1.InstancesStartCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *instancesStartMock) Do(ctx context.Context, call *v1.InstancesStartCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default regionsGetMock
-func (c *projectsSetCommonInstanceMetadataMock) Do(call *v1.ProjectsSetCommonInstanceMetadataCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *projectsSetCommonInstanceMetadataMock) Do(ctx context.Context, call *v1.ProjectsSetCommonInstanceMetadataCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default projectsGetMock
-func (c *projectsGetMock) Do(call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error) {
+func (c *projectsGetMock) Do(ctx context.Context, call *v1.ProjectsGetCall, opts ...googleapi.CallOption) (*v1.Project, error) {
 	return &v1.Project{
 		CommonInstanceMetadata: &v1.Metadata{
 			Items: []*v1.MetadataItems{},
@@ -140,7 +145,7 @@ func (c *projectsGetMock) Do(call *v1.ProjectsGetCall, opts ...googleapi.CallOpt
 }
 
 // Do is the mock for default targetPoolsListMock
-func (c *targetPoolsListMock) Do(call *v1.TargetPoolsAggregatedListCall, opts ...googleapi.CallOption) (*v1.TargetPoolAggregatedList, error) {
+func (c *targetPoolsListMock) Do(ctx context.Context, call *v1.TargetPoolsAggregatedListCall, opts ...googleapi.CallOption) (*v1.TargetPoolAggregatedList, error) {
 	return &v1.TargetPoolAggregatedList{
 		Items: map[string]v1.TargetPoolsScopedList{
 			"item": v1.TargetPoolsScopedList{
@@ -156,12 +161,12 @@ func (c *targetPoolsListMock) Do(call *v1.TargetPoolsAggregatedListCall, opts ..
 }
 
 // Do is the mock for default targetPoolDeleteMock
-func (c *targetPoolDeleteMock) Do(call *v1.TargetPoolsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *targetPoolDeleteMock) Do(ctx context.Context, call *v1.TargetPoolsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default backendServicesListMock
-func (c *backendServicesListMock) Do(call *v1.BackendServicesAggregatedListCall, opts ...googleapi.CallOption) (*v1.BackendServiceAggregatedList, error) {
+func (c *backendServicesListMock) Do(ctx context.Context, call *v1.BackendServicesAggregatedListCall, opts ...googleapi.CallOption) (*v1.BackendServiceAggregatedList, error) {
 	return &v1.BackendServiceAggregatedList{
 		Items: map[string]v1.BackendServicesScopedList{
 			"item": v1.BackendServicesScopedList{
@@ -176,17 +181,17 @@ func (c *backendServicesListMock) Do(call *v1.BackendServicesAggregatedListCall,
 }
 
 // Do is the mock for default backendServiceDeleteMock
-func (c *backendServiceDeleteMock) Do(call *v1.BackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *backendServiceDeleteMock) Do(ctx context.Context, call *v1.BackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default regionBackendServiceDeleteMock
-func (c *regionBackendServiceDeleteMock) Do(call *v1.RegionBackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *regionBackendServiceDeleteMock) Do(ctx context.Context, call *v1.RegionBackendServicesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default forwardingRulesListMock
-func (c *forwardingRulesListMock) Do(call *v1.ForwardingRulesAggregatedListCall, opts ...googleapi.CallOption) (*v1.ForwardingRuleAggregatedList, error) {
+func (c *forwardingRulesListMock) Do(ctx context.Context, call *v1.ForwardingRulesAggregatedListCall, opts ...googleapi.CallOption) (*v1.ForwardingRuleAggregatedList, error) {
 	return &v1.ForwardingRuleAggregatedList{
 		Items: map[string]v1.ForwardingRulesScopedList{
 			"item": v1.ForwardingRulesScopedList{
@@ -202,12 +207,12 @@ func (c *forwardingRulesListMock) Do(call *v1.ForwardingRulesAggregatedListCall,
 }
 
 // Do is the mock for default forwardingRuleDeleteMock
-func (c *forwardingRuleDeleteMock) Do(call *v1.ForwardingRulesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *forwardingRuleDeleteMock) Do(ctx context.Context, call *v1.ForwardingRulesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default healthChecksListMock
-func (c *healthChecksListMock) Do(call *v1.HealthChecksAggregatedListCall, opts ...googleapi.CallOption) (*v1.HealthChecksAggregatedList, error) {
+func (c *healthChecksListMock) Do(ctx context.Context, call *v1.HealthChecksAggregatedListCall, opts ...googleapi.CallOption) (*v1.HealthChecksAggregatedList, error) {
 	return &v1.HealthChecksAggregatedList{
 		Items: map[string]v1.HealthChecksScopedList{
 			"item": v1.HealthChecksScopedList{
@@ -222,12 +227,12 @@ func (c *healthChecksListMock) Do(call *v1.HealthChecksAggregatedListCall, opts
 }
 
 // Do is the mock for default healthCheckDeleteMock
-func (c *healthCheckDeleteMock) Do(call *v1.HealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *healthCheckDeleteMock) Do(ctx context.Context, call *v1.HealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default httpHealthChecksListMock
-func (c *httpHealthChecksListMock) Do(call *v1.HttpHealthChecksListCall, opts ...googleapi.CallOption) (*v1.HttpHealthCheckList, error) {
+func (c *httpHealthChecksListMock) Do(ctx context.Context, call *v1.HttpHealthChecksListCall, opts ...googleapi.CallOption) (*v1.HttpHealthCheckList, error) {
 	return &v1.HttpHealthCheckList{
 		Items: []*v1.HttpHealthCheck{
 			&v1.HttpHealthCheck{
@@ -238,12 +243,12 @@ func (c *httpHealthChecksListMock) Do(call *v1.HttpHealthChecksListCall, opts ..
 }
 
 // Do is the mock for default httpHealthCheckDeleteMock
-func (c *httpHealthCheckDeleteMock) Do(call *v1.HttpHealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *httpHealthCheckDeleteMock) Do(ctx context.Context, call *v1.HttpHealthChecksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default disksListMock
-func (c *disksListMock) Do(call *v1.DisksAggregatedListCall, opts ...googleapi.CallOption) (*v1.DiskAggregatedList, error) {
+func (c *disksListMock) Do(ctx context.Context, call *v1.DisksAggregatedListCall, opts ...googleapi.CallOption) (*v1.DiskAggregatedList, error) {
 	return &v1.DiskAggregatedList{
 		Items: map[string]v1.DisksScopedList{
 			"item": v1.DisksScopedList{
@@ -259,12 +264,12 @@ func (c *disksListMock) Do(call *v1.DisksAggregatedListCall, opts ...googleapi.C
 }
 
 // Do is the mock for default diskDeleteMock
-func (c *diskDeleteMock) Do(call *v1.DisksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *diskDeleteMock) Do(ctx context.Context, call *v1.DisksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default addressesListMock
-func (c *addressesListMock) Do(call *v1.AddressesAggregatedListCall, opts ...googleapi.CallOption) (*v1.AddressAggregatedList, error) {
+func (c *addressesListMock) Do(ctx context.Context, call *v1.AddressesAggregatedListCall, opts ...googleapi.CallOption) (*v1.AddressAggregatedList, error) {
 	return &v1.AddressAggregatedList{
 		Items: map[string]v1.AddressesScopedList{
 			"item": v1.AddressesScopedList{
@@ -280,12 +285,12 @@ func (c *addressesListMock) Do(call *v1.AddressesAggregatedListCall, opts ...goo
 }
 
 // Do is the mock for default addressDeleteMock
-func (c *addressDeleteMock) Do(call *v1.AddressesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *addressDeleteMock) Do(ctx context.Context, call *v1.AddressesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default firewallsListMock
-func (c *firewallsListMock) Do(call *v1.FirewallsListCall, opts ...googleapi.CallOption) (*v1.FirewallList, error) {
+func (c *firewallsListMock) Do(ctx context.Context, call *v1.FirewallsListCall, opts ...googleapi.CallOption) (*v1.FirewallList, error) {
 	return &v1.FirewallList{
 		Items: []*v1.Firewall{
 			&v1.Firewall{
@@ -296,12 +301,12 @@ func (c *firewallsListMock) Do(call *v1.FirewallsListCall, opts ...googleapi.Cal
 }
 
 // Do is the mock for default firewallDeleteMock
-func (c *firewallDeleteMock) Do(call *v1.FirewallsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *firewallDeleteMock) Do(ctx context.Context, call *v1.FirewallsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
 // Do is the mock for default instanceGroupsListMock
-func (c *instanceGroupsListMock) Do(call *v1.InstanceGroupsAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupAggregatedList, error) {
+func (c *instanceGroupsListMock) Do(ctx context.Context, call *v1.InstanceGroupsAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupAggregatedList, error) {
 	return &v1.InstanceGroupAggregatedList{
 		Items: map[string]v1.InstanceGroupsScopedList{
 			"item": v1.InstanceGroupsScopedList{
@@ -317,24 +322,502 @@ func (c *instanceGroupsListMock) Do(call *v1.InstanceGroupsAggregatedListCall, o
 }
 
 // Do is the mock for default instanceGroupDeleteMock
-func (c *instanceGroupDeleteMock) Do(call *v1.InstanceGroupsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *instanceGroupDeleteMock) Do(ctx context.Context, call *v1.InstanceGroupsDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
-func (c *networkGetMock) Do(call *v1.NetworksGetCall, opts ...googleapi.CallOption) (*v1.Network, error) {
+// Do is the mock for default instanceGroupManagersListMock
+func (c *instanceGroupManagersListMock) Do(ctx context.Context, call *v1.InstanceGroupManagersAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupManagerAggregatedList, error) {
+	return &v1.InstanceGroupManagerAggregatedList{
+		Items: map[string]v1.InstanceGroupManagersScopedList{
+			"item": v1.InstanceGroupManagersScopedList{
+				InstanceGroupManagers: []*v1.InstanceGroupManager{
+					&v1.InstanceGroupManager{
+						Name: "instance-group-manager",
+						Zone: "us-central1-a",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for default instanceGroupManagerDeleteMock
+func (c *instanceGroupManagerDeleteMock) Do(ctx context.Context, call *v1.InstanceGroupManagersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{}, nil
+}
+
+// Do is the mock for default regionInstanceGroupManagerDeleteMock
+func (c *regionInstanceGroupManagerDeleteMock) Do(ctx context.Context, call *v1.RegionInstanceGroupManagersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{}, nil
+}
+
+func (c *networkGetMock) Do(ctx context.Context, call *v1.NetworksGetCall, opts ...googleapi.CallOption) (*v1.Network, error) {
 	return &v1.Network{}, nil
 }
 
-func (c *networkDeleteMock) Do(call *v1.NetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+func (c *networkDeleteMock) Do(ctx context.Context, call *v1.NetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{}, nil
+}
+
+type globalOperationsWaitMock struct {
+	calls int
+}
+type globalOperationsGetMock struct{}
+type regionOperationsWaitMock struct{}
+type regionOperationsGetMock struct{}
+type zoneOperationsWaitMock struct{}
+type zoneOperationsGetMock struct{}
+type diskDeleteZoneOperationMock struct{}
+
+// Do is the mock for default globalOperationsWaitMock, reporting the operation done on the second call
+func (c *globalOperationsWaitMock) Do(ctx context.Context, call *v1.GlobalOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	c.calls++
+	if c.calls < 2 {
+		return &v1.Operation{Name: "operation", Status: "RUNNING"}, nil
+	}
+	return &v1.Operation{Name: "operation", Status: "DONE"}, nil
+}
+
+// Do is the mock for default globalOperationsGetMock
+func (c *globalOperationsGetMock) Do(ctx context.Context, call *v1.GlobalOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{Name: "operation", Status: "DONE"}, nil
+}
+
+// Do is the mock for default regionOperationsWaitMock
+func (c *regionOperationsWaitMock) Do(ctx context.Context, call *v1.RegionOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{Name: "operation", Region: "us-central1", Status: "DONE"}, nil
+}
+
+// Do is the mock for default regionOperationsGetMock
+func (c *regionOperationsGetMock) Do(ctx context.Context, call *v1.RegionOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{Name: "operation", Region: "us-central1", Status: "DONE"}, nil
+}
+
+// Do is the mock for default zoneOperationsWaitMock, reporting a finished operation with an error
+func (c *zoneOperationsWaitMock) Do(ctx context.Context, call *v1.ZoneOperationsWaitCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{
+		Name:   "operation",
+		Zone:   "us-central1-a",
+		Status: "DONE",
+		Error: &v1.OperationError{
+			Errors: []*v1.OperationErrorErrors{
+				&v1.OperationErrorErrors{
+					Code:    "RESOURCE_IN_USE_BY_ANOTHER_RESOURCE",
+					Message: "disk is in use",
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for default zoneOperationsGetMock
+func (c *zoneOperationsGetMock) Do(ctx context.Context, call *v1.ZoneOperationsGetCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{Name: "operation", Zone: "us-central1-a", Status: "DONE"}, nil
+}
+
+// Do is the mock for diskDelete that returns a pending zonal operation
+func (c *diskDeleteZoneOperationMock) Do(ctx context.Context, call *v1.DisksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{Name: "operation", Zone: "https://www.googleapis.com/compute/v1/projects/project/zones/us-central1-a", Status: "PENDING"}, nil
+}
+
+type instanceDeleteMock struct{}
+type subnetworkDeleteMock struct{}
+type teardownInstancesListMock struct{}
+type teardownTargetPoolsListMock struct{}
+type teardownForwardingRulesListMock struct{}
+type teardownHTTPHealthChecksListMock struct{}
+type teardownFirewallsListMock struct{}
+type teardownNetworkGetMock struct{}
+type teardownGlobalBackendServicesListMock struct{}
+type routesListMock struct{}
+type routeDeleteMock struct{}
+type routersAggregatedListMock struct{}
+type routerDeleteMock struct{}
+type networkRemovePeeringMock struct{}
+type teardownRoutesListMock struct{}
+type teardownRoutersAggregatedListMock struct{}
+
+// Do is the mock for default routesListMock
+func (c *routesListMock) Do(ctx context.Context, call *v1.RoutesListCall, opts ...googleapi.CallOption) (*v1.RouteList, error) {
+	return &v1.RouteList{
+		Items: []*v1.Route{
+			&v1.Route{
+				Name:    "route",
+				Network: fmt.Sprintf("/projects/%s/global/networks/one", testProjectID),
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for default routeDeleteMock
+func (c *routeDeleteMock) Do(ctx context.Context, call *v1.RoutesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{}, nil
+}
+
+// Do is the mock for default routersAggregatedListMock
+func (c *routersAggregatedListMock) Do(ctx context.Context, call *v1.RoutersAggregatedListCall, opts ...googleapi.CallOption) (*v1.RouterAggregatedList, error) {
+	return &v1.RouterAggregatedList{
+		Items: map[string]v1.RoutersScopedList{
+			"us-central1": v1.RoutersScopedList{
+				Routers: []*v1.Router{
+					&v1.Router{
+						Name:    "router",
+						Region:  "us-central1",
+						Network: fmt.Sprintf("/projects/%s/global/networks/one", testProjectID),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for default routerDeleteMock
+func (c *routerDeleteMock) Do(ctx context.Context, call *v1.RoutersDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{}, nil
+}
+
+// Do is the mock for default networkRemovePeeringMock
+func (c *networkRemovePeeringMock) Do(ctx context.Context, call *v1.NetworksRemovePeeringCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{}, nil
+}
+
+// Do is the mock for routesList with a custom route on the network to tear down, and the ones created with it
+func (c *teardownRoutesListMock) Do(ctx context.Context, call *v1.RoutesListCall, opts ...googleapi.CallOption) (*v1.RouteList, error) {
+	return &v1.RouteList{
+		Items: []*v1.Route{
+			&v1.Route{
+				Name:      "route",
+				Network:   "projects/project/global/networks/teardown",
+				NextHopIp: "10.0.0.2",
+			},
+			&v1.Route{
+				Name:           "default-route-subnetwork",
+				Network:        "projects/project/global/networks/teardown",
+				NextHopNetwork: "projects/project/global/networks/teardown",
+			},
+			&v1.Route{
+				Name:           "default-route-internet",
+				Network:        "projects/project/global/networks/teardown",
+				NextHopGateway: "projects/project/global/gateways/default-internet-gateway",
+			},
+			&v1.Route{
+				Name:           "peering-route",
+				Network:        "projects/project/global/networks/teardown",
+				NextHopPeering: "peering",
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for routersAggregatedList with a router on the network to tear down
+func (c *teardownRoutersAggregatedListMock) Do(ctx context.Context, call *v1.RoutersAggregatedListCall, opts ...googleapi.CallOption) (*v1.RouterAggregatedList, error) {
+	return &v1.RouterAggregatedList{
+		Items: map[string]v1.RoutersScopedList{
+			"us-central1": v1.RoutersScopedList{
+				Routers: []*v1.Router{
+					&v1.Router{
+						Name:    "router",
+						Region:  "projects/project/regions/us-central1",
+						Network: "projects/project/global/networks/teardown",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for default instanceDeleteMock
+func (c *instanceDeleteMock) Do(ctx context.Context, call *v1.InstancesDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
 	return &v1.Operation{}, nil
 }
 
+// Do is the mock for default subnetworkDeleteMock
+func (c *subnetworkDeleteMock) Do(ctx context.Context, call *v1.SubnetworksDeleteCall, opts ...googleapi.CallOption) (*v1.Operation, error) {
+	return &v1.Operation{}, nil
+}
+
+// Do is the mock for instancesAggregatedList with one instance on the network to tear down
+func (c *teardownInstancesListMock) Do(ctx context.Context, call *v1.InstancesAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceAggregatedList, error) {
+	return &v1.InstanceAggregatedList{
+		Items: map[string]v1.InstancesScopedList{
+			"us-central1-a": v1.InstancesScopedList{
+				Instances: []*v1.Instance{
+					&v1.Instance{
+						Name:     "instance",
+						SelfLink: "projects/project/zones/us-central1-a/instances/instance",
+						Zone:     "projects/project/zones/us-central1-a",
+						NetworkInterfaces: []*v1.NetworkInterface{
+							&v1.NetworkInterface{
+								Network: "projects/project/global/networks/teardown",
+							},
+						},
+					},
+					&v1.Instance{
+						Name:     "other-instance",
+						SelfLink: "projects/project/zones/us-central1-a/instances/other-instance",
+						Zone:     "projects/project/zones/us-central1-a",
+						NetworkInterfaces: []*v1.NetworkInterface{
+							&v1.NetworkInterface{
+								Network: "projects/project/global/networks/other",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for targetPoolsList with one pool containing the instance on the network to tear down
+func (c *teardownTargetPoolsListMock) Do(ctx context.Context, call *v1.TargetPoolsAggregatedListCall, opts ...googleapi.CallOption) (*v1.TargetPoolAggregatedList, error) {
+	return &v1.TargetPoolAggregatedList{
+		Items: map[string]v1.TargetPoolsScopedList{
+			"us-central1": v1.TargetPoolsScopedList{
+				TargetPools: []*v1.TargetPool{
+					&v1.TargetPool{
+						Name:         "pool",
+						SelfLink:     "projects/project/regions/us-central1/targetPools/pool",
+						Region:       "projects/project/regions/us-central1",
+						Instances:    []string{"projects/project/zones/us-central1-a/instances/instance"},
+						HealthChecks: []string{"projects/project/global/httpHealthChecks/health-check"},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for forwardingRulesList with one rule targeting the pool to tear down
+func (c *teardownForwardingRulesListMock) Do(ctx context.Context, call *v1.ForwardingRulesAggregatedListCall, opts ...googleapi.CallOption) (*v1.ForwardingRuleAggregatedList, error) {
+	return &v1.ForwardingRuleAggregatedList{
+		Items: map[string]v1.ForwardingRulesScopedList{
+			"us-central1": v1.ForwardingRulesScopedList{
+				ForwardingRules: []*v1.ForwardingRule{
+					&v1.ForwardingRule{
+						Name:     "forwarding-rule",
+						SelfLink: "projects/project/regions/us-central1/forwardingRules/forwarding-rule",
+						Region:   "projects/project/regions/us-central1",
+						Target:   "projects/project/regions/us-central1/targetPools/pool",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for httpHealthChecksList with the health check used by the pool to tear down
+func (c *teardownHTTPHealthChecksListMock) Do(ctx context.Context, call *v1.HttpHealthChecksListCall, opts ...googleapi.CallOption) (*v1.HttpHealthCheckList, error) {
+	return &v1.HttpHealthCheckList{
+		Items: []*v1.HttpHealthCheck{
+			&v1.HttpHealthCheck{
+				Name:     "health-check",
+				SelfLink: "projects/project/global/httpHealthChecks/health-check",
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for firewallsList with one firewall on the network to tear down and one elsewhere
+func (c *teardownFirewallsListMock) Do(ctx context.Context, call *v1.FirewallsListCall, opts ...googleapi.CallOption) (*v1.FirewallList, error) {
+	return &v1.FirewallList{
+		Items: []*v1.Firewall{
+			&v1.Firewall{
+				Name:    "firewall",
+				Network: "projects/project/global/networks/teardown",
+			},
+			&v1.Firewall{
+				Name:    "other-firewall",
+				Network: "projects/project/global/networks/other",
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for networkGet returning a custom mode network with a single subnetwork
+func (c *teardownNetworkGetMock) Do(ctx context.Context, call *v1.NetworksGetCall, opts ...googleapi.CallOption) (*v1.Network, error) {
+	return &v1.Network{
+		Name:        "teardown",
+		Subnetworks: []string{"projects/project/regions/us-central1/subnetworks/subnetwork"},
+		Peerings:    []*v1.NetworkPeering{&v1.NetworkPeering{Name: "peering"}},
+	}, nil
+}
+
+// Do is the mock for backendServicesList with a global backend service on the network to tear down
+func (c *teardownGlobalBackendServicesListMock) Do(ctx context.Context, call *v1.BackendServicesAggregatedListCall, opts ...googleapi.CallOption) (*v1.BackendServiceAggregatedList, error) {
+	return &v1.BackendServiceAggregatedList{
+		Items: map[string]v1.BackendServicesScopedList{
+			"global": v1.BackendServicesScopedList{
+				BackendServices: []*v1.BackendService{
+					&v1.BackendService{
+						Name:         "global-backend-service",
+						SelfLink:     "projects/project/global/backendServices/global-backend-service",
+						Network:      "projects/project/global/networks/teardown",
+						HealthChecks: []string{"projects/project/global/httpHealthChecks/health-check"},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+type teardownManagedInstancesListMock struct{}
+type teardownManagedInstanceGroupsListMock struct{}
+type teardownInstanceGroupManagersListMock struct{}
+type teardownGlobalForwardingRulesListMock struct{}
+type teardownGlobalAddressesListMock struct{}
+
+// Do is the mock for instancesAggregatedList with an instance of a managed instance group on the network to tear down
+func (c *teardownManagedInstancesListMock) Do(ctx context.Context, call *v1.InstancesAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceAggregatedList, error) {
+	createdBy := "projects/123456/zones/us-central1-a/instanceGroupManagers/web"
+	return &v1.InstanceAggregatedList{
+		Items: map[string]v1.InstancesScopedList{
+			"us-central1-a": v1.InstancesScopedList{
+				Instances: []*v1.Instance{
+					&v1.Instance{
+						Name:     "web-1",
+						SelfLink: "projects/project/zones/us-central1-a/instances/web-1",
+						Zone:     "projects/project/zones/us-central1-a",
+						Metadata: &v1.Metadata{
+							Items: []*v1.MetadataItems{&v1.MetadataItems{Key: "created-by", Value: &createdBy}},
+						},
+						NetworkInterfaces: []*v1.NetworkInterface{
+							&v1.NetworkInterface{
+								Network: "projects/project/global/networks/teardown",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for instanceGroupsList with the instance group of a managed instance group on the network to tear down
+func (c *teardownManagedInstanceGroupsListMock) Do(ctx context.Context, call *v1.InstanceGroupsAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupAggregatedList, error) {
+	return &v1.InstanceGroupAggregatedList{
+		Items: map[string]v1.InstanceGroupsScopedList{
+			"us-central1-a": v1.InstanceGroupsScopedList{
+				InstanceGroups: []*v1.InstanceGroup{
+					&v1.InstanceGroup{
+						Name:     "web",
+						SelfLink: "projects/project/zones/us-central1-a/instanceGroups/web",
+						Zone:     "projects/project/zones/us-central1-a",
+						Network:  "projects/project/global/networks/teardown",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for instanceGroupManagersList with a managed instance group on the network to tear down, and one
+// elsewhere
+func (c *teardownInstanceGroupManagersListMock) Do(ctx context.Context, call *v1.InstanceGroupManagersAggregatedListCall, opts ...googleapi.CallOption) (*v1.InstanceGroupManagerAggregatedList, error) {
+	return &v1.InstanceGroupManagerAggregatedList{
+		Items: map[string]v1.InstanceGroupManagersScopedList{
+			"us-central1-a": v1.InstanceGroupManagersScopedList{
+				InstanceGroupManagers: []*v1.InstanceGroupManager{
+					&v1.InstanceGroupManager{
+						Name:          "web",
+						SelfLink:      "projects/project/zones/us-central1-a/instanceGroupManagers/web",
+						Zone:          "projects/project/zones/us-central1-a",
+						InstanceGroup: "projects/project/zones/us-central1-a/instanceGroups/web",
+					},
+				},
+			},
+			"us-central1": v1.InstanceGroupManagersScopedList{
+				InstanceGroupManagers: []*v1.InstanceGroupManager{
+					&v1.InstanceGroupManager{
+						Name:          "other",
+						SelfLink:      "projects/project/regions/us-central1/instanceGroupManagers/other",
+						Region:        "projects/project/regions/us-central1",
+						InstanceGroup: "projects/project/regions/us-central1/instanceGroups/other",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for forwardingRulesList with a global forwarding rule on the network to tear down
+func (c *teardownGlobalForwardingRulesListMock) Do(ctx context.Context, call *v1.ForwardingRulesAggregatedListCall, opts ...googleapi.CallOption) (*v1.ForwardingRuleAggregatedList, error) {
+	return &v1.ForwardingRuleAggregatedList{
+		Items: map[string]v1.ForwardingRulesScopedList{
+			"global": v1.ForwardingRulesScopedList{
+				ForwardingRules: []*v1.ForwardingRule{
+					&v1.ForwardingRule{
+						Name:     "global-forwarding-rule",
+						SelfLink: "projects/project/global/forwardingRules/global-forwarding-rule",
+						Network:  "projects/project/global/networks/teardown",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Do is the mock for addressesList with a global address on the network to tear down
+func (c *teardownGlobalAddressesListMock) Do(ctx context.Context, call *v1.AddressesAggregatedListCall, opts ...googleapi.CallOption) (*v1.AddressAggregatedList, error) {
+	return &v1.AddressAggregatedList{
+		Items: map[string]v1.AddressesScopedList{
+			"global": v1.AddressesScopedList{
+				Addresses: []*v1.Address{
+					&v1.Address{
+						Name:     "global-address",
+						SelfLink: "projects/project/global/addresses/global-address",
+						Network:  "projects/project/global/networks/teardown",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func setTeardownCallMocks(c *Compute) {
+	c.Calls.InstancesAggregatedList = &teardownInstancesListMock{}
+	c.Calls.TargetPoolsList = &teardownTargetPoolsListMock{}
+	c.Calls.ForwardingRulesList = &teardownForwardingRulesListMock{}
+	c.Calls.HTTPHealthChecksList = &teardownHTTPHealthChecksListMock{}
+	c.Calls.FirewallsList = &teardownFirewallsListMock{}
+	c.Calls.NetworkGet = &teardownNetworkGetMock{}
+	c.Calls.RoutesList = &teardownRoutesListMock{}
+	c.Calls.RoutersAggregatedList = &teardownRoutersAggregatedListMock{}
+}
+
+type disksListPagedMock struct {
+	pages int
+}
+
+// Do is the mock for disksList that returns results across two pages
+func (c *disksListPagedMock) Do(ctx context.Context, call *v1.DisksAggregatedListCall, opts ...googleapi.CallOption) (*v1.DiskAggregatedList, error) {
+	c.pages++
+	nextPageToken := ""
+	if c.pages == 1 {
+		nextPageToken = "page-2"
+	}
+	return &v1.DiskAggregatedList{
+		Items: map[string]v1.DisksScopedList{
+			"item": v1.DisksScopedList{
+				Disks: []*v1.Disk{
+					&v1.Disk{
+						Name: fmt.Sprintf("disk-%d", c.pages),
+						Zone: "us-central1-a",
+					},
+				},
+			},
+		},
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
 func setCallMockDefaults(c *Compute) {
 	c.Calls = &Calls{
 		RegionsGet:                        &regionsGetMock{},
 		InstancesAggregatedList:           &instancesAggregatedListMock{},
 		InstancesStop:                     &instancesStopMock{},
 		InstancesStart:                    &instancesStartMock{},
+		InstanceDelete:                    &instanceDeleteMock{},
 		ProjectsSetCommonInstanceMetadata: &projectsSetCommonInstanceMetadataMock{},
 		ProjectsGet:                       &projectsGetMock{},
 		TargetPoolsList:                   &targetPoolsListMock{},
@@ -356,9 +839,25 @@ func setCallMockDefaults(c *Compute) {
 		FirewallDelete:                    &firewallDeleteMock{},
 		InstanceGroupsList:                &instanceGroupsListMock{},
 		InstanceGroupDelete:               &instanceGroupDeleteMock{},
+		InstanceGroupManagersList:         &instanceGroupManagersListMock{},
+		InstanceGroupManagerDelete:        &instanceGroupManagerDeleteMock{},
+		RegionInstanceGroupManagerDelete:  &regionInstanceGroupManagerDeleteMock{},
 		NetworkGet:                        &networkGetMock{},
 		NetworkDelete:                     &networkDeleteMock{},
+		SubnetworkDelete:                  &subnetworkDeleteMock{},
+		NetworkRemovePeering:              &networkRemovePeeringMock{},
+		RoutesList:                        &routesListMock{},
+		RouteDelete:                       &routeDeleteMock{},
+		RoutersAggregatedList:             &routersAggregatedListMock{},
+		RouterDelete:                      &routerDeleteMock{},
+		GlobalOperationsWait:              &globalOperationsWaitMock{},
+		GlobalOperationsGet:               &globalOperationsGetMock{},
+		RegionOperationsWait:              &regionOperationsWaitMock{},
+		RegionOperationsGet:               &regionOperationsGetMock{},
+		ZoneOperationsWait:                &zoneOperationsWaitMock{},
+		ZoneOperationsGet:                 &zoneOperationsGetMock{},
 	}
+	c.OperationPollSeconds = 0
 }
 
 func TestInitialize(t *testing.T) {
@@ -371,6 +870,16 @@ func TestInitialize(t *testing.T) {
 	if err != nil {
 		t.Errorf("Got unexpected error during compute.Initialize() with explicit credentials: %s", err)
 	}
+	if c.OperationPollSeconds != 5 || c.OperationTimeoutSeconds != 600 {
+		t.Errorf("Expected compute.Initialize() to set the default operation poll and timeout, got %d and %d", c.OperationPollSeconds, c.OperationTimeoutSeconds)
+	}
+	c = &Compute{OperationPollSeconds: 1, OperationTimeoutSeconds: -1}
+	if err = c.Initialize("", loggermock.GetLogMock()); err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with operation settings: %s", err)
+	}
+	if c.OperationPollSeconds != 1 || c.OperationTimeoutSeconds != -1 {
+		t.Errorf("Expected compute.Initialize() to keep the operation poll and timeout that were set, got %d and %d", c.OperationPollSeconds, c.OperationTimeoutSeconds)
+	}
 }
 
 func TestGetRegionZones(t *testing.T) {
@@ -698,6 +1207,48 @@ func TestDeleteInstanceGroup(t *testing.T) {
 	}
 }
 
+func TestGetInstanceGroupManagers(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	instanceGroupManagers, err := c.GetInstanceGroupManagers("project")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.GetInstanceGroupManagers(): %s", err)
+	}
+	if len(instanceGroupManagers) != 1 {
+		t.Errorf("Expected 1 managed instance group from compute.GetInstanceGroupManagers(), got %d", len(instanceGroupManagers))
+	}
+}
+
+func TestDeleteInstanceGroupManager(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	err = c.DeleteInstanceGroupManager("project", "zone", "instance-group-manager")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.DeleteInstanceGroupManager(): %s", err)
+	}
+}
+
+func TestDeleteRegionInstanceGroupManager(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	err = c.DeleteRegionInstanceGroupManager("project", "region", "instance-group-manager")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.DeleteRegionInstanceGroupManager(): %s", err)
+	}
+}
+
 func TestGetNetwork(t *testing.T) {
 	c := &Compute{}
 	err := c.Initialize("", loggermock.GetLogMock())
@@ -723,3 +1274,386 @@ func TestDeleteNetwork(t *testing.T) {
 		t.Errorf("Got unexpected error during compute.TestDeleteNetwork(): %s", err)
 	}
 }
+
+func TestGetDisksPaged(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	c.Calls.DisksList = &disksListPagedMock{}
+	disks, err := c.GetDisks("project")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.GetDisks() across pages: %s", err)
+	}
+	if len(disks) != 2 {
+		t.Errorf("Got unexpected result/value from compute.GetDisks() across pages, expecting length of \"2\", but got: %d", len(disks))
+	}
+}
+
+func TestForEachDiskStop(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	disksListMock := &disksListPagedMock{}
+	c.Calls.DisksList = disksListMock
+	stop := errors.New("stop")
+	err = c.ForEachDisk("project", func(disk *v1.Disk) error {
+		return stop
+	})
+	if err != stop {
+		t.Errorf("Expected the callback error from compute.ForEachDisk(), but got: %v", err)
+	}
+	if disksListMock.pages != 1 {
+		t.Errorf("Expected compute.ForEachDisk() to stop after the first page, but read %d pages", disksListMock.pages)
+	}
+}
+
+func TestWaitForOperation(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	globalOperationsWait := &globalOperationsWaitMock{}
+	c.Calls.GlobalOperationsWait = globalOperationsWait
+	err = c.WaitForOperation("project", &v1.Operation{Name: "operation", Status: "PENDING"})
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.WaitForOperation(): %s", err)
+	}
+	if globalOperationsWait.calls != 2 {
+		t.Errorf("Expected compute.WaitForOperation() to wait until the operation was done, but it waited %d times", globalOperationsWait.calls)
+	}
+	err = c.WaitForOperation("project", nil)
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.WaitForOperation() with no operation: %s", err)
+	}
+}
+
+func TestWaitForOperationCanceled(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	c.OperationPollSeconds = 60
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = c.WaitForOperationCtx(ctx, "project", &v1.Operation{Name: "operation", Status: "PENDING"})
+	if err == nil {
+		t.Errorf("Expected an error from compute.WaitForOperationCtx() with a canceled context, but didn't get one")
+	}
+}
+
+func TestGetOperation(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	operation, err := c.GetOperation("project", &v1.Operation{Name: "operation", Region: "us-central1"})
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.GetOperation(): %s", err)
+	}
+	if operation.Region != "us-central1" {
+		t.Errorf("Expected compute.GetOperation() to get a regional operation, got: %v", operation)
+	}
+}
+
+func TestDeleteDiskSynchronous(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	c.Calls.DiskDelete = &diskDeleteZoneOperationMock{}
+	err = c.DeleteDisk("project", "us-central1-a", "disk")
+	if err != nil {
+		t.Errorf("Got unexpected error during asynchronous compute.DeleteDisk(): %s", err)
+	}
+	err = c.DeleteDisk("project", "us-central1-a", "disk", Synchronous())
+	if _, ok := err.(*OperationError); !ok {
+		t.Errorf("Expected an *OperationError from synchronous compute.DeleteDisk(), got: %v", err)
+	}
+}
+
+func TestPowerOffSynchronous(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	err = c.PowerOff(testProjectID, Synchronous())
+	if err != nil {
+		t.Errorf("Got unexpected error testing synchronous PowerOff: %s", err)
+	}
+}
+
+func TestGetForwardingRules(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	rules, err := c.GetForwardingRules("project")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.GetForwardingRules(): %s", err)
+	}
+	if len(rules) != 1 {
+		t.Errorf("Got unexpected result/value from compute.GetForwardingRules(), expecting length of \"1\", but got: %d", len(rules))
+	}
+}
+
+func TestDeleteInstance(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	err = c.DeleteInstance("project", "us-central1-a", "instance")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.DeleteInstance(): %s", err)
+	}
+}
+
+func TestDeleteSubnetwork(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	err = c.DeleteSubnetwork("project", "us-central1", "subnetwork")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.DeleteSubnetwork(): %s", err)
+	}
+}
+
+func TestGetRoutes(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	routes, err := c.GetRoutes("project")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.GetRoutes(): %s", err)
+	}
+	if len(routes) != 1 {
+		t.Errorf("Expected 1 route from compute.GetRoutes(), got %d", len(routes))
+	}
+}
+
+func TestDeleteRoute(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	err = c.DeleteRoute("project", "route")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.DeleteRoute(): %s", err)
+	}
+}
+
+func TestGetRouters(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	routers, err := c.GetRouters("project")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.GetRouters(): %s", err)
+	}
+	if len(routers) != 1 {
+		t.Errorf("Expected 1 router from compute.GetRouters(), got %d", len(routers))
+	}
+}
+
+func TestDeleteRouter(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	err = c.DeleteRouter("project", "us-central1", "router")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.DeleteRouter(): %s", err)
+	}
+}
+
+func TestRemoveNetworkPeering(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	err = c.RemoveNetworkPeering("project", "network", "peering")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.RemoveNetworkPeering(): %s", err)
+	}
+}
+
+func TestTeardownNetworkDryRun(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	setTeardownCallMocks(c)
+	report, err := c.TeardownNetwork("project", "teardown", TeardownOptions{DryRun: true})
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.TeardownNetwork(): %s", err)
+	}
+	expected := []string{
+		"route route",
+		"forwardingRule forwarding-rule",
+		"targetPool pool",
+		"instance instance",
+		"httpHealthCheck health-check",
+		"firewall firewall",
+		"router router",
+		"peering peering",
+		"subnetwork subnetwork",
+		"network teardown",
+	}
+	if len(report.Resources) != len(expected) {
+		t.Fatalf("Expected %d resources in the compute.TeardownNetwork() report, got:\n%s", len(expected), report)
+	}
+	for i, resource := range report.Resources {
+		if fmt.Sprintf("%s %s", resource.Kind, resource.Name) != expected[i] {
+			t.Errorf("Expected \"%s\" at position %d of the compute.TeardownNetwork() report, got:\n%s", expected[i], i, report)
+		}
+	}
+	if report.Resources[8].Location != "us-central1" {
+		t.Errorf("Expected the subnetwork in the compute.TeardownNetwork() report to be in us-central1, got: %s", report.Resources[8].Location)
+	}
+}
+
+func TestTeardownNetwork(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	setTeardownCallMocks(c)
+	globalOperationsWait := &globalOperationsWaitMock{}
+	c.Calls.GlobalOperationsWait = globalOperationsWait
+	report, err := c.TeardownNetwork("project", "teardown", TeardownOptions{})
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.TeardownNetwork(): %s", err)
+	}
+	if globalOperationsWait.calls < len(report.Resources) {
+		t.Errorf("Expected compute.TeardownNetwork() to wait on every deletion, but only waited %d times for %d resources", globalOperationsWait.calls, len(report.Resources))
+	}
+}
+
+func TestTeardownNetworkBlocked(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	setTeardownCallMocks(c)
+	c.Calls.BackendServicesList = &teardownGlobalBackendServicesListMock{}
+	globalOperationsWait := &globalOperationsWaitMock{}
+	c.Calls.GlobalOperationsWait = globalOperationsWait
+	report, err := c.TeardownNetwork("project", "teardown", TeardownOptions{})
+	blockedErr, ok := err.(*TeardownBlockedError)
+	if !ok {
+		t.Fatalf("Expected a *TeardownBlockedError from compute.TeardownNetwork() with a global backend service on the network, got: %v", err)
+	}
+	if len(blockedErr.Blockers) != 1 || blockedErr.Blockers[0].Name != "global-backend-service" {
+		t.Errorf("Expected the global backend service to be the only blocker in the compute.TeardownNetwork() error, got: %s", blockedErr)
+	}
+	if globalOperationsWait.calls != 0 {
+		t.Errorf("Expected compute.TeardownNetwork() to delete nothing when blocked, but waited on %d deletions", globalOperationsWait.calls)
+	}
+	for _, resource := range report.Resources {
+		if resource.Name == "health-check" {
+			t.Errorf("Expected compute.TeardownNetwork() to keep the health check used by the global backend service, got:\n%s", report)
+		}
+	}
+}
+
+func TestTeardownNetworkManagedInstanceGroup(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	setTeardownCallMocks(c)
+	c.Calls.InstancesAggregatedList = &teardownManagedInstancesListMock{}
+	c.Calls.InstanceGroupsList = &teardownManagedInstanceGroupsListMock{}
+	c.Calls.InstanceGroupManagersList = &teardownInstanceGroupManagersListMock{}
+	report, err := c.TeardownNetwork("project", "teardown", TeardownOptions{DryRun: true})
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.TeardownNetwork(): %s", err)
+	}
+	managers := 0
+	for _, resource := range report.Resources {
+		switch resource.Kind {
+		case "instanceGroupManager", "regionInstanceGroupManager":
+			managers++
+			if resource.Name != "web" || resource.Location != "us-central1-a" || resource.Phase != teardownPhaseInstances {
+				t.Errorf("Expected only the managed instance group web in us-central1-a to be torn down with the instances, got:\n%s", report)
+			}
+		case "instance", "instanceGroup":
+			t.Errorf("Expected compute.TeardownNetwork() to leave the %s %s to its managed instance group, got:\n%s", resource.Kind, resource.Name, report)
+		}
+	}
+	if managers != 1 {
+		t.Errorf("Expected 1 managed instance group in the compute.TeardownNetwork() report, got:\n%s", report)
+	}
+}
+
+func TestTeardownNetworkBlockedGlobal(t *testing.T) {
+	c := &Compute{}
+	err := c.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(c)
+	setTeardownCallMocks(c)
+	c.Calls.ForwardingRulesList = &teardownGlobalForwardingRulesListMock{}
+	c.Calls.AddressesList = &teardownGlobalAddressesListMock{}
+	globalOperationsWait := &globalOperationsWaitMock{}
+	c.Calls.GlobalOperationsWait = globalOperationsWait
+	_, err = c.TeardownNetwork("project", "teardown", TeardownOptions{})
+	blockedErr, ok := err.(*TeardownBlockedError)
+	if !ok {
+		t.Fatalf("Expected a *TeardownBlockedError from compute.TeardownNetwork() with a global forwarding rule and address on the network, got: %v", err)
+	}
+	blockers := []string{}
+	for _, blocker := range blockedErr.Blockers {
+		blockers = append(blockers, fmt.Sprintf("%s %s", blocker.Kind, blocker.Name))
+	}
+	expected := []string{"globalForwardingRule global-forwarding-rule", "globalAddress global-address"}
+	if strings.Join(blockers, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected the blockers %v in the compute.TeardownNetwork() error, got: %v", expected, blockers)
+	}
+	if globalOperationsWait.calls != 0 {
+		t.Errorf("Expected compute.TeardownNetwork() to delete nothing when blocked, but waited on %d deletions", globalOperationsWait.calls)
+	}
+}
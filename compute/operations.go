@@ -0,0 +1,150 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/telemetry"
+	v1 "google.golang.org/api/compute/v1"
+)
+
+// operationStatusDone is the status of a compute operation that is no longer pending or running
+const operationStatusDone = "DONE"
+
+// MutateOption is an option that can be passed to any of the mutating compute methods
+type MutateOption func(*mutateOptions)
+
+type mutateOptions struct {
+	synchronous bool
+}
+
+// Synchronous instructs a mutating method to wait for its underlying operation(s) to finish
+// before returning, surfacing any error reported by the operation itself
+func Synchronous() MutateOption {
+	return func(o *mutateOptions) {
+		o.synchronous = true
+	}
+}
+
+// OperationError is returned when a compute operation finishes with errors
+type OperationError struct {
+	Operation string
+	Errors    []*v1.OperationErrorErrors
+}
+
+// Error returns a message combining all errors reported by the operation
+func (e *OperationError) Error() string {
+	messages := []string{}
+	for _, operationError := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", operationError.Code, operationError.Message))
+	}
+	return fmt.Sprintf("operation %s failed: %s", e.Operation, strings.Join(messages, "; "))
+}
+
+// GetOperation will get the latest state of a global, regional or zonal operation
+func (c *Compute) GetOperation(projectID string, operation *v1.Operation) (*v1.Operation, error) {
+	return c.GetOperationCtx(context.Background(), projectID, operation)
+}
+
+// GetOperationCtx is GetOperation, using the provided context for the underlying api calls
+func (c *Compute) GetOperationCtx(ctx context.Context, projectID string, operation *v1.Operation) (latest *v1.Operation, err error) {
+	defer events.Start(c.Events, service, "GetOperation", events.ActionRead, projectID, operation.Name).Done(&err)
+	if operation.Zone != "" {
+		zoneOperationsService := v1.NewZoneOperationsService(c.V1)
+		zoneOperationsGetCall := zoneOperationsService.Get(projectID, c.getResourceNameFromURL(operation.Zone), operation.Name).Context(ctx)
+		return c.Calls.ZoneOperationsGet.Do(telemetry.WithResource(ctx, projectID, operation.Name), zoneOperationsGetCall)
+	}
+	if operation.Region != "" {
+		regionOperationsService := v1.NewRegionOperationsService(c.V1)
+		regionOperationsGetCall := regionOperationsService.Get(projectID, c.getResourceNameFromURL(operation.Region), operation.Name).Context(ctx)
+		return c.Calls.RegionOperationsGet.Do(telemetry.WithResource(ctx, projectID, operation.Name), regionOperationsGetCall)
+	}
+	globalOperationsService := v1.NewGlobalOperationsService(c.V1)
+	globalOperationsGetCall := globalOperationsService.Get(projectID, operation.Name).Context(ctx)
+	return c.Calls.GlobalOperationsGet.Do(telemetry.WithResource(ctx, projectID, operation.Name), globalOperationsGetCall)
+}
+
+// WaitForOperation will block until a global, regional or zonal operation is done, polling every
+// OperationPollSeconds and giving up after OperationTimeoutSeconds, returning an *OperationError
+// if the finished operation reports any errors
+func (c *Compute) WaitForOperation(projectID string, operation *v1.Operation) error {
+	return c.WaitForOperationCtx(context.Background(), projectID, operation)
+}
+
+// WaitForOperationCtx is WaitForOperation, using the provided context for the underlying api calls
+func (c *Compute) WaitForOperationCtx(ctx context.Context, projectID string, operation *v1.Operation) (err error) {
+	if operation == nil {
+		return nil
+	}
+	defer events.Start(c.Events, service, "WaitForOperation", events.ActionRead, projectID, operation.Name).Done(&err)
+	return c.waitForOperation(ctx, projectID, operation)
+}
+
+// waitForOperation is WaitForOperationCtx without its event, for use within other methods
+func (c *Compute) waitForOperation(ctx context.Context, projectID string, operation *v1.Operation) (err error) {
+	if c.OperationTimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(c.OperationTimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+	for operation.Status != operationStatusDone {
+		if operation, err = c.waitOperation(ctx, projectID, operation); err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("error waiting for operation: %s", ctx.Err().Error())
+			}
+			return err
+		}
+		if operation.Status == operationStatusDone {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting for operation %s: %s", operation.Name, ctx.Err().Error())
+		case <-time.After(time.Duration(c.OperationPollSeconds) * time.Second):
+		}
+	}
+	if operation.Error != nil && len(operation.Error.Errors) > 0 {
+		return &OperationError{
+			Operation: operation.Name,
+			Errors:    operation.Error.Errors,
+		}
+	}
+	return nil
+}
+
+// waitOperation makes a single server-side wait call for an operation in the appropriate scope
+func (c *Compute) waitOperation(ctx context.Context, projectID string, operation *v1.Operation) (*v1.Operation, error) {
+	if operation.Zone != "" {
+		zoneOperationsService := v1.NewZoneOperationsService(c.V1)
+		zoneOperationsWaitCall := zoneOperationsService.Wait(projectID, c.getResourceNameFromURL(operation.Zone), operation.Name).Context(ctx)
+		return c.Calls.ZoneOperationsWait.Do(telemetry.WithResource(ctx, projectID, operation.Name), zoneOperationsWaitCall)
+	}
+	if operation.Region != "" {
+		regionOperationsService := v1.NewRegionOperationsService(c.V1)
+		regionOperationsWaitCall := regionOperationsService.Wait(projectID, c.getResourceNameFromURL(operation.Region), operation.Name).Context(ctx)
+		return c.Calls.RegionOperationsWait.Do(telemetry.WithResource(ctx, projectID, operation.Name), regionOperationsWaitCall)
+	}
+	globalOperationsService := v1.NewGlobalOperationsService(c.V1)
+	globalOperationsWaitCall := globalOperationsService.Wait(projectID, operation.Name).Context(ctx)
+	return c.Calls.GlobalOperationsWait.Do(telemetry.WithResource(ctx, projectID, operation.Name), globalOperationsWaitCall)
+}
+
+// finishOperations will wait for all of the operations when the synchronous option is present
+func (c *Compute) finishOperations(ctx context.Context, projectID string, operations []*v1.Operation, opts []MutateOption) error {
+	options := &mutateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if !options.synchronous {
+		return nil
+	}
+	for _, operation := range operations {
+		if err := c.waitForOperation(ctx, projectID, operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,527 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rockholla/go-google-lib/events"
+	v1 "google.golang.org/api/compute/v1"
+)
+
+// TeardownOptions are options for TeardownNetwork
+type TeardownOptions struct {
+	// DryRun will only discover and report what would be removed, without deleting anything
+	DryRun bool
+}
+
+// TeardownResource is a single resource found attached to a network by TeardownNetwork
+type TeardownResource struct {
+	// Phase is the order in which the resource is deleted, all resources in a phase are
+	// deleted before any in the next phase
+	Phase int
+	// Kind is the type of resource, e.g. forwardingRule, instance, firewall
+	Kind string
+	// Name is the name of the resource
+	Name string
+	// Location is the region or zone of the resource, empty for global resources
+	Location string
+	remove   func(ctx context.Context) error
+}
+
+// TeardownReport is the list of resources removed, or to be removed in the case of a dry run, by TeardownNetwork
+type TeardownReport struct {
+	Network   string
+	DryRun    bool
+	Resources []*TeardownResource
+	// Blockers are resources using the network that TeardownNetwork won't remove, which must be removed
+	// some other way before the network can be torn down
+	Blockers []*TeardownResource
+}
+
+// String will return a human-readable listing of the resources in the report, in the order they are deleted,
+// followed by any blockers
+func (r *TeardownReport) String() string {
+	lines := []string{}
+	for _, resource := range r.Resources {
+		lines = append(lines, fmt.Sprintf("%d: %s", resource.Phase, resource))
+	}
+	for _, blocker := range r.Blockers {
+		lines = append(lines, fmt.Sprintf("blocked by: %s", blocker))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// String will return the kind, name and location of the resource
+func (r *TeardownResource) String() string {
+	location := r.Location
+	if location == "" {
+		location = "global"
+	}
+	return fmt.Sprintf("%s %s (%s)", r.Kind, r.Name, location)
+}
+
+// TeardownBlockedError is returned by TeardownNetwork, before anything is deleted, when the network is in use by
+// resources it won't remove
+type TeardownBlockedError struct {
+	Network  string
+	Blockers []*TeardownResource
+}
+
+// Error returns a message listing the blockers
+func (e *TeardownBlockedError) Error() string {
+	blockers := []string{}
+	for _, blocker := range e.Blockers {
+		blockers = append(blockers, blocker.String())
+	}
+	return fmt.Sprintf("network %s can't be torn down, it's in use by: %s", e.Network, strings.Join(blockers, ", "))
+}
+
+func (r *TeardownReport) block(kind string, name string, location string) {
+	r.Blockers = append(r.Blockers, &TeardownResource{
+		Kind:     kind,
+		Name:     name,
+		Location: location,
+	})
+}
+
+func (r *TeardownReport) add(phase int, kind string, name string, location string, remove func(ctx context.Context) error) {
+	r.Resources = append(r.Resources, &TeardownResource{
+		Phase:    phase,
+		Kind:     kind,
+		Name:     name,
+		Location: location,
+		remove:   remove,
+	})
+}
+
+// the phases of a network teardown, each one depending on the removal of everything in the ones before it
+const (
+	teardownPhaseForwardingRules = iota + 1
+	teardownPhaseLoadBalancers
+	teardownPhaseInstances
+	teardownPhaseDependents
+	teardownPhaseSubnetworks
+	teardownPhaseNetwork
+)
+
+// TeardownNetwork will discover everything attached to a VPC network and delete it in dependency order, waiting
+// for each deletion to finish before moving on: custom routes and regional forwarding rules, then target pools and
+// regional backend services, then health checks no longer in use, managed instance groups along with their instances,
+// other instances and instance groups, then left-behind disks, regional addresses, firewalls, routers along with their
+// cloud nat, and peerings, then subnetworks and finally the network itself. Global load balancers and global addresses
+// aren't removed: a global backend service, forwarding rule or address using the network is listed in the report's
+// Blockers, and a *TeardownBlockedError is returned before anything is deleted. With opts.DryRun nothing is deleted,
+// the returned report only lists what would be. In a dry run of the library, the deletions are also added to its plan.
+func (c *Compute) TeardownNetwork(projectID string, network string, opts TeardownOptions) (*TeardownReport, error) {
+	return c.TeardownNetworkCtx(context.Background(), projectID, network, opts)
+}
+
+// TeardownNetworkCtx is TeardownNetwork, using the provided context for the underlying api calls
+func (c *Compute) TeardownNetworkCtx(ctx context.Context, projectID string, network string, opts TeardownOptions) (report *TeardownReport, err error) {
+	defer events.Start(c.Events, service, "TeardownNetwork", events.ActionDelete, projectID, network).Done(&err)
+	network = c.getResourceNameFromURL(network)
+	report = &TeardownReport{
+		Network: network,
+		DryRun:  opts.DryRun || c.DryRun != nil,
+	}
+	if err := c.discoverNetworkResources(ctx, projectID, network, report); err != nil {
+		return report, fmt.Errorf("error discovering resources attached to network %s: %s", network, err.Error())
+	}
+	if len(report.Blockers) > 0 {
+		return report, &TeardownBlockedError{Network: network, Blockers: report.Blockers}
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+	for _, resource := range report.Resources {
+		if c.DryRun != nil {
+			// each deletion adds itself to the plan instead of being made
+			if err := resource.remove(ctx); err != nil {
+				return report, err
+			}
+			continue
+		}
+		c.log.InfoPart("Deleting %s \"%s\"...", resource.Kind, resource.Name)
+		if err := resource.remove(ctx); err != nil {
+			c.log.InfoPart("error\n")
+			return report, fmt.Errorf("error deleting %s %s: %s", resource.Kind, resource.Name, err.Error())
+		}
+		c.log.InfoPart("done\n")
+	}
+	return report, nil
+}
+
+// discoverNetworkResources will add everything attached to the network to the report, in phase order
+func (c *Compute) discoverNetworkResources(ctx context.Context, projectID string, network string, report *TeardownReport) error {
+	existingNetwork, err := c.getNetwork(ctx, projectID, network)
+	if err != nil {
+		return err
+	}
+	onNetwork := func(url string) bool {
+		return url != "" && c.getResourceNameFromURL(url) == network
+	}
+	synchronous := Synchronous()
+
+	// routes can use an internal load balancer's forwarding rule as their next hop, so they're removed along with
+	// the forwarding rules, and ahead of them. Routes created and removed with the network, its subnetworks and
+	// peerings are skipped.
+	err = c.forEachRoute(ctx, projectID, func(route *v1.Route) error {
+		if !onNetwork(route.Network) || route.NextHopNetwork != "" || route.NextHopPeering != "" || strings.HasPrefix(route.Name, "default-route-") {
+			return nil
+		}
+		name := route.Name
+		report.add(teardownPhaseForwardingRules, "route", name, "", func(ctx context.Context) error {
+			return c.DeleteRouteCtx(ctx, projectID, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// instances created by a managed instance group are removed along with it, the group recreating them otherwise
+	instances := map[string]bool{}
+	autoDeleteDisks := map[string]bool{}
+	managedInstances := map[string][]*v1.Instance{}
+	unmanagedInstances := []*v1.Instance{}
+	err = c.forEachInstance(ctx, projectID, func(instance *v1.Instance) error {
+		for _, networkInterface := range instance.NetworkInterfaces {
+			if !onNetwork(networkInterface.Network) {
+				continue
+			}
+			instances[instance.SelfLink] = true
+			for _, disk := range instance.Disks {
+				if disk.AutoDelete {
+					autoDeleteDisks[disk.Source] = true
+				}
+			}
+			if manager := instanceCreatedBy(instance); manager != "" {
+				managedInstances[instanceGroupManagerKey(manager)] = append(managedInstances[instanceGroupManagerKey(manager)], instance)
+			} else {
+				unmanagedInstances = append(unmanagedInstances, instance)
+			}
+			break
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	instanceGroups := map[string]bool{}
+	groupsOnNetwork := []*v1.InstanceGroup{}
+	err = c.forEachInstanceGroup(ctx, projectID, func(group *v1.InstanceGroup) error {
+		if !onNetwork(group.Network) {
+			return nil
+		}
+		instanceGroups[group.SelfLink] = true
+		groupsOnNetwork = append(groupsOnNetwork, group)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// a managed instance group is on the network when its instance group is, or any of its instances are, and
+	// removing it also removes its instance group
+	managedGroups := map[string]bool{}
+	err = c.forEachInstanceGroupManager(ctx, projectID, func(manager *v1.InstanceGroupManager) error {
+		key := instanceGroupManagerKey(manager.SelfLink)
+		if !instanceGroups[manager.InstanceGroup] && len(managedInstances[key]) == 0 {
+			return nil
+		}
+		managedGroups[manager.InstanceGroup] = true
+		delete(managedInstances, key)
+		name := manager.Name
+		if manager.Region != "" {
+			region := manager.Region
+			report.add(teardownPhaseInstances, "regionInstanceGroupManager", name, c.getResourceNameFromURL(region), func(ctx context.Context) error {
+				return c.DeleteRegionInstanceGroupManagerCtx(ctx, projectID, region, name, synchronous)
+			})
+			return nil
+		}
+		zone := manager.Zone
+		report.add(teardownPhaseInstances, "instanceGroupManager", name, c.getResourceNameFromURL(zone), func(ctx context.Context) error {
+			return c.DeleteInstanceGroupManagerCtx(ctx, projectID, zone, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// instances of a managed instance group that no longer exists are left to be removed on their own
+	for _, orphaned := range managedInstances {
+		unmanagedInstances = append(unmanagedInstances, orphaned...)
+	}
+	for _, instance := range unmanagedInstances {
+		zone, name := instance.Zone, instance.Name
+		report.add(teardownPhaseInstances, "instance", name, c.getResourceNameFromURL(zone), func(ctx context.Context) error {
+			return c.DeleteInstanceCtx(ctx, projectID, zone, name, synchronous)
+		})
+	}
+	for _, group := range groupsOnNetwork {
+		if managedGroups[group.SelfLink] {
+			continue
+		}
+		zone, name := group.Zone, group.Name
+		report.add(teardownPhaseInstances, "instanceGroup", name, c.getResourceNameFromURL(zone), func(ctx context.Context) error {
+			return c.DeleteInstanceGroupCtx(ctx, projectID, zone, name, synchronous)
+		})
+	}
+
+	// health checks are only removed when everything using them is also being removed
+	healthChecksRemoved := map[string]bool{}
+	healthChecksKept := map[string]bool{}
+	targetPools := map[string]bool{}
+	err = c.forEachTargetPool(ctx, projectID, func(pool *v1.TargetPool) error {
+		attached := false
+		for _, instance := range pool.Instances {
+			if instances[instance] {
+				attached = true
+			}
+		}
+		for _, healthCheck := range pool.HealthChecks {
+			healthChecksRemoved[healthCheck] = healthChecksRemoved[healthCheck] || attached
+			healthChecksKept[healthCheck] = healthChecksKept[healthCheck] || !attached
+		}
+		if !attached {
+			return nil
+		}
+		targetPools[pool.SelfLink] = true
+		region, name := pool.Region, pool.Name
+		report.add(teardownPhaseLoadBalancers, "targetPool", name, c.getResourceNameFromURL(region), func(ctx context.Context) error {
+			return c.DeleteTargetPoolCtx(ctx, projectID, region, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	backendServices := map[string]bool{}
+	err = c.forEachBackendService(ctx, projectID, func(backendService *v1.BackendService) error {
+		attached := onNetwork(backendService.Network)
+		for _, backend := range backendService.Backends {
+			if instanceGroups[backend.Group] {
+				attached = true
+			}
+		}
+		// global backend services are behind url maps, target proxies and global forwarding rules that
+		// aren't discovered, so they have to be removed some other way
+		if attached && backendService.Region == "" {
+			report.block("backendService", backendService.Name, "")
+			attached = false
+		}
+		for _, healthCheck := range backendService.HealthChecks {
+			healthChecksRemoved[healthCheck] = healthChecksRemoved[healthCheck] || attached
+			healthChecksKept[healthCheck] = healthChecksKept[healthCheck] || !attached
+		}
+		if !attached {
+			return nil
+		}
+		backendServices[backendService.SelfLink] = true
+		region, name := backendService.Region, backendService.Name
+		report.add(teardownPhaseLoadBalancers, "regionBackendService", name, c.getResourceNameFromURL(region), func(ctx context.Context) error {
+			return c.DeleteRegionBackendServiceCtx(ctx, projectID, region, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	forwardingRules := map[string]bool{}
+	err = c.forEachForwardingRule(ctx, projectID, func(rule *v1.ForwardingRule) error {
+		// global forwarding rules are part of global load balancers, removed some other way
+		if rule.Region == "" {
+			if onNetwork(rule.Network) {
+				report.block("globalForwardingRule", rule.Name, "")
+			}
+			return nil
+		}
+		if !onNetwork(rule.Network) && !targetPools[rule.Target] && !backendServices[rule.BackendService] {
+			return nil
+		}
+		forwardingRules[rule.SelfLink] = true
+		region, name := rule.Region, rule.Name
+		report.add(teardownPhaseForwardingRules, "forwardingRule", name, c.getResourceNameFromURL(region), func(ctx context.Context) error {
+			return c.DeleteForwardingRuleCtx(ctx, projectID, region, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.forEachHealthCheck(ctx, projectID, func(healthCheck *v1.HealthCheck) error {
+		if !healthChecksRemoved[healthCheck.SelfLink] || healthChecksKept[healthCheck.SelfLink] {
+			return nil
+		}
+		name := healthCheck.Name
+		report.add(teardownPhaseInstances, "healthCheck", name, "", func(ctx context.Context) error {
+			return c.DeleteHealthCheckCtx(ctx, projectID, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = c.forEachHTTPHealthCheck(ctx, projectID, func(healthCheck *v1.HttpHealthCheck) error {
+		if !healthChecksRemoved[healthCheck.SelfLink] || healthChecksKept[healthCheck.SelfLink] {
+			return nil
+		}
+		name := healthCheck.Name
+		report.add(teardownPhaseInstances, "httpHealthCheck", name, "", func(ctx context.Context) error {
+			return c.DeleteHTTPHealthCheckCtx(ctx, projectID, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// disks are left behind by removed instances when they weren't set to auto-delete
+	err = c.forEachDisk(ctx, projectID, func(disk *v1.Disk) error {
+		if len(disk.Users) == 0 || autoDeleteDisks[disk.SelfLink] {
+			return nil
+		}
+		for _, user := range disk.Users {
+			if !instances[user] {
+				return nil
+			}
+		}
+		zone, name := disk.Zone, disk.Name
+		report.add(teardownPhaseDependents, "disk", name, c.getResourceNameFromURL(zone), func(ctx context.Context) error {
+			return c.DeleteDiskCtx(ctx, projectID, zone, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	subnetworks := map[string]bool{}
+	for _, subnetwork := range existingNetwork.Subnetworks {
+		subnetworks[subnetwork] = true
+	}
+	err = c.forEachAddress(ctx, projectID, func(address *v1.Address) error {
+		// global addresses on the network are reserved for peered services, removed some other way
+		if address.Region == "" {
+			if onNetwork(address.Network) {
+				report.block("globalAddress", address.Name, "")
+			}
+			return nil
+		}
+		attached := onNetwork(address.Network) || subnetworks[address.Subnetwork]
+		for _, user := range address.Users {
+			if forwardingRules[user] {
+				attached = true
+			}
+		}
+		if !attached {
+			return nil
+		}
+		region, name := address.Region, address.Name
+		report.add(teardownPhaseDependents, "address", name, c.getResourceNameFromURL(region), func(ctx context.Context) error {
+			return c.DeleteAddressCtx(ctx, projectID, region, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.forEachFirewall(ctx, projectID, func(firewall *v1.Firewall) error {
+		if !onNetwork(firewall.Network) {
+			return nil
+		}
+		name := firewall.Name
+		report.add(teardownPhaseDependents, "firewall", name, "", func(ctx context.Context) error {
+			return c.DeleteFirewallCtx(ctx, projectID, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// removing a router also removes any cloud nat configured on it
+	err = c.forEachRouter(ctx, projectID, func(router *v1.Router) error {
+		if !onNetwork(router.Network) {
+			return nil
+		}
+		region, name := router.Region, router.Name
+		report.add(teardownPhaseDependents, "router", name, c.getResourceNameFromURL(region), func(ctx context.Context) error {
+			return c.DeleteRouterCtx(ctx, projectID, region, name, synchronous)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, peering := range existingNetwork.Peerings {
+		name := peering.Name
+		report.add(teardownPhaseDependents, "peering", name, "", func(ctx context.Context) error {
+			return c.RemoveNetworkPeeringCtx(ctx, projectID, network, name, synchronous)
+		})
+	}
+
+	// subnetworks of auto mode networks are removed along with the network
+	if !existingNetwork.AutoCreateSubnetworks {
+		for _, subnetwork := range existingNetwork.Subnetworks {
+			region, name := urlRegion(subnetwork), c.getResourceNameFromURL(subnetwork)
+			report.add(teardownPhaseSubnetworks, "subnetwork", name, region, func(ctx context.Context) error {
+				return c.DeleteSubnetworkCtx(ctx, projectID, region, name, synchronous)
+			})
+		}
+	}
+
+	report.add(teardownPhaseNetwork, "network", network, "", func(ctx context.Context) error {
+		return c.DeleteNetworkCtx(ctx, projectID, network, synchronous)
+	})
+
+	// keep discovery order within each phase
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		return report.Resources[i].Phase < report.Resources[j].Phase
+	})
+	return nil
+}
+
+// Local utility function to extract a region name from a regional resource URL
+func urlRegion(url string) string {
+	parts := strings.Split(url, "/")
+	for i, part := range parts {
+		if part == "regions" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// instanceCreatedBy will return the url of the managed instance group that created an instance, blank if it wasn't
+func instanceCreatedBy(instance *v1.Instance) string {
+	if instance.Metadata == nil {
+		return ""
+	}
+	for _, item := range instance.Metadata.Items {
+		if item.Key == "created-by" && item.Value != nil {
+			return *item.Value
+		}
+	}
+	return ""
+}
+
+// instanceGroupManagerKey will return the location and name of a managed instance group from its url, e.g.
+// zones/us-central1-a/instanceGroupManagers/web, the same whether the url is full or partial and has the project
+// id or number
+func instanceGroupManagerKey(url string) string {
+	parts := strings.Split(url, "/")
+	if len(parts) < 4 {
+		return url
+	}
+	return strings.Join(parts[len(parts)-4:], "/")
+}
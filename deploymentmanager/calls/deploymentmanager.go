@@ -2,58 +2,105 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v2beta "google.golang.org/api/deploymentmanager/v2beta"
 	googleapi "google.golang.org/api/googleapi"
 )
 
+// service is the name of the library in the telemetry of its calls
+const service = "deploymentmanager"
+
 // DeploymentsGetCallInterface is an interface to a call to get a deployment
 type DeploymentsGetCallInterface interface {
-	Do(call *v2beta.DeploymentsGetCall, opts ...googleapi.CallOption) (*v2beta.Deployment, error)
+	Do(ctx context.Context, call *v2beta.DeploymentsGetCall, opts ...googleapi.CallOption) (*v2beta.Deployment, error)
 }
 
 // DeploymentsInsertCallInterface is an interface to a call to create/insert a deployment
 type DeploymentsInsertCallInterface interface {
-	Do(call *v2beta.DeploymentsInsertCall, opts ...googleapi.CallOption) (*v2beta.Operation, error)
+	Do(ctx context.Context, call *v2beta.DeploymentsInsertCall, opts ...googleapi.CallOption) (*v2beta.Operation, error)
 }
 
 // DeploymentsUpdateCallInterface is an interface to a call to update a deployment
 type DeploymentsUpdateCallInterface interface {
-	Do(call *v2beta.DeploymentsUpdateCall, opts ...googleapi.CallOption) (*v2beta.Operation, error)
+	Do(ctx context.Context, call *v2beta.DeploymentsUpdateCall, opts ...googleapi.CallOption) (*v2beta.Operation, error)
 }
 
 // DeploymentsDeleteCallInterface is an interface to a call to update a deployment
 type DeploymentsDeleteCallInterface interface {
-	Do(call *v2beta.DeploymentsDeleteCall, opts ...googleapi.CallOption) (*v2beta.Operation, error)
+	Do(ctx context.Context, call *v2beta.DeploymentsDeleteCall, opts ...googleapi.CallOption) (*v2beta.Operation, error)
 }
 
 // DeploymentsGetCall is the default implementation for DeploymentsGetCallInterface
-type DeploymentsGetCall struct{}
+type DeploymentsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // DeploymentsInsertCall is the default implementation for DeploymentsInsertCallInterface
-type DeploymentsInsertCall struct{}
+type DeploymentsInsertCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // DeploymentsUpdateCall is the default implementation for DeploymentsUpdateCallInterface
-type DeploymentsUpdateCall struct{}
+type DeploymentsUpdateCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // DeploymentsDeleteCall is the default implementation for DeploymentsDeleteCallInterface
-type DeploymentsDeleteCall struct{}
+type DeploymentsDeleteCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *DeploymentsGetCall) Do(call *v2beta.DeploymentsGetCall, opts ...googleapi.CallOption) (*v2beta.Deployment, error) {
-	return call.Do(opts...)
+func (c *DeploymentsGetCall) Do(ctx context.Context, call *v2beta.DeploymentsGetCall, opts ...googleapi.CallOption) (*v2beta.Deployment, error) {
+	var result *v2beta.Deployment
+	err := c.Telemetry.Do(ctx, service, "DeploymentsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *DeploymentsInsertCall) Do(call *v2beta.DeploymentsInsertCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
-	return call.Do(opts...)
+func (c *DeploymentsInsertCall) Do(ctx context.Context, call *v2beta.DeploymentsInsertCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
+	var result *v2beta.Operation
+	err := c.Telemetry.Do(ctx, service, "DeploymentsInsert", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *DeploymentsUpdateCall) Do(call *v2beta.DeploymentsUpdateCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
-	return call.Do(opts...)
+func (c *DeploymentsUpdateCall) Do(ctx context.Context, call *v2beta.DeploymentsUpdateCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
+	var result *v2beta.Operation
+	err := c.Telemetry.Do(ctx, service, "DeploymentsUpdate", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
 
 // Do performs the call, the default implementation of the interface
-func (c *DeploymentsDeleteCall) Do(call *v2beta.DeploymentsDeleteCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
-	return call.Do(opts...)
+func (c *DeploymentsDeleteCall) Do(ctx context.Context, call *v2beta.DeploymentsDeleteCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
+	var result *v2beta.Operation
+	err := c.Telemetry.Do(ctx, service, "DeploymentsDelete", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -1,19 +1,33 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v2beta "google.golang.org/api/deploymentmanager/v2beta"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // ManifestsGetCallInterface is an interface to a call to get a deployment manifest/config
 type ManifestsGetCallInterface interface {
-	Do(call *v2beta.ManifestsGetCall, opts ...googleapi.CallOption) (*v2beta.Manifest, error)
+	Do(ctx context.Context, call *v2beta.ManifestsGetCall, opts ...googleapi.CallOption) (*v2beta.Manifest, error)
 }
 
 // ManifestsGetCall is the default implementation for ManifestsGetCallInterface
-type ManifestsGetCall struct{}
+type ManifestsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *ManifestsGetCall) Do(call *v2beta.ManifestsGetCall, opts ...googleapi.CallOption) (*v2beta.Manifest, error) {
-	return call.Do(opts...)
+func (c *ManifestsGetCall) Do(ctx context.Context, call *v2beta.ManifestsGetCall, opts ...googleapi.CallOption) (*v2beta.Manifest, error) {
+	var result *v2beta.Manifest
+	err := c.Telemetry.Do(ctx, service, "ManifestsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -1,19 +1,33 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v2beta "google.golang.org/api/deploymentmanager/v2beta"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // OperationsGetCallInterface is an interface to a call to get a deployment manager operation
 type OperationsGetCallInterface interface {
-	Do(call *v2beta.OperationsGetCall, opts ...googleapi.CallOption) (*v2beta.Operation, error)
+	Do(ctx context.Context, call *v2beta.OperationsGetCall, opts ...googleapi.CallOption) (*v2beta.Operation, error)
 }
 
 // OperationsGetCall is the default implementation for OperationsGetCallInterface
-type OperationsGetCall struct{}
+type OperationsGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *OperationsGetCall) Do(call *v2beta.OperationsGetCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
-	return call.Do(opts...)
+func (c *OperationsGetCall) Do(ctx context.Context, call *v2beta.OperationsGetCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
+	var result *v2beta.Operation
+	err := c.Telemetry.Do(ctx, service, "OperationsGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
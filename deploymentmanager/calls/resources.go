@@ -1,19 +1,33 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v2beta "google.golang.org/api/deploymentmanager/v2beta"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // ResourcesGetCallInterface is an interface to a call to get a resource from a deployment
 type ResourcesGetCallInterface interface {
-	Do(call *v2beta.ResourcesGetCall, opts ...googleapi.CallOption) (*v2beta.Resource, error)
+	Do(ctx context.Context, call *v2beta.ResourcesGetCall, opts ...googleapi.CallOption) (*v2beta.Resource, error)
 }
 
 // ResourcesGetCall is the default implementation for ResourcesGetCallInterface
-type ResourcesGetCall struct{}
+type ResourcesGetCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *ResourcesGetCall) Do(call *v2beta.ResourcesGetCall, opts ...googleapi.CallOption) (*v2beta.Resource, error) {
-	return call.Do(opts...)
+func (c *ResourcesGetCall) Do(ctx context.Context, call *v2beta.ResourcesGetCall, opts ...googleapi.CallOption) (*v2beta.Resource, error) {
+	var result *v2beta.Resource
+	err := c.Telemetry.Do(ctx, service, "ResourcesGet", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
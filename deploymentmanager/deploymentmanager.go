@@ -6,23 +6,38 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/rockholla/go-google-lib/deploymentmanager/calls"
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	v2beta "google.golang.org/api/deploymentmanager/v2beta"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 	yaml "gopkg.in/yaml.v2"
 )
 
+// service is the name of the library in the events it emits
+const service = "deploymentmanager"
+
 // Interface represents functionality for DeploymentManager
 type Interface interface {
 	Initialize(credentials string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error
 	GetResourcePropertyValue(deploymentName string, inProject string, resourceName string, propertyName string) (string, error)
+	GetResourcePropertyValueCtx(ctx context.Context, deploymentName string, inProject string, resourceName string, propertyName string) (string, error)
 	GetDeployment(deploymentName string, inProject string, parseManifest bool) (*Deployment, error)
+	GetDeploymentCtx(ctx context.Context, deploymentName string, inProject string, parseManifest bool) (*Deployment, error)
 	EnsureDeployment(deploymentName string, description string, inProject string, deployment *Deployment) ([]*Output, error)
+	EnsureDeploymentCtx(ctx context.Context, deploymentName string, description string, inProject string, deployment *Deployment) ([]*Output, error)
 	DeleteDeployment(deploymentName string, inProject string, abandon bool) error
+	DeleteDeploymentCtx(ctx context.Context, deploymentName string, inProject string, abandon bool) error
 }
 
 // DeploymentManager is a wrapper around the google-provided sdks/apis for google.golang.org/api/deploymentmanager/*
@@ -30,8 +45,23 @@ type DeploymentManager struct {
 	log                 logger.Interface
 	V2Beta              *v2beta.Service
 	Calls               *Calls
-	RetryWaitSeconds    int64
+	Retry               *retry.Policy
 	ProgressWaitSeconds int64
+	ClientOptions       []option.ClientOption
+	// Endpoint overrides where api requests are sent, e.g. to a local emulator or fake
+	Endpoint string
+	// Insecure sends api requests without credentials, for an Endpoint that doesn't expect them
+	Insecure bool
+	// Transport is the base http transport api requests are sent through, underneath authentication, e.g. a
+	// recorder.Recorder to record or replay them
+	Transport http.RoundTripper
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
 }
 
 // Calls are interfaces for making the actual calls to various underlying apis
@@ -84,41 +114,62 @@ type Output struct {
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (dm *DeploymentManager) Initialize(credentials string, log logger.Interface) error {
+	return dm.InitializeCtx(context.Background(), credentials, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (dm *DeploymentManager) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	dm.log = log
-	dm.RetryWaitSeconds = 60
 	dm.ProgressWaitSeconds = 10
+	if dm.Retry == nil {
+		dm.Retry = retry.DefaultPolicy()
+	}
 	dm.Calls = &Calls{
-		ResourcesGet:      &calls.ResourcesGetCall{},
-		DeploymentsGet:    &calls.DeploymentsGetCall{},
-		DeploymentsInsert: &calls.DeploymentsInsertCall{},
-		DeploymentsUpdate: &calls.DeploymentsUpdateCall{},
-		DeploymentsDelete: &calls.DeploymentsDeleteCall{},
-		OperationsGet:     &calls.OperationsGetCall{},
-		ManifestsGet:      &calls.ManifestsGetCall{},
-	}
-	if credentials != "" {
-		if dm.V2Beta, err = v2beta.NewService(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-	} else {
-		if dm.V2Beta, err = v2beta.NewService(ctx); err != nil {
+		ResourcesGet:      &calls.ResourcesGetCall{Retry: dm.Retry, Telemetry: dm.Telemetry},
+		DeploymentsGet:    &calls.DeploymentsGetCall{Retry: dm.Retry, Telemetry: dm.Telemetry},
+		DeploymentsInsert: &calls.DeploymentsInsertCall{Retry: dm.Retry, Telemetry: dm.Telemetry},
+		DeploymentsUpdate: &calls.DeploymentsUpdateCall{Retry: dm.Retry, Telemetry: dm.Telemetry},
+		DeploymentsDelete: &calls.DeploymentsDeleteCall{Retry: dm.Retry, Telemetry: dm.Telemetry},
+		OperationsGet:     &calls.OperationsGetCall{Retry: dm.Retry, Telemetry: dm.Telemetry},
+		ManifestsGet:      &calls.ManifestsGetCall{Retry: dm.Retry, Telemetry: dm.Telemetry},
+	}
+	clientOptions := append([]option.ClientOption{}, dm.ClientOptions...)
+	if credentials != "" && !dm.Insecure {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if dm.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(dm.Endpoint))
+	}
+	if dm.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication())
+	}
+	if dm.Transport != nil {
+		transport, err := htransport.NewTransport(ctx, dm.Transport, append([]option.ClientOption{option.WithScopes(v2beta.CloudPlatformScope)}, clientOptions...)...)
+		if err != nil {
 			return err
 		}
+		clientOptions = append(clientOptions, option.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	if dm.V2Beta, err = v2beta.NewService(ctx, clientOptions...); err != nil {
+		return err
 	}
 	return nil
 }
 
 // GetResourcePropertyValue will get an existing resource property, if the resource exists, otherwise will return blank
 func (dm *DeploymentManager) GetResourcePropertyValue(deploymentName string, inProject string, resourceName string, propertyName string) (string, error) {
-	value := ""
-	ctx := context.Background()
+	return dm.GetResourcePropertyValueCtx(context.Background(), deploymentName, inProject, resourceName, propertyName)
+}
+
+// GetResourcePropertyValueCtx is GetResourcePropertyValue, using the provided context for the underlying api calls
+func (dm *DeploymentManager) GetResourcePropertyValueCtx(ctx context.Context, deploymentName string, inProject string, resourceName string, propertyName string) (value string, err error) {
+	defer events.Start(dm.Events, service, "GetResourcePropertyValue", events.ActionRead, inProject, deploymentName).Done(&err)
 	resourcesService := v2beta.NewResourcesService(dm.V2Beta)
 	resourceGetCall := resourcesService.Get(inProject, deploymentName, resourceName).Context(ctx)
-	resource, err := dm.Calls.ResourcesGet.Do(resourceGetCall)
+	resource, err := dm.Calls.ResourcesGet.Do(telemetry.WithResource(ctx, inProject, deploymentName), resourceGetCall)
 	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "notfound") {
+		if googleerrors.IsNotFound(err) {
 			return value, nil
 		}
 		return value, err
@@ -134,21 +185,26 @@ func (dm *DeploymentManager) GetResourcePropertyValue(deploymentName string, inP
 	return value, nil
 }
 
-func (dm *DeploymentManager) isRetryError(err error) bool {
-	if err == nil {
-		return false
-	}
-	if strings.Contains(strings.ToLower(err.Error()), "wait a few minutes") {
-		dm.log.Info("instructed to wait, retrying in %d seconds (this likely means that the deployment manager api is not enabled, yet)...\n", dm.RetryWaitSeconds)
-		time.Sleep(time.Duration(dm.RetryWaitSeconds) * time.Second)
-		return true
+// operationRetry will return the retry policy for the conditions specific to deployment manager that are worth
+// waiting out: the api having just been enabled, or another operation being in progress on the deployment. The
+// transient errors common to all apis are already retried by the default Calls.
+func (dm *DeploymentManager) operationRetry() *retry.Policy {
+	if dm.Retry == nil {
+		return nil
 	}
-	if strings.Contains(strings.ToLower(err.Error()), "conflicting operation") {
-		dm.log.Info("conflicting operation ongoing, retrying in %d seconds...\n", dm.RetryWaitSeconds)
-		time.Sleep(time.Duration(dm.RetryWaitSeconds) * time.Second)
-		return true
+	policy := *dm.Retry
+	policy.Retryable = func(err error) bool {
+		if googleerrors.IsServiceDisabled(err) {
+			dm.log.Info("instructed to wait, retrying (this likely means that the deployment manager api is not enabled, yet)...\n")
+			return true
+		}
+		if googleerrors.IsConflict(err) && !googleerrors.IsAlreadyExists(err) {
+			dm.log.Info("conflicting operation ongoing, retrying...\n")
+			return true
+		}
+		return false
 	}
-	return false
+	return &policy
 }
 
 func (dm *DeploymentManager) processOperation(operation *v2beta.Operation) error {
@@ -164,26 +220,37 @@ func (dm *DeploymentManager) processOperation(operation *v2beta.Operation) error
 
 // GetDeployment will get an existing deployment if it exists
 func (dm *DeploymentManager) GetDeployment(deploymentName string, inProject string, parseManifest bool) (*Deployment, error) {
-	ctx := context.Background()
-	deployment := &Deployment{}
+	return dm.GetDeploymentCtx(context.Background(), deploymentName, inProject, parseManifest)
+}
+
+// GetDeploymentCtx is GetDeployment, using the provided context for the underlying api calls
+func (dm *DeploymentManager) GetDeploymentCtx(ctx context.Context, deploymentName string, inProject string, parseManifest bool) (deployment *Deployment, err error) {
+	defer events.Start(dm.Events, service, "GetDeployment", events.ActionRead, inProject, deploymentName).Done(&err)
+	return dm.getDeployment(ctx, deploymentName, inProject, parseManifest)
+}
+
+// getDeployment is GetDeploymentCtx without its event, for use within other methods
+func (dm *DeploymentManager) getDeployment(ctx context.Context, deploymentName string, inProject string, parseManifest bool) (deployment *Deployment, err error) {
+	deployment = &Deployment{}
 	deploymentManagerService := v2beta.NewDeploymentsService(dm.V2Beta)
 	deploymentGetCall := deploymentManagerService.Get(inProject, deploymentName).Context(ctx)
-	existingDeployment, err := dm.Calls.DeploymentsGet.Do(deploymentGetCall)
+	var existingDeployment *v2beta.Deployment
+	err = dm.operationRetry().Do(ctx, func() (err error) {
+		existingDeployment, err = dm.Calls.DeploymentsGet.Do(telemetry.WithResource(ctx, inProject, deploymentName), deploymentGetCall)
+		return err
+	})
 	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "notfound") {
+		if googleerrors.IsNotFound(err) {
 			return nil, nil
-		} else if dm.isRetryError(err) {
-			return dm.GetDeployment(deploymentName, inProject, parseManifest)
-		} else {
-			return deployment, err
 		}
+		return deployment, err
 	}
 	deployment.Source = existingDeployment
 	if parseManifest {
 		manifestsService := v2beta.NewManifestsService(dm.V2Beta)
 		manifestGetCall := manifestsService.Get(inProject, deployment.Source.Name,
 			deployment.Source.Manifest[strings.LastIndex(deployment.Source.Manifest, "/")+1:]).Context(ctx)
-		manifest, err := dm.Calls.ManifestsGet.Do(manifestGetCall)
+		manifest, err := dm.Calls.ManifestsGet.Do(telemetry.WithResource(ctx, inProject, deploymentName), manifestGetCall)
 		if err != nil {
 			return deployment, fmt.Errorf("error getting deployment manifest: %s", err.Error())
 		}
@@ -215,11 +282,16 @@ func (dm *DeploymentManager) GetDeployment(deploymentName string, inProject stri
 	return deployment, nil
 }
 
-// EnsureDeployment will make sure that a deployment exists
+// EnsureDeployment will make sure that a deployment exists. A dry run returns the outputs of the existing deployment,
+// if any.
 func (dm *DeploymentManager) EnsureDeployment(deploymentName string, description string, inProject string, deployment *Deployment) ([]*Output, error) {
-	ctx := context.Background()
+	return dm.EnsureDeploymentCtx(context.Background(), deploymentName, description, inProject, deployment)
+}
+
+// EnsureDeploymentCtx is EnsureDeployment, using the provided context for the underlying api calls
+func (dm *DeploymentManager) EnsureDeploymentCtx(ctx context.Context, deploymentName string, description string, inProject string, deployment *Deployment) (outputs []*Output, err error) {
+	defer events.Start(dm.Events, service, "EnsureDeployment", events.ActionEnsure, inProject, deploymentName).Done(&err)
 	var operation *v2beta.Operation
-	var outputs []*Output
 	targetConfiguration := &v2beta.TargetConfiguration{}
 	dm.log.InfoPart("Ensuring deployment \"%s\" in project \"%s\"...", deploymentName, inProject)
 	for _, deploymentImport := range deployment.Imports {
@@ -245,26 +317,40 @@ func (dm *DeploymentManager) EnsureDeployment(deploymentName string, description
 		Description: description,
 		Target:      targetConfiguration,
 	}
+	if dm.DryRun != nil {
+		return dm.planDeployment(ctx, deploymentName, inProject, deploymentManagerDeployment)
+	}
 	deploymentManagerService := v2beta.NewDeploymentsService(dm.V2Beta)
-	existingDeployment, err := dm.GetDeployment(deploymentName, inProject, false)
-	if err != nil {
-		return outputs, fmt.Errorf("error trying to determine if deployment exists already: %s", err.Error())
+	var existingDeployment *Deployment
+	var getErr error
+	// a retry starts over from getting the existing deployment, so that an update uses the latest fingerprint
+	err = dm.operationRetry().Do(ctx, func() (err error) {
+		if existingDeployment, getErr = dm.getDeployment(ctx, deploymentName, inProject, false); getErr != nil {
+			return nil
+		}
+		if existingDeployment == nil {
+			dm.log.SpinnerStart("creating")
+			deploymentInsertCall := deploymentManagerService.Insert(inProject, deploymentManagerDeployment).Context(ctx)
+			operation, err = dm.Calls.DeploymentsInsert.Do(telemetry.WithResource(ctx, inProject, deploymentName), deploymentInsertCall)
+		} else {
+			deploymentManagerDeployment.Fingerprint = existingDeployment.Source.Fingerprint
+			dm.log.SpinnerStart("updating")
+			deploymentUpdateCall := deploymentManagerService.Update(inProject, deploymentName, deploymentManagerDeployment).Context(ctx)
+			operation, err = dm.Calls.DeploymentsUpdate.Do(telemetry.WithResource(ctx, inProject, deploymentName), deploymentUpdateCall)
+		}
+		if err != nil {
+			dm.log.SpinnerStop()
+		}
+		return err
+	})
+	if getErr != nil {
+		return outputs, fmt.Errorf("error trying to determine if deployment exists already: %s", getErr.Error())
 	}
-	if existingDeployment == nil {
-		dm.log.SpinnerStart("creating")
-		deploymentInsertCall := deploymentManagerService.Insert(inProject, deploymentManagerDeployment).Context(ctx)
-		operation, err = dm.Calls.DeploymentsInsert.Do(deploymentInsertCall)
-	} else {
-		deploymentManagerDeployment.Fingerprint = existingDeployment.Source.Fingerprint
-		dm.log.SpinnerStart("updating")
-		deploymentUpdateCall := deploymentManagerService.Update(inProject, deploymentName, deploymentManagerDeployment).Context(ctx)
-		operation, err = dm.Calls.DeploymentsUpdate.Do(deploymentUpdateCall)
-	}
-	if dm.isRetryError(err) {
-		dm.log.SpinnerStop()
-		return dm.EnsureDeployment(deploymentName, description, inProject, deployment)
+	if err != nil {
+		dm.log.InfoPart("error\n")
+		return outputs, fmt.Errorf("error creating or updating the deployment: %s", err.Error())
 	}
-	if operationErr := dm.trackOperation(operation, inProject); err != nil || operationErr != nil {
+	if operationErr := dm.trackOperation(ctx, operation, inProject); operationErr != nil {
 		if err == nil {
 			err = operationErr
 		}
@@ -274,7 +360,7 @@ func (dm *DeploymentManager) EnsureDeployment(deploymentName string, description
 	}
 	dm.log.SpinnerStop()
 	dm.log.InfoPart("done\n")
-	existingDeployment, err = dm.GetDeployment(deploymentName, inProject, true)
+	existingDeployment, err = dm.getDeployment(ctx, deploymentName, inProject, true)
 	if err != nil {
 		return outputs, fmt.Errorf("error getting updated deployment after operation: %s", err.Error())
 	}
@@ -284,10 +370,14 @@ func (dm *DeploymentManager) EnsureDeployment(deploymentName string, description
 
 // DeleteDeployment will fully delete a deployment
 func (dm *DeploymentManager) DeleteDeployment(deploymentName string, inProject string, abandon bool) error {
-	var err error
-	ctx := context.Background()
+	return dm.DeleteDeploymentCtx(context.Background(), deploymentName, inProject, abandon)
+}
+
+// DeleteDeploymentCtx is DeleteDeployment, using the provided context for the underlying api calls
+func (dm *DeploymentManager) DeleteDeploymentCtx(ctx context.Context, deploymentName string, inProject string, abandon bool) (err error) {
+	defer events.Start(dm.Events, service, "DeleteDeployment", events.ActionDelete, inProject, deploymentName).Done(&err)
 	dm.log.InfoPart("Deleting deployment \"%s\" in project \"%s\"...", deploymentName, inProject)
-	existingDeployment, err := dm.GetDeployment(deploymentName, inProject, false)
+	existingDeployment, err := dm.getDeployment(ctx, deploymentName, inProject, false)
 	if err != nil {
 		dm.log.InfoPart("\n")
 		return fmt.Errorf("error trying to determine if deployment exists already: %s", err.Error())
@@ -296,19 +386,22 @@ func (dm *DeploymentManager) DeleteDeployment(deploymentName string, inProject s
 		dm.log.InfoPart("doesn't exist\n")
 		return nil
 	}
+	if dm.DryRun.Record(plan.Change{Service: service, Method: "DeleteDeployment", Project: inProject, Resource: deploymentName, Action: plan.ActionDelete, Before: existingDeployment.Source}) {
+		dm.log.InfoPart("would be deleted\n")
+		return nil
+	}
 	dm.log.SpinnerStart("deleting")
 	deploymentManagerService := v2beta.NewDeploymentsService(dm.V2Beta)
 	deploymentDeleteCall := deploymentManagerService.Delete(inProject, deploymentName).Context(ctx)
 	if abandon {
 		deploymentDeleteCall = deploymentDeleteCall.DeletePolicy("ABANDON")
 	}
-	operation, err := dm.Calls.DeploymentsDelete.Do(deploymentDeleteCall)
-	if dm.isRetryError(err) {
-		dm.log.SpinnerStop()
-		return dm.DeleteDeployment(deploymentName, inProject, abandon)
-	}
-
-	if operationErr := dm.trackOperation(operation, inProject); err != nil || operationErr != nil {
+	var operation *v2beta.Operation
+	err = dm.operationRetry().Do(ctx, func() (err error) {
+		operation, err = dm.Calls.DeploymentsDelete.Do(telemetry.WithResource(ctx, inProject, deploymentName), deploymentDeleteCall)
+		return err
+	})
+	if operationErr := dm.trackOperation(ctx, operation, inProject); err != nil || operationErr != nil {
 		if err == nil {
 			err = operationErr
 		}
@@ -321,9 +414,29 @@ func (dm *DeploymentManager) DeleteDeployment(deploymentName string, inProject s
 	return nil
 }
 
-func (dm *DeploymentManager) trackOperation(operation *v2beta.Operation, inProject string) error {
+// planDeployment will add the creation or update of the deployment to the dry run, returning the outputs of the
+// existing deployment
+func (dm *DeploymentManager) planDeployment(ctx context.Context, deploymentName string, inProject string, deployment *v2beta.Deployment) ([]*Output, error) {
+	existingDeployment, err := dm.getDeployment(ctx, deploymentName, inProject, true)
+	if err != nil {
+		dm.log.InfoPart("\n")
+		return nil, fmt.Errorf("error trying to determine if deployment exists already: %s", err.Error())
+	}
+	change := plan.Change{Service: service, Method: "EnsureDeployment", Project: inProject, Resource: deploymentName, Action: plan.ActionCreate, After: deployment}
+	if existingDeployment != nil {
+		change.Action = plan.ActionUpdate
+		change.Before = existingDeployment.Source
+	}
+	dm.DryRun.Record(change)
+	dm.log.InfoPart("would be %sd\n", change.Action)
+	if existingDeployment == nil {
+		return nil, nil
+	}
+	return existingDeployment.Outputs, nil
+}
+
+func (dm *DeploymentManager) trackOperation(ctx context.Context, operation *v2beta.Operation, inProject string) error {
 	var err error
-	ctx := context.Background()
 	if operation == nil {
 		return nil
 	}
@@ -332,10 +445,14 @@ func (dm *DeploymentManager) trackOperation(operation *v2beta.Operation, inProje
 	}
 	operationsService := v2beta.NewOperationsService(dm.V2Beta)
 	operationGetCall := operationsService.Get(inProject, operation.Name).Context(ctx)
-	operation, err = dm.Calls.OperationsGet.Do(operationGetCall)
+	operation, err = dm.Calls.OperationsGet.Do(telemetry.WithResource(ctx, inProject, operation.Name), operationGetCall)
 	for operation != nil && operation.Progress < 100 && operation.Error == nil {
-		operation, err = dm.Calls.OperationsGet.Do(operationGetCall)
-		time.Sleep(time.Duration(dm.ProgressWaitSeconds) * time.Second)
+		operation, err = dm.Calls.OperationsGet.Do(telemetry.WithResource(ctx, inProject, operation.Name), operationGetCall)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(dm.ProgressWaitSeconds) * time.Second):
+		}
 	}
 	if operation == nil {
 		return nil
@@ -1,11 +1,13 @@
 package deploymentmanager
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
+	"github.com/rockholla/go-google-lib/retry"
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
 	v2beta "google.golang.org/api/deploymentmanager/v2beta"
 	googleapi "google.golang.org/api/googleapi"
@@ -113,10 +115,10 @@ type operationsGetMock struct{}
 type manifestsGetMock struct{}
 
 // Do is the mock for default resourcesGetMock
-func (c *resourcesGetMock) Do(call *v2beta.ResourcesGetCall, opts ...googleapi.CallOption) (*v2beta.Resource, error) {
+func (c *resourcesGetMock) Do(ctx context.Context, call *v2beta.ResourcesGetCall, opts ...googleapi.CallOption) (*v2beta.Resource, error) {
 	if triggerResourceNotFound {
 		triggerResourceNotFound = false
-		return &v2beta.Resource{}, errors.New("NotFound")
+		return &v2beta.Resource{}, &googleapi.Error{Code: http.StatusNotFound}
 	}
 	properties := testProperties
 	if triggerProjectProperties {
@@ -130,14 +132,20 @@ func (c *resourcesGetMock) Do(call *v2beta.ResourcesGetCall, opts ...googleapi.C
 }
 
 // Do is the mock for default deploymentsGetMock
-func (c *deploymentsGetMock) Do(call *v2beta.DeploymentsGetCall, opts ...googleapi.CallOption) (*v2beta.Deployment, error) {
+func (c *deploymentsGetMock) Do(ctx context.Context, call *v2beta.DeploymentsGetCall, opts ...googleapi.CallOption) (*v2beta.Deployment, error) {
 	if triggerDeploymentNotFound {
 		triggerDeploymentNotFound = false
-		return &v2beta.Deployment{}, errors.New("NotFound")
+		return &v2beta.Deployment{}, &googleapi.Error{Code: http.StatusNotFound}
 	}
 	if triggerGetDeploymentRetry {
 		triggerGetDeploymentRetry = false
-		return &v2beta.Deployment{}, errors.New("wait a few minutes")
+		return &v2beta.Deployment{}, &googleapi.Error{
+			Code:    http.StatusForbidden,
+			Message: "Cloud Deployment Manager API has not been used in project before or it is disabled, wait a few minutes",
+			Errors: []googleapi.ErrorItem{
+				{Reason: "accessNotConfigured"},
+			},
+		}
 	}
 	return &v2beta.Deployment{
 		Name:     testDeploymentName,
@@ -146,7 +154,7 @@ func (c *deploymentsGetMock) Do(call *v2beta.DeploymentsGetCall, opts ...googlea
 }
 
 // Do is the mock for default deploymentsInsertMock
-func (c *deploymentsInsertMock) Do(call *v2beta.DeploymentsInsertCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
+func (c *deploymentsInsertMock) Do(ctx context.Context, call *v2beta.DeploymentsInsertCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
 	if triggerCreateOperationError {
 		triggerCreateOperationError = false
 		return &v2beta.Operation{
@@ -170,7 +178,7 @@ func (c *deploymentsInsertMock) Do(call *v2beta.DeploymentsInsertCall, opts ...g
 }
 
 // Do is the mock for default deploymentsUpdateMock
-func (c *deploymentsUpdateMock) Do(call *v2beta.DeploymentsUpdateCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
+func (c *deploymentsUpdateMock) Do(ctx context.Context, call *v2beta.DeploymentsUpdateCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
 	if triggerUpdateOperationError {
 		triggerUpdateOperationError = false
 		return &v2beta.Operation{
@@ -194,7 +202,7 @@ func (c *deploymentsUpdateMock) Do(call *v2beta.DeploymentsUpdateCall, opts ...g
 }
 
 // Do is the mock for default deploymentsDeleteMock
-func (c *deploymentsDeleteMock) Do(call *v2beta.DeploymentsDeleteCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
+func (c *deploymentsDeleteMock) Do(ctx context.Context, call *v2beta.DeploymentsDeleteCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
 	if triggerDeleteOperationError {
 		triggerDeleteOperationError = false
 		return &v2beta.Operation{
@@ -218,7 +226,7 @@ func (c *deploymentsDeleteMock) Do(call *v2beta.DeploymentsDeleteCall, opts ...g
 }
 
 // Do is the mock for default operationsGetMock
-func (c *operationsGetMock) Do(call *v2beta.OperationsGetCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
+func (c *operationsGetMock) Do(ctx context.Context, call *v2beta.OperationsGetCall, opts ...googleapi.CallOption) (*v2beta.Operation, error) {
 	if triggerPostGetOperationError {
 		triggerPostGetOperationError = false
 		return &v2beta.Operation{
@@ -250,7 +258,7 @@ func (c *operationsGetMock) Do(call *v2beta.OperationsGetCall, opts ...googleapi
 }
 
 // Do is the mock for default manifestsGetMock
-func (c *manifestsGetMock) Do(call *v2beta.ManifestsGetCall, opts ...googleapi.CallOption) (*v2beta.Manifest, error) {
+func (c *manifestsGetMock) Do(ctx context.Context, call *v2beta.ManifestsGetCall, opts ...googleapi.CallOption) (*v2beta.Manifest, error) {
 	return &v2beta.Manifest{
 		Config: &v2beta.ConfigFile{
 			Content: testManifestConfig,
@@ -261,7 +269,7 @@ func (c *manifestsGetMock) Do(call *v2beta.ManifestsGetCall, opts ...googleapi.C
 
 func setCallMockDefaults(dm *DeploymentManager) {
 	dm.ProgressWaitSeconds = 1
-	dm.RetryWaitSeconds = 1
+	dm.Retry = &retry.Policy{MaxAttempts: 3}
 	dm.Calls = &Calls{
 		ResourcesGet:      &resourcesGetMock{},
 		DeploymentsGet:    &deploymentsGetMock{},
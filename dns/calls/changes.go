@@ -2,19 +2,36 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/dns/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
+// service is the name of the library in the telemetry of its calls
+const service = "dns"
+
 // ChangesCreateCallInterface is an interface to a call to create a dns-related change
 type ChangesCreateCallInterface interface {
-	Do(call *v1.ChangesCreateCall, opts ...googleapi.CallOption) (*v1.Change, error)
+	Do(ctx context.Context, call *v1.ChangesCreateCall, opts ...googleapi.CallOption) (*v1.Change, error)
 }
 
 // ChangesCreateCall is the default implementation for ChangesCreateCallInterface
-type ChangesCreateCall struct{}
+type ChangesCreateCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *ChangesCreateCall) Do(call *v1.ChangesCreateCall, opts ...googleapi.CallOption) (*v1.Change, error) {
-	return call.Do(opts...)
+func (c *ChangesCreateCall) Do(ctx context.Context, call *v1.ChangesCreateCall, opts ...googleapi.CallOption) (*v1.Change, error) {
+	var result *v1.Change
+	err := c.Telemetry.Do(ctx, service, "ChangesCreate", func(ctx context.Context) error {
+		return c.Retry.Mutating().Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -1,19 +1,33 @@
 package calls
 
 import (
+	"context"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	v1 "google.golang.org/api/dns/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
 
 // ResourceRecordSetsListCallInterface is an interface to a call to get a list of resource record sets in a managed zone
 type ResourceRecordSetsListCallInterface interface {
-	Do(call *v1.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*v1.ResourceRecordSetsListResponse, error)
+	Do(ctx context.Context, call *v1.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*v1.ResourceRecordSetsListResponse, error)
 }
 
 // ResourceRecordSetsListCall is the default implementation for ResourceRecordSetsListCallInterface
-type ResourceRecordSetsListCall struct{}
+type ResourceRecordSetsListCall struct {
+	Retry     *retry.Policy
+	Telemetry *telemetry.Telemetry
+}
 
 // Do performs the call, the default implementation of the interface
-func (c *ResourceRecordSetsListCall) Do(call *v1.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*v1.ResourceRecordSetsListResponse, error) {
-	return call.Do(opts...)
+func (c *ResourceRecordSetsListCall) Do(ctx context.Context, call *v1.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*v1.ResourceRecordSetsListResponse, error) {
+	var result *v1.ResourceRecordSetsListResponse
+	err := c.Telemetry.Do(ctx, service, "ResourceRecordSetsList", func(ctx context.Context) error {
+		return c.Retry.Do(ctx, func() (err error) {
+			result, err = call.Context(ctx).Do(opts...)
+			return err
+		})
+	})
+	return result, err
 }
@@ -4,22 +4,38 @@ package dns
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/rockholla/go-google-lib/dns/calls"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	v1 "google.golang.org/api/dns/v1"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
+// service is the name of the library in the events it emits
+const service = "dns"
+
 // Interface represents functionality for DNS
 type Interface interface {
 	Initialize(credentials string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error
+	ForEachResourceRecordSet(projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) error
+	ForEachResourceRecordSetCtx(ctx context.Context, projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) error
 	GetResourceRecordSets(projectID string, managedZone string) ([]*v1.ResourceRecordSet, error)
+	GetResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string) ([]*v1.ResourceRecordSet, error)
 	GetResourceRecordSet(projectID string, managedZone string, name string) (*v1.ResourceRecordSet, error)
+	GetResourceRecordSetCtx(ctx context.Context, projectID string, managedZone string, name string) (*v1.ResourceRecordSet, error)
 	SetResourceRecordSets(projectID string, managedZone string, records []*v1.ResourceRecordSet) error
+	SetResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string, records []*v1.ResourceRecordSet) error
 	DeleteResourceRecordSets(projectID string, managedZone string) error
+	DeleteResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string) error
 }
 
 // DNS is a wrapper around the google-provided sdks/apis for google.golang.org/api/dns/*
@@ -27,7 +43,23 @@ type DNS struct {
 	log                logger.Interface
 	V1                 *v1.Service
 	Calls              *Calls
+	Retry              *retry.Policy
 	PendingWaitSeconds int64
+	ClientOptions      []option.ClientOption
+	// Endpoint overrides where api requests are sent, e.g. to a local emulator or fake
+	Endpoint string
+	// Insecure sends api requests without credentials, for an Endpoint that doesn't expect them
+	Insecure bool
+	// Transport is the base http transport api requests are sent through, underneath authentication, e.g. a
+	// recorder.Recorder to record or replay them
+	Transport http.RoundTripper
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
 }
 
 // Calls are interfaces for making the actual calls to various underlying apis
@@ -38,44 +70,120 @@ type Calls struct {
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (d *DNS) Initialize(credentials string, log logger.Interface) error {
+	return d.InitializeCtx(context.Background(), credentials, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (d *DNS) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	d.log = log
 	d.PendingWaitSeconds = 5
+	if d.Retry == nil {
+		d.Retry = retry.DefaultPolicy()
+	}
 	d.Calls = &Calls{
-		ChangesCreate:          &calls.ChangesCreateCall{},
-		ResourceRecordSetsList: &calls.ResourceRecordSetsListCall{},
+		ChangesCreate:          &calls.ChangesCreateCall{Retry: d.Retry, Telemetry: d.Telemetry},
+		ResourceRecordSetsList: &calls.ResourceRecordSetsListCall{Retry: d.Retry, Telemetry: d.Telemetry},
+	}
+	clientOptions := append([]option.ClientOption{}, d.ClientOptions...)
+	if credentials != "" && !d.Insecure {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if d.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(d.Endpoint))
 	}
-	if credentials != "" {
-		if d.V1, err = v1.NewService(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
+	if d.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication())
+	}
+	if d.Transport != nil {
+		transport, err := htransport.NewTransport(ctx, d.Transport, append([]option.ClientOption{option.WithScopes(v1.CloudPlatformScope)}, clientOptions...)...)
+		if err != nil {
 			return err
 		}
-	} else {
-		if d.V1, err = v1.NewService(ctx); err != nil {
+		clientOptions = append(clientOptions, option.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	if d.V1, err = v1.NewService(ctx, clientOptions...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ForEachResourceRecordSet will call fn for every resource record set in a managed zone, following all result pages,
+// stopping at and returning the first error returned by fn
+func (d *DNS) ForEachResourceRecordSet(projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) error {
+	return d.ForEachResourceRecordSetCtx(context.Background(), projectID, managedZone, fn)
+}
+
+// ForEachResourceRecordSetCtx is ForEachResourceRecordSet, using the provided context for the underlying api calls
+func (d *DNS) ForEachResourceRecordSetCtx(ctx context.Context, projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) (err error) {
+	defer events.Start(d.Events, service, "ForEachResourceRecordSet", events.ActionRead, projectID, managedZone).Done(&err)
+	return d.forEachResourceRecordSet(ctx, projectID, managedZone, fn)
+}
+
+// forEachResourceRecordSet is ForEachResourceRecordSetCtx without its event, for use within other methods
+func (d *DNS) forEachResourceRecordSet(ctx context.Context, projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) error {
+	rrsService := v1.NewResourceRecordSetsService(d.V1)
+	pageToken := ""
+	for {
+		rrsListCall := rrsService.List(projectID, managedZone).Context(ctx)
+		if pageToken != "" {
+			rrsListCall = rrsListCall.PageToken(pageToken)
+		}
+		rrsList, err := d.Calls.ResourceRecordSetsList.Do(telemetry.WithResource(ctx, projectID, managedZone), rrsListCall)
+		if err != nil {
 			return err
 		}
+		for _, rrs := range rrsList.Rrsets {
+			if err := fn(rrs); err != nil {
+				return err
+			}
+		}
+		if rrsList.NextPageToken == "" {
+			return nil
+		}
+		pageToken = rrsList.NextPageToken
 	}
-	return nil
 }
 
 // GetResourceRecordSets will return all resource record sets for a managed zone
 func (d *DNS) GetResourceRecordSets(projectID string, managedZone string) ([]*v1.ResourceRecordSet, error) {
-	ctx := context.Background()
-	rrsService := v1.NewResourceRecordSetsService(d.V1)
-	rrsListCall := rrsService.List(projectID, managedZone).Context(ctx)
-	rrsList, err := d.Calls.ResourceRecordSetsList.Do(rrsListCall)
+	return d.GetResourceRecordSetsCtx(context.Background(), projectID, managedZone)
+}
+
+// GetResourceRecordSetsCtx is GetResourceRecordSets, using the provided context for the underlying api calls
+func (d *DNS) GetResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string) (rrsets []*v1.ResourceRecordSet, err error) {
+	defer events.Start(d.Events, service, "GetResourceRecordSets", events.ActionRead, projectID, managedZone).Done(&err)
+	return d.getResourceRecordSets(ctx, projectID, managedZone)
+}
+
+// getResourceRecordSets is GetResourceRecordSetsCtx without its event, for use within other methods
+func (d *DNS) getResourceRecordSets(ctx context.Context, projectID string, managedZone string) (rrsets []*v1.ResourceRecordSet, err error) {
+	err = d.forEachResourceRecordSet(ctx, projectID, managedZone, func(rrs *v1.ResourceRecordSet) error {
+		rrsets = append(rrsets, rrs)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return rrsList.Rrsets, nil
+	return rrsets, nil
 }
 
 // GetResourceRecordSet will search for an existing record set by the resourcer record set name
 func (d *DNS) GetResourceRecordSet(projectID string, managedZone string, name string) (*v1.ResourceRecordSet, error) {
-	ctx := context.Background()
+	return d.GetResourceRecordSetCtx(context.Background(), projectID, managedZone, name)
+}
+
+// GetResourceRecordSetCtx is GetResourceRecordSet, using the provided context for the underlying api calls
+func (d *DNS) GetResourceRecordSetCtx(ctx context.Context, projectID string, managedZone string, name string) (rrs *v1.ResourceRecordSet, err error) {
+	defer events.Start(d.Events, service, "GetResourceRecordSet", events.ActionRead, projectID, fmt.Sprintf("%s/%s", managedZone, name)).Done(&err)
+	return d.getResourceRecordSet(ctx, projectID, managedZone, name)
+}
+
+// getResourceRecordSet is GetResourceRecordSetCtx without its event, for use within other methods
+func (d *DNS) getResourceRecordSet(ctx context.Context, projectID string, managedZone string, name string) (*v1.ResourceRecordSet, error) {
 	rrsService := v1.NewResourceRecordSetsService(d.V1)
 	rrsListCall := rrsService.List(projectID, managedZone).Context(ctx).Name(name)
-	rrsList, err := d.Calls.ResourceRecordSetsList.Do(rrsListCall)
+	rrsList, err := d.Calls.ResourceRecordSetsList.Do(telemetry.WithResource(ctx, projectID, fmt.Sprintf("%s/%s", managedZone, name)), rrsListCall)
 	if err != nil {
 		return nil, err
 	}
@@ -87,22 +195,35 @@ func (d *DNS) GetResourceRecordSet(projectID string, managedZone string, name st
 
 // SetResourceRecordSets will create or update a DNS zone with one or more record sets
 func (d *DNS) SetResourceRecordSets(projectID string, managedZone string, records []*v1.ResourceRecordSet) error {
+	return d.SetResourceRecordSetsCtx(context.Background(), projectID, managedZone, records)
+}
+
+// SetResourceRecordSetsCtx is SetResourceRecordSets, using the provided context for the underlying api calls
+func (d *DNS) SetResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string, records []*v1.ResourceRecordSet) (err error) {
+	defer events.Start(d.Events, service, "SetResourceRecordSets", events.ActionUpdate, projectID, managedZone).Done(&err)
 	var deletions []*v1.ResourceRecordSet
 	var additions []*v1.ResourceRecordSet
 	var change *v1.Change
 	logItems := []string{}
+	planned := []plan.Change{}
 	for _, record := range records {
-		existing, err := d.GetResourceRecordSet(projectID, managedZone, record.Name)
+		existing, err := d.getResourceRecordSet(ctx, projectID, managedZone, record.Name)
 		if err != nil {
 			return fmt.Errorf("Error trying to get existing resource record set: %s", err)
 		}
 		action := "creating"
+		plannedAction := plan.ActionCreate
 		if existing != nil {
 			deletions = append(deletions, existing)
 			action = "recreating"
+			plannedAction = plan.ActionUpdate
 		}
 		logItems = append(logItems, fmt.Sprintf("====> %s %s => %s %s", action, record.Name, record.Type, strings.Join(record.Rrdatas, ",")))
 		additions = append(additions, record)
+		planned = append(planned, d.plannedChange("SetResourceRecordSets", projectID, managedZone, plannedAction, existing, record))
+	}
+	if d.DryRun.Record(planned...) {
+		return nil
 	}
 	d.log.Info("Ensuring the DNS zone %s has the following records:", managedZone)
 	for _, item := range logItems {
@@ -112,14 +233,14 @@ func (d *DNS) SetResourceRecordSets(projectID string, managedZone string, record
 		change = &v1.Change{
 			Deletions: deletions,
 		}
-		if err := d.executeChange(projectID, managedZone, change); err != nil {
+		if err := d.executeChange(ctx, projectID, managedZone, change); err != nil {
 			return err
 		}
 	}
 	change = &v1.Change{
 		Additions: additions,
 	}
-	if err := d.executeChange(projectID, managedZone, change); err != nil {
+	if err := d.executeChange(ctx, projectID, managedZone, change); err != nil {
 		return err
 	}
 	return nil
@@ -127,34 +248,61 @@ func (d *DNS) SetResourceRecordSets(projectID string, managedZone string, record
 
 // DeleteResourceRecordSets will remove all resource record sets from a managed zone
 func (d *DNS) DeleteResourceRecordSets(projectID string, managedZone string) error {
+	return d.DeleteResourceRecordSetsCtx(context.Background(), projectID, managedZone)
+}
+
+// DeleteResourceRecordSetsCtx is DeleteResourceRecordSets, using the provided context for the underlying api calls
+func (d *DNS) DeleteResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string) (err error) {
+	defer events.Start(d.Events, service, "DeleteResourceRecordSets", events.ActionDelete, projectID, managedZone).Done(&err)
 	var deletions []*v1.ResourceRecordSet
-	resourceRecordSets, err := d.GetResourceRecordSets(projectID, managedZone)
+	resourceRecordSets, err := d.getResourceRecordSets(ctx, projectID, managedZone)
 	if err != nil {
 		return err
 	}
-	d.log.Info("Deleting all records from DNS zone %s:", managedZone)
+	planned := []plan.Change{}
 	for _, resourceRecordSet := range resourceRecordSets {
 		if resourceRecordSet.Type == "SOA" || resourceRecordSet.Type == "NS" {
 			continue
 		}
 		deletions = append(deletions, resourceRecordSet)
-		d.log.ListItem("%s %s", resourceRecordSet.Type, resourceRecordSet.Name)
+		planned = append(planned, d.plannedChange("DeleteResourceRecordSets", projectID, managedZone, plan.ActionDelete, resourceRecordSet, nil))
+	}
+	if d.DryRun.Record(planned...) {
+		return nil
+	}
+	d.log.Info("Deleting all records from DNS zone %s:", managedZone)
+	for _, deletion := range deletions {
+		d.log.ListItem("%s %s", deletion.Type, deletion.Name)
 	}
 	change := &v1.Change{
 		Deletions: deletions,
 	}
-	if err := d.executeChange(projectID, managedZone, change); err != nil {
+	if err := d.executeChange(ctx, projectID, managedZone, change); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (d *DNS) executeChange(projectID string, managedZone string, change *v1.Change) error {
-	ctx := context.Background()
+// plannedChange will return the change of a record set in a managed zone, before being nil when it doesn't exist and
+// after when it won't
+func (d *DNS) plannedChange(method string, projectID string, managedZone string, action plan.Action, before *v1.ResourceRecordSet, after *v1.ResourceRecordSet) plan.Change {
+	change := plan.Change{Service: service, Method: method, Project: projectID, Action: action}
+	if before != nil {
+		change.Before = before
+		change.Resource = fmt.Sprintf("%s/%s", managedZone, before.Name)
+	}
+	if after != nil {
+		change.After = after
+		change.Resource = fmt.Sprintf("%s/%s", managedZone, after.Name)
+	}
+	return change
+}
+
+func (d *DNS) executeChange(ctx context.Context, projectID string, managedZone string, change *v1.Change) error {
 	changesService := v1.NewChangesService(d.V1)
 	var changesCreateCall *v1.ChangesCreateCall
 	changesCreateCall = changesService.Create(projectID, managedZone, change).Context(ctx)
-	processedChange, err := d.Calls.ChangesCreate.Do(changesCreateCall)
+	processedChange, err := d.Calls.ChangesCreate.Do(telemetry.WithResource(ctx, projectID, managedZone), changesCreateCall)
 	if err != nil {
 		return err
 	}
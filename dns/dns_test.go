@@ -1,9 +1,17 @@
 package dns
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/recorder"
+	"github.com/rockholla/go-google-lib/telemetry"
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
+	"go.opentelemetry.io/otel/oteltest"
 	v1 "google.golang.org/api/dns/v1"
 	googleapi "google.golang.org/api/googleapi"
 )
@@ -34,7 +42,7 @@ type rrsListMock struct{}
 type changesCreateMock struct{}
 
 // Do is the mock for default rrsListMock
-func (r *rrsListMock) Do(call *v1.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*v1.ResourceRecordSetsListResponse, error) {
+func (r *rrsListMock) Do(ctx context.Context, call *v1.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*v1.ResourceRecordSetsListResponse, error) {
 	if triggerExistingResourceRecordSet {
 		triggerExistingResourceRecordSet = false
 		return &v1.ResourceRecordSetsListResponse{
@@ -49,7 +57,7 @@ func (r *rrsListMock) Do(call *v1.ResourceRecordSetsListCall, opts ...googleapi.
 }
 
 // Do is the mock for default changesCreateMock
-func (cc *changesCreateMock) Do(call *v1.ChangesCreateCall, opts ...googleapi.CallOption) (*v1.Change, error) {
+func (cc *changesCreateMock) Do(ctx context.Context, call *v1.ChangesCreateCall, opts ...googleapi.CallOption) (*v1.Change, error) {
 	status := "done"
 	if triggerPendingChangeStatus {
 		triggerPendingChangeStatus = false
@@ -60,6 +68,32 @@ func (cc *changesCreateMock) Do(call *v1.ChangesCreateCall, opts ...googleapi.Ca
 	}, nil
 }
 
+type rrsListPagedMock struct {
+	pages int
+}
+
+// Do is the mock for rrsList that returns results across two pages
+func (r *rrsListPagedMock) Do(ctx context.Context, call *v1.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*v1.ResourceRecordSetsListResponse, error) {
+	r.pages++
+	nextPageToken := ""
+	if r.pages == 1 {
+		nextPageToken = "page-2"
+	}
+	return &v1.ResourceRecordSetsListResponse{
+		Rrsets: []*v1.ResourceRecordSet{
+			testResourceRecordSet,
+		},
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+type changesCreateUnexpectedMock struct{}
+
+// Do is the mock for changesCreate that fails, for changes that shouldn't be made
+func (cc *changesCreateUnexpectedMock) Do(ctx context.Context, call *v1.ChangesCreateCall, opts ...googleapi.CallOption) (*v1.Change, error) {
+	return nil, errors.New("unexpected change")
+}
+
 func setCallMockDefaults(d *DNS) {
 	d.Calls = &Calls{
 		ResourceRecordSetsList: &rrsListMock{},
@@ -154,6 +188,29 @@ func TestSetResourceRecordSetsUpdate(t *testing.T) {
 	}
 }
 
+func TestSetResourceRecordSetsEvents(t *testing.T) {
+	sink := &events.Memory{}
+	d := &DNS{Events: sink}
+	err := d.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(d)
+	err = d.SetResourceRecordSets(testProjectID, testManagedZone, []*v1.ResourceRecordSet{testResourceRecordSet})
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.SetResourceRecordSets(): %s", err)
+	}
+	emitted := sink.Events()
+	if len(emitted) != 1 {
+		t.Fatalf("Expected a single event from dns.SetResourceRecordSets(), got: %v", emitted)
+	}
+	last := emitted[0]
+	if last.Method != "SetResourceRecordSets" || last.Action != events.ActionUpdate || last.Outcome != events.OutcomeSuccess ||
+		last.Project != testProjectID || last.Resource != testManagedZone || last.Service != "dns" {
+		t.Errorf("Expected a successful update event from dns.SetResourceRecordSets(), got: %v", last)
+	}
+}
+
 func TestGetResourceRecordSets(t *testing.T) {
 	d := &DNS{}
 	err := d.Initialize("", loggermock.GetLogMock())
@@ -184,3 +241,136 @@ func TestDeleteResourceRecordSets(t *testing.T) {
 		t.Errorf("Got unexpected error during dns.DeleteResourceRecordSets(): %s", err)
 	}
 }
+
+func TestGetResourceRecordSetsPaged(t *testing.T) {
+	d := &DNS{}
+	err := d.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(d)
+	d.Calls.ResourceRecordSetsList = &rrsListPagedMock{}
+	rrsets, err := d.GetResourceRecordSets(testProjectID, testManagedZone)
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.GetResourceRecordSets() across pages: %s", err)
+	}
+	if len(rrsets) != 2 {
+		t.Errorf("Expected 2 resource record sets from dns.GetResourceRecordSets() across pages, got: %d", len(rrsets))
+	}
+}
+
+func TestInitializeEndpoint(t *testing.T) {
+	d := &DNS{Endpoint: "http://localhost:8080/dns/v1/", Insecure: true}
+	err := d.Initialize(testCredentials, loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.Initialize() with an insecure endpoint: %s", err)
+	}
+	if d.V1.BasePath != "http://localhost:8080/dns/v1/" {
+		t.Errorf("Expected dns.Initialize() to use the endpoint, got base path: %s", d.V1.BasePath)
+	}
+}
+
+// TestSetResourceRecordSetsRecorded replays the requests recorded in testdata through the real api client, set
+// GO_GOOGLE_LIB_RECORD=true with application default credentials for a real managed zone to re-record them
+func TestSetResourceRecordSetsRecorded(t *testing.T) {
+	rec, err := recorder.New("testdata/SetResourceRecordSets.json", recorder.ModeFromEnv())
+	if err != nil {
+		t.Fatalf("Got unexpected error during recorder.New(): %s", err)
+	}
+	d := &DNS{Transport: rec, Insecure: rec.Mode() == recorder.Replay}
+	err = d.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.Initialize() with a recorder transport: %s", err)
+	}
+	err = d.SetResourceRecordSets(testProjectID, testManagedZone, []*v1.ResourceRecordSet{
+		testResourceRecordSet,
+		{Name: "www." + testName, Ttl: 3600, Type: "CNAME", Rrdatas: []string{testName}},
+	})
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.SetResourceRecordSets() with recorded requests: %s", err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Errorf("Got unexpected error during recorder.Close(): %s", err)
+	}
+}
+
+func TestSetResourceRecordSetsTelemetry(t *testing.T) {
+	rec, err := recorder.New("testdata/SetResourceRecordSets.json", recorder.Replay)
+	if err != nil {
+		t.Fatalf("Got unexpected error during recorder.New(): %s", err)
+	}
+	spanRecorder := &oteltest.SpanRecorder{}
+	tel, err := telemetry.New(oteltest.NewTracerProvider(oteltest.WithSpanRecorder(spanRecorder)), nil)
+	if err != nil {
+		t.Fatalf("Got unexpected error during telemetry.New(): %s", err)
+	}
+	d := &DNS{Transport: rec, Insecure: true, Telemetry: tel}
+	err = d.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.Initialize() with a recorder transport: %s", err)
+	}
+	err = d.SetResourceRecordSets(testProjectID, testManagedZone, []*v1.ResourceRecordSet{
+		testResourceRecordSet,
+		{Name: "www." + testName, Ttl: 3600, Type: "CNAME", Rrdatas: []string{testName}},
+	})
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.SetResourceRecordSets() with recorded requests: %s", err)
+	}
+	spans := spanRecorder.Completed()
+	if len(spans) != len(rec.Interactions()) {
+		t.Fatalf("Expected a span for each of the %d recorded api calls, got %d", len(rec.Interactions()), len(spans))
+	}
+	for _, span := range spans {
+		attributes := span.Attributes()
+		if attributes[telemetry.AttributeService].AsString() != "dns" || attributes[telemetry.AttributeProject].AsString() != testProjectID ||
+			!strings.HasPrefix(attributes[telemetry.AttributeResource].AsString(), testManagedZone) {
+			t.Errorf("Got unexpected attributes for span %s of dns.SetResourceRecordSets(): %v", span.Name(), attributes)
+		}
+	}
+}
+
+func TestSetResourceRecordSetsDryRun(t *testing.T) {
+	d := &DNS{DryRun: plan.New()}
+	err := d.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(d)
+	d.Calls.ChangesCreate = &changesCreateUnexpectedMock{}
+	triggerExistingResourceRecordSet = true
+	created := &v1.ResourceRecordSet{Name: "new." + testName, Ttl: 3600, Type: "A", Rrdatas: []string{"1.2.3.4"}}
+	err = d.SetResourceRecordSets(testProjectID, testManagedZone, []*v1.ResourceRecordSet{testResourceRecordSet, created})
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.SetResourceRecordSets() in a dry run: %s", err)
+	}
+	changes := d.DryRun.Changes()
+	if len(changes) != 2 {
+		t.Fatalf("Expected a change for each record set planned by dns.SetResourceRecordSets(), got: %v", changes)
+	}
+	if changes[0].Action != plan.ActionUpdate || changes[0].Before == nil || changes[0].After != testResourceRecordSet {
+		t.Errorf("Expected the update of the existing record set planned by dns.SetResourceRecordSets(), got: %v", changes[0])
+	}
+	if changes[1].Action != plan.ActionCreate || changes[1].Before != nil || changes[1].After != created ||
+		changes[1].Resource != testManagedZone+"/"+created.Name {
+		t.Errorf("Expected the creation of the new record set planned by dns.SetResourceRecordSets(), got: %v", changes[1])
+	}
+}
+
+func TestDeleteResourceRecordSetsDryRun(t *testing.T) {
+	d := &DNS{DryRun: plan.New()}
+	err := d.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.Initialize() with blank credentials: %s", err)
+	}
+	setCallMockDefaults(d)
+	d.Calls.ChangesCreate = &changesCreateUnexpectedMock{}
+	triggerExistingResourceRecordSet = true
+	err = d.DeleteResourceRecordSets(testProjectID, testManagedZone)
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.DeleteResourceRecordSets() in a dry run: %s", err)
+	}
+	changes := d.DryRun.Changes()
+	if len(changes) != 1 || changes[0].Action != plan.ActionDelete || changes[0].Before == nil || changes[0].After != nil {
+		t.Errorf("Expected the deletion of the existing record set planned by dns.DeleteResourceRecordSets(), got: %v", changes)
+	}
+}
@@ -0,0 +1,192 @@
+// Package errors is the library for classifying errors returned by the google-provided sdks/apis, understanding
+// both *googleapi.Error http responses and gRPC status errors, including when they've been wrapped
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	googleapi "google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reasons reported in *googleapi.Error items that refine the meaning of an http status code
+const (
+	reasonAlreadyExists         = "alreadyExists"
+	reasonDuplicate             = "duplicate"
+	reasonRateLimitExceeded     = "rateLimitExceeded"
+	reasonUserRateLimitExceeded = "userRateLimitExceeded"
+	reasonAccessNotConfigured   = "accessNotConfigured"
+	reasonServiceDisabled       = "SERVICE_DISABLED"
+	reasonFailedPrecondition    = "failedPrecondition"
+)
+
+// statusFailedPrecondition is the canonical status reported in the body of a *googleapi.Error
+const statusFailedPrecondition = "FAILED_PRECONDITION"
+
+// IsNotFound will determine if an error means the requested resource doesn't exist
+func IsNotFound(err error) bool {
+	if code, ok := httpCode(err); ok {
+		return code == http.StatusNotFound
+	}
+	if code, ok := grpcCode(err); ok {
+		return code == codes.NotFound
+	}
+	return false
+}
+
+// IsAlreadyExists will determine if an error means the resource being created already exists
+func IsAlreadyExists(err error) bool {
+	if code, ok := httpCode(err); ok {
+		if code != http.StatusConflict {
+			return false
+		}
+		reasons := httpReasons(err)
+		return len(reasons) == 0 || reasons[reasonAlreadyExists] || reasons[reasonDuplicate]
+	}
+	if code, ok := grpcCode(err); ok {
+		return code == codes.AlreadyExists
+	}
+	return false
+}
+
+// IsConflict will determine if an error means the request conflicted with the current state of the resource,
+// e.g. a concurrent modification, a stale etag or another operation already in progress
+func IsConflict(err error) bool {
+	if code, ok := httpCode(err); ok {
+		return code == http.StatusConflict || code == http.StatusPreconditionFailed
+	}
+	if code, ok := grpcCode(err); ok {
+		return code == codes.Aborted || code == codes.AlreadyExists
+	}
+	return false
+}
+
+// IsFailedPrecondition will determine if an error means the request was rejected because the resource isn't in a
+// state allowing it, e.g. deleting a project that has a lien on it
+func IsFailedPrecondition(err error) bool {
+	if code, ok := httpCode(err); ok {
+		if code != http.StatusBadRequest && code != http.StatusPreconditionFailed {
+			return false
+		}
+		return httpReasons(err)[reasonFailedPrecondition] || httpStatus(err) == statusFailedPrecondition
+	}
+	if code, ok := grpcCode(err); ok {
+		return code == codes.FailedPrecondition
+	}
+	return false
+}
+
+// IsPermissionDenied will determine if an error means the caller isn't allowed to make the request
+func IsPermissionDenied(err error) bool {
+	if code, ok := httpCode(err); ok {
+		return code == http.StatusForbidden && !IsRateLimited(err)
+	}
+	if code, ok := grpcCode(err); ok {
+		return code == codes.PermissionDenied
+	}
+	return false
+}
+
+// IsRateLimited will determine if an error means the request was rejected because of a rate limit or quota
+func IsRateLimited(err error) bool {
+	if code, ok := httpCode(err); ok {
+		if code == http.StatusTooManyRequests {
+			return true
+		}
+		reasons := httpReasons(err)
+		return code == http.StatusForbidden && (reasons[reasonRateLimitExceeded] || reasons[reasonUserRateLimitExceeded])
+	}
+	if code, ok := grpcCode(err); ok {
+		return code == codes.ResourceExhausted
+	}
+	return false
+}
+
+// IsServiceDisabled will determine if an error means the api being called isn't enabled, or was enabled so
+// recently that the change hasn't propagated yet
+func IsServiceDisabled(err error) bool {
+	if _, ok := httpCode(err); ok {
+		reasons := httpReasons(err)
+		return reasons[reasonAccessNotConfigured] || reasons[reasonServiceDisabled]
+	}
+	return false
+}
+
+// IsRetryable will determine if an error is transient, meaning the same request could succeed if made again
+// later: rate limiting and server-side unavailability. Context cancellation or expiration is never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if IsRateLimited(err) {
+		return true
+	}
+	if code, ok := httpCode(err); ok {
+		switch code {
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	if code, ok := grpcCode(err); ok {
+		switch code {
+		case codes.Unavailable, codes.Internal, codes.DeadlineExceeded:
+			return true
+		}
+	}
+	return false
+}
+
+// httpCode will return the http status code of a *googleapi.Error anywhere in the chain of err
+func httpCode(err error) (int, bool) {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code, true
+	}
+	return 0, false
+}
+
+// httpReasons will return the set of reasons in the items of a *googleapi.Error anywhere in the chain of err
+func httpReasons(err error) map[string]bool {
+	reasons := map[string]bool{}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		for _, item := range apiErr.Errors {
+			reasons[item.Reason] = true
+		}
+	}
+	return reasons
+}
+
+// httpStatus will return the canonical status in the json body of a *googleapi.Error anywhere in the chain of err,
+// e.g. FAILED_PRECONDITION, blank if there isn't one
+func httpStatus(err error) string {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	var body struct {
+		Error struct {
+			Status string `json:"status"`
+		} `json:"error"`
+	}
+	if json.Unmarshal([]byte(apiErr.Body), &body) != nil {
+		return ""
+	}
+	return body.Error.Status
+}
+
+// grpcCode will return the code of a gRPC status error anywhere in the chain of err
+func grpcCode(err error) (codes.Code, bool) {
+	var statusErr interface {
+		GRPCStatus() *status.Status
+	}
+	if errors.As(err, &statusErr) {
+		return statusErr.GRPCStatus().Code(), true
+	}
+	return codes.OK, false
+}
@@ -0,0 +1,146 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	googleapi "google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func httpError(code int, reasons ...string) error {
+	apiErr := &googleapi.Error{Code: code}
+	for _, reason := range reasons {
+		apiErr.Errors = append(apiErr.Errors, googleapi.ErrorItem{Reason: reason})
+	}
+	return apiErr
+}
+
+func TestIsNotFound(t *testing.T) {
+	if !IsNotFound(httpError(http.StatusNotFound)) {
+		t.Errorf("Expected errors.IsNotFound() to be true for an http 404")
+	}
+	if !IsNotFound(status.Error(codes.NotFound, "not found")) {
+		t.Errorf("Expected errors.IsNotFound() to be true for a gRPC NotFound status")
+	}
+	if !IsNotFound(fmt.Errorf("wrapped: %w", httpError(http.StatusNotFound))) {
+		t.Errorf("Expected errors.IsNotFound() to be true for a wrapped http 404")
+	}
+	if IsNotFound(errors.New("notfound")) {
+		t.Errorf("Expected errors.IsNotFound() to be false for an untyped error, regardless of its message")
+	}
+	if IsNotFound(nil) {
+		t.Errorf("Expected errors.IsNotFound() to be false for a nil error")
+	}
+}
+
+func TestIsAlreadyExists(t *testing.T) {
+	if !IsAlreadyExists(httpError(http.StatusConflict)) {
+		t.Errorf("Expected errors.IsAlreadyExists() to be true for an http 409 without reasons")
+	}
+	if !IsAlreadyExists(httpError(http.StatusConflict, "alreadyExists")) {
+		t.Errorf("Expected errors.IsAlreadyExists() to be true for an http 409 with an alreadyExists reason")
+	}
+	if IsAlreadyExists(httpError(http.StatusConflict, "conflict")) {
+		t.Errorf("Expected errors.IsAlreadyExists() to be false for an http 409 with a conflict reason")
+	}
+	if !IsAlreadyExists(status.Error(codes.AlreadyExists, "exists")) {
+		t.Errorf("Expected errors.IsAlreadyExists() to be true for a gRPC AlreadyExists status")
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	if !IsConflict(httpError(http.StatusConflict, "conflict")) {
+		t.Errorf("Expected errors.IsConflict() to be true for an http 409")
+	}
+	if !IsConflict(httpError(http.StatusPreconditionFailed)) {
+		t.Errorf("Expected errors.IsConflict() to be true for an http 412")
+	}
+	if !IsConflict(status.Error(codes.Aborted, "aborted")) {
+		t.Errorf("Expected errors.IsConflict() to be true for a gRPC Aborted status")
+	}
+	if IsConflict(httpError(http.StatusBadRequest)) {
+		t.Errorf("Expected errors.IsConflict() to be false for an http 400")
+	}
+}
+
+func TestIsFailedPrecondition(t *testing.T) {
+	withStatus := &googleapi.Error{Code: http.StatusBadRequest, Body: `{"error": {"code": 400, "status": "FAILED_PRECONDITION"}}`}
+	if !IsFailedPrecondition(withStatus) {
+		t.Errorf("Expected errors.IsFailedPrecondition() to be true for an http 400 with a FAILED_PRECONDITION status")
+	}
+	if !IsFailedPrecondition(httpError(http.StatusBadRequest, "failedPrecondition")) {
+		t.Errorf("Expected errors.IsFailedPrecondition() to be true for an http 400 with a failedPrecondition reason")
+	}
+	if IsFailedPrecondition(httpError(http.StatusBadRequest, "invalid")) {
+		t.Errorf("Expected errors.IsFailedPrecondition() to be false for an http 400 with an invalid reason")
+	}
+	if !IsFailedPrecondition(status.Error(codes.FailedPrecondition, "precondition")) {
+		t.Errorf("Expected errors.IsFailedPrecondition() to be true for a gRPC FailedPrecondition status")
+	}
+}
+
+func TestIsPermissionDenied(t *testing.T) {
+	if !IsPermissionDenied(httpError(http.StatusForbidden, "forbidden")) {
+		t.Errorf("Expected errors.IsPermissionDenied() to be true for an http 403")
+	}
+	if IsPermissionDenied(httpError(http.StatusForbidden, "rateLimitExceeded")) {
+		t.Errorf("Expected errors.IsPermissionDenied() to be false for an http 403 caused by rate limiting")
+	}
+	if !IsPermissionDenied(status.Error(codes.PermissionDenied, "denied")) {
+		t.Errorf("Expected errors.IsPermissionDenied() to be true for a gRPC PermissionDenied status")
+	}
+}
+
+func TestIsRateLimited(t *testing.T) {
+	if !IsRateLimited(httpError(http.StatusTooManyRequests)) {
+		t.Errorf("Expected errors.IsRateLimited() to be true for an http 429")
+	}
+	if !IsRateLimited(httpError(http.StatusForbidden, "userRateLimitExceeded")) {
+		t.Errorf("Expected errors.IsRateLimited() to be true for an http 403 with a userRateLimitExceeded reason")
+	}
+	if !IsRateLimited(status.Error(codes.ResourceExhausted, "exhausted")) {
+		t.Errorf("Expected errors.IsRateLimited() to be true for a gRPC ResourceExhausted status")
+	}
+}
+
+func TestIsServiceDisabled(t *testing.T) {
+	if !IsServiceDisabled(httpError(http.StatusForbidden, "accessNotConfigured")) {
+		t.Errorf("Expected errors.IsServiceDisabled() to be true for an http 403 with an accessNotConfigured reason")
+	}
+	if IsServiceDisabled(httpError(http.StatusForbidden)) {
+		t.Errorf("Expected errors.IsServiceDisabled() to be false for an http 403 without reasons")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	retryable := []error{
+		httpError(http.StatusTooManyRequests),
+		httpError(http.StatusInternalServerError),
+		httpError(http.StatusServiceUnavailable),
+		status.Error(codes.Unavailable, "unavailable"),
+		fmt.Errorf("wrapped: %w", status.Error(codes.Internal, "internal")),
+	}
+	for _, err := range retryable {
+		if !IsRetryable(err) {
+			t.Errorf("Expected errors.IsRetryable() to be true for: %s", err)
+		}
+	}
+	notRetryable := []error{
+		nil,
+		errors.New("unknown"),
+		context.Canceled,
+		httpError(http.StatusNotFound),
+		httpError(http.StatusForbidden),
+		status.Error(codes.InvalidArgument, "invalid"),
+	}
+	for _, err := range notRetryable {
+		if IsRetryable(err) {
+			t.Errorf("Expected errors.IsRetryable() to be false for: %v", err)
+		}
+	}
+}
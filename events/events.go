@@ -0,0 +1,141 @@
+// Package events is the library for structured events from the google libraries. Each operation they perform emits
+// a typed event, with the service, method, resource, project, action, duration and outcome, to a Sink: the go-lib
+// logger, slog-style json output, memory for tests, or nowhere at all.
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Level is the severity of an event, with the same values as log/slog levels
+type Level int
+
+// Levels of events, operations that only read are emitted at LevelDebug, others at LevelInfo, and failures at
+// LevelError
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+// String will return the name of the level, as log/slog names them
+func (l Level) String() string {
+	switch {
+	case l < LevelInfo:
+		return "DEBUG"
+	case l < LevelWarn:
+		return "INFO"
+	case l < LevelError:
+		return "WARN"
+	}
+	return "ERROR"
+}
+
+// Action is what an operation does to its resource
+type Action string
+
+// Actions of operations
+const (
+	ActionRead   Action = "read"
+	ActionCreate Action = "create"
+	ActionEnsure Action = "ensure"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Outcome is how an operation finished
+type Outcome string
+
+// Outcomes of operations
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event is a single operation performed by a library, or a message logged by one through a Logger, in which case
+// only Time, Level, Service and Message are set
+type Event struct {
+	Time     time.Time
+	Level    Level
+	Service  string
+	Method   string
+	Resource string
+	Project  string
+	Action   Action
+	Duration time.Duration
+	Outcome  Outcome
+	Err      error
+	Message  string
+}
+
+// Text will return the message of the event, or a description of the operation for operation events
+func (e *Event) Text() string {
+	if e.Message != "" || e.Method == "" {
+		return e.Message
+	}
+	parts := []string{fmt.Sprintf("%s.%s", e.Service, e.Method)}
+	if e.Resource != "" {
+		parts = append(parts, e.Resource)
+	}
+	if e.Project != "" {
+		parts = append(parts, fmt.Sprintf("in project %s", e.Project))
+	}
+	parts = append(parts, fmt.Sprintf("%s after %s", e.Outcome, e.Duration.Round(time.Millisecond)))
+	if e.Err != nil {
+		parts = append(parts, fmt.Sprintf("(%s)", e.Err))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Sink receives events, implementations must be safe for concurrent use
+type Sink interface {
+	Emit(event Event)
+}
+
+// Operation is an operation in progress, started with Start
+type Operation struct {
+	sink  Sink
+	event Event
+	start time.Time
+}
+
+// Start will begin timing an operation, to be emitted to the sink when done, a nil sink emitting nothing, e.g.
+//
+// defer events.Start(d.Events, "dns", "SetResourceRecordSets", events.ActionUpdate, projectID, managedZone).Done(&err)
+func Start(sink Sink, service string, method string, action Action, project string, resource string) *Operation {
+	return &Operation{
+		sink: sink,
+		event: Event{
+			Service:  service,
+			Method:   method,
+			Resource: resource,
+			Project:  project,
+			Action:   action,
+		},
+		start: time.Now(),
+	}
+}
+
+// Done will emit the operation's event, failed if the error it points to isn't nil
+func (o *Operation) Done(err *error) {
+	if o.sink == nil {
+		return
+	}
+	event := o.event
+	event.Time = time.Now()
+	event.Duration = event.Time.Sub(o.start)
+	event.Outcome = OutcomeSuccess
+	event.Level = LevelInfo
+	if event.Action == ActionRead {
+		event.Level = LevelDebug
+	}
+	if err != nil && *err != nil {
+		event.Outcome = OutcomeFailure
+		event.Level = LevelError
+		event.Err = *err
+	}
+	o.sink.Emit(event)
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOperationDone(t *testing.T) {
+	sink := &Memory{}
+	var err error
+	Start(sink, "dns", "GetResourceRecordSets", ActionRead, "test-project", "test-zone").Done(&err)
+	Start(sink, "dns", "SetResourceRecordSets", ActionUpdate, "test-project", "test-zone").Done(&err)
+	err = errors.New("test error")
+	Start(sink, "dns", "DeleteResourceRecordSets", ActionDelete, "test-project", "test-zone").Done(&err)
+	emitted := sink.Events()
+	if len(emitted) != 3 {
+		t.Fatalf("Expected 3 events from events.Operation.Done(), got %d", len(emitted))
+	}
+	for i, expected := range []struct {
+		level   Level
+		outcome Outcome
+	}{
+		{LevelDebug, OutcomeSuccess},
+		{LevelInfo, OutcomeSuccess},
+		{LevelError, OutcomeFailure},
+	} {
+		if emitted[i].Level != expected.level || emitted[i].Outcome != expected.outcome {
+			t.Errorf("Expected event %d at level %s with outcome %s, got: %s %s", i, expected.level, expected.outcome, emitted[i].Level, emitted[i].Outcome)
+		}
+		if emitted[i].Service != "dns" || emitted[i].Project != "test-project" || emitted[i].Resource != "test-zone" {
+			t.Errorf("Expected event %d to have the operation's fields, got: %v", i, emitted[i])
+		}
+	}
+	if emitted[2].Err != err || !strings.Contains(emitted[2].Text(), "test error") {
+		t.Errorf("Expected the failed event to have the error, got: %s", emitted[2].Text())
+	}
+}
+
+func TestOperationDoneNilSink(t *testing.T) {
+	var err error
+	Start(nil, "dns", "GetResourceRecordSets", ActionRead, "test-project", "test-zone").Done(&err)
+}
+
+func TestLevelString(t *testing.T) {
+	for level, expected := range map[Level]string{LevelDebug: "DEBUG", LevelInfo: "INFO", LevelWarn: "WARN", LevelError: "ERROR"} {
+		if level.String() != expected {
+			t.Errorf("Expected level %d to be %s, got %s", level, expected, level.String())
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/rockholla/go-lib/logger"
+)
+
+var _ logger.Interface = &Logger{}
+
+// Logger is a go-lib logger that emits what the libraries log as message events to a sink, instead of writing prose.
+// Parts of lines, from InfoPart, LogPart and SpinnerStart, are collected until the line ends, and each line is a
+// single event.
+type Logger struct {
+	sink    Sink
+	service string
+	mutex   sync.Mutex
+	part    string
+}
+
+// NewLogger will return a logger emitting message events for a service to the sink
+func NewLogger(sink Sink, service string) *Logger {
+	return &Logger{sink: sink, service: service}
+}
+
+// Focused will emit the message at LevelInfo
+func (l *Logger) Focused(message string, args ...interface{}) {
+	l.line(LevelInfo, message, args...)
+}
+
+// Error will emit the message at LevelError
+func (l *Logger) Error(message string, args ...interface{}) {
+	l.line(LevelError, message, args...)
+}
+
+// Errors will emit each of the messages at LevelError
+func (l *Logger) Errors(messages []string, args ...interface{}) {
+	for _, message := range messages {
+		l.line(LevelError, message, args...)
+	}
+}
+
+// Info will emit the message at LevelInfo
+func (l *Logger) Info(message string, args ...interface{}) {
+	l.line(LevelInfo, message, args...)
+}
+
+// InfoPart will add the message to the current line, emitting it at LevelInfo once the line ends
+func (l *Logger) InfoPart(message string, args ...interface{}) {
+	l.linePart(message, args...)
+}
+
+// ListItem will emit the message at LevelInfo
+func (l *Logger) ListItem(message string, args ...interface{}) {
+	l.line(LevelInfo, message, args...)
+}
+
+// LogPart will add the message to the current line, ignoring the style
+func (l *Logger) LogPart(message string, style *color.Color, args ...interface{}) {
+	l.linePart(message, args...)
+}
+
+// Log will emit the message at LevelInfo, ignoring the style
+func (l *Logger) Log(message string, style *color.Color, args ...interface{}) {
+	l.line(LevelInfo, message, args...)
+}
+
+// SpinnerStart will add the message to the current line, as the go-lib logger prints it ahead of the spinner
+func (l *Logger) SpinnerStart(message string) {
+	l.linePart("%s...", message)
+}
+
+// SpinnerStop is a no-op, there's no spinner to stop
+func (l *Logger) SpinnerStop() {}
+
+// line will emit the message, ending any current line with it
+func (l *Logger) line(level Level, message string, args ...interface{}) {
+	l.mutex.Lock()
+	text := l.part + format(message, args...)
+	l.part = ""
+	l.mutex.Unlock()
+	l.emit(level, text)
+}
+
+// linePart will add to the current line, emitting each of its lines that end
+func (l *Logger) linePart(message string, args ...interface{}) {
+	l.mutex.Lock()
+	l.part += format(message, args...)
+	lines := strings.Split(l.part, "\n")
+	l.part = lines[len(lines)-1]
+	l.mutex.Unlock()
+	for _, text := range lines[:len(lines)-1] {
+		l.emit(LevelInfo, text)
+	}
+}
+
+func (l *Logger) emit(level Level, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	l.sink.Emit(Event{
+		Time:    time.Now(),
+		Level:   level,
+		Service: l.service,
+		Message: text,
+	})
+}
+
+func format(message string, args ...interface{}) string {
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// withoutSpinners is a logger printing the message of spinners without animating them
+type withoutSpinners struct {
+	logger.Interface
+}
+
+// WithoutSpinners will wrap a logger so that starting a spinner only prints its message, the way the go-lib logger
+// does before animating it, and stopping one does nothing
+func WithoutSpinners(log logger.Interface) logger.Interface {
+	return &withoutSpinners{Interface: log}
+}
+
+// SpinnerStart will print the message without a spinner
+func (w *withoutSpinners) SpinnerStart(message string) {
+	w.InfoPart("%s...", message)
+}
+
+// SpinnerStop is a no-op, there's no spinner to stop
+func (w *withoutSpinners) SpinnerStop() {}
+
+// IsTerminal will return whether the file is a terminal, e.g. whether spinners written to os.Stdout would animate
+func IsTerminal(file *os.File) bool {
+	return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	sink := &Memory{}
+	log := NewLogger(sink, "deploymentmanager")
+	log.InfoPart("Ensuring deployment \"%s\" in project \"%s\"...", "test-deployment", "test-project")
+	log.SpinnerStart("creating")
+	log.SpinnerStop()
+	log.InfoPart("done\n")
+	log.Info("Using provided Google credentials key")
+	log.Error("error: %s", "test error")
+	emitted := sink.Events()
+	if len(emitted) != 3 {
+		t.Fatalf("Expected 3 events from events.Logger, got %d: %v", len(emitted), emitted)
+	}
+	if emitted[0].Message != `Ensuring deployment "test-deployment" in project "test-project"...creating...done` {
+		t.Errorf("Expected the parts of a line to be a single event, got: %s", emitted[0].Message)
+	}
+	if emitted[0].Service != "deploymentmanager" || emitted[0].Level != LevelInfo {
+		t.Errorf("Expected an info event for the service, got: %v", emitted[0])
+	}
+	if emitted[2].Level != LevelError || emitted[2].Message != "error: test error" {
+		t.Errorf("Expected an error event, got: %v", emitted[2])
+	}
+}
+
+func TestWithoutSpinners(t *testing.T) {
+	sink := &Memory{}
+	log := WithoutSpinners(NewLogger(sink, "deploymentmanager"))
+	log.SpinnerStart("deleting")
+	log.SpinnerStop()
+	log.InfoPart("done\n")
+	emitted := sink.Events()
+	if len(emitted) != 1 || emitted[0].Message != "deleting...done" {
+		t.Errorf("Expected the spinner message in the logged line, got: %v", emitted)
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	file, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("Got unexpected error opening %s: %s", os.DevNull, err)
+	}
+	defer file.Close()
+	pipeReader, pipeWriter, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Got unexpected error creating a pipe: %s", err)
+	}
+	defer pipeReader.Close()
+	defer pipeWriter.Close()
+	if IsTerminal(pipeWriter) {
+		t.Errorf("Expected events.IsTerminal() to be false for a pipe")
+	}
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rockholla/go-lib/logger"
+)
+
+// Silent will return a sink that discards all events
+func Silent() Sink {
+	return silent{}
+}
+
+type silent struct{}
+
+func (s silent) Emit(event Event) {}
+
+// Memory is a sink keeping all events in memory, for tests. The zero value is ready to use.
+type Memory struct {
+	mutex  sync.Mutex
+	events []Event
+}
+
+// Emit will keep the event
+func (m *Memory) Emit(event Event) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.events = append(m.events, event)
+}
+
+// Events will return the events emitted so far, in order
+func (m *Memory) Events() []Event {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]Event{}, m.events...)
+}
+
+// LoggerSink writes events at or above its level as lines to a go-lib logger, errors with Error and all others
+// with Info
+type LoggerSink struct {
+	log   logger.Interface
+	level Level
+}
+
+// NewLoggerSink will return a sink writing events at or above the level to the logger
+func NewLoggerSink(log logger.Interface, level Level) *LoggerSink {
+	return &LoggerSink{log: log, level: level}
+}
+
+// Emit will write the event to the logger
+func (l *LoggerSink) Emit(event Event) {
+	if event.Level < l.level {
+		return
+	}
+	if event.Level >= LevelError {
+		l.log.Error("%s", event.Text())
+		return
+	}
+	l.log.Info("%s", event.Text())
+}
+
+// JSONSink writes events at or above its level as json lines in the format of log/slog's JSONHandler: time, level
+// and msg, followed by the event's fields that are set, the duration in nanoseconds
+type JSONSink struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	level  Level
+}
+
+// NewJSONSink will return a sink writing events at or above the level to the writer
+func NewJSONSink(writer io.Writer, level Level) *JSONSink {
+	return &JSONSink{writer: writer, level: level}
+}
+
+// Emit will write the event as a single json line, dropping it if the writer fails
+func (j *JSONSink) Emit(event Event) {
+	if event.Level < j.level {
+		return
+	}
+	line := &bytes.Buffer{}
+	line.WriteString("{")
+	writeField(line, "time", event.Time.Format(time.RFC3339Nano))
+	writeField(line, "level", event.Level.String())
+	writeField(line, "msg", event.Text())
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"service", event.Service},
+		{"method", event.Method},
+		{"resource", event.Resource},
+		{"project", event.Project},
+		{"action", string(event.Action)},
+	} {
+		if field.value != "" {
+			writeField(line, field.name, field.value)
+		}
+	}
+	if event.Method != "" {
+		writeField(line, "duration", event.Duration.Nanoseconds())
+		writeField(line, "outcome", string(event.Outcome))
+	}
+	if event.Err != nil {
+		writeField(line, "error", event.Err.Error())
+	}
+	line.WriteString("}\n")
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.writer.Write(line.Bytes())
+}
+
+func writeField(line *bytes.Buffer, name string, value interface{}) {
+	if line.Len() > 1 {
+		line.WriteString(",")
+	}
+	encodedName, _ := json.Marshal(name)
+	encodedValue, _ := json.Marshal(value)
+	line.Write(encodedName)
+	line.WriteString(":")
+	line.Write(encodedValue)
+}
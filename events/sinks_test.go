@@ -0,0 +1,93 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+type testLogger struct {
+	infos  []string
+	errors []string
+}
+
+func (l *testLogger) Focused(message string, args ...interface{})                     {}
+func (l *testLogger) Errors(messages []string, args ...interface{})                   {}
+func (l *testLogger) InfoPart(message string, args ...interface{})                    {}
+func (l *testLogger) ListItem(message string, args ...interface{})                    {}
+func (l *testLogger) LogPart(message string, style *color.Color, args ...interface{}) {}
+func (l *testLogger) Log(message string, style *color.Color, args ...interface{})     {}
+func (l *testLogger) SpinnerStart(message string)                                     {}
+func (l *testLogger) SpinnerStop()                                                    {}
+func (l *testLogger) Info(message string, args ...interface{}) {
+	l.infos = append(l.infos, format(message, args...))
+}
+func (l *testLogger) Error(message string, args ...interface{}) {
+	l.errors = append(l.errors, format(message, args...))
+}
+
+func TestJSONSink(t *testing.T) {
+	output := &bytes.Buffer{}
+	sink := NewJSONSink(output, LevelInfo)
+	sink.Emit(Event{Level: LevelDebug, Service: "dns", Message: "dropped"})
+	sink.Emit(Event{
+		Time:     time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		Level:    LevelError,
+		Service:  "dns",
+		Method:   "SetResourceRecordSets",
+		Resource: "test-zone",
+		Project:  "test-project",
+		Action:   ActionUpdate,
+		Duration: time.Second,
+		Outcome:  OutcomeFailure,
+		Err:      errors.New("test error"),
+	})
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected a single json line from events.JSONSink above its level, got: %v", lines)
+	}
+	if !strings.HasPrefix(lines[0], `{"time":"2021-04-01T00:00:00Z","level":"ERROR","msg":"dns.SetResourceRecordSets test-zone`) {
+		t.Errorf("Expected the json line to start with time, level and msg, got: %s", lines[0])
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(lines[0]), &fields); err != nil {
+		t.Fatalf("Got unexpected error parsing the events.JSONSink output: %s", err)
+	}
+	for name, expected := range map[string]interface{}{
+		"service":  "dns",
+		"method":   "SetResourceRecordSets",
+		"resource": "test-zone",
+		"project":  "test-project",
+		"action":   "update",
+		"duration": float64(time.Second),
+		"outcome":  "failure",
+		"error":    "test error",
+	} {
+		if fields[name] != expected {
+			t.Errorf("Expected json field %s to be %v, got: %v", name, expected, fields[name])
+		}
+	}
+}
+
+func TestLoggerSink(t *testing.T) {
+	log := &testLogger{}
+	sink := NewLoggerSink(log, LevelInfo)
+	sink.Emit(Event{Level: LevelDebug, Message: "dropped"})
+	sink.Emit(Event{Level: LevelInfo, Message: "100% done"})
+	sink.Emit(Event{Level: LevelError, Message: "failed"})
+	if len(log.infos) != 1 || log.infos[0] != "100% done" {
+		t.Errorf("Expected a single info line from events.LoggerSink, got: %v", log.infos)
+	}
+	if len(log.errors) != 1 || log.errors[0] != "failed" {
+		t.Errorf("Expected a single error line from events.LoggerSink, got: %v", log.errors)
+	}
+}
+
+func TestSilent(t *testing.T) {
+	Silent().Emit(Event{Level: LevelError, Message: "dropped"})
+}
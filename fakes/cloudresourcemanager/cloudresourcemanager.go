@@ -0,0 +1,1221 @@
+// Package cloudresourcemanager is an in-memory fake of the cloud resource manager library, for tests of code using it
+package cloudresourcemanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	crm "github.com/rockholla/go-google-lib/cloudresourcemanager"
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-lib/logger"
+	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+	"google.golang.org/api/googleapi"
+)
+
+var _ crm.Interface = &CloudResourceManager{}
+
+// CloudResourceManager is a stateful, in-memory implementation of cloudresourcemanager.Interface. Folders and
+// projects it creates can be found again, and IAM and org policies it sets are kept per resource. It's safe for
+// concurrent use, and the zero value is ready to use.
+type CloudResourceManager struct {
+	mutex         sync.Mutex
+	folders       []*folder
+	projects      []*v1.Project
+	policies      map[string]*iampolicy.Policy
+	orgPolicies   map[string]map[string]*v1.OrgPolicy
+	services      map[string][]string
+	liens         map[string][]*v1.Lien
+	nextFolderID  int64
+	nextProjectID int64
+	nextLienID    int64
+}
+
+type folder struct {
+	name        string
+	displayName string
+	parent      string
+	deleted     bool
+}
+
+// New will return an empty fake
+func New() *CloudResourceManager {
+	return &CloudResourceManager{}
+}
+
+// Initialize is a no-op for the fake
+func (f *CloudResourceManager) Initialize(credentials string, log logger.Interface) error {
+	return nil
+}
+
+// InitializeCtx is a no-op for the fake
+func (f *CloudResourceManager) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	return nil
+}
+
+// GetFolder returns an existing folder name, blank if none found
+func (f *CloudResourceManager) GetFolder(displayName string, parent string) (string, error) {
+	return f.GetFolderCtx(context.Background(), displayName, parent)
+}
+
+// GetFolderCtx is GetFolder, the context is unused
+func (f *CloudResourceManager) GetFolderCtx(ctx context.Context, displayName string, parent string) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if existing := f.findFolder(displayName, parent); existing != nil {
+		return existing.name, nil
+	}
+	return "", nil
+}
+
+// EnsureFolder will create the folder if it doesn't already exist, returning either the new or existing folder name
+func (f *CloudResourceManager) EnsureFolder(displayName string, parent string) (string, error) {
+	return f.EnsureFolderCtx(context.Background(), displayName, parent)
+}
+
+// EnsureFolderCtx is EnsureFolder, the context is unused
+func (f *CloudResourceManager) EnsureFolderCtx(ctx context.Context, displayName string, parent string) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if existing := f.findFolder(displayName, parent); existing != nil {
+		return existing.name, nil
+	}
+	f.nextFolderID++
+	created := &folder{
+		name:        fmt.Sprintf("folders/%d", 100000000000+f.nextFolderID),
+		displayName: displayName,
+		parent:      parent,
+	}
+	f.folders = append(f.folders, created)
+	return created.name, nil
+}
+
+// EnsureFolderPath will find or create each folder in the path of display names, nested in the one before it and the
+// first in the parent, returning the names of all of them
+func (f *CloudResourceManager) EnsureFolderPath(parent string, path string) ([]string, error) {
+	return f.EnsureFolderPathCtx(context.Background(), parent, path)
+}
+
+// EnsureFolderPathCtx is EnsureFolderPath, the context is unused
+func (f *CloudResourceManager) EnsureFolderPathCtx(ctx context.Context, parent string, path string) ([]string, error) {
+	displayNames := []string{}
+	for _, displayName := range strings.Split(path, "/") {
+		if displayName != "" {
+			displayNames = append(displayNames, displayName)
+		}
+	}
+	if parent == "" || len(displayNames) == 0 {
+		return nil, fmt.Errorf("Expecting a parent and a path of folder display names, but got: %q and %q", parent, path)
+	}
+	folders := []string{}
+	for _, displayName := range displayNames {
+		name, err := f.EnsureFolderCtx(ctx, displayName, parent)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, name)
+		parent = name
+	}
+	return folders, nil
+}
+
+// ResolveFolderPath will return the organization, or other resource the top folder was created in, and the path of
+// display names from it to the folder
+func (f *CloudResourceManager) ResolveFolderPath(folder string) (string, string, error) {
+	return f.ResolveFolderPathCtx(context.Background(), folder)
+}
+
+// ResolveFolderPathCtx is ResolveFolderPath, the context is unused
+func (f *CloudResourceManager) ResolveFolderPathCtx(ctx context.Context, folder string) (string, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	displayNames := []string{}
+	current := resourceName("folders", folder)
+	for strings.HasPrefix(current, "folders/") {
+		existing := f.folderByName(current)
+		if existing == nil {
+			return "", "", notFound("folder %s", current)
+		}
+		displayNames = append([]string{existing.displayName}, displayNames...)
+		current = existing.parent
+	}
+	return current, strings.Join(displayNames, "/"), nil
+}
+
+// ListFolders will return the folders in the parent, and when recursive, the folders nested in them too, breadth
+// first
+func (f *CloudResourceManager) ListFolders(parent string, recursive bool) ([]*v2beta1.Folder, error) {
+	return f.ListFoldersCtx(context.Background(), parent, recursive)
+}
+
+// ListFoldersCtx is ListFolders, the context is unused
+func (f *CloudResourceManager) ListFoldersCtx(ctx context.Context, parent string, recursive bool) ([]*v2beta1.Folder, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	folders := []*v2beta1.Folder{}
+	parents := []string{parent}
+	for len(parents) > 0 {
+		for _, existing := range f.folders {
+			if existing.parent != parents[0] || existing.deleted {
+				continue
+			}
+			folders = append(folders, &v2beta1.Folder{Name: existing.name, DisplayName: existing.displayName, Parent: existing.parent, LifecycleState: "ACTIVE"})
+			if recursive {
+				parents = append(parents, existing.name)
+			}
+		}
+		parents = parents[1:]
+	}
+	return folders, nil
+}
+
+// MoveFolder will move the folder to the parent
+func (f *CloudResourceManager) MoveFolder(folder string, parent string) error {
+	return f.MoveFolderCtx(context.Background(), folder, parent)
+}
+
+// MoveFolderCtx is MoveFolder, the context is unused
+func (f *CloudResourceManager) MoveFolderCtx(ctx context.Context, folder string, parent string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	existing := f.folderByName(resourceName("folders", folder))
+	if existing == nil {
+		return notFound("folder %s", folder)
+	}
+	existing.parent = parent
+	return nil
+}
+
+// RenameFolder will update the display name of the folder
+func (f *CloudResourceManager) RenameFolder(folder string, displayName string) error {
+	return f.RenameFolderCtx(context.Background(), folder, displayName)
+}
+
+// RenameFolderCtx is RenameFolder, the context is unused
+func (f *CloudResourceManager) RenameFolderCtx(ctx context.Context, folder string, displayName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	existing := f.folderByName(resourceName("folders", folder))
+	if existing == nil {
+		return notFound("folder %s", folder)
+	}
+	existing.displayName = displayName
+	return nil
+}
+
+// DeleteFolder will mark the folder as deleted, nothing if it doesn't exist or is already deleted, returning a
+// *cloudresourcemanager.FolderNotEmptyError if it has active folders or projects in it, unless recursive
+func (f *CloudResourceManager) DeleteFolder(folder string, recursive bool) error {
+	return f.DeleteFolderCtx(context.Background(), folder, recursive)
+}
+
+// DeleteFolderCtx is DeleteFolder, the context is unused
+func (f *CloudResourceManager) DeleteFolderCtx(ctx context.Context, folder string, recursive bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.deleteFolder(resourceName("folders", folder), recursive)
+}
+
+// UndeleteFolder will restore the deleted folder, nothing if it's active
+func (f *CloudResourceManager) UndeleteFolder(folder string) error {
+	return f.UndeleteFolderCtx(context.Background(), folder)
+}
+
+// UndeleteFolderCtx is UndeleteFolder, the context is unused
+func (f *CloudResourceManager) UndeleteFolderCtx(ctx context.Context, folder string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	existing := f.folderByName(resourceName("folders", folder))
+	if existing == nil {
+		return notFound("folder %s", folder)
+	}
+	existing.deleted = false
+	return nil
+}
+
+// EnsureFolderRoles will add the member to each of the roles in the folder's IAM policy
+func (f *CloudResourceManager) EnsureFolderRoles(folder string, member string, roles []string) error {
+	return f.EnsureFolderRolesCtx(context.Background(), folder, member, roles)
+}
+
+// EnsureFolderRolesCtx is EnsureFolderRoles, the context is unused
+func (f *CloudResourceManager) EnsureFolderRolesCtx(ctx context.Context, folder string, member string, roles []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.addMember(resourceName("folders", folder), member, roles, nil)
+	return nil
+}
+
+// EnsureFolderRolesWithCondition will add the member to each of the roles with the condition in the folder's IAM
+// policy
+func (f *CloudResourceManager) EnsureFolderRolesWithCondition(folder string, member string, roles []string, condition *iampolicy.Condition) error {
+	return f.EnsureFolderRolesWithConditionCtx(context.Background(), folder, member, roles, condition)
+}
+
+// EnsureFolderRolesWithConditionCtx is EnsureFolderRolesWithCondition, the context is unused
+func (f *CloudResourceManager) EnsureFolderRolesWithConditionCtx(ctx context.Context, folder string, member string, roles []string, condition *iampolicy.Condition) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.addMember(resourceName("folders", folder), member, roles, condition)
+	return nil
+}
+
+// RemoveFolderRoles will remove the member from each of the roles in the folder's IAM policy
+func (f *CloudResourceManager) RemoveFolderRoles(folder string, member string, roles []string) ([]string, error) {
+	return f.RemoveFolderRolesCtx(context.Background(), folder, member, roles)
+}
+
+// RemoveFolderRolesCtx is RemoveFolderRoles, the context is unused
+func (f *CloudResourceManager) RemoveFolderRolesCtx(ctx context.Context, folder string, member string, roles []string) ([]string, error) {
+	return f.RemoveFolderRolesWithConditionCtx(ctx, folder, member, roles, nil)
+}
+
+// RemoveFolderRolesWithCondition will remove the member from each of the roles with the condition in the folder's
+// IAM policy
+func (f *CloudResourceManager) RemoveFolderRolesWithCondition(folder string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	return f.RemoveFolderRolesWithConditionCtx(context.Background(), folder, member, roles, condition)
+}
+
+// RemoveFolderRolesWithConditionCtx is RemoveFolderRolesWithCondition, the context is unused
+func (f *CloudResourceManager) RemoveFolderRolesWithConditionCtx(ctx context.Context, folder string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.removeMember(resourceName("folders", folder), member, roles, condition), nil
+}
+
+// RemoveFolderMember will remove the member from every role in the folder's IAM policy
+func (f *CloudResourceManager) RemoveFolderMember(folder string, member string) ([]string, error) {
+	return f.RemoveFolderMemberCtx(context.Background(), folder, member)
+}
+
+// RemoveFolderMemberCtx is RemoveFolderMember, the context is unused
+func (f *CloudResourceManager) RemoveFolderMemberCtx(ctx context.Context, folder string, member string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.mutate(resourceName("folders", folder), member, iampolicy.RemoveMemberFromAll(member)), nil
+}
+
+// DiffFolderPolicy will return the change reconciling the folder's IAM policy with the spec would make, without
+// making it
+func (f *CloudResourceManager) DiffFolderPolicy(folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return f.DiffFolderPolicyCtx(context.Background(), folder, spec)
+}
+
+// DiffFolderPolicyCtx is DiffFolderPolicy, the context is unused
+func (f *CloudResourceManager) DiffFolderPolicyCtx(ctx context.Context, folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	folder = resourceName("folders", folder)
+	return f.reconcile(folder, plan.Change{Service: "cloudresourcemanager", Method: "DiffFolderPolicy", Resource: folder, Action: plan.ActionUpdate}, spec, true)
+}
+
+// ReconcileFolderPolicy will bring the folder's IAM policy in line with the spec, returning the change it
+// made
+func (f *CloudResourceManager) ReconcileFolderPolicy(folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return f.ReconcileFolderPolicyCtx(context.Background(), folder, spec)
+}
+
+// ReconcileFolderPolicyCtx is ReconcileFolderPolicy, the context is unused
+func (f *CloudResourceManager) ReconcileFolderPolicyCtx(ctx context.Context, folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	folder = resourceName("folders", folder)
+	return f.reconcile(folder, plan.Change{Service: "cloudresourcemanager", Method: "ReconcileFolderPolicy", Resource: folder, Action: plan.ActionUpdate}, spec, false)
+}
+
+// SetFolderOrgPolicy will keep the policy for the folder, replacing any existing one for the same constraint
+func (f *CloudResourceManager) SetFolderOrgPolicy(folder string, policy *v1.OrgPolicy) error {
+	return f.SetFolderOrgPolicyCtx(context.Background(), folder, policy)
+}
+
+// SetFolderOrgPolicyCtx is SetFolderOrgPolicy, the context is unused
+func (f *CloudResourceManager) SetFolderOrgPolicyCtx(ctx context.Context, folder string, policy *v1.OrgPolicy) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.setOrgPolicy(resourceName("folders", folder), policy)
+	return nil
+}
+
+// GetProject returns an active project with the name in the parent, nil if none found
+func (f *CloudResourceManager) GetProject(name string, parent string) (*v1.Project, error) {
+	return f.GetProjectCtx(context.Background(), name, parent)
+}
+
+// GetProjectCtx is GetProject, the context is unused
+func (f *CloudResourceManager) GetProjectCtx(ctx context.Context, name string, parent string) (*v1.Project, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.findProject(name, parent), nil
+}
+
+// DeleteProject will mark an active project as deleted, nothing if it isn't active, returning a
+// *cloudresourcemanager.ProjectLienError if it has liens on it
+func (f *CloudResourceManager) DeleteProject(id string) error {
+	return f.DeleteProjectCtx(context.Background(), id)
+}
+
+// DeleteProjectCtx is DeleteProject, the context is unused
+func (f *CloudResourceManager) DeleteProjectCtx(ctx context.Context, id string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project := f.projectByID(id)
+	if project == nil {
+		return fmt.Errorf("error determining if project to delete exists: %s", notFound("project %s", id))
+	}
+	return f.deleteProject(project)
+}
+
+// GetProjectByID returns the project with the ID, nil if it isn't active
+func (f *CloudResourceManager) GetProjectByID(id string) (*v1.Project, error) {
+	return f.GetProjectByIDCtx(context.Background(), id)
+}
+
+// GetProjectByIDCtx is GetProjectByID, the context is unused
+func (f *CloudResourceManager) GetProjectByIDCtx(ctx context.Context, id string) (*v1.Project, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project := f.projectByID(id)
+	if project == nil {
+		return nil, notFound("project %s", id)
+	}
+	if project.LifecycleState != "ACTIVE" {
+		return nil, nil
+	}
+	return copyProject(project), nil
+}
+
+// EnsureProject will create the project if it doesn't already exist, returning either the new or existing project
+// ID and number
+func (f *CloudResourceManager) EnsureProject(name string, parent string) (string, int64, error) {
+	return f.EnsureProjectCtx(context.Background(), name, parent)
+}
+
+// EnsureProjectCtx is EnsureProject, the context is unused
+func (f *CloudResourceManager) EnsureProjectCtx(ctx context.Context, name string, parent string) (string, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if existing := f.findProject(name, parent); existing != nil {
+		return existing.ProjectId, existing.ProjectNumber, nil
+	}
+	projectID, err := crm.MakeProjectID(name, parent)
+	if err != nil {
+		return "", 0, err
+	}
+	if f.projectByID(projectID) != nil {
+		return "", 0, &googleapi.Error{Code: http.StatusConflict, Message: fmt.Sprintf("project %s already exists", projectID)}
+	}
+	parentParts := strings.Split(parent, "/")
+	f.nextProjectID++
+	project := &v1.Project{
+		Name:           name,
+		ProjectId:      projectID,
+		ProjectNumber:  200000000000 + f.nextProjectID,
+		LifecycleState: "ACTIVE",
+		Parent: &v1.ResourceId{
+			Type: strings.TrimRight(parentParts[0], "s"),
+			Id:   parentParts[1],
+		},
+	}
+	f.projects = append(f.projects, project)
+	return project.ProjectId, project.ProjectNumber, nil
+}
+
+// MoveProject will move an active project to the parent, e.g. folders/1234
+func (f *CloudResourceManager) MoveProject(id string, parent string) error {
+	return f.MoveProjectCtx(context.Background(), id, parent)
+}
+
+// MoveProjectCtx is MoveProject, the context is unused
+func (f *CloudResourceManager) MoveProjectCtx(ctx context.Context, id string, parent string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project, err := f.activeProject(id)
+	if err != nil {
+		return err
+	}
+	parentParts := strings.Split(parent, "/")
+	if len(parentParts) != 2 {
+		return fmt.Errorf("Expecting the parent argument to be like [type]/[ID], e.g. folders/92737276394872, but got: %s", parent)
+	}
+	project.Parent = &v1.ResourceId{Type: strings.TrimSuffix(parentParts[0], "s"), Id: parentParts[1]}
+	return nil
+}
+
+// UndeleteProject will restore a deleted project, nothing if it's active
+func (f *CloudResourceManager) UndeleteProject(id string) error {
+	return f.UndeleteProjectCtx(context.Background(), id)
+}
+
+// UndeleteProjectCtx is UndeleteProject, the context is unused
+func (f *CloudResourceManager) UndeleteProjectCtx(ctx context.Context, id string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project := f.projectByID(id)
+	if project == nil {
+		return notFound("project %s", id)
+	}
+	project.LifecycleState = "ACTIVE"
+	return nil
+}
+
+// SetProjectLabels will replace the labels of an active project with the ones supplied
+func (f *CloudResourceManager) SetProjectLabels(id string, labels map[string]string) error {
+	return f.SetProjectLabelsCtx(context.Background(), id, labels)
+}
+
+// SetProjectLabelsCtx is SetProjectLabels, the context is unused
+func (f *CloudResourceManager) SetProjectLabelsCtx(ctx context.Context, id string, labels map[string]string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project, err := f.activeProject(id)
+	if err != nil {
+		return err
+	}
+	project.Labels = map[string]string{}
+	for key, value := range labels {
+		project.Labels[key] = value
+	}
+	return nil
+}
+
+// MergeProjectLabels will add the labels supplied to an active project, keeping its other labels
+func (f *CloudResourceManager) MergeProjectLabels(id string, labels map[string]string) error {
+	return f.MergeProjectLabelsCtx(context.Background(), id, labels)
+}
+
+// MergeProjectLabelsCtx is MergeProjectLabels, the context is unused
+func (f *CloudResourceManager) MergeProjectLabelsCtx(ctx context.Context, id string, labels map[string]string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project, err := f.activeProject(id)
+	if err != nil {
+		return err
+	}
+	if project.Labels == nil {
+		project.Labels = map[string]string{}
+	}
+	for key, value := range labels {
+		project.Labels[key] = value
+	}
+	return nil
+}
+
+// CreateProjectLien will place a lien preventing deletion on the project, returning the existing lien with the same
+// reason and origin if there is one
+func (f *CloudResourceManager) CreateProjectLien(id string, reason string, origin string) (*v1.Lien, error) {
+	return f.CreateProjectLienCtx(context.Background(), id, reason, origin)
+}
+
+// CreateProjectLienCtx is CreateProjectLien, the context is unused
+func (f *CloudResourceManager) CreateProjectLienCtx(ctx context.Context, id string, reason string, origin string) (*v1.Lien, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.projectByID(id) == nil {
+		return nil, notFound("project %s", id)
+	}
+	for _, existing := range f.liens[id] {
+		if existing.Reason == reason && existing.Origin == origin {
+			return copyLien(existing), nil
+		}
+	}
+	if f.liens == nil {
+		f.liens = map[string][]*v1.Lien{}
+	}
+	f.nextLienID++
+	lien := &v1.Lien{
+		Name:         fmt.Sprintf("liens/p%d", 300000000000+f.nextLienID),
+		Parent:       fmt.Sprintf("projects/%s", id),
+		Reason:       reason,
+		Origin:       origin,
+		Restrictions: []string{crm.ProjectDeleteRestriction},
+	}
+	f.liens[id] = append(f.liens[id], lien)
+	return copyLien(lien), nil
+}
+
+// ListProjectLiens will return the liens on the project
+func (f *CloudResourceManager) ListProjectLiens(id string) ([]*v1.Lien, error) {
+	return f.ListProjectLiensCtx(context.Background(), id)
+}
+
+// ListProjectLiensCtx is ListProjectLiens, the context is unused
+func (f *CloudResourceManager) ListProjectLiensCtx(ctx context.Context, id string) ([]*v1.Lien, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	liens := []*v1.Lien{}
+	for _, lien := range f.liens[id] {
+		liens = append(liens, copyLien(lien))
+	}
+	return liens, nil
+}
+
+// RemoveProjectLien will remove the lien from the project, nothing if it isn't on it
+func (f *CloudResourceManager) RemoveProjectLien(id string, lien string) error {
+	return f.RemoveProjectLienCtx(context.Background(), id, lien)
+}
+
+// RemoveProjectLienCtx is RemoveProjectLien, the context is unused
+func (f *CloudResourceManager) RemoveProjectLienCtx(ctx context.Context, id string, lien string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	liens := []*v1.Lien{}
+	for _, existing := range f.liens[id] {
+		if existing.Name != lien {
+			liens = append(liens, existing)
+		}
+	}
+	if f.liens != nil {
+		f.liens[id] = liens
+	}
+	return nil
+}
+
+// EnableProjectServices will record the services as enabled in an active project
+func (f *CloudResourceManager) EnableProjectServices(projectID string, services []string) error {
+	return f.EnableProjectServicesCtx(context.Background(), projectID, services)
+}
+
+// EnableProjectServicesCtx is EnableProjectServices, the context is unused
+func (f *CloudResourceManager) EnableProjectServicesCtx(ctx context.Context, projectID string, services []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project := f.projectByID(projectID)
+	if project == nil {
+		return notFound("project %s", projectID)
+	}
+	if f.services == nil {
+		f.services = map[string][]string{}
+	}
+	for _, service := range services {
+		if !contains(f.services[projectID], service) {
+			f.services[projectID] = append(f.services[projectID], service)
+		}
+	}
+	return nil
+}
+
+// EnsureProjectRoles will add the member to each of the roles in the project's IAM policy
+func (f *CloudResourceManager) EnsureProjectRoles(project string, member string, roles []string) error {
+	return f.EnsureProjectRolesCtx(context.Background(), project, member, roles)
+}
+
+// EnsureProjectRolesCtx is EnsureProjectRoles, the context is unused
+func (f *CloudResourceManager) EnsureProjectRolesCtx(ctx context.Context, project string, member string, roles []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.addMember(resourceName("projects", project), member, roles, nil)
+	return nil
+}
+
+// EnsureProjectRolesWithCondition will add the member to each of the roles with the condition in the project's IAM
+// policy
+func (f *CloudResourceManager) EnsureProjectRolesWithCondition(project string, member string, roles []string, condition *iampolicy.Condition) error {
+	return f.EnsureProjectRolesWithConditionCtx(context.Background(), project, member, roles, condition)
+}
+
+// EnsureProjectRolesWithConditionCtx is EnsureProjectRolesWithCondition, the context is unused
+func (f *CloudResourceManager) EnsureProjectRolesWithConditionCtx(ctx context.Context, project string, member string, roles []string, condition *iampolicy.Condition) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.addMember(resourceName("projects", project), member, roles, condition)
+	return nil
+}
+
+// RemoveProjectRoles will remove the member from each of the roles in the project's IAM policy
+func (f *CloudResourceManager) RemoveProjectRoles(project string, member string, roles []string) ([]string, error) {
+	return f.RemoveProjectRolesCtx(context.Background(), project, member, roles)
+}
+
+// RemoveProjectRolesCtx is RemoveProjectRoles, the context is unused
+func (f *CloudResourceManager) RemoveProjectRolesCtx(ctx context.Context, project string, member string, roles []string) ([]string, error) {
+	return f.RemoveProjectRolesWithConditionCtx(ctx, project, member, roles, nil)
+}
+
+// RemoveProjectRolesWithCondition will remove the member from each of the roles with the condition in the project's
+// IAM policy
+func (f *CloudResourceManager) RemoveProjectRolesWithCondition(project string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	return f.RemoveProjectRolesWithConditionCtx(context.Background(), project, member, roles, condition)
+}
+
+// RemoveProjectRolesWithConditionCtx is RemoveProjectRolesWithCondition, the context is unused
+func (f *CloudResourceManager) RemoveProjectRolesWithConditionCtx(ctx context.Context, project string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.removeMember(resourceName("projects", project), member, roles, condition), nil
+}
+
+// RemoveProjectMember will remove the member from every role in the project's IAM policy
+func (f *CloudResourceManager) RemoveProjectMember(project string, member string) ([]string, error) {
+	return f.RemoveProjectMemberCtx(context.Background(), project, member)
+}
+
+// RemoveProjectMemberCtx is RemoveProjectMember, the context is unused
+func (f *CloudResourceManager) RemoveProjectMemberCtx(ctx context.Context, project string, member string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.mutate(resourceName("projects", project), member, iampolicy.RemoveMemberFromAll(member)), nil
+}
+
+// DiffProjectPolicy will return the change reconciling the project's IAM policy with the spec would make, without
+// making it
+func (f *CloudResourceManager) DiffProjectPolicy(project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return f.DiffProjectPolicyCtx(context.Background(), project, spec)
+}
+
+// DiffProjectPolicyCtx is DiffProjectPolicy, the context is unused
+func (f *CloudResourceManager) DiffProjectPolicyCtx(ctx context.Context, project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project = resourceName("projects", project)
+	return f.reconcile(project, plan.Change{Service: "cloudresourcemanager", Method: "DiffProjectPolicy", Project: strings.TrimPrefix(project, "projects/"), Resource: strings.TrimPrefix(project, "projects/"), Action: plan.ActionUpdate}, spec, true)
+}
+
+// ReconcileProjectPolicy will bring the project's IAM policy in line with the spec, returning the change it
+// made
+func (f *CloudResourceManager) ReconcileProjectPolicy(project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return f.ReconcileProjectPolicyCtx(context.Background(), project, spec)
+}
+
+// ReconcileProjectPolicyCtx is ReconcileProjectPolicy, the context is unused
+func (f *CloudResourceManager) ReconcileProjectPolicyCtx(ctx context.Context, project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	project = resourceName("projects", project)
+	return f.reconcile(project, plan.Change{Service: "cloudresourcemanager", Method: "ReconcileProjectPolicy", Project: strings.TrimPrefix(project, "projects/"), Resource: strings.TrimPrefix(project, "projects/"), Action: plan.ActionUpdate}, spec, false)
+}
+
+// EnsureOrganizationRoles will add the member to each of the roles in the organization's IAM policy
+func (f *CloudResourceManager) EnsureOrganizationRoles(organization string, member string, roles []string) error {
+	return f.EnsureOrganizationRolesCtx(context.Background(), organization, member, roles)
+}
+
+// EnsureOrganizationRolesCtx is EnsureOrganizationRoles, the context is unused
+func (f *CloudResourceManager) EnsureOrganizationRolesCtx(ctx context.Context, organization string, member string, roles []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.addMember(resourceName("organizations", organization), member, roles, nil)
+	return nil
+}
+
+// EnsureOrganizationRolesWithCondition will add the member to each of the roles with the condition in the
+// organization's IAM policy
+func (f *CloudResourceManager) EnsureOrganizationRolesWithCondition(organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	return f.EnsureOrganizationRolesWithConditionCtx(context.Background(), organization, member, roles, condition)
+}
+
+// EnsureOrganizationRolesWithConditionCtx is EnsureOrganizationRolesWithCondition, the context is unused
+func (f *CloudResourceManager) EnsureOrganizationRolesWithConditionCtx(ctx context.Context, organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.addMember(resourceName("organizations", organization), member, roles, condition)
+	return nil
+}
+
+// RemoveOrganizationRoles will remove the member from each of the roles in the organization's IAM policy
+func (f *CloudResourceManager) RemoveOrganizationRoles(organization string, member string, roles []string) error {
+	return f.RemoveOrganizationRolesCtx(context.Background(), organization, member, roles)
+}
+
+// RemoveOrganizationRolesCtx is RemoveOrganizationRoles, the context is unused
+func (f *CloudResourceManager) RemoveOrganizationRolesCtx(ctx context.Context, organization string, member string, roles []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.removeMember(resourceName("organizations", organization), member, roles, nil)
+	return nil
+}
+
+// RemoveOrganizationRolesWithCondition will remove the member from each of the roles with the condition in the
+// organization's IAM policy
+func (f *CloudResourceManager) RemoveOrganizationRolesWithCondition(organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	return f.RemoveOrganizationRolesWithConditionCtx(context.Background(), organization, member, roles, condition)
+}
+
+// RemoveOrganizationRolesWithConditionCtx is RemoveOrganizationRolesWithCondition, the context is unused
+func (f *CloudResourceManager) RemoveOrganizationRolesWithConditionCtx(ctx context.Context, organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.removeMember(resourceName("organizations", organization), member, roles, condition)
+	return nil
+}
+
+// RemoveOrganizationMember will remove the member from every role in the organization's IAM policy
+func (f *CloudResourceManager) RemoveOrganizationMember(organization string, member string) ([]string, error) {
+	return f.RemoveOrganizationMemberCtx(context.Background(), organization, member)
+}
+
+// RemoveOrganizationMemberCtx is RemoveOrganizationMember, the context is unused
+func (f *CloudResourceManager) RemoveOrganizationMemberCtx(ctx context.Context, organization string, member string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.mutate(resourceName("organizations", organization), member, iampolicy.RemoveMemberFromAll(member)), nil
+}
+
+// DiffOrganizationPolicy will return the change reconciling the organization's IAM policy with the spec would make, without
+// making it
+func (f *CloudResourceManager) DiffOrganizationPolicy(organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return f.DiffOrganizationPolicyCtx(context.Background(), organization, spec)
+}
+
+// DiffOrganizationPolicyCtx is DiffOrganizationPolicy, the context is unused
+func (f *CloudResourceManager) DiffOrganizationPolicyCtx(ctx context.Context, organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	organization = resourceName("organizations", organization)
+	return f.reconcile(organization, plan.Change{Service: "cloudresourcemanager", Method: "DiffOrganizationPolicy", Resource: organization, Action: plan.ActionUpdate}, spec, true)
+}
+
+// ReconcileOrganizationPolicy will bring the organization's IAM policy in line with the spec, returning the change it
+// made
+func (f *CloudResourceManager) ReconcileOrganizationPolicy(organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	return f.ReconcileOrganizationPolicyCtx(context.Background(), organization, spec)
+}
+
+// ReconcileOrganizationPolicyCtx is ReconcileOrganizationPolicy, the context is unused
+func (f *CloudResourceManager) ReconcileOrganizationPolicyCtx(ctx context.Context, organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	organization = resourceName("organizations", organization)
+	return f.reconcile(organization, plan.Change{Service: "cloudresourcemanager", Method: "ReconcileOrganizationPolicy", Resource: organization, Action: plan.ActionUpdate}, spec, false)
+}
+
+// GetOrgPolicy will return the org policy kept for the constraint on the resource, a policy with only the constraint
+// if none has been set
+func (f *CloudResourceManager) GetOrgPolicy(resource string, constraint string) (*v1.OrgPolicy, error) {
+	return f.GetOrgPolicyCtx(context.Background(), resource, constraint)
+}
+
+// GetOrgPolicyCtx is GetOrgPolicy, the context is unused
+func (f *CloudResourceManager) GetOrgPolicyCtx(ctx context.Context, resource string, constraint string) (*v1.OrgPolicy, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if err := validOrgPolicyResource(resource); err != nil {
+		return nil, err
+	}
+	return f.orgPolicy(resource, constraintName(constraint)), nil
+}
+
+// GetEffectiveOrgPolicy will return the org policy kept for the constraint on the resource or, failing that, the
+// nearest of its parents, without merging list policies the way the api does
+func (f *CloudResourceManager) GetEffectiveOrgPolicy(resource string, constraint string) (*v1.OrgPolicy, error) {
+	return f.GetEffectiveOrgPolicyCtx(context.Background(), resource, constraint)
+}
+
+// GetEffectiveOrgPolicyCtx is GetEffectiveOrgPolicy, the context is unused
+func (f *CloudResourceManager) GetEffectiveOrgPolicyCtx(ctx context.Context, resource string, constraint string) (*v1.OrgPolicy, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if err := validOrgPolicyResource(resource); err != nil {
+		return nil, err
+	}
+	constraint = constraintName(constraint)
+	for current := resource; current != ""; current = f.parentOf(current) {
+		if policy, ok := f.orgPolicies[current][constraint]; ok {
+			if policy.RestoreDefault != nil {
+				break
+			}
+			return copyOrgPolicy(policy), nil
+		}
+	}
+	return &v1.OrgPolicy{Constraint: constraint}, nil
+}
+
+// ListOrgPolicies will return the org policies kept for the resource, ordered by constraint
+func (f *CloudResourceManager) ListOrgPolicies(resource string) ([]*v1.OrgPolicy, error) {
+	return f.ListOrgPoliciesCtx(context.Background(), resource)
+}
+
+// ListOrgPoliciesCtx is ListOrgPolicies, the context is unused
+func (f *CloudResourceManager) ListOrgPoliciesCtx(ctx context.Context, resource string) ([]*v1.OrgPolicy, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if err := validOrgPolicyResource(resource); err != nil {
+		return nil, err
+	}
+	constraints := []string{}
+	for constraint := range f.orgPolicies[resource] {
+		constraints = append(constraints, constraint)
+	}
+	sort.Strings(constraints)
+	policies := []*v1.OrgPolicy{}
+	for _, constraint := range constraints {
+		policies = append(policies, copyOrgPolicy(f.orgPolicies[resource][constraint]))
+	}
+	return policies, nil
+}
+
+// SetOrgPolicy will keep the policy for the resource, replacing any existing one for the same constraint
+func (f *CloudResourceManager) SetOrgPolicy(resource string, policy *v1.OrgPolicy) error {
+	return f.SetOrgPolicyCtx(context.Background(), resource, policy)
+}
+
+// SetOrgPolicyCtx is SetOrgPolicy, the context is unused
+func (f *CloudResourceManager) SetOrgPolicyCtx(ctx context.Context, resource string, policy *v1.OrgPolicy) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if err := validOrgPolicyResource(resource); err != nil {
+		return err
+	}
+	f.setOrgPolicy(resource, policy)
+	return nil
+}
+
+// EnsureOrgPolicy will keep the policy for the resource only when it differs from the one kept for the same
+// constraint, returning whether it did
+func (f *CloudResourceManager) EnsureOrgPolicy(resource string, policy *v1.OrgPolicy) (bool, error) {
+	return f.EnsureOrgPolicyCtx(context.Background(), resource, policy)
+}
+
+// EnsureOrgPolicyCtx is EnsureOrgPolicy, the context is unused
+func (f *CloudResourceManager) EnsureOrgPolicyCtx(ctx context.Context, resource string, policy *v1.OrgPolicy) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if err := validOrgPolicyResource(resource); err != nil {
+		return false, err
+	}
+	if crm.SameOrgPolicy(f.orgPolicy(resource, constraintName(policy.Constraint)), policy) {
+		return false, nil
+	}
+	f.setOrgPolicy(resource, policy)
+	return true, nil
+}
+
+// ClearOrgPolicy will remove the policy kept for the constraint from the resource
+func (f *CloudResourceManager) ClearOrgPolicy(resource string, constraint string) error {
+	return f.ClearOrgPolicyCtx(context.Background(), resource, constraint)
+}
+
+// ClearOrgPolicyCtx is ClearOrgPolicy, the context is unused
+func (f *CloudResourceManager) ClearOrgPolicyCtx(ctx context.Context, resource string, constraint string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if err := validOrgPolicyResource(resource); err != nil {
+		return err
+	}
+	delete(f.orgPolicies[resource], constraintName(constraint))
+	return nil
+}
+
+// IAMPolicy will return a copy of the IAM policy of a resource, e.g. projects/my-project, folders/1234 or
+// organizations/5678, an empty policy if none has been set
+func (f *CloudResourceManager) IAMPolicy(resource string) *v1.Policy {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	policy := &v1.Policy{}
+	if existing, ok := f.policies[resource]; ok {
+		policy.Version = existing.Version
+		for _, binding := range existing.Copy().Bindings {
+			b := &v1.Binding{Role: binding.Role, Members: binding.Members}
+			if binding.Condition != nil {
+				b.Condition = &v1.Expr{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+			}
+			policy.Bindings = append(policy.Bindings, b)
+		}
+	}
+	return policy
+}
+
+// OrgPolicy will return the org policy set for the constraint on a resource, nil if none has been set
+func (f *CloudResourceManager) OrgPolicy(resource string, constraint string) *v1.OrgPolicy {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.orgPolicies[resource][constraintName(constraint)]
+}
+
+// EnabledServices will return the services enabled in a project, in the order they were enabled
+func (f *CloudResourceManager) EnabledServices(projectID string) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string{}, f.services[projectID]...)
+}
+
+// Folders will return the names of all folders created, including deleted ones, in the order they were created
+func (f *CloudResourceManager) Folders() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	names := []string{}
+	for _, existing := range f.folders {
+		names = append(names, existing.name)
+	}
+	return names
+}
+
+// Projects will return copies of all projects created, including deleted ones, in the order they were created
+func (f *CloudResourceManager) Projects() []*v1.Project {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	projects := []*v1.Project{}
+	for _, project := range f.projects {
+		projects = append(projects, copyProject(project))
+	}
+	return projects
+}
+
+func (f *CloudResourceManager) findFolder(displayName string, parent string) *folder {
+	for _, existing := range f.folders {
+		if existing.displayName == displayName && (parent == "" || existing.parent == parent) && !existing.deleted {
+			return existing
+		}
+	}
+	return nil
+}
+
+func (f *CloudResourceManager) folderByName(name string) *folder {
+	for _, existing := range f.folders {
+		if existing.name == name {
+			return existing
+		}
+	}
+	return nil
+}
+
+func (f *CloudResourceManager) deleteFolder(name string, recursive bool) error {
+	existing := f.folderByName(name)
+	if existing == nil || existing.deleted {
+		return nil
+	}
+	notEmpty := &crm.FolderNotEmptyError{Folder: name, Folders: []string{}, Projects: []string{}}
+	for _, child := range f.folders {
+		if child.parent == name && !child.deleted {
+			notEmpty.Folders = append(notEmpty.Folders, child.name)
+		}
+	}
+	projects := []*v1.Project{}
+	for _, project := range f.projects {
+		if project.LifecycleState == "ACTIVE" && project.Parent != nil && project.Parent.Type == "folder" && "folders/"+project.Parent.Id == name {
+			notEmpty.Projects = append(notEmpty.Projects, project.ProjectId)
+			projects = append(projects, project)
+		}
+	}
+	if (len(notEmpty.Folders) > 0 || len(notEmpty.Projects) > 0) && !recursive {
+		return notEmpty
+	}
+	for _, child := range notEmpty.Folders {
+		if err := f.deleteFolder(child, true); err != nil {
+			return err
+		}
+	}
+	for _, project := range projects {
+		if err := f.deleteProject(project); err != nil {
+			return err
+		}
+	}
+	existing.deleted = true
+	return nil
+}
+
+// activeProject will return the project with the ID, an error if there isn't one or it isn't active
+func (f *CloudResourceManager) activeProject(id string) (*v1.Project, error) {
+	project := f.projectByID(id)
+	if project == nil {
+		return nil, notFound("project %s", id)
+	}
+	if project.LifecycleState != "ACTIVE" {
+		return nil, fmt.Errorf("Expecting project %s to be active, but it's %s", id, project.LifecycleState)
+	}
+	return project, nil
+}
+
+// deleteProject will mark an active project as deleted, returning a *cloudresourcemanager.ProjectLienError instead if it
+// has liens on it
+func (f *CloudResourceManager) deleteProject(project *v1.Project) error {
+	if project.LifecycleState != "ACTIVE" {
+		return nil
+	}
+	if liens := f.liens[project.ProjectId]; len(liens) > 0 {
+		lienError := &crm.ProjectLienError{Project: project.ProjectId}
+		for _, lien := range liens {
+			lienError.Liens = append(lienError.Liens, copyLien(lien))
+		}
+		return lienError
+	}
+	project.LifecycleState = "DELETE_REQUESTED"
+	return nil
+}
+
+func (f *CloudResourceManager) findProject(name string, parent string) *v1.Project {
+	parentParts := strings.Split(parent, "/")
+	for _, project := range f.projects {
+		if project.Name != name || project.LifecycleState != "ACTIVE" || project.Parent == nil {
+			continue
+		}
+		if project.Parent.Type == strings.TrimRight(parentParts[0], "s") && project.Parent.Id == parentParts[len(parentParts)-1] {
+			return copyProject(project)
+		}
+	}
+	return nil
+}
+
+func (f *CloudResourceManager) projectByID(id string) *v1.Project {
+	for _, project := range f.projects {
+		if project.ProjectId == id {
+			return project
+		}
+	}
+	return nil
+}
+
+func (f *CloudResourceManager) addMember(resource string, member string, roles []string, condition *iampolicy.Condition) {
+	if f.policies == nil {
+		f.policies = map[string]*iampolicy.Policy{}
+	}
+	policy, ok := f.policies[resource]
+	if !ok {
+		policy = &iampolicy.Policy{}
+		f.policies[resource] = policy
+	}
+	for _, role := range roles {
+		iampolicy.AddMember(role, member, condition)(policy)
+	}
+	if condition != nil {
+		policy.Version = iampolicy.PolicyVersion
+	}
+}
+
+func (f *CloudResourceManager) removeMember(resource string, member string, roles []string, condition *iampolicy.Condition) []string {
+	mutations := []iampolicy.Mutation{}
+	for _, role := range roles {
+		mutations = append(mutations, iampolicy.RemoveMember(role, member, condition))
+	}
+	return f.mutate(resource, member, mutations...)
+}
+
+// mutate will apply the mutations to the policy of the resource, returning the roles the member was removed from
+func (f *CloudResourceManager) mutate(resource string, member string, mutations ...iampolicy.Mutation) []string {
+	policy, ok := f.policies[resource]
+	if !ok {
+		return []string{}
+	}
+	result := &iampolicy.Result{Before: policy.Copy(), After: policy}
+	for _, mutation := range mutations {
+		mutation(policy)
+	}
+	return result.Removed(member)
+}
+
+// reconcile will apply the mutations of the spec to the policy of the resource, unless diffOnly, returning the change
+func (f *CloudResourceManager) reconcile(resource string, change plan.Change, spec *iampolicy.Spec, diffOnly bool) ([]plan.Change, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	result := &iampolicy.Result{Before: f.policies[resource].Copy()}
+	result.After = result.Before.Copy()
+	for _, mutation := range spec.Mutations() {
+		mutation(result.After)
+	}
+	for _, binding := range result.After.Bindings {
+		if binding.Condition != nil {
+			result.After.Version = iampolicy.PolicyVersion
+		}
+	}
+	if !diffOnly {
+		if f.policies == nil {
+			f.policies = map[string]*iampolicy.Policy{}
+		}
+		f.policies[resource] = result.After
+	}
+	return result.Changes(change), nil
+}
+
+// orgPolicy will return a copy of the policy kept for the constraint on the resource, a policy with only the
+// constraint if none has been set
+func (f *CloudResourceManager) orgPolicy(resource string, constraint string) *v1.OrgPolicy {
+	if policy, ok := f.orgPolicies[resource][constraint]; ok {
+		return copyOrgPolicy(policy)
+	}
+	return &v1.OrgPolicy{Constraint: constraint}
+}
+
+func (f *CloudResourceManager) setOrgPolicy(resource string, policy *v1.OrgPolicy) {
+	if f.orgPolicies == nil {
+		f.orgPolicies = map[string]map[string]*v1.OrgPolicy{}
+	}
+	if f.orgPolicies[resource] == nil {
+		f.orgPolicies[resource] = map[string]*v1.OrgPolicy{}
+	}
+	policy = copyOrgPolicy(policy)
+	policy.Constraint = constraintName(policy.Constraint)
+	f.orgPolicies[resource][policy.Constraint] = policy
+}
+
+// parentOf will return the name of the parent of a folder or project, or an empty string for an organization or a
+// resource the fake doesn't know of
+func (f *CloudResourceManager) parentOf(resource string) string {
+	if strings.HasPrefix(resource, "projects/") {
+		if project := f.projectByID(strings.TrimPrefix(resource, "projects/")); project != nil && project.Parent != nil {
+			return fmt.Sprintf("%ss/%s", project.Parent.Type, project.Parent.Id)
+		}
+		return ""
+	}
+	if existing := f.folderByName(resource); existing != nil {
+		return existing.parent
+	}
+	return ""
+}
+
+func validOrgPolicyResource(resource string) error {
+	for _, prefix := range []string{"organizations/", "folders/", "projects/"} {
+		if strings.HasPrefix(resource, prefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s isn't the name of an organization, folder or project", resource)
+}
+
+func constraintName(constraint string) string {
+	if strings.HasPrefix(constraint, "constraints/") {
+		return constraint
+	}
+	return fmt.Sprintf("constraints/%s", constraint)
+}
+
+func copyOrgPolicy(policy *v1.OrgPolicy) *v1.OrgPolicy {
+	copied := *policy
+	return &copied
+}
+
+// resourceName will make sure a resource ID or name is a name with the type's prefix, e.g. folders/1234
+func resourceName(resourceType string, resource string) string {
+	if strings.HasPrefix(resource, resourceType+"/") {
+		return resource
+	}
+	return fmt.Sprintf("%s/%s", resourceType, resource)
+}
+
+func copyProject(project *v1.Project) *v1.Project {
+	copied := *project
+	if project.Parent != nil {
+		parent := *project.Parent
+		copied.Parent = &parent
+	}
+	if project.Labels != nil {
+		copied.Labels = map[string]string{}
+		for key, value := range project.Labels {
+			copied.Labels[key] = value
+		}
+	}
+	return &copied
+}
+
+func copyLien(lien *v1.Lien) *v1.Lien {
+	copied := *lien
+	copied.Restrictions = append([]string{}, lien.Restrictions...)
+	return &copied
+}
+
+func contains(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf(format+" not found", args...)}
+}
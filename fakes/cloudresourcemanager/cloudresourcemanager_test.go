@@ -0,0 +1,308 @@
+package cloudresourcemanager
+
+import (
+	"testing"
+
+	crm "github.com/rockholla/go-google-lib/cloudresourcemanager"
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	"github.com/rockholla/go-google-lib/iampolicy"
+)
+
+var (
+	testOrganization = "organizations/1234567890"
+	testMember       = "user:test@go-google-lib.io"
+)
+
+func TestEnsureFolder(t *testing.T) {
+	f := New()
+	name, err := f.EnsureFolder("test", testOrganization)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureFolder(): %s", err)
+	}
+	again, err := f.EnsureFolder("test", testOrganization)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureFolder() for an existing folder: %s", err)
+	}
+	if again != name {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolder() to return the existing folder %s, but got %s", name, again)
+	}
+	if len(f.Folders()) != 1 {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolder() to create 1 folder, but got %d", len(f.Folders()))
+	}
+	child, err := f.EnsureFolder("test", name)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureFolder() in another parent: %s", err)
+	}
+	if child == name {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolder() to create a separate folder in another parent")
+	}
+	existing, err := f.GetFolder("test", name)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.GetFolder(): %s", err)
+	}
+	if existing != child {
+		t.Errorf("Expected cloudresourcemanager.GetFolder() to return %s, but got %s", child, existing)
+	}
+	missing, err := f.GetFolder("missing", name)
+	if err != nil || missing != "" {
+		t.Errorf("Expected cloudresourcemanager.GetFolder() to return a blank name for a missing folder, got %s, %v", missing, err)
+	}
+}
+
+func TestEnsureProject(t *testing.T) {
+	f := New()
+	folder, _ := f.EnsureFolder("test", testOrganization)
+	projectID, projectNumber, err := f.EnsureProject("test", folder)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureProject(): %s", err)
+	}
+	againID, againNumber, err := f.EnsureProject("test", folder)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureProject() for an existing project: %s", err)
+	}
+	if againID != projectID || againNumber != projectNumber {
+		t.Errorf("Expected cloudresourcemanager.EnsureProject() to return the existing project %s, but got %s", projectID, againID)
+	}
+	if err := f.EnableProjectServices(projectID, []string{"compute.googleapis.com"}); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnableProjectServices(): %s", err)
+	}
+	if services := f.EnabledServices(projectID); len(services) != 1 || services[0] != "compute.googleapis.com" {
+		t.Errorf("Got unexpected enabled services after cloudresourcemanager.EnableProjectServices(): %v", services)
+	}
+	if err := f.DeleteProject(projectID); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.DeleteProject(): %s", err)
+	}
+	project, err := f.GetProjectByID(projectID)
+	if err != nil || project != nil {
+		t.Errorf("Expected cloudresourcemanager.GetProjectByID() to return nil for a deleted project, got %v, %v", project, err)
+	}
+	project, err = f.GetProject("test", folder)
+	if err != nil || project != nil {
+		t.Errorf("Expected cloudresourcemanager.GetProject() to return nil for a deleted project, got %v, %v", project, err)
+	}
+	if _, err := f.GetProjectByID("missing"); !googleerrors.IsNotFound(err) {
+		t.Errorf("Expected cloudresourcemanager.GetProjectByID() to return a not found error for a missing project, got %v", err)
+	}
+}
+
+func TestRoles(t *testing.T) {
+	f := New()
+	roles := []string{"roles/viewer", "roles/editor"}
+	if err := f.EnsureOrganizationRoles("1234567890", testMember, roles); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureOrganizationRoles(): %s", err)
+	}
+	if err := f.EnsureOrganizationRoles(testOrganization, testMember, roles); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureOrganizationRoles() with existing roles: %s", err)
+	}
+	policy := f.IAMPolicy(testOrganization)
+	if len(policy.Bindings) != 2 || len(policy.Bindings[0].Members) != 1 {
+		t.Errorf("Expected cloudresourcemanager.EnsureOrganizationRoles() to add the member to 2 roles once, got %d bindings", len(policy.Bindings))
+	}
+	if err := f.RemoveOrganizationRoles(testOrganization, testMember, roles[:1]); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.RemoveOrganizationRoles(): %s", err)
+	}
+	policy = f.IAMPolicy(testOrganization)
+	if len(policy.Bindings) != 1 || policy.Bindings[0].Role != "roles/editor" {
+		t.Errorf("Expected cloudresourcemanager.RemoveOrganizationRoles() to leave only roles/editor, got %d bindings", len(policy.Bindings))
+	}
+	if err := f.EnsureProjectRoles("projects/test", testMember, roles); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureProjectRoles(): %s", err)
+	}
+	if len(f.IAMPolicy("projects/test").Bindings) != 2 {
+		t.Errorf("Expected cloudresourcemanager.EnsureProjectRoles() to set 2 bindings")
+	}
+}
+
+func TestRolesWithCondition(t *testing.T) {
+	f := New()
+	condition := &iampolicy.Condition{Title: "expires", Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"}
+	if err := f.EnsureFolderRoles("folders/1234", testMember, []string{"roles/viewer"}); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureFolderRoles(): %s", err)
+	}
+	if err := f.EnsureFolderRolesWithCondition("folders/1234", testMember, []string{"roles/viewer"}, condition); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureFolderRolesWithCondition(): %s", err)
+	}
+	policy := f.IAMPolicy("folders/1234")
+	if policy.Version != 3 || len(policy.Bindings) != 2 || policy.Bindings[1].Condition == nil || policy.Bindings[1].Condition.Expression != condition.Expression {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolderRolesWithCondition() to add a conditional binding of its own")
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	f := New()
+	condition := &iampolicy.Condition{Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"}
+	if err := f.EnsureProjectRoles("projects/test", testMember, []string{"roles/viewer", "roles/editor"}); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureProjectRoles(): %s", err)
+	}
+	if err := f.EnsureProjectRolesWithCondition("projects/test", testMember, []string{"roles/viewer"}, condition); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureProjectRolesWithCondition(): %s", err)
+	}
+	removed, err := f.RemoveProjectRoles("projects/test", testMember, []string{"roles/editor", "roles/owner"})
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.RemoveProjectRoles(): %s", err)
+	}
+	if len(removed) != 1 || removed[0] != "roles/editor" {
+		t.Errorf("Expected cloudresourcemanager.RemoveProjectRoles() to report only the role the member had, got %v", removed)
+	}
+	removed, err = f.RemoveProjectMember("projects/test", testMember)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.RemoveProjectMember(): %s", err)
+	}
+	if len(removed) != 2 || len(f.IAMPolicy("projects/test").Bindings) != 0 {
+		t.Errorf("Expected cloudresourcemanager.RemoveProjectMember() to remove the member from the role with and without the condition, got %v", removed)
+	}
+}
+
+func TestReconcilePolicy(t *testing.T) {
+	f := New()
+	if err := f.EnsureOrganizationRoles(testOrganization, "user:unmanaged@go-google-lib.io", []string{"roles/owner", "roles/viewer"}); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.EnsureOrganizationRoles(): %s", err)
+	}
+	spec := &iampolicy.Spec{Bindings: []*iampolicy.SpecBinding{{Role: "roles/owner", Members: []string{testMember}, Authoritative: true}}}
+	changes, err := f.DiffOrganizationPolicy(testOrganization, spec)
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.DiffOrganizationPolicy(): %s", err)
+	}
+	if len(changes) != 1 || len(f.IAMPolicy(testOrganization).Bindings[0].Members) != 1 {
+		t.Errorf("Expected cloudresourcemanager.DiffOrganizationPolicy() to return the change without making it, got %v", changes)
+	}
+	if _, err := f.ReconcileOrganizationPolicy(testOrganization, spec); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.ReconcileOrganizationPolicy(): %s", err)
+	}
+	policy := f.IAMPolicy(testOrganization)
+	if len(policy.Bindings) != 2 || policy.Bindings[0].Members[0] != testMember || policy.Bindings[1].Members[0] != "user:unmanaged@go-google-lib.io" {
+		t.Errorf("Expected cloudresourcemanager.ReconcileOrganizationPolicy() to replace the owners and leave the viewers, got %v", policy.Bindings)
+	}
+}
+
+func TestOrgPolicies(t *testing.T) {
+	f := New()
+	folder, _ := f.EnsureFolder("test", testOrganization)
+	projectID, _, err := f.EnsureProject("test", folder)
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.EnsureProject(): %s", err)
+	}
+	policy := crm.BooleanOrgPolicy("compute.skipDefaultNetworkCreation", true)
+	for i, expected := range []bool{true, false} {
+		changed, err := f.EnsureOrgPolicy(testOrganization, policy)
+		if err != nil {
+			t.Errorf("Got unexpected error during cloudresourcemanager.EnsureOrgPolicy(): %s", err)
+		}
+		if changed != expected {
+			t.Errorf("Expected cloudresourcemanager.EnsureOrgPolicy() call %d to return %t, got %t", i+1, expected, changed)
+		}
+	}
+	effective, err := f.GetEffectiveOrgPolicy("projects/"+projectID, "compute.skipDefaultNetworkCreation")
+	if err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.GetEffectiveOrgPolicy(): %s", err)
+	}
+	if effective.BooleanPolicy == nil || !effective.BooleanPolicy.Enforced {
+		t.Errorf("Expected the organization's policy to be in effect on the project from cloudresourcemanager.GetEffectiveOrgPolicy()")
+	}
+	if err := f.SetOrgPolicy(folder, crm.RestoreDefaultOrgPolicy("compute.skipDefaultNetworkCreation")); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.SetOrgPolicy(): %s", err)
+	}
+	if effective, _ := f.GetEffectiveOrgPolicy("projects/"+projectID, "compute.skipDefaultNetworkCreation"); effective.BooleanPolicy != nil {
+		t.Errorf("Expected the folder's restore default policy to be in effect on the project from cloudresourcemanager.GetEffectiveOrgPolicy()")
+	}
+	if err := f.ClearOrgPolicy(folder, "constraints/compute.skipDefaultNetworkCreation"); err != nil {
+		t.Errorf("Got unexpected error during cloudresourcemanager.ClearOrgPolicy(): %s", err)
+	}
+	if policies, _ := f.ListOrgPolicies(folder); len(policies) != 0 {
+		t.Errorf("Expected no policies on the folder from cloudresourcemanager.ListOrgPolicies() once cleared, got %d", len(policies))
+	}
+}
+
+func TestFolderPaths(t *testing.T) {
+	f := New()
+	folders, err := f.EnsureFolderPath(testOrganization, "eng/platform/prod")
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.EnsureFolderPath(): %s", err)
+	}
+	if again, _ := f.EnsureFolderPath(testOrganization, "eng/platform/prod"); len(folders) != 3 || again[2] != folders[2] || len(f.Folders()) != 3 {
+		t.Errorf("Expected cloudresourcemanager.EnsureFolderPath() to create each folder of the path once, got %v", f.Folders())
+	}
+	organization, path, err := f.ResolveFolderPath(folders[2])
+	if err != nil || organization != testOrganization || path != "eng/platform/prod" {
+		t.Errorf("Expected cloudresourcemanager.ResolveFolderPath() to return the path the folder was ensured with, got %s and %s: %v", organization, path, err)
+	}
+	if _, _, err := f.ResolveFolderPath("folders/0"); !googleerrors.IsNotFound(err) {
+		t.Errorf("Expected a not found error from cloudresourcemanager.ResolveFolderPath() for a missing folder, got %v", err)
+	}
+	if children, _ := f.ListFolders(testOrganization, false); len(children) != 1 || children[0].Name != folders[0] {
+		t.Errorf("Expected only the top folder from cloudresourcemanager.ListFolders(), got %v", children)
+	}
+	if all, _ := f.ListFolders(testOrganization, true); len(all) != 3 || all[2].Name != folders[2] {
+		t.Errorf("Expected every folder from a recursive cloudresourcemanager.ListFolders(), got %v", all)
+	}
+}
+
+func TestFolderLifecycle(t *testing.T) {
+	f := New()
+	folders, _ := f.EnsureFolderPath(testOrganization, "eng/platform")
+	ops, _ := f.EnsureFolder("ops", testOrganization)
+	if err := f.MoveFolder(ops, folders[0]); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.MoveFolder(): %s", err)
+	}
+	if err := f.RenameFolder(ops, "operations"); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.RenameFolder(): %s", err)
+	}
+	if _, path, _ := f.ResolveFolderPath(ops); path != "eng/operations" {
+		t.Errorf("Expected the folder to be moved and renamed, got %s", path)
+	}
+	projectID, _, _ := f.EnsureProject("platform", folders[1])
+	err := f.DeleteFolder(folders[1], false)
+	if notEmpty, ok := err.(*crm.FolderNotEmptyError); !ok || len(notEmpty.Projects) != 1 {
+		t.Fatalf("Expected a *FolderNotEmptyError from cloudresourcemanager.DeleteFolder() for a folder with a project in it, got %v", err)
+	}
+	if err := f.DeleteFolder(folders[0], true); err != nil {
+		t.Fatalf("Got unexpected error during a recursive cloudresourcemanager.DeleteFolder(): %s", err)
+	}
+	if project, _ := f.GetProjectByID(projectID); project != nil {
+		t.Errorf("Expected a recursive cloudresourcemanager.DeleteFolder() to delete the projects in it")
+	}
+	if children, _ := f.ListFolders(testOrganization, true); len(children) != 0 {
+		t.Errorf("Expected no folders after a recursive cloudresourcemanager.DeleteFolder(), got %v", children)
+	}
+	if err := f.UndeleteFolder(folders[0]); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.UndeleteFolder(): %s", err)
+	}
+	if name, _ := f.GetFolder("eng", testOrganization); name != folders[0] {
+		t.Errorf("Expected cloudresourcemanager.UndeleteFolder() to restore the folder, got %q", name)
+	}
+}
+
+func TestProjectLifecycle(t *testing.T) {
+	f := New()
+	folder, _ := f.EnsureFolder("eng", testOrganization)
+	projectID, _, _ := f.EnsureProject("platform", folder)
+	if err := f.MoveProject(projectID, testOrganization); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.MoveProject(): %s", err)
+	}
+	if err := f.MergeProjectLabels(projectID, map[string]string{"env": "prod"}); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.MergeProjectLabels(): %s", err)
+	}
+	project, _ := f.GetProjectByID(projectID)
+	if project.Parent.Type != "organization" || project.Labels["env"] != "prod" {
+		t.Errorf("Expected the project to be moved and labeled, got %v and %v", project.Parent, project.Labels)
+	}
+	lien, err := f.CreateProjectLien(projectID, "production project", "tests")
+	if err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.CreateProjectLien(): %s", err)
+	}
+	if _, ok := f.DeleteProject(projectID).(*crm.ProjectLienError); !ok {
+		t.Errorf("Expected a *ProjectLienError from cloudresourcemanager.DeleteProject() for a project with a lien")
+	}
+	if err := f.RemoveProjectLien(projectID, lien.Name); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.RemoveProjectLien(): %s", err)
+	}
+	if err := f.DeleteProject(projectID); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.DeleteProject(): %s", err)
+	}
+	if err := f.UndeleteProject(projectID); err != nil {
+		t.Fatalf("Got unexpected error during cloudresourcemanager.UndeleteProject(): %s", err)
+	}
+	if project, _ := f.GetProjectByID(projectID); project == nil {
+		t.Errorf("Expected cloudresourcemanager.UndeleteProject() to restore the project")
+	}
+}
@@ -0,0 +1,1177 @@
+// Package compute is an in-memory fake of the compute library, for tests of code using it
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/rockholla/go-google-lib/compute"
+	"github.com/rockholla/go-lib/logger"
+	v1 "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+var _ compute.Interface = &Compute{}
+
+// baseURL is the prefix of the self links given to resources added without one
+const baseURL = "https://www.googleapis.com/compute/v1"
+
+// kinds of resources, named like the collections they belong to in the api
+const (
+	kindAddress                    = "addresses"
+	kindBackendService             = "backendServices"
+	kindDisk                       = "disks"
+	kindFirewall                   = "firewalls"
+	kindForwardingRule             = "forwardingRules"
+	kindHealthCheck                = "healthChecks"
+	kindHTTPHealthCheck            = "httpHealthChecks"
+	kindInstance                   = "instances"
+	kindInstanceGroup              = "instanceGroups"
+	kindInstanceGroupManager       = "instanceGroupManagers"
+	kindRegionInstanceGroupManager = "regionInstanceGroupManagers"
+	kindNetwork                    = "networks"
+	kindRoute                      = "routes"
+	kindRouter                     = "routers"
+	kindSubnetwork                 = "subnetworks"
+	kindTargetPool                 = "targetPools"
+)
+
+// Compute is a stateful, in-memory implementation of compute.Interface. Resources are seeded with Add, and region
+// zones with SetRegionZones, after which they can be listed, changed and deleted through the interface. Operations
+// finish immediately, so MutateOptions are ignored. Deletes don't check whether a resource is still in use. It's
+// safe for concurrent use, and the zero value is ready to use.
+type Compute struct {
+	mutex    sync.Mutex
+	projects map[string]*project
+}
+
+type project struct {
+	regionZones map[string][]string
+	metadata    []*v1.MetadataItems
+	resources   []*resource
+}
+
+// resource is any compute resource, value being a pointer to its api type, e.g. *v1.Instance
+type resource struct {
+	kind     string
+	location string
+	name     string
+	value    interface{}
+}
+
+// New will return a fake without any resources
+func New() *Compute {
+	return &Compute{}
+}
+
+// Add will seed resources in a project, each being a pointer to one of the supported api types: Address,
+// BackendService, Disk, Firewall, ForwardingRule, HealthCheck, HttpHealthCheck, Instance, InstanceGroup,
+// InstanceGroupManager, Network, Route, Router, Subnetwork or TargetPool. Zonal and regional resources need their Zone
+// or Region set, as a name or url, and resources without a SelfLink are given one. A subnetwork is added to the
+// subnetworks of its network, if the network was added first. Deleting a managed instance group also deletes its
+// instance group and the instances with its url in their created-by metadata.
+func (c *Compute) Add(projectID string, resources ...interface{}) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	p := c.project(projectID)
+	for _, value := range resources {
+		added, err := describe(projectID, copyValue(value))
+		if err != nil {
+			return err
+		}
+		if p.find(added.kind, added.location, added.name) != nil {
+			return &googleapi.Error{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("The resource '%s' already exists", selfLink(projectID, added.kind, added.location, added.name)),
+			}
+		}
+		p.resources = append(p.resources, added)
+		if subnetwork, ok := added.value.(*v1.Subnetwork); ok && subnetwork.Network != "" {
+			if network := p.find(kindNetwork, "", lastSegment(subnetwork.Network)); network != nil {
+				value := network.value.(*v1.Network)
+				value.Subnetworks = append(value.Subnetworks, subnetwork.SelfLink)
+			}
+		}
+	}
+	return nil
+}
+
+// SetRegionZones will set the names of the zones in a region
+func (c *Compute) SetRegionZones(projectID string, region string, zones []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.project(projectID).regionZones[region] = append([]string{}, zones...)
+}
+
+// Initialize is a no-op for the fake
+func (c *Compute) Initialize(credentials string, log logger.Interface) error {
+	return nil
+}
+
+// InitializeCtx is a no-op for the fake
+func (c *Compute) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	return nil
+}
+
+// GetRegionZones will return the zones set for a region with SetRegionZones
+func (c *Compute) GetRegionZones(projectID string, region string) ([]string, error) {
+	return c.GetRegionZonesCtx(context.Background(), projectID, region)
+}
+
+// GetRegionZonesCtx is GetRegionZones, the context is unused
+func (c *Compute) GetRegionZonesCtx(ctx context.Context, projectID string, region string) ([]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	zones, ok := c.project(projectID).regionZones[region]
+	if !ok {
+		return []string{}, notFound(fmt.Sprintf("projects/%s/regions/%s", projectID, region))
+	}
+	return append([]string{}, zones...), nil
+}
+
+// ForEachInstance will call fn for a copy of every instance in a project, stopping at and returning the first
+// error returned by fn
+func (c *Compute) ForEachInstance(projectID string, fn func(*v1.Instance) error) error {
+	return c.ForEachInstanceCtx(context.Background(), projectID, fn)
+}
+
+// ForEachInstanceCtx is ForEachInstance, the context is unused
+func (c *Compute) ForEachInstanceCtx(ctx context.Context, projectID string, fn func(*v1.Instance) error) error {
+	return c.forEach(projectID, kindInstance, func(value interface{}) error { return fn(value.(*v1.Instance)) })
+}
+
+// GetInternalIPs will return the name and internal IP of every instance with an interface on the network
+func (c *Compute) GetInternalIPs(projectID string, network string) ([]*compute.InstanceIP, error) {
+	return c.GetInternalIPsCtx(context.Background(), projectID, network)
+}
+
+// GetInternalIPsCtx is GetInternalIPs, the context is unused
+func (c *Compute) GetInternalIPsCtx(ctx context.Context, projectID string, network string) ([]*compute.InstanceIP, error) {
+	var result []*compute.InstanceIP
+	err := c.ForEachInstanceCtx(ctx, projectID, func(instance *v1.Instance) error {
+		ip := ""
+		for _, networkInterface := range instance.NetworkInterfaces {
+			if strings.Contains(networkInterface.Network, fmt.Sprintf("projects/%s/global/networks/%s", projectID, network)) {
+				ip = networkInterface.NetworkIP
+			}
+		}
+		if ip != "" {
+			result = append(result, &compute.InstanceIP{VMName: instance.Name, IP: ip})
+		}
+		return nil
+	})
+	return result, err
+}
+
+// PowerOff will set the status of all instances in a project to TERMINATED
+func (c *Compute) PowerOff(projectID string, opts ...compute.MutateOption) error {
+	return c.PowerOffCtx(context.Background(), projectID, opts...)
+}
+
+// PowerOffCtx is PowerOff, the context is unused
+func (c *Compute) PowerOffCtx(ctx context.Context, projectID string, opts ...compute.MutateOption) error {
+	c.setInstanceStatus(projectID, "TERMINATED")
+	return nil
+}
+
+// PowerOn will set the status of all instances in a project to RUNNING
+func (c *Compute) PowerOn(projectID string, opts ...compute.MutateOption) error {
+	return c.PowerOnCtx(context.Background(), projectID, opts...)
+}
+
+// PowerOnCtx is PowerOn, the context is unused
+func (c *Compute) PowerOnCtx(ctx context.Context, projectID string, opts ...compute.MutateOption) error {
+	c.setInstanceStatus(projectID, "RUNNING")
+	return nil
+}
+
+// DeleteInstance will delete a single instance
+func (c *Compute) DeleteInstance(projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteInstanceCtx(context.Background(), projectID, zone, name, opts...)
+}
+
+// DeleteInstanceCtx is DeleteInstance, the context is unused
+func (c *Compute) DeleteInstanceCtx(ctx context.Context, projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindInstance, zone, name)
+}
+
+// SetCommonInstanceMetadata will replace the project-level metadata
+func (c *Compute) SetCommonInstanceMetadata(projectID string, metadataItems []*v1.MetadataItems, opts ...compute.MutateOption) error {
+	return c.SetCommonInstanceMetadataCtx(context.Background(), projectID, metadataItems, opts...)
+}
+
+// SetCommonInstanceMetadataCtx is SetCommonInstanceMetadata, the context is unused
+func (c *Compute) SetCommonInstanceMetadataCtx(ctx context.Context, projectID string, metadataItems []*v1.MetadataItems, opts ...compute.MutateOption) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.project(projectID).metadata = copyValue(metadataItems).([]*v1.MetadataItems)
+	return nil
+}
+
+// GetCommonInstanceMetadata will return a copy of the project-level metadata
+func (c *Compute) GetCommonInstanceMetadata(projectID string) ([]*v1.MetadataItems, error) {
+	return c.GetCommonInstanceMetadataCtx(context.Background(), projectID)
+}
+
+// GetCommonInstanceMetadataCtx is GetCommonInstanceMetadata, the context is unused
+func (c *Compute) GetCommonInstanceMetadataCtx(ctx context.Context, projectID string) ([]*v1.MetadataItems, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return copyValue(c.project(projectID).metadata).([]*v1.MetadataItems), nil
+}
+
+// ForEachTargetPool will call fn for a copy of every target pool in a project, stopping at and returning the first
+// error returned by fn
+func (c *Compute) ForEachTargetPool(projectID string, fn func(*v1.TargetPool) error) error {
+	return c.ForEachTargetPoolCtx(context.Background(), projectID, fn)
+}
+
+// ForEachTargetPoolCtx is ForEachTargetPool, the context is unused
+func (c *Compute) ForEachTargetPoolCtx(ctx context.Context, projectID string, fn func(*v1.TargetPool) error) error {
+	return c.forEach(projectID, kindTargetPool, func(value interface{}) error { return fn(value.(*v1.TargetPool)) })
+}
+
+// GetTargetPools will return copies of all target pools in a project
+func (c *Compute) GetTargetPools(projectID string) ([]*v1.TargetPool, error) {
+	return c.GetTargetPoolsCtx(context.Background(), projectID)
+}
+
+// GetTargetPoolsCtx is GetTargetPools, the context is unused
+func (c *Compute) GetTargetPoolsCtx(ctx context.Context, projectID string) ([]*v1.TargetPool, error) {
+	var result []*v1.TargetPool
+	err := c.ForEachTargetPoolCtx(ctx, projectID, func(pool *v1.TargetPool) error {
+		result = append(result, pool)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteTargetPool will delete a target pool in a region
+func (c *Compute) DeleteTargetPool(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteTargetPoolCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteTargetPoolCtx is DeleteTargetPool, the context is unused
+func (c *Compute) DeleteTargetPoolCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindTargetPool, region, name)
+}
+
+// ForEachForwardingRule will call fn for a copy of every forwarding rule in a project, stopping at and returning the
+// first error returned by fn
+func (c *Compute) ForEachForwardingRule(projectID string, fn func(*v1.ForwardingRule) error) error {
+	return c.ForEachForwardingRuleCtx(context.Background(), projectID, fn)
+}
+
+// ForEachForwardingRuleCtx is ForEachForwardingRule, the context is unused
+func (c *Compute) ForEachForwardingRuleCtx(ctx context.Context, projectID string, fn func(*v1.ForwardingRule) error) error {
+	return c.forEach(projectID, kindForwardingRule, func(value interface{}) error { return fn(value.(*v1.ForwardingRule)) })
+}
+
+// GetForwardingRules will return copies of all forwarding rules in a project
+func (c *Compute) GetForwardingRules(projectID string) ([]*v1.ForwardingRule, error) {
+	return c.GetForwardingRulesCtx(context.Background(), projectID)
+}
+
+// GetForwardingRulesCtx is GetForwardingRules, the context is unused
+func (c *Compute) GetForwardingRulesCtx(ctx context.Context, projectID string) ([]*v1.ForwardingRule, error) {
+	var result []*v1.ForwardingRule
+	err := c.ForEachForwardingRuleCtx(ctx, projectID, func(rule *v1.ForwardingRule) error {
+		result = append(result, rule)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteForwardingRule will delete a forwarding rule in a region
+func (c *Compute) DeleteForwardingRule(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteForwardingRuleCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteForwardingRuleCtx is DeleteForwardingRule, the context is unused
+func (c *Compute) DeleteForwardingRuleCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindForwardingRule, region, name)
+}
+
+// ForEachBackendService will call fn for a copy of every global and regional backend service in a project, stopping
+// at and returning the first error returned by fn
+func (c *Compute) ForEachBackendService(projectID string, fn func(*v1.BackendService) error) error {
+	return c.ForEachBackendServiceCtx(context.Background(), projectID, fn)
+}
+
+// ForEachBackendServiceCtx is ForEachBackendService, the context is unused
+func (c *Compute) ForEachBackendServiceCtx(ctx context.Context, projectID string, fn func(*v1.BackendService) error) error {
+	return c.forEach(projectID, kindBackendService, func(value interface{}) error { return fn(value.(*v1.BackendService)) })
+}
+
+// GetBackendServices will return copies of all global and regional backend services in a project
+func (c *Compute) GetBackendServices(projectID string) ([]*v1.BackendService, error) {
+	return c.GetBackendServicesCtx(context.Background(), projectID)
+}
+
+// GetBackendServicesCtx is GetBackendServices, the context is unused
+func (c *Compute) GetBackendServicesCtx(ctx context.Context, projectID string) ([]*v1.BackendService, error) {
+	var result []*v1.BackendService
+	err := c.ForEachBackendServiceCtx(ctx, projectID, func(backendService *v1.BackendService) error {
+		result = append(result, backendService)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteBackendService will delete a global backend service
+func (c *Compute) DeleteBackendService(projectID string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteBackendServiceCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteBackendServiceCtx is DeleteBackendService, the context is unused
+func (c *Compute) DeleteBackendServiceCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindBackendService, "", name)
+}
+
+// DeleteRegionBackendService will delete a backend service in a region
+func (c *Compute) DeleteRegionBackendService(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteRegionBackendServiceCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteRegionBackendServiceCtx is DeleteRegionBackendService, the context is unused
+func (c *Compute) DeleteRegionBackendServiceCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindBackendService, region, name)
+}
+
+// ForEachHealthCheck will call fn for a copy of every health check in a project, stopping at and returning the
+// first error returned by fn
+func (c *Compute) ForEachHealthCheck(projectID string, fn func(*v1.HealthCheck) error) error {
+	return c.ForEachHealthCheckCtx(context.Background(), projectID, fn)
+}
+
+// ForEachHealthCheckCtx is ForEachHealthCheck, the context is unused
+func (c *Compute) ForEachHealthCheckCtx(ctx context.Context, projectID string, fn func(*v1.HealthCheck) error) error {
+	return c.forEach(projectID, kindHealthCheck, func(value interface{}) error { return fn(value.(*v1.HealthCheck)) })
+}
+
+// GetHealthChecks will return copies of all health checks in a project
+func (c *Compute) GetHealthChecks(projectID string) ([]*v1.HealthCheck, error) {
+	return c.GetHealthChecksCtx(context.Background(), projectID)
+}
+
+// GetHealthChecksCtx is GetHealthChecks, the context is unused
+func (c *Compute) GetHealthChecksCtx(ctx context.Context, projectID string) ([]*v1.HealthCheck, error) {
+	var result []*v1.HealthCheck
+	err := c.ForEachHealthCheckCtx(ctx, projectID, func(healthCheck *v1.HealthCheck) error {
+		result = append(result, healthCheck)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteHealthCheck will delete a health check
+func (c *Compute) DeleteHealthCheck(projectID string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteHealthCheckCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteHealthCheckCtx is DeleteHealthCheck, the context is unused
+func (c *Compute) DeleteHealthCheckCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindHealthCheck, "", name)
+}
+
+// ForEachHTTPHealthCheck will call fn for a copy of every legacy http health check in a project, stopping at and
+// returning the first error returned by fn
+func (c *Compute) ForEachHTTPHealthCheck(projectID string, fn func(*v1.HttpHealthCheck) error) error {
+	return c.ForEachHTTPHealthCheckCtx(context.Background(), projectID, fn)
+}
+
+// ForEachHTTPHealthCheckCtx is ForEachHTTPHealthCheck, the context is unused
+func (c *Compute) ForEachHTTPHealthCheckCtx(ctx context.Context, projectID string, fn func(*v1.HttpHealthCheck) error) error {
+	return c.forEach(projectID, kindHTTPHealthCheck, func(value interface{}) error { return fn(value.(*v1.HttpHealthCheck)) })
+}
+
+// GetHTTPHealthChecks will return copies of all legacy http health checks in a project
+func (c *Compute) GetHTTPHealthChecks(projectID string) ([]*v1.HttpHealthCheck, error) {
+	return c.GetHTTPHealthChecksCtx(context.Background(), projectID)
+}
+
+// GetHTTPHealthChecksCtx is GetHTTPHealthChecks, the context is unused
+func (c *Compute) GetHTTPHealthChecksCtx(ctx context.Context, projectID string) ([]*v1.HttpHealthCheck, error) {
+	var result []*v1.HttpHealthCheck
+	err := c.ForEachHTTPHealthCheckCtx(ctx, projectID, func(healthCheck *v1.HttpHealthCheck) error {
+		result = append(result, healthCheck)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteHTTPHealthCheck will delete a legacy http health check
+func (c *Compute) DeleteHTTPHealthCheck(projectID string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteHTTPHealthCheckCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteHTTPHealthCheckCtx is DeleteHTTPHealthCheck, the context is unused
+func (c *Compute) DeleteHTTPHealthCheckCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindHTTPHealthCheck, "", name)
+}
+
+// ForEachDisk will call fn for a copy of every disk in a project, stopping at and returning the first error
+// returned by fn
+func (c *Compute) ForEachDisk(projectID string, fn func(*v1.Disk) error) error {
+	return c.ForEachDiskCtx(context.Background(), projectID, fn)
+}
+
+// ForEachDiskCtx is ForEachDisk, the context is unused
+func (c *Compute) ForEachDiskCtx(ctx context.Context, projectID string, fn func(*v1.Disk) error) error {
+	return c.forEach(projectID, kindDisk, func(value interface{}) error { return fn(value.(*v1.Disk)) })
+}
+
+// GetDisks will return copies of all disks in a project
+func (c *Compute) GetDisks(projectID string) ([]*v1.Disk, error) {
+	return c.GetDisksCtx(context.Background(), projectID)
+}
+
+// GetDisksCtx is GetDisks, the context is unused
+func (c *Compute) GetDisksCtx(ctx context.Context, projectID string) ([]*v1.Disk, error) {
+	var result []*v1.Disk
+	err := c.ForEachDiskCtx(ctx, projectID, func(disk *v1.Disk) error {
+		result = append(result, disk)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteDisk will delete a disk in a zone
+func (c *Compute) DeleteDisk(projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteDiskCtx(context.Background(), projectID, zone, name, opts...)
+}
+
+// DeleteDiskCtx is DeleteDisk, the context is unused
+func (c *Compute) DeleteDiskCtx(ctx context.Context, projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindDisk, zone, name)
+}
+
+// ForEachAddress will call fn for a copy of every global and regional address in a project, stopping at and
+// returning the first error returned by fn
+func (c *Compute) ForEachAddress(projectID string, fn func(*v1.Address) error) error {
+	return c.ForEachAddressCtx(context.Background(), projectID, fn)
+}
+
+// ForEachAddressCtx is ForEachAddress, the context is unused
+func (c *Compute) ForEachAddressCtx(ctx context.Context, projectID string, fn func(*v1.Address) error) error {
+	return c.forEach(projectID, kindAddress, func(value interface{}) error { return fn(value.(*v1.Address)) })
+}
+
+// GetAddresses will return copies of all global and regional addresses in a project
+func (c *Compute) GetAddresses(projectID string) ([]*v1.Address, error) {
+	return c.GetAddressesCtx(context.Background(), projectID)
+}
+
+// GetAddressesCtx is GetAddresses, the context is unused
+func (c *Compute) GetAddressesCtx(ctx context.Context, projectID string) ([]*v1.Address, error) {
+	var result []*v1.Address
+	err := c.ForEachAddressCtx(ctx, projectID, func(address *v1.Address) error {
+		result = append(result, address)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteAddress will delete an address in a region
+func (c *Compute) DeleteAddress(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteAddressCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteAddressCtx is DeleteAddress, the context is unused
+func (c *Compute) DeleteAddressCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindAddress, region, name)
+}
+
+// ForEachFirewall will call fn for a copy of every firewall in a project, stopping at and returning the first
+// error returned by fn
+func (c *Compute) ForEachFirewall(projectID string, fn func(*v1.Firewall) error) error {
+	return c.ForEachFirewallCtx(context.Background(), projectID, fn)
+}
+
+// ForEachFirewallCtx is ForEachFirewall, the context is unused
+func (c *Compute) ForEachFirewallCtx(ctx context.Context, projectID string, fn func(*v1.Firewall) error) error {
+	return c.forEach(projectID, kindFirewall, func(value interface{}) error { return fn(value.(*v1.Firewall)) })
+}
+
+// GetFirewalls will return copies of all firewalls in a project
+func (c *Compute) GetFirewalls(projectID string) ([]*v1.Firewall, error) {
+	return c.GetFirewallsCtx(context.Background(), projectID)
+}
+
+// GetFirewallsCtx is GetFirewalls, the context is unused
+func (c *Compute) GetFirewallsCtx(ctx context.Context, projectID string) ([]*v1.Firewall, error) {
+	var result []*v1.Firewall
+	err := c.ForEachFirewallCtx(ctx, projectID, func(firewall *v1.Firewall) error {
+		result = append(result, firewall)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteFirewall will delete a firewall
+func (c *Compute) DeleteFirewall(projectID string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteFirewallCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteFirewallCtx is DeleteFirewall, the context is unused
+func (c *Compute) DeleteFirewallCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindFirewall, "", name)
+}
+
+// ForEachInstanceGroup will call fn for a copy of every instance group in a project, stopping at and returning the
+// first error returned by fn
+func (c *Compute) ForEachInstanceGroup(projectID string, fn func(*v1.InstanceGroup) error) error {
+	return c.ForEachInstanceGroupCtx(context.Background(), projectID, fn)
+}
+
+// ForEachInstanceGroupCtx is ForEachInstanceGroup, the context is unused
+func (c *Compute) ForEachInstanceGroupCtx(ctx context.Context, projectID string, fn func(*v1.InstanceGroup) error) error {
+	return c.forEach(projectID, kindInstanceGroup, func(value interface{}) error { return fn(value.(*v1.InstanceGroup)) })
+}
+
+// GetInstanceGroups will return copies of all instance groups in a project
+func (c *Compute) GetInstanceGroups(projectID string) ([]*v1.InstanceGroup, error) {
+	return c.GetInstanceGroupsCtx(context.Background(), projectID)
+}
+
+// GetInstanceGroupsCtx is GetInstanceGroups, the context is unused
+func (c *Compute) GetInstanceGroupsCtx(ctx context.Context, projectID string) ([]*v1.InstanceGroup, error) {
+	var result []*v1.InstanceGroup
+	err := c.ForEachInstanceGroupCtx(ctx, projectID, func(group *v1.InstanceGroup) error {
+		result = append(result, group)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteInstanceGroup will delete an instance group in a zone
+func (c *Compute) DeleteInstanceGroup(projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteInstanceGroupCtx(context.Background(), projectID, zone, name, opts...)
+}
+
+// DeleteInstanceGroupCtx is DeleteInstanceGroup, the context is unused
+func (c *Compute) DeleteInstanceGroupCtx(ctx context.Context, projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindInstanceGroup, zone, name)
+}
+
+// ForEachInstanceGroupManager will call fn for a copy of every managed instance group in a project, zonal and
+// regional, stopping at and returning the first error returned by fn
+func (c *Compute) ForEachInstanceGroupManager(projectID string, fn func(*v1.InstanceGroupManager) error) error {
+	return c.ForEachInstanceGroupManagerCtx(context.Background(), projectID, fn)
+}
+
+// ForEachInstanceGroupManagerCtx is ForEachInstanceGroupManager, the context is unused
+func (c *Compute) ForEachInstanceGroupManagerCtx(ctx context.Context, projectID string, fn func(*v1.InstanceGroupManager) error) error {
+	for _, kind := range []string{kindInstanceGroupManager, kindRegionInstanceGroupManager} {
+		err := c.forEach(projectID, kind, func(value interface{}) error { return fn(value.(*v1.InstanceGroupManager)) })
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetInstanceGroupManagers will return copies of all managed instance groups in a project, zonal and regional
+func (c *Compute) GetInstanceGroupManagers(projectID string) ([]*v1.InstanceGroupManager, error) {
+	return c.GetInstanceGroupManagersCtx(context.Background(), projectID)
+}
+
+// GetInstanceGroupManagersCtx is GetInstanceGroupManagers, the context is unused
+func (c *Compute) GetInstanceGroupManagersCtx(ctx context.Context, projectID string) ([]*v1.InstanceGroupManager, error) {
+	var result []*v1.InstanceGroupManager
+	err := c.ForEachInstanceGroupManagerCtx(ctx, projectID, func(manager *v1.InstanceGroupManager) error {
+		result = append(result, manager)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteInstanceGroupManager will delete a managed instance group in a zone, along with its instance group and the
+// instances it created
+func (c *Compute) DeleteInstanceGroupManager(projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteInstanceGroupManagerCtx(context.Background(), projectID, zone, name, opts...)
+}
+
+// DeleteInstanceGroupManagerCtx is DeleteInstanceGroupManager, the context is unused
+func (c *Compute) DeleteInstanceGroupManagerCtx(ctx context.Context, projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	return c.deleteInstanceGroupManager(projectID, kindInstanceGroupManager, zone, name)
+}
+
+// DeleteRegionInstanceGroupManager will delete a managed instance group in a region, along with its instance group
+// and the instances it created
+func (c *Compute) DeleteRegionInstanceGroupManager(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteRegionInstanceGroupManagerCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteRegionInstanceGroupManagerCtx is DeleteRegionInstanceGroupManager, the context is unused
+func (c *Compute) DeleteRegionInstanceGroupManagerCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.deleteInstanceGroupManager(projectID, kindRegionInstanceGroupManager, region, name)
+}
+
+// GetNetwork will return a copy of a network
+func (c *Compute) GetNetwork(projectID string, name string) (*v1.Network, error) {
+	return c.GetNetworkCtx(context.Background(), projectID, name)
+}
+
+// GetNetworkCtx is GetNetwork, the context is unused
+func (c *Compute) GetNetworkCtx(ctx context.Context, projectID string, name string) (*v1.Network, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing := c.project(projectID).find(kindNetwork, "", name)
+	if existing == nil {
+		return nil, notFound(selfLink(projectID, kindNetwork, "", name))
+	}
+	return copyValue(existing.value).(*v1.Network), nil
+}
+
+// DeleteSubnetwork will delete a subnetwork in a region, removing it from its network's subnetworks
+func (c *Compute) DeleteSubnetwork(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteSubnetworkCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteSubnetworkCtx is DeleteSubnetwork, the context is unused
+func (c *Compute) DeleteSubnetworkCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	if err := c.delete(projectID, kindSubnetwork, region, name); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	suffix := fmt.Sprintf("/regions/%s/subnetworks/%s", lastSegment(region), lastSegment(name))
+	for _, existing := range c.project(projectID).resources {
+		if existing.kind != kindNetwork {
+			continue
+		}
+		network := existing.value.(*v1.Network)
+		subnetworks := []string{}
+		for _, subnetwork := range network.Subnetworks {
+			if !strings.HasSuffix(subnetwork, suffix) {
+				subnetworks = append(subnetworks, subnetwork)
+			}
+		}
+		network.Subnetworks = subnetworks
+	}
+	return nil
+}
+
+// RemoveNetworkPeering will remove a peering, by its name, from a network's peerings
+func (c *Compute) RemoveNetworkPeering(projectID string, network string, name string, opts ...compute.MutateOption) error {
+	return c.RemoveNetworkPeeringCtx(context.Background(), projectID, network, name, opts...)
+}
+
+// RemoveNetworkPeeringCtx is RemoveNetworkPeering, the context is unused
+func (c *Compute) RemoveNetworkPeeringCtx(ctx context.Context, projectID string, network string, name string, opts ...compute.MutateOption) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing := c.project(projectID).find(kindNetwork, "", lastSegment(network))
+	if existing == nil {
+		return notFound(selfLink(projectID, kindNetwork, "", lastSegment(network)))
+	}
+	value := existing.value.(*v1.Network)
+	peerings := []*v1.NetworkPeering{}
+	for _, peering := range value.Peerings {
+		if peering.Name != name {
+			peerings = append(peerings, peering)
+		}
+	}
+	if len(peerings) == len(value.Peerings) {
+		return notFound(fmt.Sprintf("%s/peerings/%s", value.SelfLink, name))
+	}
+	value.Peerings = peerings
+	return nil
+}
+
+// DeleteNetwork will delete a network
+func (c *Compute) DeleteNetwork(projectID string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteNetworkCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteNetworkCtx is DeleteNetwork, the context is unused
+func (c *Compute) DeleteNetworkCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindNetwork, "", name)
+}
+
+// ForEachRoute will call fn for a copy of every route in a project, stopping at and returning the first error
+// returned by fn
+func (c *Compute) ForEachRoute(projectID string, fn func(*v1.Route) error) error {
+	return c.ForEachRouteCtx(context.Background(), projectID, fn)
+}
+
+// ForEachRouteCtx is ForEachRoute, the context is unused
+func (c *Compute) ForEachRouteCtx(ctx context.Context, projectID string, fn func(*v1.Route) error) error {
+	return c.forEach(projectID, kindRoute, func(value interface{}) error { return fn(value.(*v1.Route)) })
+}
+
+// GetRoutes will return copies of all routes in a project
+func (c *Compute) GetRoutes(projectID string) ([]*v1.Route, error) {
+	return c.GetRoutesCtx(context.Background(), projectID)
+}
+
+// GetRoutesCtx is GetRoutes, the context is unused
+func (c *Compute) GetRoutesCtx(ctx context.Context, projectID string) ([]*v1.Route, error) {
+	var result []*v1.Route
+	err := c.ForEachRouteCtx(ctx, projectID, func(route *v1.Route) error {
+		result = append(result, route)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteRoute will delete a route
+func (c *Compute) DeleteRoute(projectID string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteRouteCtx(context.Background(), projectID, name, opts...)
+}
+
+// DeleteRouteCtx is DeleteRoute, the context is unused
+func (c *Compute) DeleteRouteCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindRoute, "", name)
+}
+
+// ForEachRouter will call fn for a copy of every router in a project, stopping at and returning the first error
+// returned by fn
+func (c *Compute) ForEachRouter(projectID string, fn func(*v1.Router) error) error {
+	return c.ForEachRouterCtx(context.Background(), projectID, fn)
+}
+
+// ForEachRouterCtx is ForEachRouter, the context is unused
+func (c *Compute) ForEachRouterCtx(ctx context.Context, projectID string, fn func(*v1.Router) error) error {
+	return c.forEach(projectID, kindRouter, func(value interface{}) error { return fn(value.(*v1.Router)) })
+}
+
+// GetRouters will return copies of all routers in a project
+func (c *Compute) GetRouters(projectID string) ([]*v1.Router, error) {
+	return c.GetRoutersCtx(context.Background(), projectID)
+}
+
+// GetRoutersCtx is GetRouters, the context is unused
+func (c *Compute) GetRoutersCtx(ctx context.Context, projectID string) ([]*v1.Router, error) {
+	var result []*v1.Router
+	err := c.ForEachRouterCtx(ctx, projectID, func(router *v1.Router) error {
+		result = append(result, router)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteRouter will delete a router in a region
+func (c *Compute) DeleteRouter(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.DeleteRouterCtx(context.Background(), projectID, region, name, opts...)
+}
+
+// DeleteRouterCtx is DeleteRouter, the context is unused
+func (c *Compute) DeleteRouterCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	return c.delete(projectID, kindRouter, region, name)
+}
+
+// GetOperation will return a copy of the operation, done, since the fake's operations finish immediately
+func (c *Compute) GetOperation(projectID string, operation *v1.Operation) (*v1.Operation, error) {
+	return c.GetOperationCtx(context.Background(), projectID, operation)
+}
+
+// GetOperationCtx is GetOperation, the context is unused
+func (c *Compute) GetOperationCtx(ctx context.Context, projectID string, operation *v1.Operation) (*v1.Operation, error) {
+	done := copyValue(operation).(*v1.Operation)
+	done.Status = "DONE"
+	return done, nil
+}
+
+// WaitForOperation will return the operation's error, if any, since the fake's operations finish immediately
+func (c *Compute) WaitForOperation(projectID string, operation *v1.Operation) error {
+	return c.WaitForOperationCtx(context.Background(), projectID, operation)
+}
+
+// WaitForOperationCtx is WaitForOperation, the context is unused
+func (c *Compute) WaitForOperationCtx(ctx context.Context, projectID string, operation *v1.Operation) error {
+	if operation.Error != nil && len(operation.Error.Errors) > 0 {
+		return &compute.OperationError{Operation: operation.Name, Errors: operation.Error.Errors}
+	}
+	return nil
+}
+
+// TeardownNetwork will delete a network and the resources attached to it in the same phases as the real library.
+// Discovery is simpler than the real library's: routes, instances, instance groups, managed instance groups, regional
+// forwarding rules, backend services, addresses, firewalls and routers referencing the network directly, and the
+// network's peerings and subnetworks, are removed. A managed instance group is removed in place of its instance group
+// and instances. A global backend service, forwarding rule or address on the network is a blocker, like in the real
+// library.
+func (c *Compute) TeardownNetwork(projectID string, network string, opts compute.TeardownOptions) (*compute.TeardownReport, error) {
+	return c.TeardownNetworkCtx(context.Background(), projectID, network, opts)
+}
+
+// TeardownNetworkCtx is TeardownNetwork, the context is unused
+func (c *Compute) TeardownNetworkCtx(ctx context.Context, projectID string, network string, opts compute.TeardownOptions) (*compute.TeardownReport, error) {
+	network = lastSegment(network)
+	report := &compute.TeardownReport{Network: network, DryRun: opts.DryRun}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	p := c.project(projectID)
+	existingNetwork := p.find(kindNetwork, "", network)
+	if existingNetwork == nil {
+		return report, fmt.Errorf("error discovering resources attached to network %s: %s", network, notFound(selfLink(projectID, kindNetwork, "", network)))
+	}
+	onNetwork := func(url string) bool {
+		return url != "" && lastSegment(url) == network
+	}
+	subnetworks := map[string]bool{}
+	if value := existingNetwork.value.(*v1.Network); !value.AutoCreateSubnetworks {
+		for _, subnetwork := range value.Subnetworks {
+			subnetworks[subnetwork] = true
+		}
+	}
+	// managed instance groups on the network, along with what they manage, are removed together
+	managers := map[*resource]bool{}
+	managed := map[*resource]bool{}
+	for _, found := range p.resources {
+		if _, ok := found.value.(*v1.InstanceGroupManager); !ok {
+			continue
+		}
+		for _, resource := range p.managedBy(found) {
+			if attachedTo(resource.value, onNetwork) {
+				managers[found] = true
+			}
+		}
+		if managers[found] {
+			for _, resource := range p.managedBy(found) {
+				managed[resource] = true
+			}
+		}
+	}
+	removed := []*resource{}
+	add := func(phase int, kind string, found *resource) {
+		report.Resources = append(report.Resources, &compute.TeardownResource{
+			Phase:    phase,
+			Kind:     kind,
+			Name:     found.name,
+			Location: found.location,
+		})
+		removed = append(removed, found)
+	}
+	for _, phase := range []int{phaseForwardingRules, phaseLoadBalancers, phaseInstances, phaseDependents, phaseSubnetworks} {
+		for _, found := range p.resources {
+			switch value := found.value.(type) {
+			case *v1.Route:
+				if phase == phaseForwardingRules && onNetwork(value.Network) && value.NextHopNetwork == "" && value.NextHopPeering == "" {
+					add(phase, "route", found)
+				}
+			case *v1.ForwardingRule:
+				if phase == phaseForwardingRules && onNetwork(value.Network) {
+					if found.location == "" {
+						report.Blockers = append(report.Blockers, &compute.TeardownResource{Kind: "globalForwardingRule", Name: found.name})
+					} else {
+						add(phase, "forwardingRule", found)
+					}
+				}
+			case *v1.BackendService:
+				if phase == phaseLoadBalancers && onNetwork(value.Network) {
+					if found.location == "" {
+						report.Blockers = append(report.Blockers, &compute.TeardownResource{Kind: "backendService", Name: found.name})
+					} else {
+						add(phase, "regionBackendService", found)
+					}
+				}
+			case *v1.InstanceGroupManager:
+				if phase == phaseInstances && managers[found] {
+					kind := "instanceGroupManager"
+					if found.kind == kindRegionInstanceGroupManager {
+						kind = "regionInstanceGroupManager"
+					}
+					add(phase, kind, found)
+					removed = append(removed, p.managedBy(found)...)
+				}
+			case *v1.Instance:
+				if phase == phaseInstances && !managed[found] && attachedTo(value, onNetwork) {
+					add(phase, "instance", found)
+				}
+			case *v1.InstanceGroup:
+				if phase == phaseInstances && !managed[found] && onNetwork(value.Network) {
+					add(phase, "instanceGroup", found)
+				}
+			case *v1.Address:
+				if phase == phaseDependents && found.location == "" && onNetwork(value.Network) {
+					report.Blockers = append(report.Blockers, &compute.TeardownResource{Kind: "globalAddress", Name: found.name})
+				} else if phase == phaseDependents && found.location != "" && (onNetwork(value.Network) || subnetworks[value.Subnetwork]) {
+					add(phase, "address", found)
+				}
+			case *v1.Firewall:
+				if phase == phaseDependents && onNetwork(value.Network) {
+					add(phase, "firewall", found)
+				}
+			case *v1.Router:
+				if phase == phaseDependents && onNetwork(value.Network) {
+					add(phase, "router", found)
+				}
+			case *v1.Subnetwork:
+				if phase == phaseSubnetworks && subnetworks[value.SelfLink] {
+					add(phase, "subnetwork", found)
+				}
+			}
+		}
+		if phase == phaseDependents {
+			// the network's peerings go with it, so there's nothing else to remove for them
+			for _, peering := range existingNetwork.value.(*v1.Network).Peerings {
+				report.Resources = append(report.Resources, &compute.TeardownResource{Phase: phase, Kind: "peering", Name: peering.Name})
+			}
+		}
+	}
+	add(phaseNetwork, "network", existingNetwork)
+	if len(report.Blockers) > 0 {
+		return report, &compute.TeardownBlockedError{Network: network, Blockers: report.Blockers}
+	}
+	if !opts.DryRun {
+		p.remove(removed...)
+	}
+	return report, nil
+}
+
+// attachedTo will return whether an instance or instance group is on the network onNetwork checks for
+func attachedTo(value interface{}, onNetwork func(string) bool) bool {
+	switch v := value.(type) {
+	case *v1.Instance:
+		for _, networkInterface := range v.NetworkInterfaces {
+			if onNetwork(networkInterface.Network) {
+				return true
+			}
+		}
+	case *v1.InstanceGroup:
+		return onNetwork(v.Network)
+	}
+	return false
+}
+
+// the phases of a network teardown, matching those of the real library
+const (
+	phaseForwardingRules = iota + 1
+	phaseLoadBalancers
+	phaseInstances
+	phaseDependents
+	phaseSubnetworks
+	phaseNetwork
+)
+
+// project will return the state of a project, creating it if it doesn't exist yet, the mutex must be held
+func (c *Compute) project(projectID string) *project {
+	if c.projects == nil {
+		c.projects = map[string]*project{}
+	}
+	p, ok := c.projects[projectID]
+	if !ok {
+		p = &project{regionZones: map[string][]string{}, metadata: []*v1.MetadataItems{}}
+		c.projects[projectID] = p
+	}
+	return p
+}
+
+// forEach will call fn for a copy of each resource of a kind, outside of the mutex so that fn can use the fake
+func (c *Compute) forEach(projectID string, kind string, fn func(interface{}) error) error {
+	c.mutex.Lock()
+	values := []interface{}{}
+	for _, existing := range c.project(projectID).resources {
+		if existing.kind == kind {
+			values = append(values, copyValue(existing.value))
+		}
+	}
+	c.mutex.Unlock()
+	for _, value := range values {
+		if err := fn(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Compute) delete(projectID string, kind string, location string, name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	p := c.project(projectID)
+	location, name = lastSegment(location), lastSegment(name)
+	existing := p.find(kind, location, name)
+	if existing == nil {
+		return notFound(selfLink(projectID, kind, location, name))
+	}
+	p.remove(existing)
+	return nil
+}
+
+// deleteInstanceGroupManager will delete a managed instance group along with what it manages, like the api does
+func (c *Compute) deleteInstanceGroupManager(projectID string, kind string, location string, name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	p := c.project(projectID)
+	location, name = lastSegment(location), lastSegment(name)
+	existing := p.find(kind, location, name)
+	if existing == nil {
+		return notFound(selfLink(projectID, kind, location, name))
+	}
+	p.remove(p.managedBy(existing)...)
+	p.remove(existing)
+	return nil
+}
+
+func (c *Compute) setInstanceStatus(projectID string, status string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, existing := range c.project(projectID).resources {
+		if instance, ok := existing.value.(*v1.Instance); ok {
+			instance.Status = status
+		}
+	}
+}
+
+func (p *project) find(kind string, location string, name string) *resource {
+	for _, existing := range p.resources {
+		if existing.kind == kind && existing.location == location && existing.name == name {
+			return existing
+		}
+	}
+	return nil
+}
+
+// managedBy will return the instance group of a managed instance group and the instances it created
+func (p *project) managedBy(manager *resource) []*resource {
+	value := manager.value.(*v1.InstanceGroupManager)
+	managed := []*resource{}
+	for _, existing := range p.resources {
+		switch v := existing.value.(type) {
+		case *v1.InstanceGroup:
+			if value.InstanceGroup != "" && v.SelfLink == value.InstanceGroup {
+				managed = append(managed, existing)
+			}
+		case *v1.Instance:
+			if createdBy(v) != "" && managedPath(createdBy(v)) == managedPath(value.SelfLink) {
+				managed = append(managed, existing)
+			}
+		}
+	}
+	return managed
+}
+
+func (p *project) remove(removed ...*resource) {
+	kept := []*resource{}
+	for _, existing := range p.resources {
+		keep := true
+		for _, r := range removed {
+			if existing == r {
+				keep = false
+			}
+		}
+		if keep {
+			kept = append(kept, existing)
+		}
+	}
+	p.resources = kept
+}
+
+// describe will determine the kind, location and name of a resource, giving it a self link if it has none
+func describe(projectID string, value interface{}) (*resource, error) {
+	var kind, location, name string
+	var link *string
+	switch v := value.(type) {
+	case *v1.Address:
+		kind, location, name, link = kindAddress, v.Region, v.Name, &v.SelfLink
+	case *v1.BackendService:
+		kind, location, name, link = kindBackendService, v.Region, v.Name, &v.SelfLink
+	case *v1.Disk:
+		kind, location, name, link = kindDisk, v.Zone, v.Name, &v.SelfLink
+	case *v1.Firewall:
+		kind, name, link = kindFirewall, v.Name, &v.SelfLink
+	case *v1.ForwardingRule:
+		kind, location, name, link = kindForwardingRule, v.Region, v.Name, &v.SelfLink
+	case *v1.HealthCheck:
+		kind, name, link = kindHealthCheck, v.Name, &v.SelfLink
+	case *v1.HttpHealthCheck:
+		kind, name, link = kindHTTPHealthCheck, v.Name, &v.SelfLink
+	case *v1.Instance:
+		kind, location, name, link = kindInstance, v.Zone, v.Name, &v.SelfLink
+	case *v1.InstanceGroup:
+		kind, location, name, link = kindInstanceGroup, v.Zone, v.Name, &v.SelfLink
+	case *v1.InstanceGroupManager:
+		kind, location, name, link = kindInstanceGroupManager, v.Zone, v.Name, &v.SelfLink
+		if v.Region != "" {
+			kind, location = kindRegionInstanceGroupManager, v.Region
+		}
+	case *v1.Network:
+		kind, name, link = kindNetwork, v.Name, &v.SelfLink
+	case *v1.Route:
+		kind, name, link = kindRoute, v.Name, &v.SelfLink
+	case *v1.Router:
+		kind, location, name, link = kindRouter, v.Region, v.Name, &v.SelfLink
+	case *v1.Subnetwork:
+		kind, location, name, link = kindSubnetwork, v.Region, v.Name, &v.SelfLink
+	case *v1.TargetPool:
+		kind, location, name, link = kindTargetPool, v.Region, v.Name, &v.SelfLink
+	default:
+		return nil, fmt.Errorf("unsupported compute resource type %T", value)
+	}
+	location = lastSegment(location)
+	if *link == "" {
+		*link = selfLink(projectID, kind, location, name)
+	}
+	return &resource{kind: kind, location: location, name: name, value: value}, nil
+}
+
+// selfLink will construct the url of a resource, zonal for zonal kinds, regional for the rest with a location
+func selfLink(projectID string, kind string, location string, name string) string {
+	scope := "global"
+	if location != "" {
+		scope = fmt.Sprintf("regions/%s", location)
+		if kind == kindDisk || kind == kindInstance || kind == kindInstanceGroup || kind == kindInstanceGroupManager {
+			scope = fmt.Sprintf("zones/%s", location)
+		}
+	}
+	if kind == kindRegionInstanceGroupManager {
+		kind = kindInstanceGroupManager
+	}
+	return fmt.Sprintf("%s/projects/%s/%s/%s/%s", baseURL, projectID, scope, kind, name)
+}
+
+// copyValue will deep copy an api value through its json representation, so callers can't change the fake's state
+func copyValue(value interface{}) interface{} {
+	copied := reflect.New(reflect.TypeOf(value))
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(data, copied.Interface())
+	}
+	if err != nil {
+		panic(fmt.Sprintf("unable to copy %T: %s", value, err))
+	}
+	return copied.Elem().Interface()
+}
+
+// createdBy will return the url of the managed instance group that created an instance, blank if it wasn't
+func createdBy(instance *v1.Instance) string {
+	if instance.Metadata == nil {
+		return ""
+	}
+	for _, item := range instance.Metadata.Items {
+		if item.Key == "created-by" && item.Value != nil {
+			return *item.Value
+		}
+	}
+	return ""
+}
+
+// managedPath will return the location and name part of a managed instance group's url, the same whether the url
+// is full or partial
+func managedPath(url string) string {
+	parts := strings.Split(url, "/")
+	if len(parts) < 4 {
+		return url
+	}
+	return strings.Join(parts[len(parts)-4:], "/")
+}
+
+func lastSegment(url string) string {
+	parts := strings.Split(url, "/")
+	return parts[len(parts)-1]
+}
+
+func notFound(resource string) error {
+	return &googleapi.Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("The resource '%s' was not found", resource),
+	}
+}
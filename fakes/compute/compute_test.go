@@ -0,0 +1,175 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/rockholla/go-google-lib/compute"
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	v1 "google.golang.org/api/compute/v1"
+)
+
+var (
+	testProjectID = "project-11111111111"
+	testNetwork   = "https://www.googleapis.com/compute/v1/projects/project-11111111111/global/networks/test"
+)
+
+func addTestNetwork(t *testing.T, c *Compute) {
+	err := c.Add(testProjectID,
+		&v1.Network{Name: "test", Peerings: []*v1.NetworkPeering{{Name: "peering"}}},
+		&v1.Subnetwork{Name: "test", Region: "us-central1", Network: testNetwork},
+		&v1.Instance{
+			Name:              "test",
+			Zone:              "https://www.googleapis.com/compute/v1/projects/project-11111111111/zones/us-central1-a",
+			NetworkInterfaces: []*v1.NetworkInterface{{Network: testNetwork, NetworkIP: "10.0.0.2"}},
+		},
+		&v1.Firewall{Name: "test", Network: testNetwork},
+		&v1.Firewall{Name: "other", Network: "other"},
+		&v1.Route{Name: "test", Network: testNetwork, NextHopIp: "10.0.0.2"},
+		&v1.Router{Name: "test", Region: "us-central1", Network: testNetwork},
+		&v1.ForwardingRule{Name: "test", Region: "us-central1", Network: testNetwork},
+	)
+	if err != nil {
+		t.Errorf("Got unexpected error adding resources to the fake: %s", err)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	c := New()
+	addTestNetwork(t, c)
+	if err := c.Add(testProjectID, &v1.Firewall{Name: "test"}); !googleerrors.IsAlreadyExists(err) {
+		t.Errorf("Expected adding an existing resource to return an already exists error, got %v", err)
+	}
+	if err := c.Add(testProjectID, &v1.Project{}); err == nil {
+		t.Errorf("Expected adding an unsupported resource to return an error")
+	}
+	network, err := c.GetNetwork(testProjectID, "test")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.GetNetwork(): %s", err)
+	}
+	if len(network.Subnetworks) != 1 {
+		t.Errorf("Expected the added subnetwork to be in the network's subnetworks, got %v", network.Subnetworks)
+	}
+	ips, err := c.GetInternalIPs(testProjectID, "test")
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.GetInternalIPs(): %s", err)
+	}
+	if len(ips) != 1 || ips[0].IP != "10.0.0.2" {
+		t.Errorf("Got unexpected result from compute.GetInternalIPs(): %v", ips)
+	}
+}
+
+func TestPowerOff(t *testing.T) {
+	c := New()
+	addTestNetwork(t, c)
+	if err := c.PowerOff(testProjectID, compute.Synchronous()); err != nil {
+		t.Errorf("Got unexpected error during compute.PowerOff(): %s", err)
+	}
+	c.ForEachInstance(testProjectID, func(instance *v1.Instance) error {
+		if instance.Status != "TERMINATED" {
+			t.Errorf("Expected compute.PowerOff() to stop instance %s, got status %s", instance.Name, instance.Status)
+		}
+		return nil
+	})
+}
+
+func TestDeleteDisk(t *testing.T) {
+	c := New()
+	c.Add(testProjectID, &v1.Disk{Name: "test", Zone: "us-central1-a"})
+	if err := c.DeleteDisk(testProjectID, "https://www.googleapis.com/compute/v1/projects/project-11111111111/zones/us-central1-a", "test"); err != nil {
+		t.Errorf("Got unexpected error during compute.DeleteDisk(): %s", err)
+	}
+	if err := c.DeleteDisk(testProjectID, "us-central1-a", "test"); !googleerrors.IsNotFound(err) {
+		t.Errorf("Expected compute.DeleteDisk() to return a not found error for a missing disk, got %v", err)
+	}
+}
+
+func TestTeardownNetwork(t *testing.T) {
+	c := New()
+	addTestNetwork(t, c)
+	report, err := c.TeardownNetwork(testProjectID, "test", compute.TeardownOptions{DryRun: true})
+	if err != nil {
+		t.Errorf("Got unexpected error during compute.TeardownNetwork() dry run: %s", err)
+	}
+	expected := "1: route test (global)\n1: forwardingRule test (us-central1)\n3: instance test (us-central1-a)\n4: firewall test (global)\n4: router test (us-central1)\n4: peering peering (global)\n5: subnetwork test (us-central1)\n6: network test (global)"
+	if report.String() != expected {
+		t.Errorf("Got unexpected report from compute.TeardownNetwork(), expected:\n%s\ngot:\n%s", expected, report.String())
+	}
+	if _, err := c.GetNetwork(testProjectID, "test"); err != nil {
+		t.Errorf("Expected compute.TeardownNetwork() dry run not to delete anything, got %s", err)
+	}
+	if _, err := c.TeardownNetwork(testProjectID, "test", compute.TeardownOptions{}); err != nil {
+		t.Errorf("Got unexpected error during compute.TeardownNetwork(): %s", err)
+	}
+	firewalls, _ := c.GetFirewalls(testProjectID)
+	if len(firewalls) != 1 || firewalls[0].Name != "other" {
+		t.Errorf("Expected compute.TeardownNetwork() to only keep the firewall on another network, got %d firewalls", len(firewalls))
+	}
+	if _, err := c.GetNetwork(testProjectID, "test"); !googleerrors.IsNotFound(err) {
+		t.Errorf("Expected compute.TeardownNetwork() to delete the network, got %v", err)
+	}
+}
+
+func TestDeleteInstanceGroupManager(t *testing.T) {
+	c := New()
+	createdBy := "projects/123456/zones/us-central1-a/instanceGroupManagers/web"
+	c.Add(testProjectID,
+		&v1.InstanceGroup{Name: "web", Zone: "us-central1-a"},
+		&v1.InstanceGroupManager{
+			Name:          "web",
+			Zone:          "us-central1-a",
+			InstanceGroup: "https://www.googleapis.com/compute/v1/projects/project-11111111111/zones/us-central1-a/instanceGroups/web",
+		},
+		&v1.Instance{
+			Name:     "web-1",
+			Zone:     "us-central1-a",
+			Metadata: &v1.Metadata{Items: []*v1.MetadataItems{{Key: "created-by", Value: &createdBy}}},
+		},
+		&v1.Instance{Name: "other", Zone: "us-central1-a"},
+	)
+	if err := c.DeleteInstanceGroupManager(testProjectID, "us-central1-a", "web"); err != nil {
+		t.Errorf("Got unexpected error during compute.DeleteInstanceGroupManager(): %s", err)
+	}
+	instances := []string{}
+	c.ForEachInstance(testProjectID, func(instance *v1.Instance) error {
+		instances = append(instances, instance.Name)
+		return nil
+	})
+	if len(instances) != 1 || instances[0] != "other" {
+		t.Errorf("Expected compute.DeleteInstanceGroupManager() to only delete the instances it created, got %v", instances)
+	}
+	if groups, _ := c.GetInstanceGroups(testProjectID); len(groups) != 0 {
+		t.Errorf("Expected compute.DeleteInstanceGroupManager() to delete its instance group, got %d instance groups", len(groups))
+	}
+	if err := c.DeleteRegionInstanceGroupManager(testProjectID, "us-central1", "web"); !googleerrors.IsNotFound(err) {
+		t.Errorf("Expected compute.DeleteRegionInstanceGroupManager() to return a not found error for a missing group, got %v", err)
+	}
+}
+
+func TestTeardownNetworkBlocked(t *testing.T) {
+	c := New()
+	addTestNetwork(t, c)
+	c.Add(testProjectID, &v1.ForwardingRule{Name: "global", Network: testNetwork}, &v1.Address{Name: "global", Network: testNetwork})
+	_, err := c.TeardownNetwork(testProjectID, "test", compute.TeardownOptions{})
+	blockedErr, ok := err.(*compute.TeardownBlockedError)
+	if !ok || len(blockedErr.Blockers) != 2 {
+		t.Fatalf("Expected a *compute.TeardownBlockedError with 2 blockers from compute.TeardownNetwork(), got %v", err)
+	}
+	if _, err := c.GetNetwork(testProjectID, "test"); err != nil {
+		t.Errorf("Expected compute.TeardownNetwork() to delete nothing when blocked, got %s", err)
+	}
+}
+
+func TestRemoveNetworkPeering(t *testing.T) {
+	c := New()
+	addTestNetwork(t, c)
+	if err := c.RemoveNetworkPeering(testProjectID, testNetwork, "peering"); err != nil {
+		t.Errorf("Got unexpected error during compute.RemoveNetworkPeering(): %s", err)
+	}
+	network, _ := c.GetNetwork(testProjectID, "test")
+	if len(network.Peerings) != 0 {
+		t.Errorf("Expected compute.RemoveNetworkPeering() to remove the peering, got %d peerings", len(network.Peerings))
+	}
+	if err := c.RemoveNetworkPeering(testProjectID, "test", "peering"); !googleerrors.IsNotFound(err) {
+		t.Errorf("Expected compute.RemoveNetworkPeering() to return a not found error for a missing peering, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+// Package dns is an in-memory fake of the dns library, for tests of code using it
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/rockholla/go-google-lib/dns"
+	"github.com/rockholla/go-lib/logger"
+	v1 "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+)
+
+var _ dns.Interface = &DNS{}
+
+// DNS is a stateful, in-memory implementation of dns.Interface. Managed zones have to be added with AddManagedZone
+// before they're used, after which record set changes are applied to them immediately. It's safe for concurrent use,
+// and the zero value is ready to use.
+type DNS struct {
+	mutex sync.Mutex
+	zones map[string][]*v1.ResourceRecordSet
+}
+
+// New will return a fake without any managed zones
+func New() *DNS {
+	return &DNS{}
+}
+
+// AddManagedZone will create an empty managed zone in a project, with any initial record sets, e.g. its SOA and NS
+func (d *DNS) AddManagedZone(projectID string, managedZone string, records ...*v1.ResourceRecordSet) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.zones == nil {
+		d.zones = map[string][]*v1.ResourceRecordSet{}
+	}
+	zone := []*v1.ResourceRecordSet{}
+	for _, record := range records {
+		zone = append(zone, copyRecord(record))
+	}
+	d.zones[zoneKey(projectID, managedZone)] = zone
+}
+
+// Initialize is a no-op for the fake
+func (d *DNS) Initialize(credentials string, log logger.Interface) error {
+	return nil
+}
+
+// InitializeCtx is a no-op for the fake
+func (d *DNS) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	return nil
+}
+
+// ForEachResourceRecordSet will call fn for every resource record set in a managed zone, stopping at and returning
+// the first error returned by fn
+func (d *DNS) ForEachResourceRecordSet(projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) error {
+	return d.ForEachResourceRecordSetCtx(context.Background(), projectID, managedZone, fn)
+}
+
+// ForEachResourceRecordSetCtx is ForEachResourceRecordSet, the context is unused
+func (d *DNS) ForEachResourceRecordSetCtx(ctx context.Context, projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) error {
+	records, err := d.GetResourceRecordSetsCtx(ctx, projectID, managedZone)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetResourceRecordSets will return copies of all resource record sets in a managed zone
+func (d *DNS) GetResourceRecordSets(projectID string, managedZone string) ([]*v1.ResourceRecordSet, error) {
+	return d.GetResourceRecordSetsCtx(context.Background(), projectID, managedZone)
+}
+
+// GetResourceRecordSetsCtx is GetResourceRecordSets, the context is unused
+func (d *DNS) GetResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string) ([]*v1.ResourceRecordSet, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	zone, err := d.zone(projectID, managedZone)
+	if err != nil {
+		return nil, err
+	}
+	var records []*v1.ResourceRecordSet
+	for _, record := range zone {
+		records = append(records, copyRecord(record))
+	}
+	return records, nil
+}
+
+// GetResourceRecordSet will return a copy of the first record set with the name, nil if none found
+func (d *DNS) GetResourceRecordSet(projectID string, managedZone string, name string) (*v1.ResourceRecordSet, error) {
+	return d.GetResourceRecordSetCtx(context.Background(), projectID, managedZone, name)
+}
+
+// GetResourceRecordSetCtx is GetResourceRecordSet, the context is unused
+func (d *DNS) GetResourceRecordSetCtx(ctx context.Context, projectID string, managedZone string, name string) (*v1.ResourceRecordSet, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	zone, err := d.zone(projectID, managedZone)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range zone {
+		if record.Name == name {
+			return copyRecord(record), nil
+		}
+	}
+	return nil, nil
+}
+
+// SetResourceRecordSets will replace an existing record set with the same name as each of the records, and add the
+// records, failing without changes if the records would result in a duplicate name and type
+func (d *DNS) SetResourceRecordSets(projectID string, managedZone string, records []*v1.ResourceRecordSet) error {
+	return d.SetResourceRecordSetsCtx(context.Background(), projectID, managedZone, records)
+}
+
+// SetResourceRecordSetsCtx is SetResourceRecordSets, the context is unused
+func (d *DNS) SetResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string, records []*v1.ResourceRecordSet) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	zone, err := d.zone(projectID, managedZone)
+	if err != nil {
+		return err
+	}
+	// like the real change, the first existing record set of each name is deleted, then all records are added
+	deleted := map[string]bool{}
+	for _, record := range records {
+		deleted[record.Name] = true
+	}
+	updated := []*v1.ResourceRecordSet{}
+	for _, existing := range zone {
+		if deleted[existing.Name] {
+			deleted[existing.Name] = false
+			continue
+		}
+		updated = append(updated, existing)
+	}
+	for _, record := range records {
+		for _, existing := range updated {
+			if existing.Name == record.Name && existing.Type == record.Type {
+				return &googleapi.Error{
+					Code:    http.StatusConflict,
+					Message: fmt.Sprintf("The resource record set %s of type %s already exists", record.Name, record.Type),
+				}
+			}
+		}
+		updated = append(updated, copyRecord(record))
+	}
+	d.zones[zoneKey(projectID, managedZone)] = updated
+	return nil
+}
+
+// DeleteResourceRecordSets will remove all resource record sets, other than SOA and NS, from a managed zone
+func (d *DNS) DeleteResourceRecordSets(projectID string, managedZone string) error {
+	return d.DeleteResourceRecordSetsCtx(context.Background(), projectID, managedZone)
+}
+
+// DeleteResourceRecordSetsCtx is DeleteResourceRecordSets, the context is unused
+func (d *DNS) DeleteResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	zone, err := d.zone(projectID, managedZone)
+	if err != nil {
+		return err
+	}
+	kept := []*v1.ResourceRecordSet{}
+	for _, record := range zone {
+		if record.Type == "SOA" || record.Type == "NS" {
+			kept = append(kept, record)
+		}
+	}
+	d.zones[zoneKey(projectID, managedZone)] = kept
+	return nil
+}
+
+func (d *DNS) zone(projectID string, managedZone string) ([]*v1.ResourceRecordSet, error) {
+	zone, ok := d.zones[zoneKey(projectID, managedZone)]
+	if !ok {
+		return nil, &googleapi.Error{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("The managed zone %s in project %s does not exist", managedZone, projectID),
+		}
+	}
+	return zone, nil
+}
+
+func zoneKey(projectID string, managedZone string) string {
+	return fmt.Sprintf("%s/%s", projectID, managedZone)
+}
+
+func copyRecord(record *v1.ResourceRecordSet) *v1.ResourceRecordSet {
+	copied := *record
+	copied.Rrdatas = append([]string{}, record.Rrdatas...)
+	return &copied
+}
@@ -0,0 +1,71 @@
+package dns
+
+import (
+	"testing"
+
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	v1 "google.golang.org/api/dns/v1"
+)
+
+var (
+	testProjectID   = "project-11111111111"
+	testManagedZone = "go-google-lib-io"
+	testName        = "tests.go-google-lib.io."
+)
+
+func TestSetResourceRecordSets(t *testing.T) {
+	d := New()
+	d.AddManagedZone(testProjectID, testManagedZone,
+		&v1.ResourceRecordSet{Name: "go-google-lib.io.", Type: "SOA", Rrdatas: []string{"soa"}},
+		&v1.ResourceRecordSet{Name: "go-google-lib.io.", Type: "NS", Rrdatas: []string{"ns"}},
+	)
+	record := &v1.ResourceRecordSet{Name: testName, Type: "A", Ttl: 300, Rrdatas: []string{"10.0.0.1"}}
+	if err := d.SetResourceRecordSets(testProjectID, testManagedZone, []*v1.ResourceRecordSet{record}); err != nil {
+		t.Errorf("Got unexpected error during dns.SetResourceRecordSets(): %s", err)
+	}
+	record = &v1.ResourceRecordSet{Name: testName, Type: "A", Ttl: 300, Rrdatas: []string{"10.0.0.2"}}
+	if err := d.SetResourceRecordSets(testProjectID, testManagedZone, []*v1.ResourceRecordSet{record}); err != nil {
+		t.Errorf("Got unexpected error during dns.SetResourceRecordSets() replacing a record set: %s", err)
+	}
+	existing, err := d.GetResourceRecordSet(testProjectID, testManagedZone, testName)
+	if err != nil {
+		t.Errorf("Got unexpected error during dns.GetResourceRecordSet(): %s", err)
+	}
+	if existing == nil || existing.Rrdatas[0] != "10.0.0.2" {
+		t.Errorf("Expected dns.GetResourceRecordSet() to return the replaced record set, got %v", existing)
+	}
+	records, _ := d.GetResourceRecordSets(testProjectID, testManagedZone)
+	if len(records) != 3 {
+		t.Errorf("Expected 3 record sets in the zone, got %d", len(records))
+	}
+	if err := d.DeleteResourceRecordSets(testProjectID, testManagedZone); err != nil {
+		t.Errorf("Got unexpected error during dns.DeleteResourceRecordSets(): %s", err)
+	}
+	records, _ = d.GetResourceRecordSets(testProjectID, testManagedZone)
+	if len(records) != 2 {
+		t.Errorf("Expected dns.DeleteResourceRecordSets() to keep the SOA and NS record sets, got %d record sets", len(records))
+	}
+}
+
+func TestSetResourceRecordSetsDuplicate(t *testing.T) {
+	d := New()
+	d.AddManagedZone(testProjectID, testManagedZone)
+	records := []*v1.ResourceRecordSet{
+		{Name: testName, Type: "A", Rrdatas: []string{"10.0.0.1"}},
+		{Name: testName, Type: "A", Rrdatas: []string{"10.0.0.2"}},
+	}
+	if err := d.SetResourceRecordSets(testProjectID, testManagedZone, records); !googleerrors.IsAlreadyExists(err) {
+		t.Errorf("Expected dns.SetResourceRecordSets() to return an already exists error for duplicate records, got %v", err)
+	}
+	existing, _ := d.GetResourceRecordSets(testProjectID, testManagedZone)
+	if len(existing) != 0 {
+		t.Errorf("Expected a failed dns.SetResourceRecordSets() not to change the zone, got %d record sets", len(existing))
+	}
+}
+
+func TestMissingManagedZone(t *testing.T) {
+	d := New()
+	if _, err := d.GetResourceRecordSets(testProjectID, testManagedZone); !googleerrors.IsNotFound(err) {
+		t.Errorf("Expected dns.GetResourceRecordSets() to return a not found error for a missing zone, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Package fakes has stateful, in-memory implementations of the google libraries, for tests of code using them that
+// need the results of earlier calls reflected in later ones, without scripting every call like with the mocks
+package fakes
+
+import (
+	"context"
+	"fmt"
+
+	google "github.com/rockholla/go-google-lib"
+	"github.com/rockholla/go-google-lib/admin"
+	"github.com/rockholla/go-google-lib/cloudbilling"
+	"github.com/rockholla/go-google-lib/cloudidentity"
+	"github.com/rockholla/go-google-lib/cloudkms"
+	"github.com/rockholla/go-google-lib/cloudresourcemanager"
+	"github.com/rockholla/go-google-lib/compute"
+	"github.com/rockholla/go-google-lib/deploymentmanager"
+	"github.com/rockholla/go-google-lib/dns"
+	crmfake "github.com/rockholla/go-google-lib/fakes/cloudresourcemanager"
+	computefake "github.com/rockholla/go-google-lib/fakes/compute"
+	dnsfake "github.com/rockholla/go-google-lib/fakes/dns"
+	storagefake "github.com/rockholla/go-google-lib/fakes/storage"
+	"github.com/rockholla/go-google-lib/iam"
+	"github.com/rockholla/go-google-lib/oauth"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/storage"
+	"github.com/rockholla/go-lib/logger"
+)
+
+var _ google.Interface = &Google{}
+
+// Google is an implementation of google.Interface returning the fakes, so that state is shared between everything
+// that gets a library from it. Libraries without a fake can be set to any implementation, like a mock, e.g.
+//
+// fake := fakes.NewGoogle()
+// fake.IAM = &iammock.Interface{}
+//
+// and getting one that isn't set returns an error.
+type Google struct {
+	CloudResourceManager *crmfake.CloudResourceManager
+	Compute              *computefake.Compute
+	DNS                  *dnsfake.DNS
+	Storage              *storagefake.Storage
+	CloudBilling         cloudbilling.Interface
+	CloudIdentity        cloudidentity.Interface
+	Admin                admin.Interface
+	DeploymentManager    deploymentmanager.Interface
+	IAM                  iam.Interface
+	OAuth                oauth.Interface
+	CloudKMS             cloudkms.Interface
+}
+
+// NewGoogle will return a Google with empty fakes
+func NewGoogle() *Google {
+	return &Google{
+		CloudResourceManager: crmfake.New(),
+		Compute:              computefake.New(),
+		DNS:                  dnsfake.New(),
+		Storage:              storagefake.New(),
+	}
+}
+
+// Initialize is a no-op for the fake
+func (g *Google) Initialize(credentials string, log logger.Interface, opts ...google.Option) {}
+
+// SetRetryPolicy is a no-op for the fake
+func (g *Google) SetRetryPolicy(policy *retry.Policy) {}
+
+// Close is a no-op for the fake, the state of the fakes is kept
+func (g *Google) Close() error {
+	return nil
+}
+
+// GetCloudResourceManager will return the cloud resource manager fake
+func (g *Google) GetCloudResourceManager() (cloudresourcemanager.Interface, error) {
+	return g.GetCloudResourceManagerCtx(context.Background())
+}
+
+// GetCloudResourceManagerCtx is GetCloudResourceManager, the context is unused
+func (g *Google) GetCloudResourceManagerCtx(ctx context.Context) (cloudresourcemanager.Interface, error) {
+	if g.CloudResourceManager == nil {
+		return nil, notSet("CloudResourceManager")
+	}
+	return g.CloudResourceManager, nil
+}
+
+// GetCloudBilling will return the cloud billing library that was set
+func (g *Google) GetCloudBilling() (cloudbilling.Interface, error) {
+	return g.GetCloudBillingCtx(context.Background())
+}
+
+// GetCloudBillingCtx is GetCloudBilling, the context is unused
+func (g *Google) GetCloudBillingCtx(ctx context.Context) (cloudbilling.Interface, error) {
+	if g.CloudBilling == nil {
+		return nil, notSet("CloudBilling")
+	}
+	return g.CloudBilling, nil
+}
+
+// GetIAM will return the iam library that was set
+func (g *Google) GetIAM() (iam.Interface, error) {
+	return g.GetIAMCtx(context.Background())
+}
+
+// GetIAMCtx is GetIAM, the context is unused
+func (g *Google) GetIAMCtx(ctx context.Context) (iam.Interface, error) {
+	if g.IAM == nil {
+		return nil, notSet("IAM")
+	}
+	return g.IAM, nil
+}
+
+// GetDeploymentManager will return the deployment manager library that was set
+func (g *Google) GetDeploymentManager() (deploymentmanager.Interface, error) {
+	return g.GetDeploymentManagerCtx(context.Background())
+}
+
+// GetDeploymentManagerCtx is GetDeploymentManager, the context is unused
+func (g *Google) GetDeploymentManagerCtx(ctx context.Context) (deploymentmanager.Interface, error) {
+	if g.DeploymentManager == nil {
+		return nil, notSet("DeploymentManager")
+	}
+	return g.DeploymentManager, nil
+}
+
+// GetStorage will return the storage fake
+func (g *Google) GetStorage() (storage.Interface, error) {
+	return g.GetStorageCtx(context.Background())
+}
+
+// GetStorageCtx is GetStorage, the context is unused
+func (g *Google) GetStorageCtx(ctx context.Context) (storage.Interface, error) {
+	if g.Storage == nil {
+		return nil, notSet("Storage")
+	}
+	return g.Storage, nil
+}
+
+// GetCompute will return the compute fake
+func (g *Google) GetCompute() (compute.Interface, error) {
+	return g.GetComputeCtx(context.Background())
+}
+
+// GetComputeCtx is GetCompute, the context is unused
+func (g *Google) GetComputeCtx(ctx context.Context) (compute.Interface, error) {
+	if g.Compute == nil {
+		return nil, notSet("Compute")
+	}
+	return g.Compute, nil
+}
+
+// GetDNS will return the dns fake
+func (g *Google) GetDNS() (dns.Interface, error) {
+	return g.GetDNSCtx(context.Background())
+}
+
+// GetDNSCtx is GetDNS, the context is unused
+func (g *Google) GetDNSCtx(ctx context.Context) (dns.Interface, error) {
+	if g.DNS == nil {
+		return nil, notSet("DNS")
+	}
+	return g.DNS, nil
+}
+
+// GetCloudIdentity will return the cloud identity library that was set, regardless of the service account
+func (g *Google) GetCloudIdentity(impersonateServiceAccountEmail string) (cloudidentity.Interface, error) {
+	return g.GetCloudIdentityCtx(context.Background(), impersonateServiceAccountEmail)
+}
+
+// GetCloudIdentityCtx is GetCloudIdentity, the context is unused
+func (g *Google) GetCloudIdentityCtx(ctx context.Context, impersonateServiceAccountEmail string) (cloudidentity.Interface, error) {
+	if g.CloudIdentity == nil {
+		return nil, notSet("CloudIdentity")
+	}
+	return g.CloudIdentity, nil
+}
+
+// GetAdmin will return the admin library that was set, regardless of the arguments
+func (g *Google) GetAdmin(credentialsJSON string, domain string, adminUsername string) (admin.Interface, error) {
+	return g.GetAdminCtx(context.Background(), credentialsJSON, domain, adminUsername)
+}
+
+// GetAdminCtx is GetAdmin, the context is unused
+func (g *Google) GetAdminCtx(ctx context.Context, credentialsJSON string, domain string, adminUsername string) (admin.Interface, error) {
+	if g.Admin == nil {
+		return nil, notSet("Admin")
+	}
+	return g.Admin, nil
+}
+
+// GetOAuth will return the oauth library that was set, regardless of the scopes
+func (g *Google) GetOAuth(scopes []string) (oauth.Interface, error) {
+	return g.GetOAuthCtx(context.Background(), scopes)
+}
+
+// GetOAuthCtx is GetOAuth, the context is unused
+func (g *Google) GetOAuthCtx(ctx context.Context, scopes []string) (oauth.Interface, error) {
+	if g.OAuth == nil {
+		return nil, notSet("OAuth")
+	}
+	return g.OAuth, nil
+}
+
+// GetCloudKMS will return the cloud kms library that was set
+func (g *Google) GetCloudKMS() (cloudkms.Interface, error) {
+	return g.GetCloudKMSCtx(context.Background())
+}
+
+// GetCloudKMSCtx is GetCloudKMS, the context is unused
+func (g *Google) GetCloudKMSCtx(ctx context.Context) (cloudkms.Interface, error) {
+	if g.CloudKMS == nil {
+		return nil, notSet("CloudKMS")
+	}
+	return g.CloudKMS, nil
+}
+
+func notSet(library string) error {
+	return fmt.Errorf("no %s library is set on the fake", library)
+}
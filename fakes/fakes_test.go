@@ -0,0 +1,27 @@
+package fakes
+
+import (
+	"testing"
+
+	iammock "github.com/rockholla/go-google-lib/mocks/iam"
+)
+
+func TestNewGoogle(t *testing.T) {
+	fake := NewGoogle()
+	crm, err := fake.GetCloudResourceManager()
+	if err != nil {
+		t.Errorf("Got unexpected error during fakes.GetCloudResourceManager(): %s", err)
+	}
+	name, _ := crm.EnsureFolder("test", "organizations/1234567890")
+	again, _ := fake.CloudResourceManager.GetFolder("test", "organizations/1234567890")
+	if name != again {
+		t.Errorf("Expected the fake returned by fakes.GetCloudResourceManager() to share state with the field")
+	}
+	if _, err := fake.GetIAM(); err == nil {
+		t.Errorf("Expected fakes.GetIAM() to return an error when no library is set")
+	}
+	fake.IAM = &iammock.Interface{}
+	if _, err := fake.GetIAM(); err != nil {
+		t.Errorf("Got unexpected error during fakes.GetIAM() with a library set: %s", err)
+	}
+}
@@ -0,0 +1,271 @@
+// Package storage is an in-memory fake of the storage library, for tests of code using it
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	api "cloud.google.com/go/storage"
+	"github.com/rockholla/go-google-lib/storage"
+	"github.com/rockholla/go-lib/logger"
+)
+
+var _ storage.Interface = &Storage{}
+
+// Storage is a stateful, in-memory implementation of storage.Interface. Buckets it creates keep the objects
+// written to them and the roles granted on them. It's safe for concurrent use, and the zero value is ready to use.
+type Storage struct {
+	mutex   sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	projectID string
+	attrs     api.BucketAttrs
+	objects   map[string]*storage.Object
+	roles     map[string][]string
+}
+
+// New will return a fake without any buckets
+func New() *Storage {
+	return &Storage{}
+}
+
+// Initialize is a no-op for the fake
+func (s *Storage) Initialize(credentials string, log logger.Interface) error {
+	return nil
+}
+
+// InitializeCtx is a no-op for the fake
+func (s *Storage) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	return nil
+}
+
+// EnsureBucket will create the bucket in the project if it doesn't already exist
+func (s *Storage) EnsureBucket(name string, projectID string, attrs *api.BucketAttrs) error {
+	return s.EnsureBucketCtx(context.Background(), name, projectID, attrs)
+}
+
+// EnsureBucketCtx is EnsureBucket, the context is unused
+func (s *Storage) EnsureBucketCtx(ctx context.Context, name string, projectID string, attrs *api.BucketAttrs) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.buckets[name]; ok {
+		return nil
+	}
+	if s.buckets == nil {
+		s.buckets = map[string]*bucket{}
+	}
+	created := &bucket{
+		projectID: projectID,
+		objects:   map[string]*storage.Object{},
+		roles:     map[string][]string{},
+	}
+	if attrs != nil {
+		created.attrs = *attrs
+	}
+	created.attrs.Name = name
+	s.buckets[name] = created
+	return nil
+}
+
+// EnsureObject will create or replace an object in an existing bucket
+func (s *Storage) EnsureObject(bucket string, path string, object *storage.Object) error {
+	return s.EnsureObjectCtx(context.Background(), bucket, path, object)
+}
+
+// EnsureObjectCtx is EnsureObject, the context is unused
+func (s *Storage) EnsureObjectCtx(ctx context.Context, bucket string, path string, object *storage.Object) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.buckets[bucket]
+	if !ok {
+		return api.ErrBucketNotExist
+	}
+	existing.objects[path] = &storage.Object{
+		ContentType: object.ContentType,
+		Data:        append([]byte{}, object.Data...),
+	}
+	return nil
+}
+
+// GetObject will return a copy of an object's content
+func (s *Storage) GetObject(bucket string, path string) ([]byte, error) {
+	return s.GetObjectCtx(context.Background(), bucket, path)
+}
+
+// GetObjectCtx is GetObject, the context is unused
+func (s *Storage) GetObjectCtx(ctx context.Context, bucket string, path string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.buckets[bucket]
+	if !ok {
+		return []byte{}, api.ErrBucketNotExist
+	}
+	object, ok := existing.objects[path]
+	if !ok {
+		return []byte{}, api.ErrObjectNotExist
+	}
+	return append([]byte{}, object.Data...), nil
+}
+
+// GetServiceAccount will return the storage service account for a project, derived from the project ID
+func (s *Storage) GetServiceAccount(projectID string) (string, error) {
+	return s.GetServiceAccountCtx(context.Background(), projectID)
+}
+
+// GetServiceAccountCtx is GetServiceAccount, the context is unused
+func (s *Storage) GetServiceAccountCtx(ctx context.Context, projectID string) (string, error) {
+	return fmt.Sprintf("service-%s@gs-project-accounts.iam.gserviceaccount.com", projectID), nil
+}
+
+// EnsureBucketRoles will grant the member each of the roles on an existing bucket
+func (s *Storage) EnsureBucketRoles(bucket string, member string, roles []string) error {
+	return s.EnsureBucketRolesCtx(context.Background(), bucket, member, roles)
+}
+
+// EnsureBucketRolesCtx is EnsureBucketRoles, the context is unused
+func (s *Storage) EnsureBucketRolesCtx(ctx context.Context, bucket string, member string, roles []string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.buckets[bucket]
+	if !ok {
+		return api.ErrBucketNotExist
+	}
+	for _, role := range roles {
+		if !contains(existing.roles[role], member) {
+			existing.roles[role] = append(existing.roles[role], member)
+		}
+	}
+	return nil
+}
+
+// RemoveBucketRoles will remove the member from each of the roles on an existing bucket
+func (s *Storage) RemoveBucketRoles(bucket string, member string, roles []string) ([]string, error) {
+	return s.RemoveBucketRolesCtx(context.Background(), bucket, member, roles)
+}
+
+// RemoveBucketRolesCtx is RemoveBucketRoles, the context is unused
+func (s *Storage) RemoveBucketRolesCtx(ctx context.Context, bucket string, member string, roles []string) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.buckets[bucket]
+	if !ok {
+		return nil, api.ErrBucketNotExist
+	}
+	removed := []string{}
+	for _, role := range roles {
+		if contains(existing.roles[role], member) {
+			existing.removeMember(role, member)
+			removed = append(removed, role)
+		}
+	}
+	sort.Strings(removed)
+	return removed, nil
+}
+
+// RemoveBucketMember will remove the member from every role on an existing bucket
+func (s *Storage) RemoveBucketMember(bucket string, member string) ([]string, error) {
+	return s.RemoveBucketMemberCtx(context.Background(), bucket, member)
+}
+
+// RemoveBucketMemberCtx is RemoveBucketMember, the context is unused
+func (s *Storage) RemoveBucketMemberCtx(ctx context.Context, bucket string, member string) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.buckets[bucket]
+	if !ok {
+		return nil, api.ErrBucketNotExist
+	}
+	removed := []string{}
+	for role, members := range existing.roles {
+		if contains(members, member) {
+			existing.removeMember(role, member)
+			removed = append(removed, role)
+		}
+	}
+	sort.Strings(removed)
+	return removed, nil
+}
+
+// Close is a no-op for the fake, buckets and their objects are kept
+func (s *Storage) Close() error {
+	return nil
+}
+
+// Buckets will return the names of all buckets created, sorted
+func (s *Storage) Buckets() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	names := []string{}
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BucketAttrs will return the attributes a bucket was created with and the project it was created in, nil if the
+// bucket doesn't exist
+func (s *Storage) BucketAttrs(bucket string) (*api.BucketAttrs, string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.buckets[bucket]
+	if !ok {
+		return nil, ""
+	}
+	attrs := existing.attrs
+	return &attrs, existing.projectID
+}
+
+// Object will return a copy of an object, nil if either the bucket or object doesn't exist
+func (s *Storage) Object(bucket string, path string) *storage.Object {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.buckets[bucket]
+	if !ok {
+		return nil
+	}
+	object, ok := existing.objects[path]
+	if !ok {
+		return nil
+	}
+	return &storage.Object{ContentType: object.ContentType, Data: append([]byte{}, object.Data...)}
+}
+
+// BucketRoleMembers will return the members granted a role on a bucket
+func (s *Storage) BucketRoleMembers(bucket string, role string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.buckets[bucket]
+	if !ok {
+		return []string{}
+	}
+	return append([]string{}, existing.roles[role]...)
+}
+
+func contains(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
+
+// removeMember will remove the member from the role, removing the role once it has no members
+func (b *bucket) removeMember(role string, member string) {
+	members := []string{}
+	for _, m := range b.roles[role] {
+		if m != member {
+			members = append(members, m)
+		}
+	}
+	if len(members) == 0 {
+		delete(b.roles, role)
+		return
+	}
+	b.roles[role] = members
+}
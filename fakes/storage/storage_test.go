@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"testing"
+
+	api "cloud.google.com/go/storage"
+	"github.com/rockholla/go-google-lib/storage"
+)
+
+var (
+	testBucket    = "go-google-lib-test"
+	testProjectID = "project-11111111111"
+	testMember    = "user:test@go-google-lib.io"
+)
+
+func TestEnsureObject(t *testing.T) {
+	s := New()
+	object := &storage.Object{ContentType: "text/plain", Data: []byte("test")}
+	if err := s.EnsureObject(testBucket, "test.txt", object); err != api.ErrBucketNotExist {
+		t.Errorf("Expected storage.EnsureObject() to return api.ErrBucketNotExist for a missing bucket, got %v", err)
+	}
+	if err := s.EnsureBucket(testBucket, testProjectID, &api.BucketAttrs{Location: "US"}); err != nil {
+		t.Errorf("Got unexpected error during storage.EnsureBucket(): %s", err)
+	}
+	if err := s.EnsureBucket(testBucket, testProjectID, nil); err != nil {
+		t.Errorf("Got unexpected error during storage.EnsureBucket() for an existing bucket: %s", err)
+	}
+	if attrs, projectID := s.BucketAttrs(testBucket); attrs.Location != "US" || projectID != testProjectID {
+		t.Errorf("Expected storage.EnsureBucket() to keep the original bucket, got location %s in %s", attrs.Location, projectID)
+	}
+	if err := s.EnsureObject(testBucket, "test.txt", object); err != nil {
+		t.Errorf("Got unexpected error during storage.EnsureObject(): %s", err)
+	}
+	object.Data[0] = 'b'
+	content, err := s.GetObject(testBucket, "test.txt")
+	if err != nil {
+		t.Errorf("Got unexpected error during storage.GetObject(): %s", err)
+	}
+	if string(content) != "test" {
+		t.Errorf("Expected storage.GetObject() to return the written content, got %s", string(content))
+	}
+	if _, err := s.GetObject(testBucket, "missing.txt"); err != api.ErrObjectNotExist {
+		t.Errorf("Expected storage.GetObject() to return api.ErrObjectNotExist for a missing object, got %v", err)
+	}
+}
+
+func TestEnsureBucketRoles(t *testing.T) {
+	s := New()
+	s.EnsureBucket(testBucket, testProjectID, nil)
+	for i := 0; i < 2; i++ {
+		if err := s.EnsureBucketRoles(testBucket, testMember, []string{"roles/storage.objectViewer"}); err != nil {
+			t.Errorf("Got unexpected error during storage.EnsureBucketRoles(): %s", err)
+		}
+	}
+	if members := s.BucketRoleMembers(testBucket, "roles/storage.objectViewer"); len(members) != 1 || members[0] != testMember {
+		t.Errorf("Expected storage.EnsureBucketRoles() to grant the role to the member once, got %v", members)
+	}
+}
+
+func TestRemoveBucketRoles(t *testing.T) {
+	s := New()
+	s.EnsureBucket(testBucket, testProjectID, nil)
+	roles := []string{"roles/storage.objectViewer", "roles/storage.objectAdmin"}
+	if err := s.EnsureBucketRoles(testBucket, testMember, roles); err != nil {
+		t.Errorf("Got unexpected error during storage.EnsureBucketRoles(): %s", err)
+	}
+	removed, err := s.RemoveBucketRoles(testBucket, testMember, []string{"roles/storage.objectViewer", "roles/storage.legacyBucketReader"})
+	if err != nil {
+		t.Errorf("Got unexpected error during storage.RemoveBucketRoles(): %s", err)
+	}
+	if len(removed) != 1 || removed[0] != "roles/storage.objectViewer" {
+		t.Errorf("Expected storage.RemoveBucketRoles() to report only the role the member had, got %v", removed)
+	}
+	removed, err = s.RemoveBucketMember(testBucket, testMember)
+	if err != nil {
+		t.Errorf("Got unexpected error during storage.RemoveBucketMember(): %s", err)
+	}
+	if len(removed) != 1 || removed[0] != "roles/storage.objectAdmin" || len(s.BucketRoleMembers(testBucket, "roles/storage.objectAdmin")) != 0 {
+		t.Errorf("Expected storage.RemoveBucketMember() to remove the member from its remaining role, got %v", removed)
+	}
+	if _, err := s.RemoveBucketMember("missing", testMember); err != api.ErrBucketNotExist {
+		t.Errorf("Expected storage.RemoveBucketMember() to return api.ErrBucketNotExist for a missing bucket, got %v", err)
+	}
+}
@@ -5,12 +5,18 @@ go 1.16
 require (
 	cloud.google.com/go v0.81.0
 	cloud.google.com/go/storage v1.14.0
+	github.com/fatih/color v1.10.0
 	github.com/googleapis/gax-go/v2 v2.0.5
+	github.com/mattn/go-isatty v0.0.12
 	github.com/rockholla/go-lib v0.0.0-20210415215125-210830ee2741
-	github.com/stretchr/testify v1.7.0 // indirect
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel v0.19.0
+	go.opentelemetry.io/otel/metric v0.19.0
+	go.opentelemetry.io/otel/oteltest v0.19.0
+	go.opentelemetry.io/otel/trace v0.19.0
 	golang.org/x/oauth2 v0.0.0-20210413134643-5e61552d6c78
 	google.golang.org/api v0.44.0
 	google.golang.org/genproto v0.0.0-20210415145412-64678f1ae2d5
-	google.golang.org/grpc v1.36.1 // indirect
+	google.golang.org/grpc v1.36.1
 	gopkg.in/yaml.v2 v2.4.0
 )
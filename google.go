@@ -2,157 +2,401 @@
 package google
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/rockholla/go-google-lib/admin"
 	"github.com/rockholla/go-google-lib/cloudbilling"
 	"github.com/rockholla/go-google-lib/cloudidentity"
+	"github.com/rockholla/go-google-lib/cloudkms"
 	"github.com/rockholla/go-google-lib/cloudresourcemanager"
 	"github.com/rockholla/go-google-lib/compute"
 	"github.com/rockholla/go-google-lib/deploymentmanager"
 	"github.com/rockholla/go-google-lib/dns"
+	"github.com/rockholla/go-google-lib/events"
 	"github.com/rockholla/go-google-lib/iam"
 	"github.com/rockholla/go-google-lib/oauth"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
 	"github.com/rockholla/go-google-lib/storage"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/option"
 )
 
-// Interface is the interface for all google api/sdk libraries
+// Interface is the interface for all google api/sdk libraries. The Get*Ctx variants use the provided context
+// when a library is first initialized, and underlying credentials may retain it, so it should outlive the library.
+// Implementations are safe for concurrent use, each library being initialized only once.
 type Interface interface {
-	Initialize(credentials string, log logger.Interface)
+	Initialize(credentials string, log logger.Interface, opts ...Option)
+	SetRetryPolicy(policy *retry.Policy)
 	GetCloudResourceManager() (cloudresourcemanager.Interface, error)
+	GetCloudResourceManagerCtx(ctx context.Context) (cloudresourcemanager.Interface, error)
 	GetCloudBilling() (cloudbilling.Interface, error)
+	GetCloudBillingCtx(ctx context.Context) (cloudbilling.Interface, error)
 	GetIAM() (iam.Interface, error)
+	GetIAMCtx(ctx context.Context) (iam.Interface, error)
 	GetDeploymentManager() (deploymentmanager.Interface, error)
+	GetDeploymentManagerCtx(ctx context.Context) (deploymentmanager.Interface, error)
 	GetStorage() (storage.Interface, error)
+	GetStorageCtx(ctx context.Context) (storage.Interface, error)
 	GetCompute() (compute.Interface, error)
+	GetComputeCtx(ctx context.Context) (compute.Interface, error)
 	GetDNS() (dns.Interface, error)
+	GetDNSCtx(ctx context.Context) (dns.Interface, error)
 	GetCloudIdentity(impersonateServiceAccountEmail string) (cloudidentity.Interface, error)
+	GetCloudIdentityCtx(ctx context.Context, impersonateServiceAccountEmail string) (cloudidentity.Interface, error)
 	GetAdmin(credentialsJSON string, domain string, adminUsername string) (admin.Interface, error)
+	GetAdminCtx(ctx context.Context, credentialsJSON string, domain string, adminUsername string) (admin.Interface, error)
 	GetOAuth(scopes []string) (oauth.Interface, error)
+	GetOAuthCtx(ctx context.Context, scopes []string) (oauth.Interface, error)
+	GetCloudKMS() (cloudkms.Interface, error)
+	GetCloudKMSCtx(ctx context.Context) (cloudkms.Interface, error)
+	Close() error
 }
 
 // Google is all related api/sdk libraries
 type Google struct {
-	credentials          string
-	log                  logger.Interface
-	cloudResourceManager cloudresourcemanager.Interface
-	cloudBilling         cloudbilling.Interface
-	iam                  iam.Interface
-	deploymentManager    deploymentmanager.Interface
-	storage              storage.Interface
-	compute              compute.Interface
-	dns                  dns.Interface
-	cloudIdentity        cloudidentity.Interface
-	admin                admin.Interface
-	oauth                oauth.Interface
-}
-
-// Initialize will set initial values for all libraries: credentials, logger
-func (google *Google) Initialize(credentials string, log logger.Interface) {
-	google.log = log
-	google.credentials = credentials
-	if google.credentials != "" {
-		google.log.Info("Using provided Google credentials key")
+	mutex                sync.RWMutex
+	settings             settings
+	cloudResourceManager instances
+	cloudBilling         instances
+	iam                  instances
+	deploymentManager    instances
+	storage              instances
+	compute              instances
+	dns                  instances
+	cloudIdentity        instances
+	admin                instances
+	oauth                instances
+	cloudKMS             instances
+}
+
+// settings are the values libraries are initialized with
+type settings struct {
+	credentials       string
+	log               logger.Interface
+	retryPolicy       *retry.Policy
+	clientOptions     []option.ClientOption
+	credentialOptions []option.ClientOption
+	endpoints         map[Service]endpoint
+	transport         http.RoundTripper
+	events            events.Sink
+	tracerProvider    trace.TracerProvider
+	meterProvider     metric.MeterProvider
+	telemetry         *telemetry.Telemetry
+	dryRun            *plan.Plan
+	conflictRetry     *retry.Policy
+}
+
+// Initialize will set initial values for all libraries: credentials, logger and options for how they authenticate
+// and connect. JSON credentials, when provided, take precedence over other credentials options. Spinners are left
+// out of the logging when stdout isn't a terminal. Without tracer or meter providers, no telemetry is recorded.
+// Libraries initialized before are closed, and initialized again with the new values if requested afterwards.
+func (google *Google) Initialize(credentials string, log logger.Interface, opts ...Option) {
+	closeErr := google.Close()
+	google.mutex.Lock()
+	google.settings.log = log
+	google.settings.credentials = credentials
+	google.settings.clientOptions = nil
+	google.settings.credentialOptions = nil
+	google.settings.endpoints = map[Service]endpoint{}
+	google.settings.transport = nil
+	google.settings.events = nil
+	google.settings.tracerProvider = nil
+	google.settings.meterProvider = nil
+	google.settings.telemetry = nil
+	google.settings.dryRun = nil
+	google.settings.conflictRetry = nil
+	for _, opt := range opts {
+		opt(&google.settings)
+	}
+	if !events.IsTerminal(os.Stdout) {
+		google.settings.log = events.WithoutSpinners(log)
+	}
+	log = google.settings.logger("google")
+	var telemetryErr error
+	if google.settings.tracerProvider != nil || google.settings.meterProvider != nil {
+		google.settings.telemetry, telemetryErr = telemetry.New(google.settings.tracerProvider, google.settings.meterProvider)
+	}
+	google.mutex.Unlock()
+	if closeErr != nil {
+		log.Error("Unable to close the libraries initialized before, continuing: %s", closeErr)
+	}
+	if telemetryErr != nil {
+		log.Error("Unable to record telemetry, continuing without it: %s", telemetryErr)
+	}
+	if credentials != "" {
+		log.Info("Using provided Google credentials key")
 	} else {
-		google.log.Info("Using Google default application credentials")
+		log.Info("Using Google default application credentials")
 	}
 }
 
+// SetRetryPolicy will set the retry policy used by all libraries for their underlying api calls, taking effect
+// for libraries not yet initialized. Each library uses retry.DefaultPolicy() when one isn't set.
+func (google *Google) SetRetryPolicy(policy *retry.Policy) {
+	google.mutex.Lock()
+	defer google.mutex.Unlock()
+	google.settings.retryPolicy = policy
+}
+
+// Close will release the underlying clients of all initialized libraries, those holding connections being closed.
+// Libraries are initialized again if requested afterwards.
+func (google *Google) Close() error {
+	errs := []string{}
+	caches := []*instances{
+		&google.cloudResourceManager, &google.cloudBilling, &google.iam, &google.deploymentManager, &google.storage,
+		&google.compute, &google.dns, &google.cloudIdentity, &google.admin, &google.oauth, &google.cloudKMS,
+	}
+	for _, cache := range caches {
+		for _, lib := range cache.drain() {
+			if closer, ok := lib.(io.Closer); ok {
+				if err := closer.Close(); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error closing libraries: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// authenticatedClientOptions will return the client options for a library, including those for credentials
+func (s settings) authenticatedClientOptions() []option.ClientOption {
+	clientOptions := append([]option.ClientOption{}, s.clientOptions...)
+	return append(clientOptions, s.credentialOptions...)
+}
+
+// connection will return the client options, endpoint override and whether requests should be sent without
+// credentials for a service's library. Credentials options are left out for insecure endpoints, which includes
+// storage when STORAGE_EMULATOR_HOST is set.
+func (s settings) connection(service Service) ([]option.ClientOption, string, bool) {
+	endpoint := s.endpoints[service]
+	insecure := endpoint.insecure || (service == ServiceStorage && os.Getenv(storageEmulatorHostEnv) != "")
+	if insecure {
+		return append([]option.ClientOption{}, s.clientOptions...), endpoint.url, true
+	}
+	return s.authenticatedClientOptions(), endpoint.url, false
+}
+
+// logger will return the logger for a library, logging to the event sink instead when one is set
+func (s settings) logger(service Service) logger.Interface {
+	if s.events != nil {
+		return events.NewLogger(s.events, string(service))
+	}
+	return s.log
+}
+
+// current will return a copy of the settings for initializing a library
+func (google *Google) current() settings {
+	google.mutex.RLock()
+	defer google.mutex.RUnlock()
+	return google.settings
+}
+
 // GetCloudResourceManager will get the cloud resource manager library
 func (google *Google) GetCloudResourceManager() (cloudresourcemanager.Interface, error) {
-	var err error
-	if google.cloudResourceManager == nil {
-		google.cloudResourceManager = &cloudresourcemanager.CloudResourceManager{}
-		err = google.cloudResourceManager.Initialize(google.credentials, google.log)
-	}
-	return google.cloudResourceManager, err
+	return google.GetCloudResourceManagerCtx(context.Background())
+}
+
+// GetCloudResourceManagerCtx is GetCloudResourceManager, using the provided context if the library needs to be initialized
+func (google *Google) GetCloudResourceManagerCtx(ctx context.Context) (cloudresourcemanager.Interface, error) {
+	settings := google.current()
+	lib, err := google.cloudResourceManager.get(instanceKey(), func() (interface{}, error) {
+		lib := &cloudresourcemanager.CloudResourceManager{Retry: settings.retryPolicy, Transport: settings.transport, Events: settings.events, Telemetry: settings.telemetry, DryRun: settings.dryRun, ConflictRetry: settings.conflictRetry}
+		lib.ClientOptions, lib.Endpoint, lib.Insecure = settings.connection(ServiceCloudResourceManager)
+		lib.ServiceUsageEndpoint = settings.endpoints[ServiceServiceUsage].url
+		return lib, lib.InitializeCtx(ctx, settings.credentials, settings.logger(ServiceCloudResourceManager))
+	})
+	return lib.(cloudresourcemanager.Interface), err
 }
 
 // GetCloudBilling will get the cloud billing library
 func (google *Google) GetCloudBilling() (cloudbilling.Interface, error) {
-	var err error
-	if google.cloudBilling == nil {
-		google.cloudBilling = &cloudbilling.CloudBilling{}
-		err = google.cloudBilling.Initialize(google.credentials, google.log)
-	}
-	return google.cloudBilling, err
+	return google.GetCloudBillingCtx(context.Background())
+}
+
+// GetCloudBillingCtx is GetCloudBilling, using the provided context if the library needs to be initialized
+func (google *Google) GetCloudBillingCtx(ctx context.Context) (cloudbilling.Interface, error) {
+	settings := google.current()
+	lib, err := google.cloudBilling.get(instanceKey(), func() (interface{}, error) {
+		lib := &cloudbilling.CloudBilling{Retry: settings.retryPolicy, Transport: settings.transport, Events: settings.events, Telemetry: settings.telemetry, DryRun: settings.dryRun, ConflictRetry: settings.conflictRetry}
+		lib.ClientOptions, lib.Endpoint, lib.Insecure = settings.connection(ServiceCloudBilling)
+		return lib, lib.InitializeCtx(ctx, settings.credentials, settings.logger(ServiceCloudBilling))
+	})
+	return lib.(cloudbilling.Interface), err
 }
 
 // GetIAM will get the IAM library
 func (google *Google) GetIAM() (iam.Interface, error) {
-	var err error
-	if google.iam == nil {
-		google.iam = &iam.IAM{}
-		err = google.iam.Initialize(google.credentials, google.log)
-	}
-	return google.iam, err
+	return google.GetIAMCtx(context.Background())
+}
+
+// GetIAMCtx is GetIAM, using the provided context if the library needs to be initialized
+func (google *Google) GetIAMCtx(ctx context.Context) (iam.Interface, error) {
+	settings := google.current()
+	lib, err := google.iam.get(instanceKey(), func() (interface{}, error) {
+		lib := &iam.IAM{Retry: settings.retryPolicy, Events: settings.events, Telemetry: settings.telemetry, DryRun: settings.dryRun}
+		lib.ClientOptions, lib.Endpoint, lib.Insecure = settings.connection(ServiceIAM)
+		return lib, lib.InitializeCtx(ctx, settings.credentials, settings.logger(ServiceIAM))
+	})
+	return lib.(iam.Interface), err
 }
 
 // GetDeploymentManager will get the deployment manager library
 func (google *Google) GetDeploymentManager() (deploymentmanager.Interface, error) {
-	var err error
-	if google.deploymentManager == nil {
-		google.deploymentManager = &deploymentmanager.DeploymentManager{}
-		err = google.deploymentManager.Initialize(google.credentials, google.log)
-	}
-	return google.deploymentManager, err
+	return google.GetDeploymentManagerCtx(context.Background())
+}
+
+// GetDeploymentManagerCtx is GetDeploymentManager, using the provided context if the library needs to be initialized
+func (google *Google) GetDeploymentManagerCtx(ctx context.Context) (deploymentmanager.Interface, error) {
+	settings := google.current()
+	lib, err := google.deploymentManager.get(instanceKey(), func() (interface{}, error) {
+		lib := &deploymentmanager.DeploymentManager{Retry: settings.retryPolicy, Transport: settings.transport, Events: settings.events, Telemetry: settings.telemetry, DryRun: settings.dryRun}
+		lib.ClientOptions, lib.Endpoint, lib.Insecure = settings.connection(ServiceDeploymentManager)
+		return lib, lib.InitializeCtx(ctx, settings.credentials, settings.logger(ServiceDeploymentManager))
+	})
+	return lib.(deploymentmanager.Interface), err
 }
 
 // GetStorage will get the storage library
 func (google *Google) GetStorage() (storage.Interface, error) {
-	var err error
-	if google.storage == nil {
-		google.storage = &storage.Storage{}
-		err = google.storage.Initialize(google.credentials, google.log)
-	}
-	return google.storage, err
+	return google.GetStorageCtx(context.Background())
+}
+
+// GetStorageCtx is GetStorage, using the provided context if the library needs to be initialized
+func (google *Google) GetStorageCtx(ctx context.Context) (storage.Interface, error) {
+	settings := google.current()
+	lib, err := google.storage.get(instanceKey(), func() (interface{}, error) {
+		lib := &storage.Storage{Retry: settings.retryPolicy, Transport: settings.transport, Events: settings.events, Telemetry: settings.telemetry, DryRun: settings.dryRun, ConflictRetry: settings.conflictRetry}
+		lib.ClientOptions, lib.Endpoint, lib.Insecure = settings.connection(ServiceStorage)
+		return lib, lib.InitializeCtx(ctx, settings.credentials, settings.logger(ServiceStorage))
+	})
+	return lib.(storage.Interface), err
 }
 
 // GetCompute will get the compute library
 func (google *Google) GetCompute() (compute.Interface, error) {
-	var err error
-	if google.compute == nil {
-		google.compute = &compute.Compute{}
-		err = google.compute.Initialize(google.credentials, google.log)
-	}
-	return google.compute, err
+	return google.GetComputeCtx(context.Background())
+}
+
+// GetComputeCtx is GetCompute, using the provided context if the library needs to be initialized
+func (google *Google) GetComputeCtx(ctx context.Context) (compute.Interface, error) {
+	settings := google.current()
+	lib, err := google.compute.get(instanceKey(), func() (interface{}, error) {
+		lib := &compute.Compute{Retry: settings.retryPolicy, Transport: settings.transport, Events: settings.events, Telemetry: settings.telemetry, DryRun: settings.dryRun}
+		lib.ClientOptions, lib.Endpoint, lib.Insecure = settings.connection(ServiceCompute)
+		return lib, lib.InitializeCtx(ctx, settings.credentials, settings.logger(ServiceCompute))
+	})
+	return lib.(compute.Interface), err
 }
 
 // GetDNS will get the dns library
 func (google *Google) GetDNS() (dns.Interface, error) {
-	var err error
-	if google.dns == nil {
-		google.dns = &dns.DNS{}
-		err = google.dns.Initialize(google.credentials, google.log)
-	}
-	return google.dns, err
+	return google.GetDNSCtx(context.Background())
+}
+
+// GetDNSCtx is GetDNS, using the provided context if the library needs to be initialized
+func (google *Google) GetDNSCtx(ctx context.Context) (dns.Interface, error) {
+	settings := google.current()
+	lib, err := google.dns.get(instanceKey(), func() (interface{}, error) {
+		lib := &dns.DNS{Retry: settings.retryPolicy, Transport: settings.transport, Events: settings.events, Telemetry: settings.telemetry, DryRun: settings.dryRun}
+		lib.ClientOptions, lib.Endpoint, lib.Insecure = settings.connection(ServiceDNS)
+		return lib, lib.InitializeCtx(ctx, settings.credentials, settings.logger(ServiceDNS))
+	})
+	return lib.(dns.Interface), err
 }
 
 // GetCloudIdentity will get the cloud identity library
 func (google *Google) GetCloudIdentity(impersonateServiceAccountEmail string) (cloudidentity.Interface, error) {
-	var err error
-	if google.cloudIdentity == nil {
-		google.cloudIdentity = &cloudidentity.CloudIdentity{}
-		err = google.cloudIdentity.Initialize(impersonateServiceAccountEmail, google.log)
-	}
-	return google.cloudIdentity, err
+	return google.GetCloudIdentityCtx(context.Background(), impersonateServiceAccountEmail)
+}
+
+// GetCloudIdentityCtx is GetCloudIdentity, using the provided context if the library needs to be initialized. A
+// separate library is kept for each impersonated service account.
+func (google *Google) GetCloudIdentityCtx(ctx context.Context, impersonateServiceAccountEmail string) (cloudidentity.Interface, error) {
+	settings := google.current()
+	cloudIdentity, err := google.cloudIdentity.get(instanceKey(impersonateServiceAccountEmail), func() (interface{}, error) {
+		cloudIdentity := &cloudidentity.CloudIdentity{
+			Retry:     settings.retryPolicy,
+			Transport: settings.transport,
+			Events:    settings.events,
+			Telemetry: settings.telemetry,
+			DryRun:    settings.dryRun,
+		}
+		cloudIdentity.ClientOptions, cloudIdentity.Endpoint, cloudIdentity.Insecure = settings.connection(ServiceCloudIdentity)
+		if settings.credentials != "" && !cloudIdentity.Insecure {
+			cloudIdentity.ClientOptions = append(cloudIdentity.ClientOptions, option.WithCredentialsJSON([]byte(settings.credentials)))
+		}
+		return cloudIdentity, cloudIdentity.InitializeCtx(ctx, impersonateServiceAccountEmail, settings.logger(ServiceCloudIdentity))
+	})
+	return cloudIdentity.(cloudidentity.Interface), err
 }
 
 // GetAdmin will get the admin library
 func (google *Google) GetAdmin(credentialsJSON string, domain string, adminUsername string) (admin.Interface, error) {
-	var err error
-	if google.admin == nil {
-		google.admin = &admin.Admin{}
-		err = google.admin.Initialize(credentialsJSON, domain, adminUsername, google.log)
-	}
-	return google.admin, err
+	return google.GetAdminCtx(context.Background(), credentialsJSON, domain, adminUsername)
+}
+
+// GetAdminCtx is GetAdmin, using the provided context if the library needs to be initialized. A separate library
+// is kept for each combination of credentials, domain and admin username.
+func (google *Google) GetAdminCtx(ctx context.Context, credentialsJSON string, domain string, adminUsername string) (admin.Interface, error) {
+	settings := google.current()
+	adminLib, err := google.admin.get(instanceKey(credentialsJSON, domain, adminUsername), func() (interface{}, error) {
+		adminLib := &admin.Admin{
+			Retry:         settings.retryPolicy,
+			ClientOptions: settings.authenticatedClientOptions(),
+			Transport:     settings.transport,
+			Events:        settings.events,
+			Telemetry:     settings.telemetry,
+			DryRun:        settings.dryRun,
+		}
+		return adminLib, adminLib.InitializeCtx(ctx, credentialsJSON, domain, adminUsername, settings.logger("admin"))
+	})
+	return adminLib.(admin.Interface), err
 }
 
 // GetOAuth will get the oauth library
 func (google *Google) GetOAuth(scopes []string) (oauth.Interface, error) {
-	var err error
-	if google.oauth == nil {
-		google.oauth = &oauth.OAuth{}
-		err = google.oauth.Initialize(google.credentials, google.log, scopes)
-	}
-	return google.oauth, err
+	return google.GetOAuthCtx(context.Background(), scopes)
+}
+
+// GetOAuthCtx is GetOAuth, using the provided context if the library needs to be initialized. A separate library
+// is kept for each set of scopes, regardless of their order.
+func (google *Google) GetOAuthCtx(ctx context.Context, scopes []string) (oauth.Interface, error) {
+	settings := google.current()
+	sortedScopes := append([]string{}, scopes...)
+	sort.Strings(sortedScopes)
+	oauthLib, err := google.oauth.get(instanceKey(sortedScopes...), func() (interface{}, error) {
+		oauthLib := &oauth.OAuth{ClientOptions: settings.authenticatedClientOptions()}
+		return oauthLib, oauthLib.InitializeCtx(ctx, settings.credentials, settings.logger("oauth"), scopes)
+	})
+	return oauthLib.(oauth.Interface), err
+}
+
+// GetCloudKMS will get the cloud kms library
+func (google *Google) GetCloudKMS() (cloudkms.Interface, error) {
+	return google.GetCloudKMSCtx(context.Background())
+}
+
+// GetCloudKMSCtx is GetCloudKMS, using the provided context if the library needs to be initialized
+func (google *Google) GetCloudKMSCtx(ctx context.Context) (cloudkms.Interface, error) {
+	settings := google.current()
+	lib, err := google.cloudKMS.get(instanceKey(), func() (interface{}, error) {
+		lib := &cloudkms.CloudKMS{Retry: settings.retryPolicy, Events: settings.events, Telemetry: settings.telemetry}
+		lib.ClientOptions, lib.Endpoint, lib.Insecure = settings.connection(ServiceCloudKMS)
+		return lib, lib.InitializeCtx(ctx, settings.credentials, settings.logger(ServiceCloudKMS))
+	})
+	return lib.(cloudkms.Interface), err
 }
@@ -1,9 +1,27 @@
 package google
 
 import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/rockholla/go-google-lib/cloudidentity"
+	"github.com/rockholla/go-google-lib/cloudresourcemanager"
+	"github.com/rockholla/go-google-lib/compute"
+	computecalls "github.com/rockholla/go-google-lib/compute/calls"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/storage"
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
+	"go.opentelemetry.io/otel/oteltest"
+	"golang.org/x/oauth2"
+	dnsv1 "google.golang.org/api/dns/v1"
 )
 
 const (
@@ -99,6 +117,19 @@ func TestGetCompute(t *testing.T) {
 	}
 }
 
+func TestGetComputeCtx(t *testing.T) {
+	var err error
+	g := &Google{}
+	_, err = g.GetComputeCtx(context.Background())
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetComputeCtx(): %s", err)
+	}
+	_, err = g.GetComputeCtx(context.Background())
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetComputeCtx() second run: %s", err)
+	}
+}
+
 func TestGetDNS(t *testing.T) {
 	var err error
 	g := &Google{}
@@ -179,3 +210,451 @@ func TestGetOAuthWithKey(t *testing.T) {
 		t.Errorf("Got unexpected error from google.GetOAuth() with key second run: %s", err)
 	}
 }
+
+func TestGetCloudKMS(t *testing.T) {
+	var err error
+	g := &Google{}
+	_, err = g.GetCloudKMS()
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetCloudKMS(): %s", err)
+	}
+	_, err = g.GetCloudKMS()
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetCloudKMS() second run: %s", err)
+	}
+}
+
+func TestSetRetryPolicy(t *testing.T) {
+	g := &Google{}
+	policy := retry.NoRetry()
+	g.SetRetryPolicy(policy)
+	c, err := g.GetCompute()
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetCompute() with a retry policy: %s", err)
+	}
+	if c.(*compute.Compute).Retry != policy {
+		t.Errorf("Expected the retry policy set on google to be used by the compute library")
+	}
+	if c.(*compute.Compute).Calls.RegionsGet.(*computecalls.RegionsGetCall).Retry != policy {
+		t.Errorf("Expected the retry policy set on google to be used by the compute library calls")
+	}
+}
+
+func TestGetAdminPerArguments(t *testing.T) {
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock())
+	first, err := g.GetAdmin(testCredentials, "one.go-google-lib.tests", "admin")
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetAdmin() for the first domain: %s", err)
+	}
+	second, err := g.GetAdmin(testCredentials, "two.go-google-lib.tests", "admin")
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetAdmin() for the second domain: %s", err)
+	}
+	if first == second {
+		t.Errorf("Expected google.GetAdmin() to return a separate library for a different domain")
+	}
+	again, _ := g.GetAdmin(testCredentials, "one.go-google-lib.tests", "admin")
+	if again != first {
+		t.Errorf("Expected google.GetAdmin() to return the same library for the same arguments")
+	}
+}
+
+func TestGetCloudIdentityPerArguments(t *testing.T) {
+	g := &Google{}
+	first, _ := g.GetCloudIdentity("one@sa")
+	second, _ := g.GetCloudIdentity("two@sa")
+	if first == second {
+		t.Errorf("Expected google.GetCloudIdentity() to return a separate library for a different service account")
+	}
+	if again, _ := g.GetCloudIdentity("one@sa"); again != first {
+		t.Errorf("Expected google.GetCloudIdentity() to return the same library for the same service account")
+	}
+}
+
+func TestGetOAuthPerArguments(t *testing.T) {
+	g := &Google{}
+	g.Initialize(testCredentials, loggermock.GetLogMock())
+	first, _ := g.GetOAuth([]string{"scope-a", "scope-b"})
+	second, _ := g.GetOAuth([]string{"scope-c"})
+	if first == second {
+		t.Errorf("Expected google.GetOAuth() to return a separate library for different scopes")
+	}
+	if again, _ := g.GetOAuth([]string{"scope-b", "scope-a"}); again != first {
+		t.Errorf("Expected google.GetOAuth() to return the same library for the same scopes in any order")
+	}
+}
+
+func TestGetCloudIdentityConcurrent(t *testing.T) {
+	g := &Google{}
+	results := make(chan cloudidentity.Interface, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cloudIdentity, err := g.GetCloudIdentity("concurrent@sa")
+			if err != nil {
+				t.Errorf("Got unexpected error from google.GetCloudIdentity() concurrently: %s", err)
+			}
+			results <- cloudIdentity
+		}()
+	}
+	wg.Wait()
+	close(results)
+	first := <-results
+	for cloudIdentity := range results {
+		if cloudIdentity != first {
+			t.Errorf("Expected concurrent google.GetCloudIdentity() calls to share a single library")
+		}
+	}
+}
+
+func TestGetComputeConcurrent(t *testing.T) {
+	g := &Google{}
+	results := make(chan compute.Interface, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := g.GetCompute()
+			if err != nil {
+				t.Errorf("Got unexpected error from google.GetCompute() concurrently: %s", err)
+			}
+			results <- c
+		}()
+	}
+	wg.Wait()
+	close(results)
+	first := <-results
+	for c := range results {
+		if c != first {
+			t.Errorf("Expected concurrent google.GetCompute() calls to share a single library")
+		}
+	}
+}
+
+func TestClose(t *testing.T) {
+	g := &Google{}
+	if err := g.Close(); err != nil {
+		t.Errorf("Got unexpected error from google.Close() with nothing initialized: %s", err)
+	}
+	first, err := g.GetStorage()
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetStorage(): %s", err)
+	}
+	if err = g.Close(); err != nil {
+		t.Errorf("Got unexpected error from google.Close(): %s", err)
+	}
+	second, err := g.GetStorage()
+	if err != nil {
+		t.Errorf("Got unexpected error from google.GetStorage() after close: %s", err)
+	}
+	if first == second {
+		t.Errorf("Expected google.GetStorage() to initialize a new library after google.Close()")
+	}
+	firstDNS, _ := g.GetDNS()
+	g.Close()
+	if secondDNS, _ := g.GetDNS(); firstDNS == secondDNS {
+		t.Errorf("Expected google.GetDNS() to initialize a new library after google.Close()")
+	}
+	g.Close()
+}
+
+func TestInitializeAgain(t *testing.T) {
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock())
+	storage, err := g.GetStorage()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetStorage(): %s", err)
+	}
+	dns, err := g.GetDNS()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetDNS(): %s", err)
+	}
+	g.Initialize("", loggermock.GetLogMock(), WithDryRun(plan.New()))
+	if again, _ := g.GetStorage(); again == storage {
+		t.Errorf("Expected google.GetStorage() to initialize a new library after google.Initialize() again")
+	}
+	if again, _ := g.GetDNS(); again == dns {
+		t.Errorf("Expected google.GetDNS() to initialize a new library with the new values after google.Initialize() again")
+	}
+	g.Close()
+}
+
+func TestInitializeOptions(t *testing.T) {
+	requestedPaths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"commonInstanceMetadata": {"items": [{"key": "test-key", "value": "test-value"}]}}`))
+	}))
+	defer server.Close()
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock(),
+		WithEndpoint(server.URL+"/compute/v1/"),
+		WithHTTPClient(server.Client()),
+		WithUserAgent("go-google-lib-tests"),
+	)
+	c, err := g.GetCompute()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetCompute() with options: %s", err)
+	}
+	if len(c.(*compute.Compute).ClientOptions) != 3 {
+		t.Errorf("Expected 3 client options on the compute library, got %d", len(c.(*compute.Compute).ClientOptions))
+	}
+	items, err := c.GetCommonInstanceMetadata("test-project")
+	if err != nil {
+		t.Fatalf("Got unexpected error from compute.GetCommonInstanceMetadata() with options: %s", err)
+	}
+	if len(items) != 1 || items[0].Key != "test-key" {
+		t.Errorf("Expected the metadata served by the test endpoint, got: %v", items)
+	}
+	if len(requestedPaths) != 1 || requestedPaths[0] != "/compute/v1/projects/test-project" {
+		t.Errorf("Expected a single request to the test endpoint, got: %v", requestedPaths)
+	}
+}
+
+func TestInitializeCredentialsOptions(t *testing.T) {
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock(),
+		WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})),
+		WithImpersonatedServiceAccount("target@test-project.iam.gserviceaccount.com", "delegate@test-project.iam.gserviceaccount.com"),
+		WithQuotaProject("test-quota-project"),
+	)
+	if _, err := g.GetDNS(); err != nil {
+		t.Errorf("Got unexpected error from google.GetDNS() with credentials options: %s", err)
+	}
+	g = &Google{}
+	g.Initialize("", loggermock.GetLogMock(), WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})))
+	o, err := g.GetOAuth([]string{})
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetOAuth() with a token source: %s", err)
+	}
+	token, err := o.GetAccessToken()
+	if err != nil || token != "test-token" {
+		t.Errorf("Expected the access token from the token source, got %s (error: %v)", token, err)
+	}
+	g = &Google{}
+	g.Initialize("", loggermock.GetLogMock(), WithCredentialsFile("/does/not/exist.json"))
+	if _, err := g.GetCompute(); err == nil {
+		t.Errorf("Expected an error from google.GetCompute() with a missing credentials file")
+	}
+}
+
+func TestInitializeServiceEndpoints(t *testing.T) {
+	requestedPaths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Expected no credentials in requests to an insecure endpoint")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/storage/") {
+			w.Write([]byte(`{"email_address": "storage@test-project.iam.gserviceaccount.com"}`))
+			return
+		}
+		w.Write([]byte(`{"rrsets": [{"name": "test.example.com.", "type": "A"}]}`))
+	}))
+	defer server.Close()
+	os.Setenv("STORAGE_EMULATOR_HOST", server.URL+"/storage/v1/")
+	defer os.Unsetenv("STORAGE_EMULATOR_HOST")
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock(),
+		WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})),
+		WithInsecureServiceEndpoint(ServiceDNS, server.URL+"/dns/v1/"),
+		WithServiceEndpoint(ServiceCompute, "https://compute.example.com/compute/v1/"),
+		WithServiceEndpoint(ServiceCloudResourceManager, "https://crm.example.com/"),
+		WithServiceEndpoint(ServiceServiceUsage, "https://serviceusage.example.com/"),
+	)
+	d, err := g.GetDNS()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetDNS() with an insecure endpoint: %s", err)
+	}
+	recordSets, err := d.GetResourceRecordSets("test-project", "test-zone")
+	if err != nil {
+		t.Fatalf("Got unexpected error from dns.GetResourceRecordSets() with an insecure endpoint: %s", err)
+	}
+	if len(recordSets) != 1 || recordSets[0].Name != "test.example.com." {
+		t.Errorf("Expected the record sets served by the test endpoint, got: %v", recordSets)
+	}
+	s, err := g.GetStorage()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetStorage() with an emulator host: %s", err)
+	}
+	serviceAccount, err := s.GetServiceAccount("test-project")
+	if err != nil {
+		t.Fatalf("Got unexpected error from storage.GetServiceAccount() with an emulator host: %s", err)
+	}
+	if serviceAccount != "storage@test-project.iam.gserviceaccount.com" {
+		t.Errorf("Expected the service account served by the emulator host, got: %s", serviceAccount)
+	}
+	if len(requestedPaths) != 2 {
+		t.Errorf("Expected 2 requests to the test endpoint, got: %v", requestedPaths)
+	}
+	c, err := g.GetCompute()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetCompute() with an endpoint: %s", err)
+	}
+	if c.(*compute.Compute).V1.BasePath != "https://compute.example.com/compute/v1/" {
+		t.Errorf("Expected the compute library to use the endpoint, got base path: %s", c.(*compute.Compute).V1.BasePath)
+	}
+	if c.(*compute.Compute).Insecure || len(c.(*compute.Compute).ClientOptions) != 1 {
+		t.Errorf("Expected the compute library to keep its credentials options")
+	}
+	crm, err := g.GetCloudResourceManager()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetCloudResourceManager() with endpoints: %s", err)
+	}
+	if crm.(*cloudresourcemanager.CloudResourceManager).V1.BasePath != "https://crm.example.com/" || crm.(*cloudresourcemanager.CloudResourceManager).SUV1.BasePath != "https://serviceusage.example.com/" {
+		t.Errorf("Expected the cloud resource manager and service usage apis to use their own endpoints")
+	}
+}
+
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (fn transportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestInitializeTransport(t *testing.T) {
+	authorizations := []string{}
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		authorizations = append(authorizations, req.Header.Get("Authorization"))
+		w := httptest.NewRecorder()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rrsets": [{"name": "test.example.com.", "type": "A"}]}`))
+		return w.Result(), nil
+	})
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock(),
+		WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})),
+		WithTransport(transport),
+	)
+	d, err := g.GetDNS()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetDNS() with a transport: %s", err)
+	}
+	recordSets, err := d.GetResourceRecordSets("test-project", "test-zone")
+	if err != nil {
+		t.Fatalf("Got unexpected error from dns.GetResourceRecordSets() with a transport: %s", err)
+	}
+	if len(recordSets) != 1 {
+		t.Errorf("Expected the record sets served by the transport, got: %v", recordSets)
+	}
+	if len(authorizations) != 1 || authorizations[0] != "Bearer test-token" {
+		t.Errorf("Expected a single authenticated request through the transport, got: %v", authorizations)
+	}
+}
+
+func TestInitializeEventSink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rrsets": [{"name": "test.example.com.", "type": "A"}]}`))
+	}))
+	defer server.Close()
+	output := &bytes.Buffer{}
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock(),
+		WithInsecureServiceEndpoint(ServiceDNS, server.URL+"/dns/v1/"),
+		WithEventSink(events.NewJSONSink(output, events.LevelDebug)),
+	)
+	d, err := g.GetDNS()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetDNS() with an event sink: %s", err)
+	}
+	if _, err := d.GetResourceRecordSets("test-project", "test-zone"); err != nil {
+		t.Fatalf("Got unexpected error from dns.GetResourceRecordSets() with an event sink: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 json events, got: %v", lines)
+	}
+	if !strings.Contains(lines[0], `"msg":"Using Google default application credentials"`) {
+		t.Errorf("Expected the initialize message as a json event, got: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"method":"GetResourceRecordSets"`) || !strings.Contains(lines[1], `"outcome":"success"`) {
+		t.Errorf("Expected the operation as a json event, got: %s", lines[1])
+	}
+}
+
+func TestInitializeTelemetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rrsets": [{"name": "test.example.com.", "type": "A"}]}`))
+	}))
+	defer server.Close()
+	spanRecorder := &oteltest.SpanRecorder{}
+	meter, meterProvider := oteltest.NewMeterProvider()
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock(),
+		WithInsecureServiceEndpoint(ServiceDNS, server.URL+"/dns/v1/"),
+		WithTracerProvider(oteltest.NewTracerProvider(oteltest.WithSpanRecorder(spanRecorder))),
+		WithMeterProvider(meterProvider),
+	)
+	d, err := g.GetDNS()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetDNS() with telemetry: %s", err)
+	}
+	if _, err := d.GetResourceRecordSets("test-project", "test-zone"); err != nil {
+		t.Fatalf("Got unexpected error from dns.GetResourceRecordSets() with telemetry: %s", err)
+	}
+	spans := spanRecorder.Completed()
+	if len(spans) != 1 || spans[0].Name() != "dns.ResourceRecordSetsList" {
+		t.Fatalf("Expected a single span for the api call of dns.GetResourceRecordSets(), got %d", len(spans))
+	}
+	if len(meter.MeasurementBatches) == 0 {
+		t.Errorf("Expected metrics for the api call of dns.GetResourceRecordSets()")
+	}
+}
+
+func TestInitializeDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected only reads in a dry run, got: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rrsets": []}`))
+	}))
+	defer server.Close()
+	dryRun := plan.New()
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock(),
+		WithInsecureServiceEndpoint(ServiceDNS, server.URL+"/dns/v1/"),
+		WithDryRun(dryRun),
+	)
+	d, err := g.GetDNS()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetDNS() in a dry run: %s", err)
+	}
+	err = d.SetResourceRecordSets("test-project", "test-zone", []*dnsv1.ResourceRecordSet{{Name: "test.example.com.", Type: "A"}})
+	if err != nil {
+		t.Fatalf("Got unexpected error from dns.SetResourceRecordSets() in a dry run: %s", err)
+	}
+	changes := dryRun.Changes()
+	if len(changes) != 1 || changes[0].Action != plan.ActionCreate || changes[0].Resource != "test-zone/test.example.com." {
+		t.Errorf("Expected the creation of the record set planned by dns.SetResourceRecordSets(), got: %v", changes)
+	}
+}
+
+func TestInitializeConflictRetryPolicy(t *testing.T) {
+	policy := &retry.Policy{MaxAttempts: 2}
+	g := &Google{}
+	g.Initialize("", loggermock.GetLogMock(), WithConflictRetryPolicy(policy))
+	crm, err := g.GetCloudResourceManager()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetCloudResourceManager() with a conflict retry policy: %s", err)
+	}
+	if crm.(*cloudresourcemanager.CloudResourceManager).ConflictRetry != policy {
+		t.Errorf("Expected the conflict retry policy on the cloud resource manager library")
+	}
+	g.Initialize("", loggermock.GetLogMock())
+	s, err := g.GetStorage()
+	if err != nil {
+		t.Fatalf("Got unexpected error from google.GetStorage(): %s", err)
+	}
+	if s.(*storage.Storage).ConflictRetry == nil || s.(*storage.Storage).ConflictRetry == policy {
+		t.Errorf("Expected the default conflict retry policy on the storage library once initialized without one")
+	}
+}
@@ -4,20 +4,36 @@ package iam
 import (
 	"context"
 	"fmt"
-	"strings"
+	"io"
 
 	adminv1 "cloud.google.com/go/iam/admin/apiv1"
 	gax "github.com/googleapis/gax-go/v2"
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	"google.golang.org/api/option"
 	adminpb "google.golang.org/genproto/googleapis/iam/admin/v1"
+	"google.golang.org/grpc"
 )
 
+// service is the name of the library in the events it emits
+const service = "iam"
+
+// noClientRetry turns off the iam client's own retries, so that Retry is the only policy retrying a call
+var noClientRetry = gax.WithRetry(func() gax.Retryer { return nil })
+
 // Interface represents functionality for IAM
 type Interface interface {
 	Initialize(credentials string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error
 	EnsureServiceAccount(projectID string, serviceAccount *ServiceAccount, createNewKey bool) error
+	EnsureServiceAccountCtx(ctx context.Context, projectID string, serviceAccount *ServiceAccount, createNewKey bool) error
 	DeleteServiceAccount(projectID string, serviceAccountName string) error
+	DeleteServiceAccountCtx(ctx context.Context, projectID string, serviceAccountName string) error
+	Close() error
 }
 
 // AdminV1 is an interface for the underlying IAM sdk/library for api interaction
@@ -30,8 +46,21 @@ type AdminV1 interface {
 
 // IAM wraps google-provided apis for interacting with cloud.google.com/go/iam/*
 type IAM struct {
-	log     logger.Interface
-	AdminV1 AdminV1
+	log           logger.Interface
+	AdminV1       AdminV1
+	Retry         *retry.Policy
+	ClientOptions []option.ClientOption
+	// Endpoint overrides the host:port api requests are sent to, e.g. a local emulator or fake
+	Endpoint string
+	// Insecure sends api requests without credentials or TLS, for an Endpoint that doesn't expect them
+	Insecure bool
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
 }
 
 // ServiceAccount is an object representing a service account
@@ -45,17 +74,28 @@ type ServiceAccount struct {
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (iam *IAM) Initialize(credentials string, log logger.Interface) error {
+	return iam.InitializeCtx(context.Background(), credentials, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (iam *IAM) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	iam.log = log
-	if credentials != "" {
-		if iam.AdminV1, err = adminv1.NewIamClient(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-	} else {
-		if iam.AdminV1, err = adminv1.NewIamClient(ctx); err != nil {
-			return err
-		}
+	if iam.Retry == nil {
+		iam.Retry = retry.DefaultPolicy()
+	}
+	clientOptions := append([]option.ClientOption{}, iam.ClientOptions...)
+	if credentials != "" && !iam.Insecure {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if iam.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(iam.Endpoint))
+	}
+	if iam.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication(), option.WithGRPCDialOption(grpc.WithInsecure()))
+	}
+	if iam.AdminV1, err = adminv1.NewIamClient(ctx, clientOptions...); err != nil {
+		return err
 	}
 	return nil
 }
@@ -63,16 +103,27 @@ func (iam *IAM) Initialize(credentials string, log logger.Interface) error {
 // EnsureServiceAccount will make sure that a service account and key exists for a particular service account name
 // in the specified project ID. You can also instruct to force create a new/additional key if one already exists
 func (iam *IAM) EnsureServiceAccount(projectID string, serviceAccount *ServiceAccount, createNewKey bool) error {
+	return iam.EnsureServiceAccountCtx(context.Background(), projectID, serviceAccount, createNewKey)
+}
+
+// EnsureServiceAccountCtx is EnsureServiceAccount, using the provided context for the underlying api calls
+func (iam *IAM) EnsureServiceAccountCtx(ctx context.Context, projectID string, serviceAccount *ServiceAccount, createNewKey bool) (err error) {
+	defer events.Start(iam.Events, service, "EnsureServiceAccount", events.ActionEnsure, projectID, serviceAccount.Name).Done(&err)
 	serviceAccount.setEmail(projectID)
-	ctx := context.Background()
 	createServiceAccount := false
 	iam.log.Info(`Ensuring that service account %s exists in project %s`, serviceAccount.Name, projectID)
 	getServiceAccountRequest := &adminpb.GetServiceAccountRequest{
 		Name: fmt.Sprintf("projects/%s/serviceAccounts/%s", projectID, serviceAccount.Email),
 	}
-	existing, err := iam.AdminV1.GetServiceAccount(ctx, getServiceAccountRequest)
+	var existing *adminpb.ServiceAccount
+	err = iam.Telemetry.Call(ctx, service, "GetServiceAccount", projectID, serviceAccount.Name, func(ctx context.Context) error {
+		return iam.Retry.Do(ctx, func() (err error) {
+			existing, err = iam.AdminV1.GetServiceAccount(ctx, getServiceAccountRequest, noClientRetry)
+			return err
+		})
+	})
 	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "notfound") {
+		if googleerrors.IsNotFound(err) {
 			createServiceAccount = true
 		} else {
 			return err
@@ -80,16 +131,32 @@ func (iam *IAM) EnsureServiceAccount(projectID string, serviceAccount *ServiceAc
 	} else {
 		serviceAccount.ID = existing.UniqueId
 	}
+	createServiceAccountRequest := &adminpb.CreateServiceAccountRequest{
+		Name:      fmt.Sprintf("projects/%s", projectID),
+		AccountId: serviceAccount.Name,
+		ServiceAccount: &adminpb.ServiceAccount{
+			DisplayName: serviceAccount.Name,
+			Description: serviceAccount.Description,
+		},
+	}
+	planned := []plan.Change{}
 	if createServiceAccount {
-		createServiceAccountRequest := &adminpb.CreateServiceAccountRequest{
-			Name:      fmt.Sprintf("projects/%s", projectID),
-			AccountId: serviceAccount.Name,
-			ServiceAccount: &adminpb.ServiceAccount{
-				DisplayName: serviceAccount.Name,
-				Description: serviceAccount.Description,
-			},
-		}
-		created, err := iam.AdminV1.CreateServiceAccount(ctx, createServiceAccountRequest)
+		planned = append(planned, plan.Change{Service: service, Method: "EnsureServiceAccount", Project: projectID, Resource: serviceAccount.Email, Action: plan.ActionCreate, After: createServiceAccountRequest.ServiceAccount})
+	}
+	if createNewKey {
+		planned = append(planned, plan.Change{Service: service, Method: "EnsureServiceAccount", Project: projectID, Resource: serviceAccount.Email + "/keys", Action: plan.ActionCreate})
+	}
+	if iam.DryRun.Record(planned...) {
+		return nil
+	}
+	if createServiceAccount {
+		var created *adminpb.ServiceAccount
+		err := iam.Telemetry.Call(ctx, service, "CreateServiceAccount", projectID, serviceAccount.Name, func(ctx context.Context) error {
+			return iam.Retry.Mutating().Do(ctx, func() (err error) {
+				created, err = iam.AdminV1.CreateServiceAccount(ctx, createServiceAccountRequest, noClientRetry)
+				return err
+			})
+		})
 		if err != nil {
 			return err
 		}
@@ -99,7 +166,13 @@ func (iam *IAM) EnsureServiceAccount(projectID string, serviceAccount *ServiceAc
 		createServiceAccountKeyRequest := &adminpb.CreateServiceAccountKeyRequest{
 			Name: fmt.Sprintf("projects/%s/serviceAccounts/%s", projectID, serviceAccount.Email),
 		}
-		serviceAccountKey, err := iam.AdminV1.CreateServiceAccountKey(ctx, createServiceAccountKeyRequest)
+		var serviceAccountKey *adminpb.ServiceAccountKey
+		err := iam.Telemetry.Call(ctx, service, "CreateServiceAccountKey", projectID, serviceAccount.Name, func(ctx context.Context) error {
+			return iam.Retry.Mutating().Do(ctx, func() (err error) {
+				serviceAccountKey, err = iam.AdminV1.CreateServiceAccountKey(ctx, createServiceAccountKeyRequest, noClientRetry)
+				return err
+			})
+		})
 		if err != nil {
 			return err
 		}
@@ -111,18 +184,38 @@ func (iam *IAM) EnsureServiceAccount(projectID string, serviceAccount *ServiceAc
 
 // DeleteServiceAccount will remove a service account from a project
 func (iam *IAM) DeleteServiceAccount(projectID string, serviceAccountName string) error {
+	return iam.DeleteServiceAccountCtx(context.Background(), projectID, serviceAccountName)
+}
+
+// DeleteServiceAccountCtx is DeleteServiceAccount, using the provided context for the underlying api calls
+func (iam *IAM) DeleteServiceAccountCtx(ctx context.Context, projectID string, serviceAccountName string) (err error) {
+	defer events.Start(iam.Events, service, "DeleteServiceAccount", events.ActionDelete, projectID, serviceAccountName).Done(&err)
 	serviceAccount := &ServiceAccount{
 		Name: serviceAccountName,
 	}
 	serviceAccount.setEmail(projectID)
-	ctx := context.Background()
-	iam.log.Info(`Deleting service account %s in project %s`, serviceAccountName, projectID)
 	deleteServiceAccountRequest := &adminpb.DeleteServiceAccountRequest{
 		Name: fmt.Sprintf("projects/%s/serviceAccounts/%s", projectID, serviceAccount.Email),
 	}
-	err := iam.AdminV1.DeleteServiceAccount(ctx, deleteServiceAccountRequest)
+	var existing *adminpb.ServiceAccount
+	if iam.DryRun != nil {
+		// only a service account that exists would be deleted
+		if existing, err = iam.getServiceAccount(ctx, projectID, serviceAccount); err != nil || existing == nil {
+			return err
+		}
+	}
+	if iam.DryRun.Record(plan.Change{Service: service, Method: "DeleteServiceAccount", Project: projectID, Resource: serviceAccount.Email, Action: plan.ActionDelete, Before: existing}) {
+		iam.log.Info(`Service account %s in project %s would be deleted`, serviceAccountName, projectID)
+		return nil
+	}
+	iam.log.Info(`Deleting service account %s in project %s`, serviceAccountName, projectID)
+	err = iam.Telemetry.Call(ctx, service, "DeleteServiceAccount", projectID, serviceAccountName, func(ctx context.Context) error {
+		return iam.Retry.Mutating().Do(ctx, func() error {
+			return iam.AdminV1.DeleteServiceAccount(ctx, deleteServiceAccountRequest, noClientRetry)
+		})
+	})
 	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "notfound") {
+		if googleerrors.IsNotFound(err) {
 			return nil
 		}
 		return err
@@ -130,6 +223,35 @@ func (iam *IAM) DeleteServiceAccount(projectID string, serviceAccountName string
 	return nil
 }
 
+// Close will release the underlying api client's connections
+func (iam *IAM) Close() error {
+	if closer, ok := iam.AdminV1.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// getServiceAccount will return the service account, nil if it doesn't exist
+func (iam *IAM) getServiceAccount(ctx context.Context, projectID string, serviceAccount *ServiceAccount) (*adminpb.ServiceAccount, error) {
+	getServiceAccountRequest := &adminpb.GetServiceAccountRequest{
+		Name: fmt.Sprintf("projects/%s/serviceAccounts/%s", projectID, serviceAccount.Email),
+	}
+	var existing *adminpb.ServiceAccount
+	err := iam.Telemetry.Call(ctx, service, "GetServiceAccount", projectID, serviceAccount.Name, func(ctx context.Context) error {
+		return iam.Retry.Do(ctx, func() (err error) {
+			existing, err = iam.AdminV1.GetServiceAccount(ctx, getServiceAccountRequest, noClientRetry)
+			return err
+		})
+	})
+	if err != nil {
+		if googleerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return existing, nil
+}
+
 func (serviceAccount *ServiceAccount) setEmail(projectID string) {
 	serviceAccount.Email = fmt.Sprintf("%s@%s.iam.gserviceaccount.com", serviceAccount.Name, projectID)
 }
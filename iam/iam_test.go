@@ -2,13 +2,15 @@ package iam
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"testing"
 
 	gax "github.com/googleapis/gax-go/v2"
+	"github.com/rockholla/go-google-lib/plan"
 	loggermock "github.com/rockholla/go-lib/mocks/custom-mocks/logger"
 	adminpb "google.golang.org/genproto/googleapis/iam/admin/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -28,6 +30,7 @@ const (
 
 var (
 	triggerNotFound = false
+	deletions       = 0
 )
 
 type adminV1Mock struct{}
@@ -35,7 +38,7 @@ type adminV1Mock struct{}
 func (mock *adminV1Mock) GetServiceAccount(ctx context.Context, req *adminpb.GetServiceAccountRequest, opts ...gax.CallOption) (*adminpb.ServiceAccount, error) {
 	if triggerNotFound {
 		triggerNotFound = false
-		return &adminpb.ServiceAccount{}, errors.New("notfound")
+		return &adminpb.ServiceAccount{}, status.Error(codes.NotFound, "notfound")
 	}
 	return &adminpb.ServiceAccount{
 		Name:        testServiceAccountFullName,
@@ -64,8 +67,9 @@ func (mock *adminV1Mock) CreateServiceAccountKey(ctx context.Context, req *admin
 func (mock *adminV1Mock) DeleteServiceAccount(ctx context.Context, req *adminpb.DeleteServiceAccountRequest, opts ...gax.CallOption) error {
 	if triggerNotFound {
 		triggerNotFound = false
-		return errors.New("notfound")
+		return status.Error(codes.NotFound, "notfound")
 	}
+	deletions++
 	return nil
 }
 
@@ -186,3 +190,37 @@ func TestDeleteServiceAccountNotFound(t *testing.T) {
 		t.Errorf("Got unexpected error from DeleteServiceAccount(): %s", err)
 	}
 }
+
+func TestDeleteServiceAccountDryRun(t *testing.T) {
+	iam := &IAM{}
+	err := iam.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during iam.Initialize() with blank credentials: %s", err)
+	}
+	setMocks(iam)
+	iam.DryRun = plan.New()
+	deletions = 0
+	err = iam.DeleteServiceAccount("project", "service-account")
+	if err != nil {
+		t.Errorf("Got unexpected error from DeleteServiceAccount() in a dry run: %s", err)
+	}
+	changes := iam.DryRun.Changes()
+	if deletions != 0 || len(changes) != 1 || changes[0].Action != plan.ActionDelete || changes[0].Before == nil {
+		t.Errorf("Expected a dry run DeleteServiceAccount() to plan the deletion of the existing service account without deleting it, got %v", changes)
+	}
+	triggerNotFound = true
+	iam.DryRun = plan.New()
+	err = iam.DeleteServiceAccount("project", "service-account")
+	if err != nil || len(iam.DryRun.Changes()) != 0 {
+		t.Errorf("Expected a dry run DeleteServiceAccount() to plan nothing for a service account that doesn't exist, got %v", err)
+	}
+}
+
+func TestInitializeEndpoint(t *testing.T) {
+	iam := &IAM{Endpoint: "localhost:9090", Insecure: true}
+	err := iam.Initialize(testCredentials, loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during iam.Initialize() with an insecure endpoint: %s", err)
+	}
+	iam.Close()
+}
@@ -0,0 +1,355 @@
+// Package iampolicy is the library for changing the iam policies of google cloud resources the same way regardless of
+// the resource, e.g. projects, folders, organizations, billing accounts and buckets: reading the policy, applying
+// mutations to it and setting it only when they changed it, starting over from a fresh read when the set conflicts
+// with a concurrent change to the policy.
+package iampolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+)
+
+// PolicyVersion is the version policies are read with, and set with when they have conditions, the version
+// supporting conditional bindings. Reading with an older version would leave conditions out of the policy, so that
+// setting it would lose them.
+const PolicyVersion = 3
+
+// DefaultRetryPolicy will return the policy for retrying conflicting updates used by all libraries when none is
+// provided: up to 5 attempts over at most 1 minute, starting at 500 milliseconds between attempts and doubling up to
+// 8 seconds, jittered so that concurrent updaters don't conflict again
+func DefaultRetryPolicy() *retry.Policy {
+	return &retry.Policy{
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     8 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+		MaxAttempts:    5,
+		MaxElapsed:     1 * time.Minute,
+	}
+}
+
+// Condition is an iam condition, limiting when the members of a binding are granted its role
+type Condition struct {
+	Title       string `yaml:"title,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Expression  string `yaml:"expression"`
+}
+
+// String will return a description of the condition, e.g. "if request.time < timestamp('2030-01-01T00:00:00Z')
+// (title)"
+func (c *Condition) String() string {
+	if c.Title == "" {
+		return fmt.Sprintf("if %s", c.Expression)
+	}
+	return fmt.Sprintf("if %s (%s)", c.Expression, c.Title)
+}
+
+// Binding is the members granted a role, when its condition is met if it has one
+type Binding struct {
+	Role      string
+	Members   []string
+	Condition *Condition
+}
+
+// Policy is an iam policy, independent of the api of the resource it belongs to. The etag is sent back when it's set,
+// so that the set fails if the policy changed since it was read.
+type Policy struct {
+	Version  int64
+	Etag     string
+	Bindings []*Binding
+}
+
+// Resource is a resource with an iam policy, e.g. a project or bucket
+type Resource interface {
+	GetPolicy(ctx context.Context) (*Policy, error)
+	SetPolicy(ctx context.Context, policy *Policy) error
+}
+
+// Mutation is a change to a policy
+type Mutation func(policy *Policy)
+
+// Result is the policy before and after an update
+type Result struct {
+	Before *Policy
+	After  *Policy
+}
+
+// Updater updates the policies of resources
+type Updater struct {
+	// Retry is how an update whose set conflicts with a concurrent change to the policy, its etag no longer matching,
+	// is retried from a fresh read. Only conflicts are retried, regardless of the policy's Retryable, the underlying
+	// calls retry their own transient errors. Each update is made once when nil.
+	Retry *retry.Policy
+	// DryRun reads and mutates policies without setting them, when set
+	DryRun bool
+}
+
+// Update will read the policy of the resource, apply the mutations to it, in order, and set it if they changed it.
+// A set conflicting with a concurrent change to the policy is started over from a fresh read, reapplying the
+// mutations to it, within the updater's retry policy.
+func (u *Updater) Update(ctx context.Context, resource Resource, mutations ...Mutation) (*Result, error) {
+	var conflicts *retry.Policy
+	if u.Retry != nil {
+		copied := *u.Retry
+		copied.Retryable = googleerrors.IsConflict
+		conflicts = &copied
+	}
+	var result *Result
+	err := conflicts.Do(ctx, func() (err error) {
+		result, err = u.update(ctx, resource, mutations)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (u *Updater) update(ctx context.Context, resource Resource, mutations []Mutation) (*Result, error) {
+	before, err := resource.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	after := before.Copy()
+	for _, mutation := range mutations {
+		mutation(after)
+	}
+	result := &Result{Before: before, After: after}
+	if !result.Changed() || u.DryRun {
+		return result, nil
+	}
+	if after.hasConditions() {
+		after.Version = PolicyVersion
+	}
+	if err := resource.SetPolicy(ctx, after); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Changed will return whether the members of any role differ before and after
+func (r *Result) Changed() bool {
+	return len(plan.RolesChanges(plan.Change{}, r.Before.Roles(), r.After.Roles())) > 0
+}
+
+// Changes will return the change with the roles whose members differ before and after, or no changes at all when
+// none differ
+func (r *Result) Changes(change plan.Change) []plan.Change {
+	return plan.RolesChanges(change, r.Before.Roles(), r.After.Roles())
+}
+
+// Removed will return the roles the member was removed from, described as they are by Roles, sorted
+func (r *Result) Removed(member string) []string {
+	removed := []string{}
+	after := r.After.Roles()
+	for role, members := range r.Before.Roles() {
+		if contains(members, member) && !contains(after[role], member) {
+			removed = append(removed, role)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// Diff will return a line for each member granted or removed from a role, e.g. "+ roles/viewer user:someone", sorted
+// by role, then removals before grants
+func (r *Result) Diff() []string {
+	before := r.Before.Roles()
+	after := r.After.Roles()
+	roles := []string{}
+	for role := range merge(before, after) {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	diff := []string{}
+	for _, role := range roles {
+		for _, member := range sorted(before[role]) {
+			if !contains(after[role], member) {
+				diff = append(diff, fmt.Sprintf("- %s %s", role, member))
+			}
+		}
+		for _, member := range sorted(after[role]) {
+			if !contains(before[role], member) {
+				diff = append(diff, fmt.Sprintf("+ %s %s", role, member))
+			}
+		}
+	}
+	return diff
+}
+
+// AddMember will return a mutation granting the member the role, with the condition if not nil
+func AddMember(role string, member string, condition *Condition) Mutation {
+	return func(policy *Policy) {
+		binding := policy.Binding(role, condition)
+		if binding == nil {
+			policy.Bindings = append(policy.Bindings, &Binding{Role: role, Members: []string{member}, Condition: condition.copy()})
+			return
+		}
+		if !contains(binding.Members, member) {
+			binding.Members = append(binding.Members, member)
+		}
+	}
+}
+
+// RemoveMember will return a mutation removing the member from the role, with the condition if not nil, removing
+// the binding altogether once it has no members
+func RemoveMember(role string, member string, condition *Condition) Mutation {
+	return func(policy *Policy) {
+		binding := policy.Binding(role, condition)
+		if binding == nil {
+			return
+		}
+		members := []string{}
+		for _, bindingMember := range binding.Members {
+			if bindingMember != member {
+				members = append(members, bindingMember)
+			}
+		}
+		binding.Members = members
+		policy.removeEmpty()
+	}
+}
+
+// RemoveMemberFromAll will return a mutation removing the member from every role it's granted, with or without a
+// condition, removing the bindings left without members
+func RemoveMemberFromAll(member string) Mutation {
+	return func(policy *Policy) {
+		for _, binding := range policy.Bindings {
+			RemoveMember(binding.Role, member, binding.Condition)(policy)
+		}
+	}
+}
+
+// SetMembers will return a mutation granting the role, with the condition if not nil, to exactly the members,
+// removing any others, and removing the binding altogether when there are no members
+func SetMembers(role string, members []string, condition *Condition) Mutation {
+	return func(policy *Policy) {
+		binding := policy.Binding(role, condition)
+		if binding == nil {
+			binding = &Binding{Role: role, Condition: condition.copy()}
+			policy.Bindings = append(policy.Bindings, binding)
+		}
+		binding.Members = []string{}
+		for _, member := range members {
+			if !contains(binding.Members, member) {
+				binding.Members = append(binding.Members, member)
+			}
+		}
+		policy.removeEmpty()
+	}
+}
+
+// Binding will return the binding of the role with the condition, nil meaning the binding without one, or nil if
+// there's none
+func (p *Policy) Binding(role string, condition *Condition) *Binding {
+	for _, binding := range p.Bindings {
+		if binding.Role == role && binding.Condition.equal(condition) {
+			return binding
+		}
+	}
+	return nil
+}
+
+// HasMember will return whether the member is granted the role with the condition, nil meaning without one
+func (p *Policy) HasMember(role string, member string, condition *Condition) bool {
+	binding := p.Binding(role, condition)
+	return binding != nil && contains(binding.Members, member)
+}
+
+// Roles will return the members granted each role, the roles of conditional bindings being described along with
+// their condition, e.g. "roles/viewer if request.time < timestamp('2030-01-01T00:00:00Z') (title)"
+func (p *Policy) Roles() plan.Roles {
+	roles := plan.Roles{}
+	if p == nil {
+		return roles
+	}
+	for _, binding := range p.Bindings {
+		key := binding.Role
+		if binding.Condition != nil {
+			key = fmt.Sprintf("%s %s", key, binding.Condition)
+		}
+		roles[key] = append(roles[key], binding.Members...)
+	}
+	return roles
+}
+
+// Copy will return a deep copy of the policy, so that mutating it leaves the original as it was
+func (p *Policy) Copy() *Policy {
+	if p == nil {
+		return &Policy{}
+	}
+	c := &Policy{Version: p.Version, Etag: p.Etag}
+	for _, binding := range p.Bindings {
+		c.Bindings = append(c.Bindings, &Binding{
+			Role:      binding.Role,
+			Members:   append([]string{}, binding.Members...),
+			Condition: binding.Condition.copy(),
+		})
+	}
+	return c
+}
+
+func (p *Policy) hasConditions() bool {
+	for _, binding := range p.Bindings {
+		if binding.Condition != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) removeEmpty() {
+	bindings := []*Binding{}
+	for _, binding := range p.Bindings {
+		if len(binding.Members) > 0 {
+			bindings = append(bindings, binding)
+		}
+	}
+	p.Bindings = bindings
+}
+
+func (c *Condition) equal(other *Condition) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return *c == *other
+}
+
+func (c *Condition) copy() *Condition {
+	if c == nil {
+		return nil
+	}
+	copied := *c
+	return &copied
+}
+
+func merge(roles ...plan.Roles) map[string]bool {
+	merged := map[string]bool{}
+	for _, r := range roles {
+		for role := range r {
+			merged[role] = true
+		}
+	}
+	return merged
+}
+
+func sorted(members []string) []string {
+	members = append([]string{}, members...)
+	sort.Strings(members)
+	return members
+}
+
+func contains(members []string, member string) bool {
+	for _, m := range members {
+		if m == member {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package iampolicy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rockholla/go-google-lib/retry"
+	"google.golang.org/api/googleapi"
+)
+
+// fakeClock is a clock that doesn't actually wait
+type fakeClock struct {
+	now    time.Time
+	sleeps int
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.sleeps++
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func testRetryPolicy(clock *fakeClock) *retry.Policy {
+	policy := DefaultRetryPolicy()
+	policy.Clock = clock
+	return policy
+}
+
+// fakeResource is a resource whose policy changes underneath the updater, and fails to set, as many times as
+// there are concurrent changes
+type fakeResource struct {
+	policy     *Policy
+	concurrent []Mutation
+	gets       int
+	sets       int
+}
+
+func (f *fakeResource) GetPolicy(ctx context.Context) (*Policy, error) {
+	f.gets++
+	return f.policy.Copy(), nil
+}
+
+func (f *fakeResource) SetPolicy(ctx context.Context, policy *Policy) error {
+	f.sets++
+	if len(f.concurrent) > 0 {
+		f.concurrent[0](f.policy)
+		f.concurrent = f.concurrent[1:]
+		f.policy.Etag += "1"
+		return &googleapi.Error{Code: http.StatusConflict}
+	}
+	if policy.Etag != f.policy.Etag {
+		return errors.New("stale etag")
+	}
+	f.policy = policy.Copy()
+	return nil
+}
+
+func TestUpdate(t *testing.T) {
+	resource := &fakeResource{policy: &Policy{Etag: "etag", Bindings: []*Binding{
+		{Role: "role1", Members: []string{"a"}},
+		{Role: "role2", Members: []string{"a", "b"}},
+	}}}
+	result, err := (&Updater{}).Update(context.Background(), resource,
+		AddMember("role1", "b", nil),
+		RemoveMember("role2", "a", nil),
+		SetMembers("role3", []string{"c", "c"}, nil),
+	)
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.Updater.Update(): %s", err)
+	}
+	expected := map[string][]string{"role1": {"a", "b"}, "role2": {"b"}, "role3": {"c"}}
+	for role, members := range expected {
+		if !reflect.DeepEqual(resource.policy.Roles()[role], members) {
+			t.Errorf("Expected members %v for %s after iampolicy.Updater.Update(), got %v", members, role, resource.policy.Roles()[role])
+		}
+	}
+	if !result.Changed() || len(result.Before.Bindings) != 2 {
+		t.Errorf("Expected iampolicy.Updater.Update() to return the unmutated policy before and a change")
+	}
+}
+
+func TestUpdateUnchanged(t *testing.T) {
+	resource := &fakeResource{policy: &Policy{Bindings: []*Binding{{Role: "role1", Members: []string{"a"}}}}}
+	result, err := (&Updater{}).Update(context.Background(), resource, AddMember("role1", "a", nil), RemoveMember("role2", "a", nil))
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.Updater.Update(): %s", err)
+	}
+	if resource.sets != 0 || result.Changed() {
+		t.Errorf("Expected iampolicy.Updater.Update() not to set a policy that the mutations didn't change")
+	}
+}
+
+func TestUpdateDryRun(t *testing.T) {
+	resource := &fakeResource{policy: &Policy{}}
+	result, err := (&Updater{DryRun: true}).Update(context.Background(), resource, AddMember("role1", "a", nil))
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.Updater.Update(): %s", err)
+	}
+	if resource.sets != 0 || !result.Changed() || !result.After.HasMember("role1", "a", nil) {
+		t.Errorf("Expected a dry run iampolicy.Updater.Update() to return the change without setting it")
+	}
+}
+
+func TestUpdateConflict(t *testing.T) {
+	resource := &fakeResource{
+		policy:     &Policy{Etag: "etag"},
+		concurrent: []Mutation{AddMember("role1", "concurrent", nil)},
+	}
+	clock := &fakeClock{}
+	_, err := (&Updater{Retry: testRetryPolicy(clock)}).Update(context.Background(), resource, AddMember("role1", "a", nil))
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.Updater.Update() with a conflict: %s", err)
+	}
+	if clock.sleeps != 1 {
+		t.Errorf("Expected iampolicy.Updater.Update() to back off once before retrying a conflict, got %d", clock.sleeps)
+	}
+	if resource.gets != 2 || !resource.policy.HasMember("role1", "a", nil) || !resource.policy.HasMember("role1", "concurrent", nil) {
+		t.Errorf("Expected iampolicy.Updater.Update() to reapply the mutations to a fresh read after a conflict")
+	}
+}
+
+func TestUpdateConflictAttempts(t *testing.T) {
+	resource := &fakeResource{policy: &Policy{}}
+	for i := 0; i < 3; i++ {
+		resource.concurrent = append(resource.concurrent, AddMember("role1", "concurrent", nil))
+	}
+	policy := testRetryPolicy(&fakeClock{})
+	policy.MaxAttempts = 2
+	_, err := (&Updater{Retry: policy}).Update(context.Background(), resource, AddMember("role1", "a", nil))
+	if err == nil || resource.sets != 2 {
+		t.Errorf("Expected iampolicy.Updater.Update() to give up on conflicts after its attempts, got %d sets", resource.sets)
+	}
+}
+
+func TestUpdateConflictWithoutRetry(t *testing.T) {
+	resource := &fakeResource{
+		policy:     &Policy{},
+		concurrent: []Mutation{AddMember("role1", "concurrent", nil)},
+	}
+	_, err := (&Updater{}).Update(context.Background(), resource, AddMember("role1", "a", nil))
+	if err == nil || resource.sets != 1 {
+		t.Errorf("Expected iampolicy.Updater.Update() without a retry policy to update once, got %d sets", resource.sets)
+	}
+}
+
+func TestUpdateOnlyRetriesConflicts(t *testing.T) {
+	resource := &erroringResource{err: &googleapi.Error{Code: http.StatusServiceUnavailable}}
+	_, err := (&Updater{Retry: testRetryPolicy(&fakeClock{})}).Update(context.Background(), resource, AddMember("role1", "a", nil))
+	if err == nil || resource.gets != 1 {
+		t.Errorf("Expected iampolicy.Updater.Update() to leave errors other than conflicts to the underlying calls, got %d reads", resource.gets)
+	}
+}
+
+// erroringResource is a resource whose policy can't be read
+type erroringResource struct {
+	err  error
+	gets int
+}
+
+func (e *erroringResource) GetPolicy(ctx context.Context) (*Policy, error) {
+	e.gets++
+	return nil, e.err
+}
+
+func (e *erroringResource) SetPolicy(ctx context.Context, policy *Policy) error {
+	return nil
+}
+
+func TestUpdateConditions(t *testing.T) {
+	condition := &Condition{Title: "expires", Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"}
+	resource := &fakeResource{policy: &Policy{Version: 1, Bindings: []*Binding{{Role: "role1", Members: []string{"a"}}}}}
+	_, err := (&Updater{}).Update(context.Background(), resource, AddMember("role1", "b", condition))
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.Updater.Update(): %s", err)
+	}
+	if resource.policy.Version != 3 {
+		t.Errorf("Expected iampolicy.Updater.Update() to set a policy with conditions as version 3, got %d", resource.policy.Version)
+	}
+	if resource.policy.HasMember("role1", "b", nil) || !resource.policy.HasMember("role1", "b", condition) || !resource.policy.HasMember("role1", "a", nil) {
+		t.Errorf("Expected iampolicy.Updater.Update() to grant the role with the condition in its own binding")
+	}
+	expectedRole := "role1 if request.time < timestamp('2030-01-01T00:00:00Z') (expires)"
+	if members := resource.policy.Roles()[expectedRole]; !reflect.DeepEqual(members, []string{"b"}) {
+		t.Errorf("Expected the conditional binding as %q in iampolicy.Policy.Roles(), got %v", expectedRole, resource.policy.Roles())
+	}
+}
+
+func TestUpdateRemoveMemberFromAll(t *testing.T) {
+	condition := &Condition{Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"}
+	resource := &fakeResource{policy: &Policy{Bindings: []*Binding{
+		{Role: "role1", Members: []string{"a", "b"}},
+		{Role: "role2", Members: []string{"a"}},
+		{Role: "role2", Members: []string{"a"}, Condition: condition},
+		{Role: "role3", Members: []string{"b"}},
+	}}}
+	result, err := (&Updater{}).Update(context.Background(), resource, RemoveMemberFromAll("a"))
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.Updater.Update(): %s", err)
+	}
+	expected := []string{"role1", "role2", "role2 if request.time < timestamp('2030-01-01T00:00:00Z')"}
+	if removed := result.Removed("a"); !reflect.DeepEqual(removed, expected) {
+		t.Errorf("Expected the member removed from %v by iampolicy.RemoveMemberFromAll(), got %v", expected, removed)
+	}
+	if len(resource.policy.Bindings) != 2 || resource.policy.HasMember("role1", "a", nil) {
+		t.Errorf("Expected iampolicy.RemoveMemberFromAll() to leave only the bindings of other members, got %v", resource.policy.Roles())
+	}
+}
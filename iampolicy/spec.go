@@ -0,0 +1,103 @@
+package iampolicy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Spec is the desired bindings of an iam policy, e.g. declared in yaml as:
+//
+//	bindings:
+//	- role: roles/owner
+//	  authoritative: true
+//	  members:
+//	  - group:admins@example.com
+//	- role: roles/viewer
+//	  members:
+//	  - user:someone@example.com
+//	  condition:
+//	    title: expires
+//	    expression: request.time < timestamp('2030-01-01T00:00:00Z')
+//
+// The members of each binding are granted its role, with its condition if it has one. Other members of the role are
+// left as they are, unless the binding is authoritative, in which case they're removed from the role with that
+// condition. Roles not in the spec are left as they are.
+type Spec struct {
+	Bindings []*SpecBinding `yaml:"bindings"`
+}
+
+// SpecBinding is the desired members of a role, with its condition if not nil
+type SpecBinding struct {
+	Role          string     `yaml:"role"`
+	Members       []string   `yaml:"members,omitempty"`
+	Condition     *Condition `yaml:"condition,omitempty"`
+	Authoritative bool       `yaml:"authoritative,omitempty"`
+}
+
+// LoadSpec will read and parse the spec in the yaml file at the path
+func LoadSpec(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := ParseSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading iam policy spec %s: %s", path, err)
+	}
+	return spec, nil
+}
+
+// ParseSpec will parse the yaml spec, failing on unknown fields so that typos such as "authorative" don't go unnoticed
+func ParseSpec(data []byte) (*Spec, error) {
+	spec := &Spec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Validate will return an error if there's no spec, a binding has no role or a condition without an expression, or
+// there's more than one binding for the same role and condition
+func (s *Spec) Validate() error {
+	if s == nil {
+		return fmt.Errorf("no iam policy spec")
+	}
+	seen := map[string]bool{}
+	for i, binding := range s.Bindings {
+		if binding.Role == "" {
+			return fmt.Errorf("binding %d of the iam policy spec has no role", i)
+		}
+		key := binding.Role
+		if binding.Condition != nil {
+			if binding.Condition.Expression == "" {
+				return fmt.Errorf("the condition of %s in the iam policy spec has no expression", binding.Role)
+			}
+			key = fmt.Sprintf("%s %s", key, binding.Condition)
+		}
+		if seen[key] {
+			return fmt.Errorf("%s is in the iam policy spec more than once", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Mutations will return the mutations bringing a policy in line with the spec
+func (s *Spec) Mutations() []Mutation {
+	mutations := []Mutation{}
+	for _, binding := range s.Bindings {
+		if binding.Authoritative {
+			mutations = append(mutations, SetMembers(binding.Role, binding.Members, binding.Condition))
+			continue
+		}
+		for _, member := range binding.Members {
+			mutations = append(mutations, AddMember(binding.Role, member, binding.Condition))
+		}
+	}
+	return mutations
+}
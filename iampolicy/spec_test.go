@@ -0,0 +1,86 @@
+package iampolicy
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testSpec = `
+bindings:
+- role: roles/owner
+  authoritative: true
+  members:
+  - group:admins@go-google-lib.tests
+- role: roles/viewer
+  members:
+  - user:someone@go-google-lib.tests
+- role: roles/viewer
+  members:
+  - user:temporary@go-google-lib.tests
+  condition:
+    title: expires
+    expression: request.time < timestamp('2030-01-01T00:00:00Z')
+`
+
+func TestLoadSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := ioutil.WriteFile(path, []byte(testSpec), 0600); err != nil {
+		t.Fatalf("Got unexpected error writing the test spec: %s", err)
+	}
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.LoadSpec(): %s", err)
+	}
+	if len(spec.Bindings) != 3 || !spec.Bindings[0].Authoritative || spec.Bindings[1].Authoritative {
+		t.Fatalf("Expected 3 bindings, only the first authoritative, from iampolicy.LoadSpec(), got %v", spec.Bindings)
+	}
+	condition := spec.Bindings[2].Condition
+	if condition == nil || condition.Title != "expires" || condition.Expression != "request.time < timestamp('2030-01-01T00:00:00Z')" {
+		t.Errorf("Expected the condition of the last binding from iampolicy.LoadSpec(), got %v", condition)
+	}
+}
+
+func TestParseSpecInvalid(t *testing.T) {
+	invalid := map[string]string{
+		"unknown field":  "bindings:\n- role: roles/owner\n  authorative: true\n",
+		"no role":        "bindings:\n- members:\n  - user:someone@go-google-lib.tests\n",
+		"duplicate role": "bindings:\n- role: roles/owner\n- role: roles/owner\n",
+		"no expression":  "bindings:\n- role: roles/owner\n  condition:\n    title: expires\n",
+	}
+	for name, spec := range invalid {
+		if _, err := ParseSpec([]byte(spec)); err == nil {
+			t.Errorf("Expected an error from iampolicy.ParseSpec() for a spec with %s", name)
+		}
+	}
+}
+
+func TestSpecMutations(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.ParseSpec(): %s", err)
+	}
+	resource := &fakeResource{policy: &Policy{Bindings: []*Binding{
+		{Role: "roles/owner", Members: []string{"user:unmanaged@go-google-lib.tests", "group:admins@go-google-lib.tests"}},
+		{Role: "roles/viewer", Members: []string{"user:unmanaged@go-google-lib.tests"}},
+		{Role: "roles/editor", Members: []string{"user:unmanaged@go-google-lib.tests"}},
+	}}}
+	result, err := (&Updater{}).Update(context.Background(), resource, spec.Mutations()...)
+	if err != nil {
+		t.Fatalf("Got unexpected error during iampolicy.Updater.Update() with the mutations of a spec: %s", err)
+	}
+	expected := []string{
+		"- roles/owner user:unmanaged@go-google-lib.tests",
+		"+ roles/viewer user:someone@go-google-lib.tests",
+		"+ roles/viewer if request.time < timestamp('2030-01-01T00:00:00Z') (expires) user:temporary@go-google-lib.tests",
+	}
+	diff := result.Diff()
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("Expected the unmanaged member removed only from the authoritative role in iampolicy.Result.Diff(), got %v", diff)
+	}
+	if !resource.policy.HasMember("roles/editor", "user:unmanaged@go-google-lib.tests", nil) {
+		t.Errorf("Expected the mutations of a spec to leave roles not in it as they are")
+	}
+}
@@ -0,0 +1,67 @@
+package google
+
+import (
+	"strings"
+	"sync"
+)
+
+// instances is a cache of initialized libraries keyed by the arguments they were initialized with, if any. It's
+// safe for concurrent use: callers asking for the same key share a single instance, initialized once, while
+// instances for different keys can be initialized at the same time. A failed initialization isn't cached, so it's
+// tried again on the next call.
+type instances struct {
+	mutex   sync.Mutex
+	entries map[string]*instance
+}
+
+type instance struct {
+	mutex sync.Mutex
+	value interface{}
+}
+
+// get will return the instance for a key, calling initialize to create it if there isn't one yet
+func (i *instances) get(key string, initialize func() (interface{}, error)) (interface{}, error) {
+	i.mutex.Lock()
+	if i.entries == nil {
+		i.entries = map[string]*instance{}
+	}
+	entry, ok := i.entries[key]
+	if !ok {
+		entry = &instance{}
+		i.entries[key] = entry
+	}
+	i.mutex.Unlock()
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	if entry.value == nil {
+		value, err := initialize()
+		if err != nil {
+			return value, err
+		}
+		entry.value = value
+	}
+	return entry.value, nil
+}
+
+// drain will return all initialized instances, removing them from the cache
+func (i *instances) drain() []interface{} {
+	i.mutex.Lock()
+	entries := i.entries
+	i.entries = nil
+	i.mutex.Unlock()
+	values := []interface{}{}
+	for _, entry := range entries {
+		entry.mutex.Lock()
+		if entry.value != nil {
+			values = append(values, entry.value)
+		}
+		entry.mutex.Unlock()
+	}
+	return values
+}
+
+// instanceKey will combine the arguments a library was initialized with into a single cache key
+func instanceKey(args ...string) string {
+	return strings.Join(args, "\x00")
+}
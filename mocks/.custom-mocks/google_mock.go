@@ -1,9 +1,13 @@
 package googlemock
 
 import (
+	"context"
+
+	google "github.com/rockholla/go-google-lib"
 	"github.com/rockholla/go-google-lib/admin"
 	"github.com/rockholla/go-google-lib/cloudbilling"
 	"github.com/rockholla/go-google-lib/cloudidentity"
+	"github.com/rockholla/go-google-lib/cloudkms"
 	"github.com/rockholla/go-google-lib/cloudresourcemanager"
 	"github.com/rockholla/go-google-lib/compute"
 	"github.com/rockholla/go-google-lib/deploymentmanager"
@@ -12,6 +16,7 @@ import (
 	adminmock "github.com/rockholla/go-google-lib/mocks/admin"
 	cloudbillingmock "github.com/rockholla/go-google-lib/mocks/cloudbilling"
 	cloudidentitymock "github.com/rockholla/go-google-lib/mocks/cloudidentity"
+	cloudkmsmock "github.com/rockholla/go-google-lib/mocks/cloudkms"
 	cloudresourcemanagermock "github.com/rockholla/go-google-lib/mocks/cloudresourcemanager"
 	computemock "github.com/rockholla/go-google-lib/mocks/compute"
 	deploymentmanagermock "github.com/rockholla/go-google-lib/mocks/deploymentmanager"
@@ -20,6 +25,7 @@ import (
 	oauthmock "github.com/rockholla/go-google-lib/mocks/oauth"
 	storagemock "github.com/rockholla/go-google-lib/mocks/storage"
 	"github.com/rockholla/go-google-lib/oauth"
+	"github.com/rockholla/go-google-lib/retry"
 	"github.com/rockholla/go-google-lib/storage"
 	"github.com/rockholla/go-lib/logger"
 )
@@ -45,57 +51,126 @@ type GoogleMock struct {
 	IAM                  *iammock.Interface
 	Storage              *storagemock.Interface
 	OAuth                *oauthmock.Interface
+	CloudKMS             *cloudkmsmock.Interface
 }
 
 // Initialize is a no-op in the mock
-func (m *GoogleMock) Initialize(credentials string, log logger.Interface) {}
+func (m *GoogleMock) Initialize(credentials string, log logger.Interface, opts ...google.Option) {}
+
+// SetRetryPolicy is a no-op in the mock
+func (m *GoogleMock) SetRetryPolicy(policy *retry.Policy) {}
+
+// Close is a no-op in the mock
+func (m *GoogleMock) Close() error {
+	return nil
+}
 
 // GetCloudResourceManager mock
 func (m *GoogleMock) GetCloudResourceManager() (cloudresourcemanager.Interface, error) {
 	return m.CloudResourceManager, nil
 }
 
+// GetCloudResourceManagerCtx mock
+func (m *GoogleMock) GetCloudResourceManagerCtx(ctx context.Context) (cloudresourcemanager.Interface, error) {
+	return m.CloudResourceManager, nil
+}
+
 // GetCloudBilling mock
 func (m *GoogleMock) GetCloudBilling() (cloudbilling.Interface, error) {
 	return m.CloudBilling, nil
 }
 
+// GetCloudBillingCtx mock
+func (m *GoogleMock) GetCloudBillingCtx(ctx context.Context) (cloudbilling.Interface, error) {
+	return m.CloudBilling, nil
+}
+
 // GetCloudIdentity mock
 func (m *GoogleMock) GetCloudIdentity(credentialsJSON string) (cloudidentity.Interface, error) {
 	return m.CloudIdentity, nil
 }
 
+// GetCloudIdentityCtx mock
+func (m *GoogleMock) GetCloudIdentityCtx(ctx context.Context, credentialsJSON string) (cloudidentity.Interface, error) {
+	return m.CloudIdentity, nil
+}
+
 // GetIAM mock
 func (m *GoogleMock) GetIAM() (iam.Interface, error) {
 	return m.IAM, nil
 }
 
+// GetIAMCtx mock
+func (m *GoogleMock) GetIAMCtx(ctx context.Context) (iam.Interface, error) {
+	return m.IAM, nil
+}
+
 // GetDeploymentManager mock
 func (m *GoogleMock) GetDeploymentManager() (deploymentmanager.Interface, error) {
 	return m.DeploymentManager, nil
 }
 
+// GetDeploymentManagerCtx mock
+func (m *GoogleMock) GetDeploymentManagerCtx(ctx context.Context) (deploymentmanager.Interface, error) {
+	return m.DeploymentManager, nil
+}
+
 // GetStorage mock
 func (m *GoogleMock) GetStorage() (storage.Interface, error) {
 	return m.Storage, nil
 }
 
+// GetStorageCtx mock
+func (m *GoogleMock) GetStorageCtx(ctx context.Context) (storage.Interface, error) {
+	return m.Storage, nil
+}
+
 // GetCompute mock
 func (m *GoogleMock) GetCompute() (compute.Interface, error) {
 	return m.Compute, nil
 }
 
+// GetComputeCtx mock
+func (m *GoogleMock) GetComputeCtx(ctx context.Context) (compute.Interface, error) {
+	return m.Compute, nil
+}
+
 // GetDNS mock
 func (m *GoogleMock) GetDNS() (dns.Interface, error) {
 	return m.DNS, nil
 }
 
+// GetDNSCtx mock
+func (m *GoogleMock) GetDNSCtx(ctx context.Context) (dns.Interface, error) {
+	return m.DNS, nil
+}
+
 // GetAdmin mock
 func (m *GoogleMock) GetAdmin(credentialsJSON string, domain string, adminUsername string) (admin.Interface, error) {
 	return m.Admin, nil
 }
 
+// GetAdminCtx mock
+func (m *GoogleMock) GetAdminCtx(ctx context.Context, credentialsJSON string, domain string, adminUsername string) (admin.Interface, error) {
+	return m.Admin, nil
+}
+
 // GetOAuth mock
 func (m *GoogleMock) GetOAuth(scopes []string) (oauth.Interface, error) {
 	return m.OAuth, nil
 }
+
+// GetOAuthCtx mock
+func (m *GoogleMock) GetOAuthCtx(ctx context.Context, scopes []string) (oauth.Interface, error) {
+	return m.OAuth, nil
+}
+
+// GetCloudKMS mock
+func (m *GoogleMock) GetCloudKMS() (cloudkms.Interface, error) {
+	return m.CloudKMS, nil
+}
+
+// GetCloudKMSCtx mock
+func (m *GoogleMock) GetCloudKMSCtx(ctx context.Context) (cloudkms.Interface, error) {
+	return m.CloudKMS, nil
+}
@@ -3,28 +3,23 @@
 package mocks
 
 import (
+	context "context"
+
+	google "github.com/rockholla/go-google-lib"
 	admin "github.com/rockholla/go-google-lib/admin"
 	cloudbilling "github.com/rockholla/go-google-lib/cloudbilling"
-
 	cloudidentity "github.com/rockholla/go-google-lib/cloudidentity"
-
+	cloudkms "github.com/rockholla/go-google-lib/cloudkms"
 	cloudresourcemanager "github.com/rockholla/go-google-lib/cloudresourcemanager"
-
 	compute "github.com/rockholla/go-google-lib/compute"
-
 	deploymentmanager "github.com/rockholla/go-google-lib/deploymentmanager"
-
 	dns "github.com/rockholla/go-google-lib/dns"
-
 	iam "github.com/rockholla/go-google-lib/iam"
-
-	logger "github.com/rockholla/go-lib/logger"
-
-	mock "github.com/stretchr/testify/mock"
-
 	oauth "github.com/rockholla/go-google-lib/oauth"
-
+	retry "github.com/rockholla/go-google-lib/retry"
 	storage "github.com/rockholla/go-google-lib/storage"
+	logger "github.com/rockholla/go-lib/logger"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Interface is an autogenerated mock type for the Interface type
@@ -32,6 +27,20 @@ type Interface struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields:
+func (_m *Interface) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAdmin provides a mock function with given fields: credentialsJSON, domain, adminUsername
 func (_m *Interface) GetAdmin(credentialsJSON string, domain string, adminUsername string) (admin.Interface, error) {
 	ret := _m.Called(credentialsJSON, domain, adminUsername)
@@ -55,6 +64,29 @@ func (_m *Interface) GetAdmin(credentialsJSON string, domain string, adminUserna
 	return r0, r1
 }
 
+// GetAdminCtx provides a mock function with given fields: ctx, credentialsJSON, domain, adminUsername
+func (_m *Interface) GetAdminCtx(ctx context.Context, credentialsJSON string, domain string, adminUsername string) (admin.Interface, error) {
+	ret := _m.Called(ctx, credentialsJSON, domain, adminUsername)
+
+	var r0 admin.Interface
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) admin.Interface); ok {
+		r0 = rf(ctx, credentialsJSON, domain, adminUsername)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(admin.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, credentialsJSON, domain, adminUsername)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCloudBilling provides a mock function with given fields:
 func (_m *Interface) GetCloudBilling() (cloudbilling.Interface, error) {
 	ret := _m.Called()
@@ -78,6 +110,29 @@ func (_m *Interface) GetCloudBilling() (cloudbilling.Interface, error) {
 	return r0, r1
 }
 
+// GetCloudBillingCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetCloudBillingCtx(ctx context.Context) (cloudbilling.Interface, error) {
+	ret := _m.Called(ctx)
+
+	var r0 cloudbilling.Interface
+	if rf, ok := ret.Get(0).(func(context.Context) cloudbilling.Interface); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cloudbilling.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCloudIdentity provides a mock function with given fields: impersonateServiceAccountEmail
 func (_m *Interface) GetCloudIdentity(impersonateServiceAccountEmail string) (cloudidentity.Interface, error) {
 	ret := _m.Called(impersonateServiceAccountEmail)
@@ -101,6 +156,75 @@ func (_m *Interface) GetCloudIdentity(impersonateServiceAccountEmail string) (cl
 	return r0, r1
 }
 
+// GetCloudIdentityCtx provides a mock function with given fields: ctx, impersonateServiceAccountEmail
+func (_m *Interface) GetCloudIdentityCtx(ctx context.Context, impersonateServiceAccountEmail string) (cloudidentity.Interface, error) {
+	ret := _m.Called(ctx, impersonateServiceAccountEmail)
+
+	var r0 cloudidentity.Interface
+	if rf, ok := ret.Get(0).(func(context.Context, string) cloudidentity.Interface); ok {
+		r0 = rf(ctx, impersonateServiceAccountEmail)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cloudidentity.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, impersonateServiceAccountEmail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCloudKMS provides a mock function with given fields:
+func (_m *Interface) GetCloudKMS() (cloudkms.Interface, error) {
+	ret := _m.Called()
+
+	var r0 cloudkms.Interface
+	if rf, ok := ret.Get(0).(func() cloudkms.Interface); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cloudkms.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCloudKMSCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetCloudKMSCtx(ctx context.Context) (cloudkms.Interface, error) {
+	ret := _m.Called(ctx)
+
+	var r0 cloudkms.Interface
+	if rf, ok := ret.Get(0).(func(context.Context) cloudkms.Interface); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cloudkms.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCloudResourceManager provides a mock function with given fields:
 func (_m *Interface) GetCloudResourceManager() (cloudresourcemanager.Interface, error) {
 	ret := _m.Called()
@@ -124,6 +248,29 @@ func (_m *Interface) GetCloudResourceManager() (cloudresourcemanager.Interface,
 	return r0, r1
 }
 
+// GetCloudResourceManagerCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetCloudResourceManagerCtx(ctx context.Context) (cloudresourcemanager.Interface, error) {
+	ret := _m.Called(ctx)
+
+	var r0 cloudresourcemanager.Interface
+	if rf, ok := ret.Get(0).(func(context.Context) cloudresourcemanager.Interface); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cloudresourcemanager.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCompute provides a mock function with given fields:
 func (_m *Interface) GetCompute() (compute.Interface, error) {
 	ret := _m.Called()
@@ -147,6 +294,29 @@ func (_m *Interface) GetCompute() (compute.Interface, error) {
 	return r0, r1
 }
 
+// GetComputeCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetComputeCtx(ctx context.Context) (compute.Interface, error) {
+	ret := _m.Called(ctx)
+
+	var r0 compute.Interface
+	if rf, ok := ret.Get(0).(func(context.Context) compute.Interface); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(compute.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDNS provides a mock function with given fields:
 func (_m *Interface) GetDNS() (dns.Interface, error) {
 	ret := _m.Called()
@@ -170,6 +340,29 @@ func (_m *Interface) GetDNS() (dns.Interface, error) {
 	return r0, r1
 }
 
+// GetDNSCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetDNSCtx(ctx context.Context) (dns.Interface, error) {
+	ret := _m.Called(ctx)
+
+	var r0 dns.Interface
+	if rf, ok := ret.Get(0).(func(context.Context) dns.Interface); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dns.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeploymentManager provides a mock function with given fields:
 func (_m *Interface) GetDeploymentManager() (deploymentmanager.Interface, error) {
 	ret := _m.Called()
@@ -193,6 +386,29 @@ func (_m *Interface) GetDeploymentManager() (deploymentmanager.Interface, error)
 	return r0, r1
 }
 
+// GetDeploymentManagerCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetDeploymentManagerCtx(ctx context.Context) (deploymentmanager.Interface, error) {
+	ret := _m.Called(ctx)
+
+	var r0 deploymentmanager.Interface
+	if rf, ok := ret.Get(0).(func(context.Context) deploymentmanager.Interface); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(deploymentmanager.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetIAM provides a mock function with given fields:
 func (_m *Interface) GetIAM() (iam.Interface, error) {
 	ret := _m.Called()
@@ -216,6 +432,29 @@ func (_m *Interface) GetIAM() (iam.Interface, error) {
 	return r0, r1
 }
 
+// GetIAMCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetIAMCtx(ctx context.Context) (iam.Interface, error) {
+	ret := _m.Called(ctx)
+
+	var r0 iam.Interface
+	if rf, ok := ret.Get(0).(func(context.Context) iam.Interface); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iam.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOAuth provides a mock function with given fields: scopes
 func (_m *Interface) GetOAuth(scopes []string) (oauth.Interface, error) {
 	ret := _m.Called(scopes)
@@ -239,6 +478,29 @@ func (_m *Interface) GetOAuth(scopes []string) (oauth.Interface, error) {
 	return r0, r1
 }
 
+// GetOAuthCtx provides a mock function with given fields: ctx, scopes
+func (_m *Interface) GetOAuthCtx(ctx context.Context, scopes []string) (oauth.Interface, error) {
+	ret := _m.Called(ctx, scopes)
+
+	var r0 oauth.Interface
+	if rf, ok := ret.Get(0).(func(context.Context, []string) oauth.Interface); ok {
+		r0 = rf(ctx, scopes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(oauth.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, scopes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorage provides a mock function with given fields:
 func (_m *Interface) GetStorage() (storage.Interface, error) {
 	ret := _m.Called()
@@ -262,7 +524,42 @@ func (_m *Interface) GetStorage() (storage.Interface, error) {
 	return r0, r1
 }
 
-// Initialize provides a mock function with given fields: credentials, log
-func (_m *Interface) Initialize(credentials string, log logger.Interface) {
-	_m.Called(credentials, log)
+// GetStorageCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetStorageCtx(ctx context.Context) (storage.Interface, error) {
+	ret := _m.Called(ctx)
+
+	var r0 storage.Interface
+	if rf, ok := ret.Get(0).(func(context.Context) storage.Interface); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(storage.Interface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Initialize provides a mock function with given fields: credentials, log, opts
+func (_m *Interface) Initialize(credentials string, log logger.Interface, opts ...google.Option) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, credentials, log)
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// SetRetryPolicy provides a mock function with given fields: policy
+func (_m *Interface) SetRetryPolicy(policy *retry.Policy) {
+	_m.Called(policy)
 }
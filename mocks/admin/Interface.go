@@ -3,9 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	logger "github.com/rockholla/go-lib/logger"
 	mock "github.com/stretchr/testify/mock"
-
 	v1 "google.golang.org/api/admin/directory/v1"
 )
 
@@ -28,6 +29,20 @@ func (_m *Interface) DeleteGroup(name string) error {
 	return r0
 }
 
+// DeleteGroupCtx provides a mock function with given fields: ctx, name
+func (_m *Interface) DeleteGroupCtx(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteMembership provides a mock function with given fields: group, member
 func (_m *Interface) DeleteMembership(group string, member string) error {
 	ret := _m.Called(group, member)
@@ -42,6 +57,20 @@ func (_m *Interface) DeleteMembership(group string, member string) error {
 	return r0
 }
 
+// DeleteMembershipCtx provides a mock function with given fields: ctx, group, member
+func (_m *Interface) DeleteMembershipCtx(ctx context.Context, group string, member string) error {
+	ret := _m.Called(ctx, group, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, group, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnsureGroup provides a mock function with given fields: name, description
 func (_m *Interface) EnsureGroup(name string, description string) (*v1.Group, error) {
 	ret := _m.Called(name, description)
@@ -65,6 +94,29 @@ func (_m *Interface) EnsureGroup(name string, description string) (*v1.Group, er
 	return r0, r1
 }
 
+// EnsureGroupCtx provides a mock function with given fields: ctx, name, description
+func (_m *Interface) EnsureGroupCtx(ctx context.Context, name string, description string) (*v1.Group, error) {
+	ret := _m.Called(ctx, name, description)
+
+	var r0 *v1.Group
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.Group); ok {
+		r0 = rf(ctx, name, description)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EnsureMembership provides a mock function with given fields: group, member
 func (_m *Interface) EnsureMembership(group string, member string) (*v1.Member, error) {
 	ret := _m.Called(group, member)
@@ -88,6 +140,29 @@ func (_m *Interface) EnsureMembership(group string, member string) (*v1.Member,
 	return r0, r1
 }
 
+// EnsureMembershipCtx provides a mock function with given fields: ctx, group, member
+func (_m *Interface) EnsureMembershipCtx(ctx context.Context, group string, member string) (*v1.Member, error) {
+	ret := _m.Called(ctx, group, member)
+
+	var r0 *v1.Member
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.Member); ok {
+		r0 = rf(ctx, group, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Member)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, group, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Initialize provides a mock function with given fields: credentialsJSON, domain, adminUsername, log
 func (_m *Interface) Initialize(credentialsJSON string, domain string, adminUsername string, log logger.Interface) error {
 	ret := _m.Called(credentialsJSON, domain, adminUsername, log)
@@ -101,3 +176,17 @@ func (_m *Interface) Initialize(credentialsJSON string, domain string, adminUser
 
 	return r0
 }
+
+// InitializeCtx provides a mock function with given fields: ctx, credentialsJSON, domain, adminUsername, log
+func (_m *Interface) InitializeCtx(ctx context.Context, credentialsJSON string, domain string, adminUsername string, log logger.Interface) error {
+	ret := _m.Called(ctx, credentialsJSON, domain, adminUsername, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, logger.Interface) error); ok {
+		r0 = rf(ctx, credentialsJSON, domain, adminUsername, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -5,9 +5,10 @@ package mocks
 import (
 	admin "google.golang.org/api/admin/directory/v1"
 
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // GroupsDeleteCallInterface is an autogenerated mock type for the GroupsDeleteCallInterface type
@@ -15,20 +16,20 @@ type GroupsDeleteCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *GroupsDeleteCallInterface) Do(call *admin.GroupsDeleteCall, opts ...googleapi.CallOption) error {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *GroupsDeleteCallInterface) Do(ctx context.Context, call *admin.GroupsDeleteCall, opts ...googleapi.CallOption) error {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*admin.GroupsDeleteCall, ...googleapi.CallOption) error); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.GroupsDeleteCall, ...googleapi.CallOption) error); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	context "context"
+
+	iampolicy "github.com/rockholla/go-google-lib/iampolicy"
 	logger "github.com/rockholla/go-lib/logger"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -26,6 +29,48 @@ func (_m *Interface) EnsureRoles(billingAccount string, member string, roles []s
 	return r0
 }
 
+// EnsureRolesCtx provides a mock function with given fields: ctx, billingAccount, member, roles
+func (_m *Interface) EnsureRolesCtx(ctx context.Context, billingAccount string, member string, roles []string) error {
+	ret := _m.Called(ctx, billingAccount, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, billingAccount, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureRolesWithCondition provides a mock function with given fields: billingAccount, member, roles, condition
+func (_m *Interface) EnsureRolesWithCondition(billingAccount string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(billingAccount, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(billingAccount, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureRolesWithConditionCtx provides a mock function with given fields: ctx, billingAccount, member, roles, condition
+func (_m *Interface) EnsureRolesWithConditionCtx(ctx context.Context, billingAccount string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(ctx, billingAccount, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(ctx, billingAccount, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Initialize provides a mock function with given fields: credentials, log
 func (_m *Interface) Initialize(credentials string, log logger.Interface) error {
 	ret := _m.Called(credentials, log)
@@ -40,6 +85,20 @@ func (_m *Interface) Initialize(credentials string, log logger.Interface) error
 	return r0
 }
 
+// InitializeCtx provides a mock function with given fields: ctx, credentials, log
+func (_m *Interface) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	ret := _m.Called(ctx, credentials, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface) error); ok {
+		r0 = rf(ctx, credentials, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveRoles provides a mock function with given fields: billingAccount, member, roles
 func (_m *Interface) RemoveRoles(billingAccount string, member string, roles []string) error {
 	ret := _m.Called(billingAccount, member, roles)
@@ -54,6 +113,48 @@ func (_m *Interface) RemoveRoles(billingAccount string, member string, roles []s
 	return r0
 }
 
+// RemoveRolesCtx provides a mock function with given fields: ctx, billingAccount, member, roles
+func (_m *Interface) RemoveRolesCtx(ctx context.Context, billingAccount string, member string, roles []string) error {
+	ret := _m.Called(ctx, billingAccount, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, billingAccount, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveRolesWithCondition provides a mock function with given fields: billingAccount, member, roles, condition
+func (_m *Interface) RemoveRolesWithCondition(billingAccount string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(billingAccount, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(billingAccount, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveRolesWithConditionCtx provides a mock function with given fields: ctx, billingAccount, member, roles, condition
+func (_m *Interface) RemoveRolesWithConditionCtx(ctx context.Context, billingAccount string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(ctx, billingAccount, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(ctx, billingAccount, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetProjectBillingAccount provides a mock function with given fields: projectID, billingAccountID
 func (_m *Interface) SetProjectBillingAccount(projectID string, billingAccountID string) (string, error) {
 	ret := _m.Called(projectID, billingAccountID)
@@ -74,3 +175,24 @@ func (_m *Interface) SetProjectBillingAccount(projectID string, billingAccountID
 
 	return r0, r1
 }
+
+// SetProjectBillingAccountCtx provides a mock function with given fields: ctx, projectID, billingAccountID
+func (_m *Interface) SetProjectBillingAccountCtx(ctx context.Context, projectID string, billingAccountID string) (string, error) {
+	ret := _m.Called(ctx, projectID, billingAccountID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, projectID, billingAccountID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, projectID, billingAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	cloudbilling "google.golang.org/api/cloudbilling/v1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	cloudbilling "google.golang.org/api/cloudbilling/v1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // BillingAccountsSetIAMPolicyCallInterface is an autogenerated mock type for the BillingAccountsSetIAMPolicyCallInterface type
@@ -14,20 +15,20 @@ type BillingAccountsSetIAMPolicyCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *BillingAccountsSetIAMPolicyCallInterface) Do(call *cloudbilling.BillingAccountsSetIamPolicyCall, opts ...googleapi.CallOption) (*cloudbilling.Policy, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *BillingAccountsSetIAMPolicyCallInterface) Do(ctx context.Context, call *cloudbilling.BillingAccountsSetIamPolicyCall, opts ...googleapi.CallOption) (*cloudbilling.Policy, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *cloudbilling.Policy
-	if rf, ok := ret.Get(0).(func(*cloudbilling.BillingAccountsSetIamPolicyCall, ...googleapi.CallOption) *cloudbilling.Policy); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudbilling.BillingAccountsSetIamPolicyCall, ...googleapi.CallOption) *cloudbilling.Policy); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*cloudbilling.Policy)
@@ -35,8 +36,8 @@ func (_m *BillingAccountsSetIAMPolicyCallInterface) Do(call *cloudbilling.Billin
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*cloudbilling.BillingAccountsSetIamPolicyCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudbilling.BillingAccountsSetIamPolicyCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	cloudbilling "google.golang.org/api/cloudbilling/v1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	cloudbilling "google.golang.org/api/cloudbilling/v1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // ProjectsUpdateBillingInfoCallInterface is an autogenerated mock type for the ProjectsUpdateBillingInfoCallInterface type
@@ -14,20 +15,20 @@ type ProjectsUpdateBillingInfoCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *ProjectsUpdateBillingInfoCallInterface) Do(call *cloudbilling.ProjectsUpdateBillingInfoCall, opts ...googleapi.CallOption) (*cloudbilling.ProjectBillingInfo, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *ProjectsUpdateBillingInfoCallInterface) Do(ctx context.Context, call *cloudbilling.ProjectsUpdateBillingInfoCall, opts ...googleapi.CallOption) (*cloudbilling.ProjectBillingInfo, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *cloudbilling.ProjectBillingInfo
-	if rf, ok := ret.Get(0).(func(*cloudbilling.ProjectsUpdateBillingInfoCall, ...googleapi.CallOption) *cloudbilling.ProjectBillingInfo); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudbilling.ProjectsUpdateBillingInfoCall, ...googleapi.CallOption) *cloudbilling.ProjectBillingInfo); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*cloudbilling.ProjectBillingInfo)
@@ -35,8 +36,8 @@ func (_m *ProjectsUpdateBillingInfoCallInterface) Do(call *cloudbilling.Projects
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*cloudbilling.ProjectsUpdateBillingInfoCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudbilling.ProjectsUpdateBillingInfoCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,10 +3,11 @@
 package mocks
 
 import (
+	context "context"
+
 	cloudidentity "google.golang.org/api/cloudidentity/v1beta1"
 
 	logger "github.com/rockholla/go-lib/logger"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,6 +39,29 @@ func (_m *Interface) EnsureGroup(name string, domain string, customerID string)
 	return r0, r1
 }
 
+// EnsureGroupCtx provides a mock function with given fields: ctx, name, domain, customerID
+func (_m *Interface) EnsureGroupCtx(ctx context.Context, name string, domain string, customerID string) (*cloudidentity.Group, error) {
+	ret := _m.Called(ctx, name, domain, customerID)
+
+	var r0 *cloudidentity.Group
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *cloudidentity.Group); ok {
+		r0 = rf(ctx, name, domain, customerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cloudidentity.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, name, domain, customerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Initialize provides a mock function with given fields: impersonateServiceAccountEmail, log
 func (_m *Interface) Initialize(impersonateServiceAccountEmail string, log logger.Interface) error {
 	ret := _m.Called(impersonateServiceAccountEmail, log)
@@ -51,3 +75,17 @@ func (_m *Interface) Initialize(impersonateServiceAccountEmail string, log logge
 
 	return r0
 }
+
+// InitializeCtx provides a mock function with given fields: ctx, impersonateServiceAccountEmail, log
+func (_m *Interface) InitializeCtx(ctx context.Context, impersonateServiceAccountEmail string, log logger.Interface) error {
+	ret := _m.Called(ctx, impersonateServiceAccountEmail, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface) error); ok {
+		r0 = rf(ctx, impersonateServiceAccountEmail, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
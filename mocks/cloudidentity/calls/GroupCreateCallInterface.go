@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	cloudidentity "google.golang.org/api/cloudidentity/v1beta1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	cloudidentity "google.golang.org/api/cloudidentity/v1beta1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // GroupCreateCallInterface is an autogenerated mock type for the GroupCreateCallInterface type
@@ -14,20 +15,20 @@ type GroupCreateCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *GroupCreateCallInterface) Do(call *cloudidentity.GroupsCreateCall, opts ...googleapi.CallOption) (*cloudidentity.Operation, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *GroupCreateCallInterface) Do(ctx context.Context, call *cloudidentity.GroupsCreateCall, opts ...googleapi.CallOption) (*cloudidentity.Operation, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *cloudidentity.Operation
-	if rf, ok := ret.Get(0).(func(*cloudidentity.GroupsCreateCall, ...googleapi.CallOption) *cloudidentity.Operation); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudidentity.GroupsCreateCall, ...googleapi.CallOption) *cloudidentity.Operation); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*cloudidentity.Operation)
@@ -35,8 +36,8 @@ func (_m *GroupCreateCallInterface) Do(call *cloudidentity.GroupsCreateCall, opt
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*cloudidentity.GroupsCreateCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudidentity.GroupsCreateCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,9 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	cloudkms "github.com/rockholla/go-google-lib/cloudkms"
 	logger "github.com/rockholla/go-lib/logger"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +15,20 @@ type Interface struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields:
+func (_m *Interface) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Decrypt provides a mock function with given fields: key, data
 func (_m *Interface) Decrypt(key *cloudkms.CryptoKey, data string) (string, error) {
 	ret := _m.Called(key, data)
@@ -35,6 +50,27 @@ func (_m *Interface) Decrypt(key *cloudkms.CryptoKey, data string) (string, erro
 	return r0, r1
 }
 
+// DecryptCtx provides a mock function with given fields: ctx, key, data
+func (_m *Interface) DecryptCtx(ctx context.Context, key *cloudkms.CryptoKey, data string) (string, error) {
+	ret := _m.Called(ctx, key, data)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudkms.CryptoKey, string) string); ok {
+		r0 = rf(ctx, key, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudkms.CryptoKey, string) error); ok {
+		r1 = rf(ctx, key, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Encrypt provides a mock function with given fields: key, data
 func (_m *Interface) Encrypt(key *cloudkms.CryptoKey, data string) (string, error) {
 	ret := _m.Called(key, data)
@@ -56,6 +92,27 @@ func (_m *Interface) Encrypt(key *cloudkms.CryptoKey, data string) (string, erro
 	return r0, r1
 }
 
+// EncryptCtx provides a mock function with given fields: ctx, key, data
+func (_m *Interface) EncryptCtx(ctx context.Context, key *cloudkms.CryptoKey, data string) (string, error) {
+	ret := _m.Called(ctx, key, data)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudkms.CryptoKey, string) string); ok {
+		r0 = rf(ctx, key, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudkms.CryptoKey, string) error); ok {
+		r1 = rf(ctx, key, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Initialize provides a mock function with given fields: credentials, log
 func (_m *Interface) Initialize(credentials string, log logger.Interface) error {
 	ret := _m.Called(credentials, log)
@@ -69,3 +126,17 @@ func (_m *Interface) Initialize(credentials string, log logger.Interface) error
 
 	return r0
 }
+
+// InitializeCtx provides a mock function with given fields: ctx, credentials, log
+func (_m *Interface) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	ret := _m.Called(ctx, credentials, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface) error); ok {
+		r0 = rf(ctx, credentials, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
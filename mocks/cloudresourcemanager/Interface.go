@@ -3,10 +3,14 @@
 package mocks
 
 import (
+	context "context"
+
+	iampolicy "github.com/rockholla/go-google-lib/iampolicy"
+	plan "github.com/rockholla/go-google-lib/plan"
 	logger "github.com/rockholla/go-lib/logger"
 	mock "github.com/stretchr/testify/mock"
-
 	v1 "google.golang.org/api/cloudresourcemanager/v1"
+	v2beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
 )
 
 // Interface is an autogenerated mock type for the Interface type
@@ -14,13 +18,13 @@ type Interface struct {
 	mock.Mock
 }
 
-// DeleteProject provides a mock function with given fields: id
-func (_m *Interface) DeleteProject(id string) error {
-	ret := _m.Called(id)
+// ClearOrgPolicy provides a mock function with given fields: resource, constraint
+func (_m *Interface) ClearOrgPolicy(resource string, constraint string) error {
+	ret := _m.Called(resource, constraint)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(resource, constraint)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -28,13 +32,13 @@ func (_m *Interface) DeleteProject(id string) error {
 	return r0
 }
 
-// EnableProjectServices provides a mock function with given fields: projectID, services
-func (_m *Interface) EnableProjectServices(projectID string, services []string) error {
-	ret := _m.Called(projectID, services)
+// ClearOrgPolicyCtx provides a mock function with given fields: ctx, resource, constraint
+func (_m *Interface) ClearOrgPolicyCtx(ctx context.Context, resource string, constraint string) error {
+	ret := _m.Called(ctx, resource, constraint)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
-		r0 = rf(projectID, services)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, resource, constraint)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -42,20 +46,22 @@ func (_m *Interface) EnableProjectServices(projectID string, services []string)
 	return r0
 }
 
-// EnsureFolder provides a mock function with given fields: displayName, parent
-func (_m *Interface) EnsureFolder(displayName string, parent string) (string, error) {
-	ret := _m.Called(displayName, parent)
+// CreateProjectLien provides a mock function with given fields: id, reason, origin
+func (_m *Interface) CreateProjectLien(id string, reason string, origin string) (*v1.Lien, error) {
+	ret := _m.Called(id, reason, origin)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(displayName, parent)
+	var r0 *v1.Lien
+	if rf, ok := ret.Get(0).(func(string, string, string) *v1.Lien); ok {
+		r0 = rf(id, reason, origin)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Lien)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(displayName, parent)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(id, reason, origin)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,13 +69,36 @@ func (_m *Interface) EnsureFolder(displayName string, parent string) (string, er
 	return r0, r1
 }
 
-// EnsureFolderRoles provides a mock function with given fields: folder, member, roles
-func (_m *Interface) EnsureFolderRoles(folder string, member string, roles []string) error {
-	ret := _m.Called(folder, member, roles)
+// CreateProjectLienCtx provides a mock function with given fields: ctx, id, reason, origin
+func (_m *Interface) CreateProjectLienCtx(ctx context.Context, id string, reason string, origin string) (*v1.Lien, error) {
+	ret := _m.Called(ctx, id, reason, origin)
+
+	var r0 *v1.Lien
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *v1.Lien); ok {
+		r0 = rf(ctx, id, reason, origin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Lien)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, id, reason, origin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteFolder provides a mock function with given fields: folder, recursive
+func (_m *Interface) DeleteFolder(folder string, recursive bool) error {
+	ret := _m.Called(folder, recursive)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
-		r0 = rf(folder, member, roles)
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(folder, recursive)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -77,13 +106,13 @@ func (_m *Interface) EnsureFolderRoles(folder string, member string, roles []str
 	return r0
 }
 
-// EnsureOrganizationRoles provides a mock function with given fields: organization, member, roles
-func (_m *Interface) EnsureOrganizationRoles(organization string, member string, roles []string) error {
-	ret := _m.Called(organization, member, roles)
+// DeleteFolderCtx provides a mock function with given fields: ctx, folder, recursive
+func (_m *Interface) DeleteFolderCtx(ctx context.Context, folder string, recursive bool) error {
+	ret := _m.Called(ctx, folder, recursive)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
-		r0 = rf(organization, member, roles)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, folder, recursive)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,41 +120,179 @@ func (_m *Interface) EnsureOrganizationRoles(organization string, member string,
 	return r0
 }
 
-// EnsureProject provides a mock function with given fields: name, parent
-func (_m *Interface) EnsureProject(name string, parent string) (string, int64, error) {
-	ret := _m.Called(name, parent)
+// DeleteProject provides a mock function with given fields: id
+func (_m *Interface) DeleteProject(id string) error {
+	ret := _m.Called(id)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(name, parent)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
 	}
 
-	var r1 int64
-	if rf, ok := ret.Get(1).(func(string, string) int64); ok {
-		r1 = rf(name, parent)
+	return r0
+}
+
+// DeleteProjectCtx provides a mock function with given fields: ctx, id
+func (_m *Interface) DeleteProjectCtx(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r0 = ret.Error(0)
 	}
 
-	var r2 error
-	if rf, ok := ret.Get(2).(func(string, string) error); ok {
-		r2 = rf(name, parent)
+	return r0
+}
+
+// DiffFolderPolicy provides a mock function with given fields: folder, spec
+func (_m *Interface) DiffFolderPolicy(folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(folder, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(folder, spec)
 	} else {
-		r2 = ret.Error(2)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
 	}
 
-	return r0, r1, r2
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *iampolicy.Spec) error); ok {
+		r1 = rf(folder, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// EnsureProjectRoles provides a mock function with given fields: project, member, roles
-func (_m *Interface) EnsureProjectRoles(project string, member string, roles []string) error {
-	ret := _m.Called(project, member, roles)
+// DiffFolderPolicyCtx provides a mock function with given fields: ctx, folder, spec
+func (_m *Interface) DiffFolderPolicyCtx(ctx context.Context, folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(ctx, folder, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(context.Context, string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(ctx, folder, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *iampolicy.Spec) error); ok {
+		r1 = rf(ctx, folder, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffOrganizationPolicy provides a mock function with given fields: organization, spec
+func (_m *Interface) DiffOrganizationPolicy(organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(organization, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(organization, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *iampolicy.Spec) error); ok {
+		r1 = rf(organization, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffOrganizationPolicyCtx provides a mock function with given fields: ctx, organization, spec
+func (_m *Interface) DiffOrganizationPolicyCtx(ctx context.Context, organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(ctx, organization, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(context.Context, string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(ctx, organization, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *iampolicy.Spec) error); ok {
+		r1 = rf(ctx, organization, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffProjectPolicy provides a mock function with given fields: project, spec
+func (_m *Interface) DiffProjectPolicy(project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(project, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(project, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *iampolicy.Spec) error); ok {
+		r1 = rf(project, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffProjectPolicyCtx provides a mock function with given fields: ctx, project, spec
+func (_m *Interface) DiffProjectPolicyCtx(ctx context.Context, project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(ctx, project, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(context.Context, string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(ctx, project, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *iampolicy.Spec) error); ok {
+		r1 = rf(ctx, project, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnableProjectServices provides a mock function with given fields: projectID, services
+func (_m *Interface) EnableProjectServices(projectID string, services []string) error {
+	ret := _m.Called(projectID, services)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
-		r0 = rf(project, member, roles)
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(projectID, services)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -133,8 +300,22 @@ func (_m *Interface) EnsureProjectRoles(project string, member string, roles []s
 	return r0
 }
 
-// GetFolder provides a mock function with given fields: displayName, parent
-func (_m *Interface) GetFolder(displayName string, parent string) (string, error) {
+// EnableProjectServicesCtx provides a mock function with given fields: ctx, projectID, services
+func (_m *Interface) EnableProjectServicesCtx(ctx context.Context, projectID string, services []string) error {
+	ret := _m.Called(ctx, projectID, services)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, projectID, services)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureFolder provides a mock function with given fields: displayName, parent
+func (_m *Interface) EnsureFolder(displayName string, parent string) (string, error) {
 	ret := _m.Called(displayName, parent)
 
 	var r0 string
@@ -154,22 +335,43 @@ func (_m *Interface) GetFolder(displayName string, parent string) (string, error
 	return r0, r1
 }
 
-// GetProject provides a mock function with given fields: name, parent
-func (_m *Interface) GetProject(name string, parent string) (*v1.Project, error) {
-	ret := _m.Called(name, parent)
+// EnsureFolderCtx provides a mock function with given fields: ctx, displayName, parent
+func (_m *Interface) EnsureFolderCtx(ctx context.Context, displayName string, parent string) (string, error) {
+	ret := _m.Called(ctx, displayName, parent)
 
-	var r0 *v1.Project
-	if rf, ok := ret.Get(0).(func(string, string) *v1.Project); ok {
-		r0 = rf(name, parent)
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, displayName, parent)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, displayName, parent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnsureFolderPath provides a mock function with given fields: parent, path
+func (_m *Interface) EnsureFolderPath(parent string, path string) ([]string, error) {
+	ret := _m.Called(parent, path)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(parent, path)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Project)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(name, parent)
+		r1 = rf(parent, path)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -177,22 +379,22 @@ func (_m *Interface) GetProject(name string, parent string) (*v1.Project, error)
 	return r0, r1
 }
 
-// GetProjectByID provides a mock function with given fields: id
-func (_m *Interface) GetProjectByID(id string) (*v1.Project, error) {
-	ret := _m.Called(id)
+// EnsureFolderPathCtx provides a mock function with given fields: ctx, parent, path
+func (_m *Interface) EnsureFolderPathCtx(ctx context.Context, parent string, path string) ([]string, error) {
+	ret := _m.Called(ctx, parent, path)
 
-	var r0 *v1.Project
-	if rf, ok := ret.Get(0).(func(string) *v1.Project); ok {
-		r0 = rf(id)
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, parent, path)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Project)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, parent, path)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -200,13 +402,13 @@ func (_m *Interface) GetProjectByID(id string) (*v1.Project, error) {
 	return r0, r1
 }
 
-// Initialize provides a mock function with given fields: credentials, log
-func (_m *Interface) Initialize(credentials string, log logger.Interface) error {
-	ret := _m.Called(credentials, log)
+// EnsureFolderRoles provides a mock function with given fields: folder, member, roles
+func (_m *Interface) EnsureFolderRoles(folder string, member string, roles []string) error {
+	ret := _m.Called(folder, member, roles)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, logger.Interface) error); ok {
-		r0 = rf(credentials, log)
+	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
+		r0 = rf(folder, member, roles)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -214,13 +416,13 @@ func (_m *Interface) Initialize(credentials string, log logger.Interface) error
 	return r0
 }
 
-// RemoveOrganizationRoles provides a mock function with given fields: organization, member, roles
-func (_m *Interface) RemoveOrganizationRoles(organization string, member string, roles []string) error {
-	ret := _m.Called(organization, member, roles)
+// EnsureFolderRolesCtx provides a mock function with given fields: ctx, folder, member, roles
+func (_m *Interface) EnsureFolderRolesCtx(ctx context.Context, folder string, member string, roles []string) error {
+	ret := _m.Called(ctx, folder, member, roles)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
-		r0 = rf(organization, member, roles)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, folder, member, roles)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -228,13 +430,1481 @@ func (_m *Interface) RemoveOrganizationRoles(organization string, member string,
 	return r0
 }
 
-// SetFolderOrgPolicy provides a mock function with given fields: folder, policy
-func (_m *Interface) SetFolderOrgPolicy(folder string, policy *v1.OrgPolicy) error {
-	ret := _m.Called(folder, policy)
+// EnsureFolderRolesWithCondition provides a mock function with given fields: folder, member, roles, condition
+func (_m *Interface) EnsureFolderRolesWithCondition(folder string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(folder, member, roles, condition)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, *v1.OrgPolicy) error); ok {
-		r0 = rf(folder, policy)
+	if rf, ok := ret.Get(0).(func(string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(folder, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureFolderRolesWithConditionCtx provides a mock function with given fields: ctx, folder, member, roles, condition
+func (_m *Interface) EnsureFolderRolesWithConditionCtx(ctx context.Context, folder string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(ctx, folder, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(ctx, folder, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureOrgPolicy provides a mock function with given fields: resource, policy
+func (_m *Interface) EnsureOrgPolicy(resource string, policy *v1.OrgPolicy) (bool, error) {
+	ret := _m.Called(resource, policy)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, *v1.OrgPolicy) bool); ok {
+		r0 = rf(resource, policy)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *v1.OrgPolicy) error); ok {
+		r1 = rf(resource, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnsureOrgPolicyCtx provides a mock function with given fields: ctx, resource, policy
+func (_m *Interface) EnsureOrgPolicyCtx(ctx context.Context, resource string, policy *v1.OrgPolicy) (bool, error) {
+	ret := _m.Called(ctx, resource, policy)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.OrgPolicy) bool); ok {
+		r0 = rf(ctx, resource, policy)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1.OrgPolicy) error); ok {
+		r1 = rf(ctx, resource, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnsureOrganizationRoles provides a mock function with given fields: organization, member, roles
+func (_m *Interface) EnsureOrganizationRoles(organization string, member string, roles []string) error {
+	ret := _m.Called(organization, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
+		r0 = rf(organization, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureOrganizationRolesCtx provides a mock function with given fields: ctx, organization, member, roles
+func (_m *Interface) EnsureOrganizationRolesCtx(ctx context.Context, organization string, member string, roles []string) error {
+	ret := _m.Called(ctx, organization, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, organization, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureOrganizationRolesWithCondition provides a mock function with given fields: organization, member, roles, condition
+func (_m *Interface) EnsureOrganizationRolesWithCondition(organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(organization, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(organization, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureOrganizationRolesWithConditionCtx provides a mock function with given fields: ctx, organization, member, roles, condition
+func (_m *Interface) EnsureOrganizationRolesWithConditionCtx(ctx context.Context, organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(ctx, organization, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(ctx, organization, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureProject provides a mock function with given fields: name, parent
+func (_m *Interface) EnsureProject(name string, parent string) (string, int64, error) {
+	ret := _m.Called(name, parent)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(name, parent)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, string) int64); ok {
+		r1 = rf(name, parent)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(name, parent)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EnsureProjectCtx provides a mock function with given fields: ctx, name, parent
+func (_m *Interface) EnsureProjectCtx(ctx context.Context, name string, parent string) (string, int64, error) {
+	ret := _m.Called(ctx, name, parent)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, name, parent)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) int64); ok {
+		r1 = rf(ctx, name, parent)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, name, parent)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EnsureProjectRoles provides a mock function with given fields: project, member, roles
+func (_m *Interface) EnsureProjectRoles(project string, member string, roles []string) error {
+	ret := _m.Called(project, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
+		r0 = rf(project, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureProjectRolesCtx provides a mock function with given fields: ctx, project, member, roles
+func (_m *Interface) EnsureProjectRolesCtx(ctx context.Context, project string, member string, roles []string) error {
+	ret := _m.Called(ctx, project, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, project, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureProjectRolesWithCondition provides a mock function with given fields: project, member, roles, condition
+func (_m *Interface) EnsureProjectRolesWithCondition(project string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(project, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(project, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureProjectRolesWithConditionCtx provides a mock function with given fields: ctx, project, member, roles, condition
+func (_m *Interface) EnsureProjectRolesWithConditionCtx(ctx context.Context, project string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(ctx, project, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(ctx, project, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetEffectiveOrgPolicy provides a mock function with given fields: resource, constraint
+func (_m *Interface) GetEffectiveOrgPolicy(resource string, constraint string) (*v1.OrgPolicy, error) {
+	ret := _m.Called(resource, constraint)
+
+	var r0 *v1.OrgPolicy
+	if rf, ok := ret.Get(0).(func(string, string) *v1.OrgPolicy); ok {
+		r0 = rf(resource, constraint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.OrgPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(resource, constraint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEffectiveOrgPolicyCtx provides a mock function with given fields: ctx, resource, constraint
+func (_m *Interface) GetEffectiveOrgPolicyCtx(ctx context.Context, resource string, constraint string) (*v1.OrgPolicy, error) {
+	ret := _m.Called(ctx, resource, constraint)
+
+	var r0 *v1.OrgPolicy
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.OrgPolicy); ok {
+		r0 = rf(ctx, resource, constraint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.OrgPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, resource, constraint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFolder provides a mock function with given fields: displayName, parent
+func (_m *Interface) GetFolder(displayName string, parent string) (string, error) {
+	ret := _m.Called(displayName, parent)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(displayName, parent)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(displayName, parent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFolderCtx provides a mock function with given fields: ctx, displayName, parent
+func (_m *Interface) GetFolderCtx(ctx context.Context, displayName string, parent string) (string, error) {
+	ret := _m.Called(ctx, displayName, parent)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, displayName, parent)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, displayName, parent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrgPolicy provides a mock function with given fields: resource, constraint
+func (_m *Interface) GetOrgPolicy(resource string, constraint string) (*v1.OrgPolicy, error) {
+	ret := _m.Called(resource, constraint)
+
+	var r0 *v1.OrgPolicy
+	if rf, ok := ret.Get(0).(func(string, string) *v1.OrgPolicy); ok {
+		r0 = rf(resource, constraint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.OrgPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(resource, constraint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrgPolicyCtx provides a mock function with given fields: ctx, resource, constraint
+func (_m *Interface) GetOrgPolicyCtx(ctx context.Context, resource string, constraint string) (*v1.OrgPolicy, error) {
+	ret := _m.Called(ctx, resource, constraint)
+
+	var r0 *v1.OrgPolicy
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.OrgPolicy); ok {
+		r0 = rf(ctx, resource, constraint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.OrgPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, resource, constraint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProject provides a mock function with given fields: name, parent
+func (_m *Interface) GetProject(name string, parent string) (*v1.Project, error) {
+	ret := _m.Called(name, parent)
+
+	var r0 *v1.Project
+	if rf, ok := ret.Get(0).(func(string, string) *v1.Project); ok {
+		r0 = rf(name, parent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Project)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, parent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectByID provides a mock function with given fields: id
+func (_m *Interface) GetProjectByID(id string) (*v1.Project, error) {
+	ret := _m.Called(id)
+
+	var r0 *v1.Project
+	if rf, ok := ret.Get(0).(func(string) *v1.Project); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Project)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectByIDCtx provides a mock function with given fields: ctx, id
+func (_m *Interface) GetProjectByIDCtx(ctx context.Context, id string) (*v1.Project, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *v1.Project
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1.Project); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Project)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectCtx provides a mock function with given fields: ctx, name, parent
+func (_m *Interface) GetProjectCtx(ctx context.Context, name string, parent string) (*v1.Project, error) {
+	ret := _m.Called(ctx, name, parent)
+
+	var r0 *v1.Project
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.Project); ok {
+		r0 = rf(ctx, name, parent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Project)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, parent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Initialize provides a mock function with given fields: credentials, log
+func (_m *Interface) Initialize(credentials string, log logger.Interface) error {
+	ret := _m.Called(credentials, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, logger.Interface) error); ok {
+		r0 = rf(credentials, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InitializeCtx provides a mock function with given fields: ctx, credentials, log
+func (_m *Interface) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	ret := _m.Called(ctx, credentials, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface) error); ok {
+		r0 = rf(ctx, credentials, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListFolders provides a mock function with given fields: parent, recursive
+func (_m *Interface) ListFolders(parent string, recursive bool) ([]*v2beta1.Folder, error) {
+	ret := _m.Called(parent, recursive)
+
+	var r0 []*v2beta1.Folder
+	if rf, ok := ret.Get(0).(func(string, bool) []*v2beta1.Folder); ok {
+		r0 = rf(parent, recursive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v2beta1.Folder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(parent, recursive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFoldersCtx provides a mock function with given fields: ctx, parent, recursive
+func (_m *Interface) ListFoldersCtx(ctx context.Context, parent string, recursive bool) ([]*v2beta1.Folder, error) {
+	ret := _m.Called(ctx, parent, recursive)
+
+	var r0 []*v2beta1.Folder
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) []*v2beta1.Folder); ok {
+		r0 = rf(ctx, parent, recursive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v2beta1.Folder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, parent, recursive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOrgPolicies provides a mock function with given fields: resource
+func (_m *Interface) ListOrgPolicies(resource string) ([]*v1.OrgPolicy, error) {
+	ret := _m.Called(resource)
+
+	var r0 []*v1.OrgPolicy
+	if rf, ok := ret.Get(0).(func(string) []*v1.OrgPolicy); ok {
+		r0 = rf(resource)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.OrgPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOrgPoliciesCtx provides a mock function with given fields: ctx, resource
+func (_m *Interface) ListOrgPoliciesCtx(ctx context.Context, resource string) ([]*v1.OrgPolicy, error) {
+	ret := _m.Called(ctx, resource)
+
+	var r0 []*v1.OrgPolicy
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.OrgPolicy); ok {
+		r0 = rf(ctx, resource)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.OrgPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProjectLiens provides a mock function with given fields: id
+func (_m *Interface) ListProjectLiens(id string) ([]*v1.Lien, error) {
+	ret := _m.Called(id)
+
+	var r0 []*v1.Lien
+	if rf, ok := ret.Get(0).(func(string) []*v1.Lien); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Lien)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProjectLiensCtx provides a mock function with given fields: ctx, id
+func (_m *Interface) ListProjectLiensCtx(ctx context.Context, id string) ([]*v1.Lien, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []*v1.Lien
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.Lien); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Lien)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeProjectLabels provides a mock function with given fields: id, labels
+func (_m *Interface) MergeProjectLabels(id string, labels map[string]string) error {
+	ret := _m.Called(id, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, map[string]string) error); ok {
+		r0 = rf(id, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeProjectLabelsCtx provides a mock function with given fields: ctx, id, labels
+func (_m *Interface) MergeProjectLabelsCtx(ctx context.Context, id string, labels map[string]string) error {
+	ret := _m.Called(ctx, id, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) error); ok {
+		r0 = rf(ctx, id, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveFolder provides a mock function with given fields: folder, parent
+func (_m *Interface) MoveFolder(folder string, parent string) error {
+	ret := _m.Called(folder, parent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(folder, parent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveFolderCtx provides a mock function with given fields: ctx, folder, parent
+func (_m *Interface) MoveFolderCtx(ctx context.Context, folder string, parent string) error {
+	ret := _m.Called(ctx, folder, parent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, folder, parent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveProject provides a mock function with given fields: id, parent
+func (_m *Interface) MoveProject(id string, parent string) error {
+	ret := _m.Called(id, parent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, parent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveProjectCtx provides a mock function with given fields: ctx, id, parent
+func (_m *Interface) MoveProjectCtx(ctx context.Context, id string, parent string) error {
+	ret := _m.Called(ctx, id, parent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, parent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReconcileFolderPolicy provides a mock function with given fields: folder, spec
+func (_m *Interface) ReconcileFolderPolicy(folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(folder, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(folder, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *iampolicy.Spec) error); ok {
+		r1 = rf(folder, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileFolderPolicyCtx provides a mock function with given fields: ctx, folder, spec
+func (_m *Interface) ReconcileFolderPolicyCtx(ctx context.Context, folder string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(ctx, folder, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(context.Context, string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(ctx, folder, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *iampolicy.Spec) error); ok {
+		r1 = rf(ctx, folder, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileOrganizationPolicy provides a mock function with given fields: organization, spec
+func (_m *Interface) ReconcileOrganizationPolicy(organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(organization, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(organization, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *iampolicy.Spec) error); ok {
+		r1 = rf(organization, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileOrganizationPolicyCtx provides a mock function with given fields: ctx, organization, spec
+func (_m *Interface) ReconcileOrganizationPolicyCtx(ctx context.Context, organization string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(ctx, organization, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(context.Context, string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(ctx, organization, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *iampolicy.Spec) error); ok {
+		r1 = rf(ctx, organization, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileProjectPolicy provides a mock function with given fields: project, spec
+func (_m *Interface) ReconcileProjectPolicy(project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(project, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(project, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *iampolicy.Spec) error); ok {
+		r1 = rf(project, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileProjectPolicyCtx provides a mock function with given fields: ctx, project, spec
+func (_m *Interface) ReconcileProjectPolicyCtx(ctx context.Context, project string, spec *iampolicy.Spec) ([]plan.Change, error) {
+	ret := _m.Called(ctx, project, spec)
+
+	var r0 []plan.Change
+	if rf, ok := ret.Get(0).(func(context.Context, string, *iampolicy.Spec) []plan.Change); ok {
+		r0 = rf(ctx, project, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]plan.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *iampolicy.Spec) error); ok {
+		r1 = rf(ctx, project, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveFolderMember provides a mock function with given fields: folder, member
+func (_m *Interface) RemoveFolderMember(folder string, member string) ([]string, error) {
+	ret := _m.Called(folder, member)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(folder, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(folder, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveFolderMemberCtx provides a mock function with given fields: ctx, folder, member
+func (_m *Interface) RemoveFolderMemberCtx(ctx context.Context, folder string, member string) ([]string, error) {
+	ret := _m.Called(ctx, folder, member)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, folder, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, folder, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveFolderRoles provides a mock function with given fields: folder, member, roles
+func (_m *Interface) RemoveFolderRoles(folder string, member string, roles []string) ([]string, error) {
+	ret := _m.Called(folder, member, roles)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, []string) []string); ok {
+		r0 = rf(folder, member, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string) error); ok {
+		r1 = rf(folder, member, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveFolderRolesCtx provides a mock function with given fields: ctx, folder, member, roles
+func (_m *Interface) RemoveFolderRolesCtx(ctx context.Context, folder string, member string, roles []string) ([]string, error) {
+	ret := _m.Called(ctx, folder, member, roles)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) []string); ok {
+		r0 = rf(ctx, folder, member, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
+		r1 = rf(ctx, folder, member, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveFolderRolesWithCondition provides a mock function with given fields: folder, member, roles, condition
+func (_m *Interface) RemoveFolderRolesWithCondition(folder string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	ret := _m.Called(folder, member, roles, condition)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, []string, *iampolicy.Condition) []string); ok {
+		r0 = rf(folder, member, roles, condition)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string, *iampolicy.Condition) error); ok {
+		r1 = rf(folder, member, roles, condition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveFolderRolesWithConditionCtx provides a mock function with given fields: ctx, folder, member, roles, condition
+func (_m *Interface) RemoveFolderRolesWithConditionCtx(ctx context.Context, folder string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	ret := _m.Called(ctx, folder, member, roles, condition)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, *iampolicy.Condition) []string); ok {
+		r0 = rf(ctx, folder, member, roles, condition)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, *iampolicy.Condition) error); ok {
+		r1 = rf(ctx, folder, member, roles, condition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveOrganizationMember provides a mock function with given fields: organization, member
+func (_m *Interface) RemoveOrganizationMember(organization string, member string) ([]string, error) {
+	ret := _m.Called(organization, member)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(organization, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(organization, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveOrganizationMemberCtx provides a mock function with given fields: ctx, organization, member
+func (_m *Interface) RemoveOrganizationMemberCtx(ctx context.Context, organization string, member string) ([]string, error) {
+	ret := _m.Called(ctx, organization, member)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, organization, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, organization, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveOrganizationRoles provides a mock function with given fields: organization, member, roles
+func (_m *Interface) RemoveOrganizationRoles(organization string, member string, roles []string) error {
+	ret := _m.Called(organization, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
+		r0 = rf(organization, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveOrganizationRolesCtx provides a mock function with given fields: ctx, organization, member, roles
+func (_m *Interface) RemoveOrganizationRolesCtx(ctx context.Context, organization string, member string, roles []string) error {
+	ret := _m.Called(ctx, organization, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, organization, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveOrganizationRolesWithCondition provides a mock function with given fields: organization, member, roles, condition
+func (_m *Interface) RemoveOrganizationRolesWithCondition(organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(organization, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(organization, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveOrganizationRolesWithConditionCtx provides a mock function with given fields: ctx, organization, member, roles, condition
+func (_m *Interface) RemoveOrganizationRolesWithConditionCtx(ctx context.Context, organization string, member string, roles []string, condition *iampolicy.Condition) error {
+	ret := _m.Called(ctx, organization, member, roles, condition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, *iampolicy.Condition) error); ok {
+		r0 = rf(ctx, organization, member, roles, condition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveProjectLien provides a mock function with given fields: id, lien
+func (_m *Interface) RemoveProjectLien(id string, lien string) error {
+	ret := _m.Called(id, lien)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, lien)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveProjectLienCtx provides a mock function with given fields: ctx, id, lien
+func (_m *Interface) RemoveProjectLienCtx(ctx context.Context, id string, lien string) error {
+	ret := _m.Called(ctx, id, lien)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, lien)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveProjectMember provides a mock function with given fields: project, member
+func (_m *Interface) RemoveProjectMember(project string, member string) ([]string, error) {
+	ret := _m.Called(project, member)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(project, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(project, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveProjectMemberCtx provides a mock function with given fields: ctx, project, member
+func (_m *Interface) RemoveProjectMemberCtx(ctx context.Context, project string, member string) ([]string, error) {
+	ret := _m.Called(ctx, project, member)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, project, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, project, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveProjectRoles provides a mock function with given fields: project, member, roles
+func (_m *Interface) RemoveProjectRoles(project string, member string, roles []string) ([]string, error) {
+	ret := _m.Called(project, member, roles)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, []string) []string); ok {
+		r0 = rf(project, member, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string) error); ok {
+		r1 = rf(project, member, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveProjectRolesCtx provides a mock function with given fields: ctx, project, member, roles
+func (_m *Interface) RemoveProjectRolesCtx(ctx context.Context, project string, member string, roles []string) ([]string, error) {
+	ret := _m.Called(ctx, project, member, roles)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) []string); ok {
+		r0 = rf(ctx, project, member, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
+		r1 = rf(ctx, project, member, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveProjectRolesWithCondition provides a mock function with given fields: project, member, roles, condition
+func (_m *Interface) RemoveProjectRolesWithCondition(project string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	ret := _m.Called(project, member, roles, condition)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, []string, *iampolicy.Condition) []string); ok {
+		r0 = rf(project, member, roles, condition)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string, *iampolicy.Condition) error); ok {
+		r1 = rf(project, member, roles, condition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveProjectRolesWithConditionCtx provides a mock function with given fields: ctx, project, member, roles, condition
+func (_m *Interface) RemoveProjectRolesWithConditionCtx(ctx context.Context, project string, member string, roles []string, condition *iampolicy.Condition) ([]string, error) {
+	ret := _m.Called(ctx, project, member, roles, condition)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, *iampolicy.Condition) []string); ok {
+		r0 = rf(ctx, project, member, roles, condition)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, *iampolicy.Condition) error); ok {
+		r1 = rf(ctx, project, member, roles, condition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenameFolder provides a mock function with given fields: folder, displayName
+func (_m *Interface) RenameFolder(folder string, displayName string) error {
+	ret := _m.Called(folder, displayName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(folder, displayName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameFolderCtx provides a mock function with given fields: ctx, folder, displayName
+func (_m *Interface) RenameFolderCtx(ctx context.Context, folder string, displayName string) error {
+	ret := _m.Called(ctx, folder, displayName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, folder, displayName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveFolderPath provides a mock function with given fields: folder
+func (_m *Interface) ResolveFolderPath(folder string) (string, string, error) {
+	ret := _m.Called(folder)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(folder)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(folder)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(folder)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ResolveFolderPathCtx provides a mock function with given fields: ctx, folder
+func (_m *Interface) ResolveFolderPathCtx(ctx context.Context, folder string) (string, string, error) {
+	ret := _m.Called(ctx, folder)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, folder)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, folder)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, folder)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetFolderOrgPolicy provides a mock function with given fields: folder, policy
+func (_m *Interface) SetFolderOrgPolicy(folder string, policy *v1.OrgPolicy) error {
+	ret := _m.Called(folder, policy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *v1.OrgPolicy) error); ok {
+		r0 = rf(folder, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetFolderOrgPolicyCtx provides a mock function with given fields: ctx, folder, policy
+func (_m *Interface) SetFolderOrgPolicyCtx(ctx context.Context, folder string, policy *v1.OrgPolicy) error {
+	ret := _m.Called(ctx, folder, policy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.OrgPolicy) error); ok {
+		r0 = rf(ctx, folder, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetOrgPolicy provides a mock function with given fields: resource, policy
+func (_m *Interface) SetOrgPolicy(resource string, policy *v1.OrgPolicy) error {
+	ret := _m.Called(resource, policy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *v1.OrgPolicy) error); ok {
+		r0 = rf(resource, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetOrgPolicyCtx provides a mock function with given fields: ctx, resource, policy
+func (_m *Interface) SetOrgPolicyCtx(ctx context.Context, resource string, policy *v1.OrgPolicy) error {
+	ret := _m.Called(ctx, resource, policy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.OrgPolicy) error); ok {
+		r0 = rf(ctx, resource, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetProjectLabels provides a mock function with given fields: id, labels
+func (_m *Interface) SetProjectLabels(id string, labels map[string]string) error {
+	ret := _m.Called(id, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, map[string]string) error); ok {
+		r0 = rf(id, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetProjectLabelsCtx provides a mock function with given fields: ctx, id, labels
+func (_m *Interface) SetProjectLabelsCtx(ctx context.Context, id string, labels map[string]string) error {
+	ret := _m.Called(ctx, id, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) error); ok {
+		r0 = rf(ctx, id, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UndeleteFolder provides a mock function with given fields: folder
+func (_m *Interface) UndeleteFolder(folder string) error {
+	ret := _m.Called(folder)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(folder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UndeleteFolderCtx provides a mock function with given fields: ctx, folder
+func (_m *Interface) UndeleteFolderCtx(ctx context.Context, folder string) error {
+	ret := _m.Called(ctx, folder)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, folder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UndeleteProject provides a mock function with given fields: id
+func (_m *Interface) UndeleteProject(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UndeleteProjectCtx provides a mock function with given fields: ctx, id
+func (_m *Interface) UndeleteProjectCtx(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v2beta1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v2beta1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // FoldersSetIAMPolicyCallInterface is an autogenerated mock type for the FoldersSetIAMPolicyCallInterface type
@@ -14,20 +15,20 @@ type FoldersSetIAMPolicyCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *FoldersSetIAMPolicyCallInterface) Do(call *cloudresourcemanager.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*cloudresourcemanager.Policy, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *FoldersSetIAMPolicyCallInterface) Do(ctx context.Context, call *cloudresourcemanager.FoldersSetIamPolicyCall, opts ...googleapi.CallOption) (*cloudresourcemanager.Policy, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *cloudresourcemanager.Policy
-	if rf, ok := ret.Get(0).(func(*cloudresourcemanager.FoldersSetIamPolicyCall, ...googleapi.CallOption) *cloudresourcemanager.Policy); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudresourcemanager.FoldersSetIamPolicyCall, ...googleapi.CallOption) *cloudresourcemanager.Policy); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*cloudresourcemanager.Policy)
@@ -35,8 +36,8 @@ func (_m *FoldersSetIAMPolicyCallInterface) Do(call *cloudresourcemanager.Folder
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*cloudresourcemanager.FoldersSetIamPolicyCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudresourcemanager.FoldersSetIamPolicyCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
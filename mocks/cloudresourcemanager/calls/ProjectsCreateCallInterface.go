@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // ProjectsCreateCallInterface is an autogenerated mock type for the ProjectsCreateCallInterface type
@@ -14,20 +15,20 @@ type ProjectsCreateCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *ProjectsCreateCallInterface) Do(call *cloudresourcemanager.ProjectsCreateCall, opts ...googleapi.CallOption) (*cloudresourcemanager.Operation, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *ProjectsCreateCallInterface) Do(ctx context.Context, call *cloudresourcemanager.ProjectsCreateCall, opts ...googleapi.CallOption) (*cloudresourcemanager.Operation, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *cloudresourcemanager.Operation
-	if rf, ok := ret.Get(0).(func(*cloudresourcemanager.ProjectsCreateCall, ...googleapi.CallOption) *cloudresourcemanager.Operation); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudresourcemanager.ProjectsCreateCall, ...googleapi.CallOption) *cloudresourcemanager.Operation); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*cloudresourcemanager.Operation)
@@ -35,8 +36,8 @@ func (_m *ProjectsCreateCallInterface) Do(call *cloudresourcemanager.ProjectsCre
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*cloudresourcemanager.ProjectsCreateCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudresourcemanager.ProjectsCreateCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // ProjectsDeleteCallInterface is an autogenerated mock type for the ProjectsDeleteCallInterface type
@@ -14,20 +15,20 @@ type ProjectsDeleteCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *ProjectsDeleteCallInterface) Do(call *cloudresourcemanager.ProjectsDeleteCall, opts ...googleapi.CallOption) (*cloudresourcemanager.Empty, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *ProjectsDeleteCallInterface) Do(ctx context.Context, call *cloudresourcemanager.ProjectsDeleteCall, opts ...googleapi.CallOption) (*cloudresourcemanager.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *cloudresourcemanager.Empty
-	if rf, ok := ret.Get(0).(func(*cloudresourcemanager.ProjectsDeleteCall, ...googleapi.CallOption) *cloudresourcemanager.Empty); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudresourcemanager.ProjectsDeleteCall, ...googleapi.CallOption) *cloudresourcemanager.Empty); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*cloudresourcemanager.Empty)
@@ -35,8 +36,8 @@ func (_m *ProjectsDeleteCallInterface) Do(call *cloudresourcemanager.ProjectsDel
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*cloudresourcemanager.ProjectsDeleteCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudresourcemanager.ProjectsDeleteCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
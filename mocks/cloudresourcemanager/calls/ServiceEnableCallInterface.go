@@ -3,9 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 	googleapi "google.golang.org/api/googleapi"
-
 	serviceusage "google.golang.org/api/serviceusage/v1"
 )
 
@@ -14,20 +15,20 @@ type ServiceEnableCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *ServiceEnableCallInterface) Do(call *serviceusage.ServicesEnableCall, opts ...googleapi.CallOption) (*serviceusage.Operation, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *ServiceEnableCallInterface) Do(ctx context.Context, call *serviceusage.ServicesEnableCall, opts ...googleapi.CallOption) (*serviceusage.Operation, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *serviceusage.Operation
-	if rf, ok := ret.Get(0).(func(*serviceusage.ServicesEnableCall, ...googleapi.CallOption) *serviceusage.Operation); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *serviceusage.ServicesEnableCall, ...googleapi.CallOption) *serviceusage.Operation); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*serviceusage.Operation)
@@ -35,8 +36,8 @@ func (_m *ServiceEnableCallInterface) Do(call *serviceusage.ServicesEnableCall,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*serviceusage.ServicesEnableCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *serviceusage.ServicesEnableCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,11 +3,11 @@
 package mocks
 
 import (
+	context "context"
+
 	compute "github.com/rockholla/go-google-lib/compute"
 	logger "github.com/rockholla/go-lib/logger"
-
 	mock "github.com/stretchr/testify/mock"
-
 	v1 "google.golang.org/api/compute/v1"
 )
 
@@ -16,13 +16,20 @@ type Interface struct {
 	mock.Mock
 }
 
-// DeleteAddress provides a mock function with given fields: projectID, region, name
-func (_m *Interface) DeleteAddress(projectID string, region string, name string) error {
-	ret := _m.Called(projectID, region, name)
+// DeleteAddress provides a mock function with given fields: projectID, region, name, opts
+func (_m *Interface) DeleteAddress(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(projectID, region, name)
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, region, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -30,13 +37,20 @@ func (_m *Interface) DeleteAddress(projectID string, region string, name string)
 	return r0
 }
 
-// DeleteBackendService provides a mock function with given fields: projectID, name
-func (_m *Interface) DeleteBackendService(projectID string, name string) error {
-	ret := _m.Called(projectID, name)
+// DeleteAddressCtx provides a mock function with given fields: ctx, projectID, region, name, opts
+func (_m *Interface) DeleteAddressCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(projectID, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, region, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -44,13 +58,20 @@ func (_m *Interface) DeleteBackendService(projectID string, name string) error {
 	return r0
 }
 
-// DeleteDisk provides a mock function with given fields: projectID, zone, name
-func (_m *Interface) DeleteDisk(projectID string, zone string, name string) error {
-	ret := _m.Called(projectID, zone, name)
+// DeleteBackendService provides a mock function with given fields: projectID, name, opts
+func (_m *Interface) DeleteBackendService(projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(projectID, zone, name)
+	if rf, ok := ret.Get(0).(func(string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -58,13 +79,20 @@ func (_m *Interface) DeleteDisk(projectID string, zone string, name string) erro
 	return r0
 }
 
-// DeleteFirewall provides a mock function with given fields: projectID, name
-func (_m *Interface) DeleteFirewall(projectID string, name string) error {
-	ret := _m.Called(projectID, name)
+// DeleteBackendServiceCtx provides a mock function with given fields: ctx, projectID, name, opts
+func (_m *Interface) DeleteBackendServiceCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(projectID, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -72,13 +100,20 @@ func (_m *Interface) DeleteFirewall(projectID string, name string) error {
 	return r0
 }
 
-// DeleteForwardingRule provides a mock function with given fields: projectID, region, name
-func (_m *Interface) DeleteForwardingRule(projectID string, region string, name string) error {
-	ret := _m.Called(projectID, region, name)
+// DeleteDisk provides a mock function with given fields: projectID, zone, name, opts
+func (_m *Interface) DeleteDisk(projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, zone, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(projectID, region, name)
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, zone, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -86,13 +121,20 @@ func (_m *Interface) DeleteForwardingRule(projectID string, region string, name
 	return r0
 }
 
-// DeleteHTTPHealthCheck provides a mock function with given fields: projectID, name
-func (_m *Interface) DeleteHTTPHealthCheck(projectID string, name string) error {
-	ret := _m.Called(projectID, name)
+// DeleteDiskCtx provides a mock function with given fields: ctx, projectID, zone, name, opts
+func (_m *Interface) DeleteDiskCtx(ctx context.Context, projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, zone, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(projectID, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, zone, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -100,13 +142,20 @@ func (_m *Interface) DeleteHTTPHealthCheck(projectID string, name string) error
 	return r0
 }
 
-// DeleteHealthCheck provides a mock function with given fields: projectID, name
-func (_m *Interface) DeleteHealthCheck(projectID string, name string) error {
-	ret := _m.Called(projectID, name)
+// DeleteFirewall provides a mock function with given fields: projectID, name, opts
+func (_m *Interface) DeleteFirewall(projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(projectID, name)
+	if rf, ok := ret.Get(0).(func(string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -114,13 +163,20 @@ func (_m *Interface) DeleteHealthCheck(projectID string, name string) error {
 	return r0
 }
 
-// DeleteInstanceGroup provides a mock function with given fields: projectID, zone, name
-func (_m *Interface) DeleteInstanceGroup(projectID string, zone string, name string) error {
-	ret := _m.Called(projectID, zone, name)
+// DeleteFirewallCtx provides a mock function with given fields: ctx, projectID, name, opts
+func (_m *Interface) DeleteFirewallCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(projectID, zone, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -128,13 +184,377 @@ func (_m *Interface) DeleteInstanceGroup(projectID string, zone string, name str
 	return r0
 }
 
-// DeleteNetwork provides a mock function with given fields: projectID, name
-func (_m *Interface) DeleteNetwork(projectID string, name string) error {
-	ret := _m.Called(projectID, name)
+// DeleteForwardingRule provides a mock function with given fields: projectID, region, name, opts
+func (_m *Interface) DeleteForwardingRule(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(projectID, name)
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteForwardingRuleCtx provides a mock function with given fields: ctx, projectID, region, name, opts
+func (_m *Interface) DeleteForwardingRuleCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteHTTPHealthCheck provides a mock function with given fields: projectID, name, opts
+func (_m *Interface) DeleteHTTPHealthCheck(projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteHTTPHealthCheckCtx provides a mock function with given fields: ctx, projectID, name, opts
+func (_m *Interface) DeleteHTTPHealthCheckCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteHealthCheck provides a mock function with given fields: projectID, name, opts
+func (_m *Interface) DeleteHealthCheck(projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteHealthCheckCtx provides a mock function with given fields: ctx, projectID, name, opts
+func (_m *Interface) DeleteHealthCheckCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInstance provides a mock function with given fields: projectID, zone, name, opts
+func (_m *Interface) DeleteInstance(projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, zone, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, zone, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInstanceCtx provides a mock function with given fields: ctx, projectID, zone, name, opts
+func (_m *Interface) DeleteInstanceCtx(ctx context.Context, projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, zone, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, zone, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInstanceGroup provides a mock function with given fields: projectID, zone, name, opts
+func (_m *Interface) DeleteInstanceGroup(projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, zone, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, zone, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInstanceGroupCtx provides a mock function with given fields: ctx, projectID, zone, name, opts
+func (_m *Interface) DeleteInstanceGroupCtx(ctx context.Context, projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, zone, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, zone, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInstanceGroupManager provides a mock function with given fields: projectID, zone, name, opts
+func (_m *Interface) DeleteInstanceGroupManager(projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, zone, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, zone, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInstanceGroupManagerCtx provides a mock function with given fields: ctx, projectID, zone, name, opts
+func (_m *Interface) DeleteInstanceGroupManagerCtx(ctx context.Context, projectID string, zone string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, zone, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, zone, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNetwork provides a mock function with given fields: projectID, name, opts
+func (_m *Interface) DeleteNetwork(projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNetworkCtx provides a mock function with given fields: ctx, projectID, name, opts
+func (_m *Interface) DeleteNetworkCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRegionBackendService provides a mock function with given fields: projectID, region, name, opts
+func (_m *Interface) DeleteRegionBackendService(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRegionBackendServiceCtx provides a mock function with given fields: ctx, projectID, region, name, opts
+func (_m *Interface) DeleteRegionBackendServiceCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRegionInstanceGroupManager provides a mock function with given fields: projectID, region, name, opts
+func (_m *Interface) DeleteRegionInstanceGroupManager(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRegionInstanceGroupManagerCtx provides a mock function with given fields: ctx, projectID, region, name, opts
+func (_m *Interface) DeleteRegionInstanceGroupManagerCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, region, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -142,13 +562,146 @@ func (_m *Interface) DeleteNetwork(projectID string, name string) error {
 	return r0
 }
 
-// DeleteRegionBackendService provides a mock function with given fields: projectID, region, name
-func (_m *Interface) DeleteRegionBackendService(projectID string, region string, name string) error {
-	ret := _m.Called(projectID, region, name)
+// DeleteRoute provides a mock function with given fields: projectID, name, opts
+func (_m *Interface) DeleteRoute(projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRouteCtx provides a mock function with given fields: ctx, projectID, name, opts
+func (_m *Interface) DeleteRouteCtx(ctx context.Context, projectID string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRouter provides a mock function with given fields: projectID, region, name, opts
+func (_m *Interface) DeleteRouter(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRouterCtx provides a mock function with given fields: ctx, projectID, region, name, opts
+func (_m *Interface) DeleteRouterCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSubnetwork provides a mock function with given fields: projectID, region, name, opts
+func (_m *Interface) DeleteSubnetwork(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSubnetworkCtx provides a mock function with given fields: ctx, projectID, region, name, opts
+func (_m *Interface) DeleteSubnetworkCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTargetPool provides a mock function with given fields: projectID, region, name, opts
+func (_m *Interface) DeleteTargetPool(projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(projectID, region, name)
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, region, name, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -156,36 +709,890 @@ func (_m *Interface) DeleteRegionBackendService(projectID string, region string,
 	return r0
 }
 
-// DeleteTargetPool provides a mock function with given fields: projectID, region, name
-func (_m *Interface) DeleteTargetPool(projectID string, region string, name string) error {
-	ret := _m.Called(projectID, region, name)
+// DeleteTargetPoolCtx provides a mock function with given fields: ctx, projectID, region, name, opts
+func (_m *Interface) DeleteTargetPoolCtx(ctx context.Context, projectID string, region string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, region, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(projectID, region, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, region, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachAddress provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachAddress(projectID string, fn func(*v1.Address) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.Address) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachAddressCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachAddressCtx(ctx context.Context, projectID string, fn func(*v1.Address) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.Address) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachBackendService provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachBackendService(projectID string, fn func(*v1.BackendService) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.BackendService) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachBackendServiceCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachBackendServiceCtx(ctx context.Context, projectID string, fn func(*v1.BackendService) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.BackendService) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachDisk provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachDisk(projectID string, fn func(*v1.Disk) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.Disk) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachDiskCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachDiskCtx(ctx context.Context, projectID string, fn func(*v1.Disk) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.Disk) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachFirewall provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachFirewall(projectID string, fn func(*v1.Firewall) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.Firewall) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachFirewallCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachFirewallCtx(ctx context.Context, projectID string, fn func(*v1.Firewall) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.Firewall) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachForwardingRule provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachForwardingRule(projectID string, fn func(*v1.ForwardingRule) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.ForwardingRule) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachForwardingRuleCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachForwardingRuleCtx(ctx context.Context, projectID string, fn func(*v1.ForwardingRule) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.ForwardingRule) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachHTTPHealthCheck provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachHTTPHealthCheck(projectID string, fn func(*v1.HttpHealthCheck) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.HttpHealthCheck) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachHTTPHealthCheckCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachHTTPHealthCheckCtx(ctx context.Context, projectID string, fn func(*v1.HttpHealthCheck) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.HttpHealthCheck) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachHealthCheck provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachHealthCheck(projectID string, fn func(*v1.HealthCheck) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.HealthCheck) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachHealthCheckCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachHealthCheckCtx(ctx context.Context, projectID string, fn func(*v1.HealthCheck) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.HealthCheck) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachInstance provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachInstance(projectID string, fn func(*v1.Instance) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.Instance) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachInstanceCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachInstanceCtx(ctx context.Context, projectID string, fn func(*v1.Instance) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.Instance) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachInstanceGroup provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachInstanceGroup(projectID string, fn func(*v1.InstanceGroup) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.InstanceGroup) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachInstanceGroupCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachInstanceGroupCtx(ctx context.Context, projectID string, fn func(*v1.InstanceGroup) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.InstanceGroup) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachInstanceGroupManager provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachInstanceGroupManager(projectID string, fn func(*v1.InstanceGroupManager) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.InstanceGroupManager) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachInstanceGroupManagerCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachInstanceGroupManagerCtx(ctx context.Context, projectID string, fn func(*v1.InstanceGroupManager) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.InstanceGroupManager) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachRoute provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachRoute(projectID string, fn func(*v1.Route) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.Route) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachRouteCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachRouteCtx(ctx context.Context, projectID string, fn func(*v1.Route) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.Route) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachRouter provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachRouter(projectID string, fn func(*v1.Router) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.Router) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachRouterCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachRouterCtx(ctx context.Context, projectID string, fn func(*v1.Router) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.Router) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachTargetPool provides a mock function with given fields: projectID, fn
+func (_m *Interface) ForEachTargetPool(projectID string, fn func(*v1.TargetPool) error) error {
+	ret := _m.Called(projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(*v1.TargetPool) error) error); ok {
+		r0 = rf(projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachTargetPoolCtx provides a mock function with given fields: ctx, projectID, fn
+func (_m *Interface) ForEachTargetPoolCtx(ctx context.Context, projectID string, fn func(*v1.TargetPool) error) error {
+	ret := _m.Called(ctx, projectID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1.TargetPool) error) error); ok {
+		r0 = rf(ctx, projectID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAddresses provides a mock function with given fields: projectID
+func (_m *Interface) GetAddresses(projectID string) ([]*v1.Address, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.Address
+	if rf, ok := ret.Get(0).(func(string) []*v1.Address); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAddressesCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetAddressesCtx(ctx context.Context, projectID string) ([]*v1.Address, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.Address
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.Address); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBackendServices provides a mock function with given fields: projectID
+func (_m *Interface) GetBackendServices(projectID string) ([]*v1.BackendService, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.BackendService
+	if rf, ok := ret.Get(0).(func(string) []*v1.BackendService); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.BackendService)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBackendServicesCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetBackendServicesCtx(ctx context.Context, projectID string) ([]*v1.BackendService, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.BackendService
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.BackendService); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.BackendService)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommonInstanceMetadata provides a mock function with given fields: projectID
+func (_m *Interface) GetCommonInstanceMetadata(projectID string) ([]*v1.MetadataItems, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.MetadataItems
+	if rf, ok := ret.Get(0).(func(string) []*v1.MetadataItems); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.MetadataItems)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommonInstanceMetadataCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetCommonInstanceMetadataCtx(ctx context.Context, projectID string) ([]*v1.MetadataItems, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.MetadataItems
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.MetadataItems); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.MetadataItems)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDisks provides a mock function with given fields: projectID
+func (_m *Interface) GetDisks(projectID string) ([]*v1.Disk, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.Disk
+	if rf, ok := ret.Get(0).(func(string) []*v1.Disk); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Disk)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDisksCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetDisksCtx(ctx context.Context, projectID string) ([]*v1.Disk, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.Disk
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.Disk); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Disk)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFirewalls provides a mock function with given fields: projectID
+func (_m *Interface) GetFirewalls(projectID string) ([]*v1.Firewall, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.Firewall
+	if rf, ok := ret.Get(0).(func(string) []*v1.Firewall); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Firewall)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFirewallsCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetFirewallsCtx(ctx context.Context, projectID string) ([]*v1.Firewall, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.Firewall
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.Firewall); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Firewall)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForwardingRules provides a mock function with given fields: projectID
+func (_m *Interface) GetForwardingRules(projectID string) ([]*v1.ForwardingRule, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.ForwardingRule
+	if rf, ok := ret.Get(0).(func(string) []*v1.ForwardingRule); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.ForwardingRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForwardingRulesCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetForwardingRulesCtx(ctx context.Context, projectID string) ([]*v1.ForwardingRule, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.ForwardingRule
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.ForwardingRule); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.ForwardingRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHTTPHealthChecks provides a mock function with given fields: projectID
+func (_m *Interface) GetHTTPHealthChecks(projectID string) ([]*v1.HttpHealthCheck, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.HttpHealthCheck
+	if rf, ok := ret.Get(0).(func(string) []*v1.HttpHealthCheck); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.HttpHealthCheck)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHTTPHealthChecksCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetHTTPHealthChecksCtx(ctx context.Context, projectID string) ([]*v1.HttpHealthCheck, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.HttpHealthCheck
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.HttpHealthCheck); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.HttpHealthCheck)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHealthChecks provides a mock function with given fields: projectID
+func (_m *Interface) GetHealthChecks(projectID string) ([]*v1.HealthCheck, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.HealthCheck
+	if rf, ok := ret.Get(0).(func(string) []*v1.HealthCheck); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.HealthCheck)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHealthChecksCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetHealthChecksCtx(ctx context.Context, projectID string) ([]*v1.HealthCheck, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.HealthCheck
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.HealthCheck); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.HealthCheck)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInstanceGroupManagers provides a mock function with given fields: projectID
+func (_m *Interface) GetInstanceGroupManagers(projectID string) ([]*v1.InstanceGroupManager, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.InstanceGroupManager
+	if rf, ok := ret.Get(0).(func(string) []*v1.InstanceGroupManager); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.InstanceGroupManager)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInstanceGroupManagersCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetInstanceGroupManagersCtx(ctx context.Context, projectID string) ([]*v1.InstanceGroupManager, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.InstanceGroupManager
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.InstanceGroupManager); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.InstanceGroupManager)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInstanceGroups provides a mock function with given fields: projectID
+func (_m *Interface) GetInstanceGroups(projectID string) ([]*v1.InstanceGroup, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []*v1.InstanceGroup
+	if rf, ok := ret.Get(0).(func(string) []*v1.InstanceGroup); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.InstanceGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInstanceGroupsCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetInstanceGroupsCtx(ctx context.Context, projectID string) ([]*v1.InstanceGroup, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.InstanceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.InstanceGroup); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.InstanceGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInternalIPs provides a mock function with given fields: projectID, network
+func (_m *Interface) GetInternalIPs(projectID string, network string) ([]*compute.InstanceIP, error) {
+	ret := _m.Called(projectID, network)
+
+	var r0 []*compute.InstanceIP
+	if rf, ok := ret.Get(0).(func(string, string) []*compute.InstanceIP); ok {
+		r0 = rf(projectID, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.InstanceIP)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(projectID, network)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// GetAddresses provides a mock function with given fields: projectID
-func (_m *Interface) GetAddresses(projectID string) ([]*v1.Address, error) {
-	ret := _m.Called(projectID)
+// GetInternalIPsCtx provides a mock function with given fields: ctx, projectID, network
+func (_m *Interface) GetInternalIPsCtx(ctx context.Context, projectID string, network string) ([]*compute.InstanceIP, error) {
+	ret := _m.Called(ctx, projectID, network)
 
-	var r0 []*v1.Address
-	if rf, ok := ret.Get(0).(func(string) []*v1.Address); ok {
-		r0 = rf(projectID)
+	var r0 []*compute.InstanceIP
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*compute.InstanceIP); ok {
+		r0 = rf(ctx, projectID, network)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.Address)
+			r0 = ret.Get(0).([]*compute.InstanceIP)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(projectID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, projectID, network)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -193,22 +1600,22 @@ func (_m *Interface) GetAddresses(projectID string) ([]*v1.Address, error) {
 	return r0, r1
 }
 
-// GetBackendServices provides a mock function with given fields: projectID
-func (_m *Interface) GetBackendServices(projectID string) ([]*v1.BackendService, error) {
-	ret := _m.Called(projectID)
+// GetNetwork provides a mock function with given fields: projectID, name
+func (_m *Interface) GetNetwork(projectID string, name string) (*v1.Network, error) {
+	ret := _m.Called(projectID, name)
 
-	var r0 []*v1.BackendService
-	if rf, ok := ret.Get(0).(func(string) []*v1.BackendService); ok {
-		r0 = rf(projectID)
+	var r0 *v1.Network
+	if rf, ok := ret.Get(0).(func(string, string) *v1.Network); ok {
+		r0 = rf(projectID, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.BackendService)
+			r0 = ret.Get(0).(*v1.Network)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(projectID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(projectID, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -216,22 +1623,22 @@ func (_m *Interface) GetBackendServices(projectID string) ([]*v1.BackendService,
 	return r0, r1
 }
 
-// GetCommonInstanceMetadata provides a mock function with given fields: projectID
-func (_m *Interface) GetCommonInstanceMetadata(projectID string) ([]*v1.MetadataItems, error) {
-	ret := _m.Called(projectID)
+// GetNetworkCtx provides a mock function with given fields: ctx, projectID, name
+func (_m *Interface) GetNetworkCtx(ctx context.Context, projectID string, name string) (*v1.Network, error) {
+	ret := _m.Called(ctx, projectID, name)
 
-	var r0 []*v1.MetadataItems
-	if rf, ok := ret.Get(0).(func(string) []*v1.MetadataItems); ok {
-		r0 = rf(projectID)
+	var r0 *v1.Network
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.Network); ok {
+		r0 = rf(ctx, projectID, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.MetadataItems)
+			r0 = ret.Get(0).(*v1.Network)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(projectID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, projectID, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -239,22 +1646,22 @@ func (_m *Interface) GetCommonInstanceMetadata(projectID string) ([]*v1.Metadata
 	return r0, r1
 }
 
-// GetDisks provides a mock function with given fields: projectID
-func (_m *Interface) GetDisks(projectID string) ([]*v1.Disk, error) {
-	ret := _m.Called(projectID)
+// GetOperation provides a mock function with given fields: projectID, operation
+func (_m *Interface) GetOperation(projectID string, operation *v1.Operation) (*v1.Operation, error) {
+	ret := _m.Called(projectID, operation)
 
-	var r0 []*v1.Disk
-	if rf, ok := ret.Get(0).(func(string) []*v1.Disk); ok {
-		r0 = rf(projectID)
+	var r0 *v1.Operation
+	if rf, ok := ret.Get(0).(func(string, *v1.Operation) *v1.Operation); ok {
+		r0 = rf(projectID, operation)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.Disk)
+			r0 = ret.Get(0).(*v1.Operation)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(projectID)
+	if rf, ok := ret.Get(1).(func(string, *v1.Operation) error); ok {
+		r1 = rf(projectID, operation)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -262,22 +1669,22 @@ func (_m *Interface) GetDisks(projectID string) ([]*v1.Disk, error) {
 	return r0, r1
 }
 
-// GetFirewalls provides a mock function with given fields: projectID
-func (_m *Interface) GetFirewalls(projectID string) ([]*v1.Firewall, error) {
-	ret := _m.Called(projectID)
+// GetOperationCtx provides a mock function with given fields: ctx, projectID, operation
+func (_m *Interface) GetOperationCtx(ctx context.Context, projectID string, operation *v1.Operation) (*v1.Operation, error) {
+	ret := _m.Called(ctx, projectID, operation)
 
-	var r0 []*v1.Firewall
-	if rf, ok := ret.Get(0).(func(string) []*v1.Firewall); ok {
-		r0 = rf(projectID)
+	var r0 *v1.Operation
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.Operation) *v1.Operation); ok {
+		r0 = rf(ctx, projectID, operation)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.Firewall)
+			r0 = ret.Get(0).(*v1.Operation)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(projectID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1.Operation) error); ok {
+		r1 = rf(ctx, projectID, operation)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -285,22 +1692,22 @@ func (_m *Interface) GetFirewalls(projectID string) ([]*v1.Firewall, error) {
 	return r0, r1
 }
 
-// GetHTTPHealthChecks provides a mock function with given fields: projectID
-func (_m *Interface) GetHTTPHealthChecks(projectID string) ([]*v1.HttpHealthCheck, error) {
-	ret := _m.Called(projectID)
+// GetRegionZones provides a mock function with given fields: projectID, region
+func (_m *Interface) GetRegionZones(projectID string, region string) ([]string, error) {
+	ret := _m.Called(projectID, region)
 
-	var r0 []*v1.HttpHealthCheck
-	if rf, ok := ret.Get(0).(func(string) []*v1.HttpHealthCheck); ok {
-		r0 = rf(projectID)
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(projectID, region)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.HttpHealthCheck)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(projectID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(projectID, region)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -308,22 +1715,22 @@ func (_m *Interface) GetHTTPHealthChecks(projectID string) ([]*v1.HttpHealthChec
 	return r0, r1
 }
 
-// GetHealthChecks provides a mock function with given fields: projectID
-func (_m *Interface) GetHealthChecks(projectID string) ([]*v1.HealthCheck, error) {
-	ret := _m.Called(projectID)
+// GetRegionZonesCtx provides a mock function with given fields: ctx, projectID, region
+func (_m *Interface) GetRegionZonesCtx(ctx context.Context, projectID string, region string) ([]string, error) {
+	ret := _m.Called(ctx, projectID, region)
 
-	var r0 []*v1.HealthCheck
-	if rf, ok := ret.Get(0).(func(string) []*v1.HealthCheck); ok {
-		r0 = rf(projectID)
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, projectID, region)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.HealthCheck)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(projectID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, projectID, region)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -331,16 +1738,16 @@ func (_m *Interface) GetHealthChecks(projectID string) ([]*v1.HealthCheck, error
 	return r0, r1
 }
 
-// GetInstanceGroups provides a mock function with given fields: projectID
-func (_m *Interface) GetInstanceGroups(projectID string) ([]*v1.InstanceGroup, error) {
+// GetRouters provides a mock function with given fields: projectID
+func (_m *Interface) GetRouters(projectID string) ([]*v1.Router, error) {
 	ret := _m.Called(projectID)
 
-	var r0 []*v1.InstanceGroup
-	if rf, ok := ret.Get(0).(func(string) []*v1.InstanceGroup); ok {
+	var r0 []*v1.Router
+	if rf, ok := ret.Get(0).(func(string) []*v1.Router); ok {
 		r0 = rf(projectID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.InstanceGroup)
+			r0 = ret.Get(0).([]*v1.Router)
 		}
 	}
 
@@ -354,22 +1761,22 @@ func (_m *Interface) GetInstanceGroups(projectID string) ([]*v1.InstanceGroup, e
 	return r0, r1
 }
 
-// GetInternalIPs provides a mock function with given fields: projectID, network
-func (_m *Interface) GetInternalIPs(projectID string, network string) ([]*compute.InstanceIP, error) {
-	ret := _m.Called(projectID, network)
+// GetRoutersCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetRoutersCtx(ctx context.Context, projectID string) ([]*v1.Router, error) {
+	ret := _m.Called(ctx, projectID)
 
-	var r0 []*compute.InstanceIP
-	if rf, ok := ret.Get(0).(func(string, string) []*compute.InstanceIP); ok {
-		r0 = rf(projectID, network)
+	var r0 []*v1.Router
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.Router); ok {
+		r0 = rf(ctx, projectID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*compute.InstanceIP)
+			r0 = ret.Get(0).([]*v1.Router)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(projectID, network)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -377,22 +1784,22 @@ func (_m *Interface) GetInternalIPs(projectID string, network string) ([]*comput
 	return r0, r1
 }
 
-// GetNetwork provides a mock function with given fields: projectID, name
-func (_m *Interface) GetNetwork(projectID string, name string) (*v1.Network, error) {
-	ret := _m.Called(projectID, name)
+// GetRoutes provides a mock function with given fields: projectID
+func (_m *Interface) GetRoutes(projectID string) ([]*v1.Route, error) {
+	ret := _m.Called(projectID)
 
-	var r0 *v1.Network
-	if rf, ok := ret.Get(0).(func(string, string) *v1.Network); ok {
-		r0 = rf(projectID, name)
+	var r0 []*v1.Route
+	if rf, ok := ret.Get(0).(func(string) []*v1.Route); ok {
+		r0 = rf(projectID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Network)
+			r0 = ret.Get(0).([]*v1.Route)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(projectID, name)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -400,22 +1807,22 @@ func (_m *Interface) GetNetwork(projectID string, name string) (*v1.Network, err
 	return r0, r1
 }
 
-// GetRegionZones provides a mock function with given fields: projectID, region
-func (_m *Interface) GetRegionZones(projectID string, region string) ([]string, error) {
-	ret := _m.Called(projectID, region)
+// GetRoutesCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetRoutesCtx(ctx context.Context, projectID string) ([]*v1.Route, error) {
+	ret := _m.Called(ctx, projectID)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
-		r0 = rf(projectID, region)
+	var r0 []*v1.Route
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.Route); ok {
+		r0 = rf(ctx, projectID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*v1.Route)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(projectID, region)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -446,6 +1853,29 @@ func (_m *Interface) GetTargetPools(projectID string) ([]*v1.TargetPool, error)
 	return r0, r1
 }
 
+// GetTargetPoolsCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetTargetPoolsCtx(ctx context.Context, projectID string) ([]*v1.TargetPool, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*v1.TargetPool
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1.TargetPool); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.TargetPool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Initialize provides a mock function with given fields: credentials, log
 func (_m *Interface) Initialize(credentials string, log logger.Interface) error {
 	ret := _m.Called(credentials, log)
@@ -460,13 +1890,13 @@ func (_m *Interface) Initialize(credentials string, log logger.Interface) error
 	return r0
 }
 
-// PowerOff provides a mock function with given fields: projectID
-func (_m *Interface) PowerOff(projectID string) error {
-	ret := _m.Called(projectID)
+// InitializeCtx provides a mock function with given fields: ctx, credentials, log
+func (_m *Interface) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	ret := _m.Called(ctx, credentials, log)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(projectID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface) error); ok {
+		r0 = rf(ctx, credentials, log)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -474,13 +1904,227 @@ func (_m *Interface) PowerOff(projectID string) error {
 	return r0
 }
 
-// PowerOn provides a mock function with given fields: projectID
-func (_m *Interface) PowerOn(projectID string) error {
-	ret := _m.Called(projectID)
+// PowerOff provides a mock function with given fields: projectID, opts
+func (_m *Interface) PowerOff(projectID string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(projectID)
+	if rf, ok := ret.Get(0).(func(string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PowerOffCtx provides a mock function with given fields: ctx, projectID, opts
+func (_m *Interface) PowerOffCtx(ctx context.Context, projectID string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PowerOn provides a mock function with given fields: projectID, opts
+func (_m *Interface) PowerOn(projectID string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PowerOnCtx provides a mock function with given fields: ctx, projectID, opts
+func (_m *Interface) PowerOnCtx(ctx context.Context, projectID string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveNetworkPeering provides a mock function with given fields: projectID, network, name, opts
+func (_m *Interface) RemoveNetworkPeering(projectID string, network string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, network, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, network, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveNetworkPeeringCtx provides a mock function with given fields: ctx, projectID, network, name, opts
+func (_m *Interface) RemoveNetworkPeeringCtx(ctx context.Context, projectID string, network string, name string, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, network, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, network, name, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCommonInstanceMetadata provides a mock function with given fields: projectID, metadataItems, opts
+func (_m *Interface) SetCommonInstanceMetadata(projectID string, metadataItems []*v1.MetadataItems, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, projectID, metadataItems)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []*v1.MetadataItems, ...compute.MutateOption) error); ok {
+		r0 = rf(projectID, metadataItems, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCommonInstanceMetadataCtx provides a mock function with given fields: ctx, projectID, metadataItems, opts
+func (_m *Interface) SetCommonInstanceMetadataCtx(ctx context.Context, projectID string, metadataItems []*v1.MetadataItems, opts ...compute.MutateOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID, metadataItems)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*v1.MetadataItems, ...compute.MutateOption) error); ok {
+		r0 = rf(ctx, projectID, metadataItems, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TeardownNetwork provides a mock function with given fields: projectID, network, opts
+func (_m *Interface) TeardownNetwork(projectID string, network string, opts compute.TeardownOptions) (*compute.TeardownReport, error) {
+	ret := _m.Called(projectID, network, opts)
+
+	var r0 *compute.TeardownReport
+	if rf, ok := ret.Get(0).(func(string, string, compute.TeardownOptions) *compute.TeardownReport); ok {
+		r0 = rf(projectID, network, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*compute.TeardownReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, compute.TeardownOptions) error); ok {
+		r1 = rf(projectID, network, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TeardownNetworkCtx provides a mock function with given fields: ctx, projectID, network, opts
+func (_m *Interface) TeardownNetworkCtx(ctx context.Context, projectID string, network string, opts compute.TeardownOptions) (*compute.TeardownReport, error) {
+	ret := _m.Called(ctx, projectID, network, opts)
+
+	var r0 *compute.TeardownReport
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, compute.TeardownOptions) *compute.TeardownReport); ok {
+		r0 = rf(ctx, projectID, network, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*compute.TeardownReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, compute.TeardownOptions) error); ok {
+		r1 = rf(ctx, projectID, network, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaitForOperation provides a mock function with given fields: projectID, operation
+func (_m *Interface) WaitForOperation(projectID string, operation *v1.Operation) error {
+	ret := _m.Called(projectID, operation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *v1.Operation) error); ok {
+		r0 = rf(projectID, operation)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -488,13 +2132,13 @@ func (_m *Interface) PowerOn(projectID string) error {
 	return r0
 }
 
-// SetCommonInstanceMetadata provides a mock function with given fields: projectID, metadataItems
-func (_m *Interface) SetCommonInstanceMetadata(projectID string, metadataItems []*v1.MetadataItems) error {
-	ret := _m.Called(projectID, metadataItems)
+// WaitForOperationCtx provides a mock function with given fields: ctx, projectID, operation
+func (_m *Interface) WaitForOperationCtx(ctx context.Context, projectID string, operation *v1.Operation) error {
+	ret := _m.Called(ctx, projectID, operation)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []*v1.MetadataItems) error); ok {
-		r0 = rf(projectID, metadataItems)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.Operation) error); ok {
+		r0 = rf(ctx, projectID, operation)
 	} else {
 		r0 = ret.Error(0)
 	}
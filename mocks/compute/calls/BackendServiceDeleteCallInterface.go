@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	compute "google.golang.org/api/compute/v1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	compute "google.golang.org/api/compute/v1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // BackendServiceDeleteCallInterface is an autogenerated mock type for the BackendServiceDeleteCallInterface type
@@ -14,20 +15,20 @@ type BackendServiceDeleteCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *BackendServiceDeleteCallInterface) Do(call *compute.BackendServicesDeleteCall, opts ...googleapi.CallOption) (*compute.Operation, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *BackendServiceDeleteCallInterface) Do(ctx context.Context, call *compute.BackendServicesDeleteCall, opts ...googleapi.CallOption) (*compute.Operation, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *compute.Operation
-	if rf, ok := ret.Get(0).(func(*compute.BackendServicesDeleteCall, ...googleapi.CallOption) *compute.Operation); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *compute.BackendServicesDeleteCall, ...googleapi.CallOption) *compute.Operation); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*compute.Operation)
@@ -35,8 +36,8 @@ func (_m *BackendServiceDeleteCallInterface) Do(call *compute.BackendServicesDel
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*compute.BackendServicesDeleteCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *compute.BackendServicesDeleteCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,46 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	compute "google.golang.org/api/compute/v1"
+	googleapi "google.golang.org/api/googleapi"
+)
+
+// InstanceGroupManagersListCallInterface is an autogenerated mock type for the InstanceGroupManagersListCallInterface type
+type InstanceGroupManagersListCallInterface struct {
+	mock.Mock
+}
+
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *InstanceGroupManagersListCallInterface) Do(ctx context.Context, call *compute.InstanceGroupManagersAggregatedListCall, opts ...googleapi.CallOption) (*compute.InstanceGroupManagerAggregatedList, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, call)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *compute.InstanceGroupManagerAggregatedList
+	if rf, ok := ret.Get(0).(func(context.Context, *compute.InstanceGroupManagersAggregatedListCall, ...googleapi.CallOption) *compute.InstanceGroupManagerAggregatedList); ok {
+		r0 = rf(ctx, call, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*compute.InstanceGroupManagerAggregatedList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *compute.InstanceGroupManagersAggregatedListCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	deploymentmanager "google.golang.org/api/deploymentmanager/v2beta"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	deploymentmanager "google.golang.org/api/deploymentmanager/v2beta"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // DeploymentsUpdateCallInterface is an autogenerated mock type for the DeploymentsUpdateCallInterface type
@@ -14,20 +15,20 @@ type DeploymentsUpdateCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *DeploymentsUpdateCallInterface) Do(call *deploymentmanager.DeploymentsUpdateCall, opts ...googleapi.CallOption) (*deploymentmanager.Operation, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *DeploymentsUpdateCallInterface) Do(ctx context.Context, call *deploymentmanager.DeploymentsUpdateCall, opts ...googleapi.CallOption) (*deploymentmanager.Operation, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *deploymentmanager.Operation
-	if rf, ok := ret.Get(0).(func(*deploymentmanager.DeploymentsUpdateCall, ...googleapi.CallOption) *deploymentmanager.Operation); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *deploymentmanager.DeploymentsUpdateCall, ...googleapi.CallOption) *deploymentmanager.Operation); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*deploymentmanager.Operation)
@@ -35,8 +36,8 @@ func (_m *DeploymentsUpdateCallInterface) Do(call *deploymentmanager.Deployments
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*deploymentmanager.DeploymentsUpdateCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *deploymentmanager.DeploymentsUpdateCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,9 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	logger "github.com/rockholla/go-lib/logger"
 	mock "github.com/stretchr/testify/mock"
-
 	v1 "google.golang.org/api/dns/v1"
 )
 
@@ -28,6 +29,48 @@ func (_m *Interface) DeleteResourceRecordSets(projectID string, managedZone stri
 	return r0
 }
 
+// DeleteResourceRecordSetsCtx provides a mock function with given fields: ctx, projectID, managedZone
+func (_m *Interface) DeleteResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string) error {
+	ret := _m.Called(ctx, projectID, managedZone)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, projectID, managedZone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachResourceRecordSet provides a mock function with given fields: projectID, managedZone, fn
+func (_m *Interface) ForEachResourceRecordSet(projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) error {
+	ret := _m.Called(projectID, managedZone, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, func(*v1.ResourceRecordSet) error) error); ok {
+		r0 = rf(projectID, managedZone, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachResourceRecordSetCtx provides a mock function with given fields: ctx, projectID, managedZone, fn
+func (_m *Interface) ForEachResourceRecordSetCtx(ctx context.Context, projectID string, managedZone string, fn func(*v1.ResourceRecordSet) error) error {
+	ret := _m.Called(ctx, projectID, managedZone, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, func(*v1.ResourceRecordSet) error) error); ok {
+		r0 = rf(ctx, projectID, managedZone, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetResourceRecordSet provides a mock function with given fields: projectID, managedZone, name
 func (_m *Interface) GetResourceRecordSet(projectID string, managedZone string, name string) (*v1.ResourceRecordSet, error) {
 	ret := _m.Called(projectID, managedZone, name)
@@ -51,6 +94,29 @@ func (_m *Interface) GetResourceRecordSet(projectID string, managedZone string,
 	return r0, r1
 }
 
+// GetResourceRecordSetCtx provides a mock function with given fields: ctx, projectID, managedZone, name
+func (_m *Interface) GetResourceRecordSetCtx(ctx context.Context, projectID string, managedZone string, name string) (*v1.ResourceRecordSet, error) {
+	ret := _m.Called(ctx, projectID, managedZone, name)
+
+	var r0 *v1.ResourceRecordSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *v1.ResourceRecordSet); ok {
+		r0 = rf(ctx, projectID, managedZone, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.ResourceRecordSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, projectID, managedZone, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetResourceRecordSets provides a mock function with given fields: projectID, managedZone
 func (_m *Interface) GetResourceRecordSets(projectID string, managedZone string) ([]*v1.ResourceRecordSet, error) {
 	ret := _m.Called(projectID, managedZone)
@@ -74,6 +140,29 @@ func (_m *Interface) GetResourceRecordSets(projectID string, managedZone string)
 	return r0, r1
 }
 
+// GetResourceRecordSetsCtx provides a mock function with given fields: ctx, projectID, managedZone
+func (_m *Interface) GetResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string) ([]*v1.ResourceRecordSet, error) {
+	ret := _m.Called(ctx, projectID, managedZone)
+
+	var r0 []*v1.ResourceRecordSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*v1.ResourceRecordSet); ok {
+		r0 = rf(ctx, projectID, managedZone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.ResourceRecordSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, projectID, managedZone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Initialize provides a mock function with given fields: credentials, log
 func (_m *Interface) Initialize(credentials string, log logger.Interface) error {
 	ret := _m.Called(credentials, log)
@@ -88,6 +177,20 @@ func (_m *Interface) Initialize(credentials string, log logger.Interface) error
 	return r0
 }
 
+// InitializeCtx provides a mock function with given fields: ctx, credentials, log
+func (_m *Interface) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	ret := _m.Called(ctx, credentials, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface) error); ok {
+		r0 = rf(ctx, credentials, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetResourceRecordSets provides a mock function with given fields: projectID, managedZone, records
 func (_m *Interface) SetResourceRecordSets(projectID string, managedZone string, records []*v1.ResourceRecordSet) error {
 	ret := _m.Called(projectID, managedZone, records)
@@ -101,3 +204,17 @@ func (_m *Interface) SetResourceRecordSets(projectID string, managedZone string,
 
 	return r0
 }
+
+// SetResourceRecordSetsCtx provides a mock function with given fields: ctx, projectID, managedZone, records
+func (_m *Interface) SetResourceRecordSetsCtx(ctx context.Context, projectID string, managedZone string, records []*v1.ResourceRecordSet) error {
+	ret := _m.Called(ctx, projectID, managedZone, records)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []*v1.ResourceRecordSet) error); ok {
+		r0 = rf(ctx, projectID, managedZone, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
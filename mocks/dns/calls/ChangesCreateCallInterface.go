@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	dns "google.golang.org/api/dns/v1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	dns "google.golang.org/api/dns/v1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // ChangesCreateCallInterface is an autogenerated mock type for the ChangesCreateCallInterface type
@@ -14,20 +15,20 @@ type ChangesCreateCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *ChangesCreateCallInterface) Do(call *dns.ChangesCreateCall, opts ...googleapi.CallOption) (*dns.Change, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *ChangesCreateCallInterface) Do(ctx context.Context, call *dns.ChangesCreateCall, opts ...googleapi.CallOption) (*dns.Change, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *dns.Change
-	if rf, ok := ret.Get(0).(func(*dns.ChangesCreateCall, ...googleapi.CallOption) *dns.Change); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *dns.ChangesCreateCall, ...googleapi.CallOption) *dns.Change); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dns.Change)
@@ -35,8 +36,8 @@ func (_m *ChangesCreateCallInterface) Do(call *dns.ChangesCreateCall, opts ...go
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*dns.ChangesCreateCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *dns.ChangesCreateCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	dns "google.golang.org/api/dns/v1"
-	googleapi "google.golang.org/api/googleapi"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	dns "google.golang.org/api/dns/v1"
+	googleapi "google.golang.org/api/googleapi"
 )
 
 // ResourceRecordSetsListCallInterface is an autogenerated mock type for the ResourceRecordSetsListCallInterface type
@@ -14,20 +15,20 @@ type ResourceRecordSetsListCallInterface struct {
 	mock.Mock
 }
 
-// Do provides a mock function with given fields: call, opts
-func (_m *ResourceRecordSetsListCallInterface) Do(call *dns.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*dns.ResourceRecordSetsListResponse, error) {
+// Do provides a mock function with given fields: ctx, call, opts
+func (_m *ResourceRecordSetsListCallInterface) Do(ctx context.Context, call *dns.ResourceRecordSetsListCall, opts ...googleapi.CallOption) (*dns.ResourceRecordSetsListResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, call)
+	_ca = append(_ca, ctx, call)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *dns.ResourceRecordSetsListResponse
-	if rf, ok := ret.Get(0).(func(*dns.ResourceRecordSetsListCall, ...googleapi.CallOption) *dns.ResourceRecordSetsListResponse); ok {
-		r0 = rf(call, opts...)
+	if rf, ok := ret.Get(0).(func(context.Context, *dns.ResourceRecordSetsListCall, ...googleapi.CallOption) *dns.ResourceRecordSetsListResponse); ok {
+		r0 = rf(ctx, call, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dns.ResourceRecordSetsListResponse)
@@ -35,8 +36,8 @@ func (_m *ResourceRecordSetsListCallInterface) Do(call *dns.ResourceRecordSetsLi
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*dns.ResourceRecordSetsListCall, ...googleapi.CallOption) error); ok {
-		r1 = rf(call, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *dns.ResourceRecordSetsListCall, ...googleapi.CallOption) error); ok {
+		r1 = rf(ctx, call, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,9 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	iam "github.com/rockholla/go-google-lib/iam"
 	logger "github.com/rockholla/go-lib/logger"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +15,20 @@ type Interface struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields:
+func (_m *Interface) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteServiceAccount provides a mock function with given fields: projectID, serviceAccountName
 func (_m *Interface) DeleteServiceAccount(projectID string, serviceAccountName string) error {
 	ret := _m.Called(projectID, serviceAccountName)
@@ -28,6 +43,20 @@ func (_m *Interface) DeleteServiceAccount(projectID string, serviceAccountName s
 	return r0
 }
 
+// DeleteServiceAccountCtx provides a mock function with given fields: ctx, projectID, serviceAccountName
+func (_m *Interface) DeleteServiceAccountCtx(ctx context.Context, projectID string, serviceAccountName string) error {
+	ret := _m.Called(ctx, projectID, serviceAccountName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, projectID, serviceAccountName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnsureServiceAccount provides a mock function with given fields: projectID, serviceAccount, createNewKey
 func (_m *Interface) EnsureServiceAccount(projectID string, serviceAccount *iam.ServiceAccount, createNewKey bool) error {
 	ret := _m.Called(projectID, serviceAccount, createNewKey)
@@ -42,6 +71,20 @@ func (_m *Interface) EnsureServiceAccount(projectID string, serviceAccount *iam.
 	return r0
 }
 
+// EnsureServiceAccountCtx provides a mock function with given fields: ctx, projectID, serviceAccount, createNewKey
+func (_m *Interface) EnsureServiceAccountCtx(ctx context.Context, projectID string, serviceAccount *iam.ServiceAccount, createNewKey bool) error {
+	ret := _m.Called(ctx, projectID, serviceAccount, createNewKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *iam.ServiceAccount, bool) error); ok {
+		r0 = rf(ctx, projectID, serviceAccount, createNewKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Initialize provides a mock function with given fields: credentials, log
 func (_m *Interface) Initialize(credentials string, log logger.Interface) error {
 	ret := _m.Called(credentials, log)
@@ -55,3 +98,17 @@ func (_m *Interface) Initialize(credentials string, log logger.Interface) error
 
 	return r0
 }
+
+// InitializeCtx provides a mock function with given fields: ctx, credentials, log
+func (_m *Interface) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	ret := _m.Called(ctx, credentials, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface) error); ok {
+		r0 = rf(ctx, credentials, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
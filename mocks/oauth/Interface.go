@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	logger "github.com/rockholla/go-lib/logger"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -33,6 +35,27 @@ func (_m *Interface) GetAccessToken() (string, error) {
 	return r0, r1
 }
 
+// GetAccessTokenCtx provides a mock function with given fields: ctx
+func (_m *Interface) GetAccessTokenCtx(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Initialize provides a mock function with given fields: credentials, log, scopes
 func (_m *Interface) Initialize(credentials string, log logger.Interface, scopes []string) error {
 	ret := _m.Called(credentials, log, scopes)
@@ -46,3 +69,17 @@ func (_m *Interface) Initialize(credentials string, log logger.Interface, scopes
 
 	return r0
 }
+
+// InitializeCtx provides a mock function with given fields: ctx, credentials, log, scopes
+func (_m *Interface) InitializeCtx(ctx context.Context, credentials string, log logger.Interface, scopes []string) error {
+	ret := _m.Called(ctx, credentials, log, scopes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface, []string) error); ok {
+		r0 = rf(ctx, credentials, log, scopes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
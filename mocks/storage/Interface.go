@@ -3,12 +3,12 @@
 package mocks
 
 import (
-	go_google_libstorage "github.com/rockholla/go-google-lib/storage"
-	logger "github.com/rockholla/go-lib/logger"
+	context "context"
 
+	gostorage "cloud.google.com/go/storage"
+	storage "github.com/rockholla/go-google-lib/storage"
+	logger "github.com/rockholla/go-lib/logger"
 	mock "github.com/stretchr/testify/mock"
-
-	storage "cloud.google.com/go/storage"
 )
 
 // Interface is an autogenerated mock type for the Interface type
@@ -16,12 +16,26 @@ type Interface struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields:
+func (_m *Interface) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnsureBucket provides a mock function with given fields: name, projectID, attrs
-func (_m *Interface) EnsureBucket(name string, projectID string, attrs *storage.BucketAttrs) error {
+func (_m *Interface) EnsureBucket(name string, projectID string, attrs *gostorage.BucketAttrs) error {
 	ret := _m.Called(name, projectID, attrs)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, *storage.BucketAttrs) error); ok {
+	if rf, ok := ret.Get(0).(func(string, string, *gostorage.BucketAttrs) error); ok {
 		r0 = rf(name, projectID, attrs)
 	} else {
 		r0 = ret.Error(0)
@@ -30,6 +44,20 @@ func (_m *Interface) EnsureBucket(name string, projectID string, attrs *storage.
 	return r0
 }
 
+// EnsureBucketCtx provides a mock function with given fields: ctx, name, projectID, attrs
+func (_m *Interface) EnsureBucketCtx(ctx context.Context, name string, projectID string, attrs *gostorage.BucketAttrs) error {
+	ret := _m.Called(ctx, name, projectID, attrs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gostorage.BucketAttrs) error); ok {
+		r0 = rf(ctx, name, projectID, attrs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnsureBucketRoles provides a mock function with given fields: bucket, member, roles
 func (_m *Interface) EnsureBucketRoles(bucket string, member string, roles []string) error {
 	ret := _m.Called(bucket, member, roles)
@@ -44,12 +72,26 @@ func (_m *Interface) EnsureBucketRoles(bucket string, member string, roles []str
 	return r0
 }
 
+// EnsureBucketRolesCtx provides a mock function with given fields: ctx, bucket, member, roles
+func (_m *Interface) EnsureBucketRolesCtx(ctx context.Context, bucket string, member string, roles []string) error {
+	ret := _m.Called(ctx, bucket, member, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, bucket, member, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnsureObject provides a mock function with given fields: bucket, path, object
-func (_m *Interface) EnsureObject(bucket string, path string, object *go_google_libstorage.Object) error {
+func (_m *Interface) EnsureObject(bucket string, path string, object *storage.Object) error {
 	ret := _m.Called(bucket, path, object)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, *go_google_libstorage.Object) error); ok {
+	if rf, ok := ret.Get(0).(func(string, string, *storage.Object) error); ok {
 		r0 = rf(bucket, path, object)
 	} else {
 		r0 = ret.Error(0)
@@ -58,6 +100,20 @@ func (_m *Interface) EnsureObject(bucket string, path string, object *go_google_
 	return r0
 }
 
+// EnsureObjectCtx provides a mock function with given fields: ctx, bucket, path, object
+func (_m *Interface) EnsureObjectCtx(ctx context.Context, bucket string, path string, object *storage.Object) error {
+	ret := _m.Called(ctx, bucket, path, object)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *storage.Object) error); ok {
+		r0 = rf(ctx, bucket, path, object)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetObject provides a mock function with given fields: bucket, path
 func (_m *Interface) GetObject(bucket string, path string) ([]byte, error) {
 	ret := _m.Called(bucket, path)
@@ -81,6 +137,29 @@ func (_m *Interface) GetObject(bucket string, path string) ([]byte, error) {
 	return r0, r1
 }
 
+// GetObjectCtx provides a mock function with given fields: ctx, bucket, path
+func (_m *Interface) GetObjectCtx(ctx context.Context, bucket string, path string) ([]byte, error) {
+	ret := _m.Called(ctx, bucket, path)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
+		r0 = rf(ctx, bucket, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bucket, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetServiceAccount provides a mock function with given fields: projectID
 func (_m *Interface) GetServiceAccount(projectID string) (string, error) {
 	ret := _m.Called(projectID)
@@ -102,6 +181,27 @@ func (_m *Interface) GetServiceAccount(projectID string) (string, error) {
 	return r0, r1
 }
 
+// GetServiceAccountCtx provides a mock function with given fields: ctx, projectID
+func (_m *Interface) GetServiceAccountCtx(ctx context.Context, projectID string) (string, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Initialize provides a mock function with given fields: credentials, log
 func (_m *Interface) Initialize(credentials string, log logger.Interface) error {
 	ret := _m.Called(credentials, log)
@@ -115,3 +215,109 @@ func (_m *Interface) Initialize(credentials string, log logger.Interface) error
 
 	return r0
 }
+
+// InitializeCtx provides a mock function with given fields: ctx, credentials, log
+func (_m *Interface) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
+	ret := _m.Called(ctx, credentials, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, logger.Interface) error); ok {
+		r0 = rf(ctx, credentials, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveBucketMember provides a mock function with given fields: bucket, member
+func (_m *Interface) RemoveBucketMember(bucket string, member string) ([]string, error) {
+	ret := _m.Called(bucket, member)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(bucket, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(bucket, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveBucketMemberCtx provides a mock function with given fields: ctx, bucket, member
+func (_m *Interface) RemoveBucketMemberCtx(ctx context.Context, bucket string, member string) ([]string, error) {
+	ret := _m.Called(ctx, bucket, member)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, bucket, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bucket, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveBucketRoles provides a mock function with given fields: bucket, member, roles
+func (_m *Interface) RemoveBucketRoles(bucket string, member string, roles []string) ([]string, error) {
+	ret := _m.Called(bucket, member, roles)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, []string) []string); ok {
+		r0 = rf(bucket, member, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string) error); ok {
+		r1 = rf(bucket, member, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveBucketRolesCtx provides a mock function with given fields: ctx, bucket, member, roles
+func (_m *Interface) RemoveBucketRolesCtx(ctx context.Context, bucket string, member string, roles []string) ([]string, error) {
+	ret := _m.Called(ctx, bucket, member, roles)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) []string); ok {
+		r0 = rf(ctx, bucket, member, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
+		r1 = rf(ctx, bucket, member, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
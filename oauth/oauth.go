@@ -6,6 +6,8 @@ import (
 
 	"github.com/rockholla/go-lib/logger"
 	googleoauth "golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	"google.golang.org/api/transport"
 )
 
 // will be used if no list of scopes is provided explicitly
@@ -21,36 +23,36 @@ var defaultScopes = []string{
 // Interface represents functionality for OAuth
 type Interface interface {
 	Initialize(credentials string, log logger.Interface, scopes []string) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface, scopes []string) error
 	GetAccessToken() (string, error)
+	GetAccessTokenCtx(ctx context.Context) (string, error)
 }
 
 // OAuth wraps google-provided apis for interacting with pkg.go.dev/golang.org/x/oauth2/google/*
 type OAuth struct {
-	log         logger.Interface
-	Credentials *googleoauth.Credentials
+	log           logger.Interface
+	Credentials   *googleoauth.Credentials
+	ClientOptions []option.ClientOption
 }
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (o *OAuth) Initialize(credentials string, log logger.Interface, scopes []string) error {
+	return o.InitializeCtx(context.Background(), credentials, log, scopes)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (o *OAuth) InitializeCtx(ctx context.Context, credentials string, log logger.Interface, scopes []string) error {
 	var err error
-	ctx := context.Background()
 	o.log = log
 	if len(scopes) == 0 {
 		scopes = defaultScopes
 	}
+	clientOptions := append([]option.ClientOption{option.WithScopes(scopes...)}, o.ClientOptions...)
 	if credentials != "" {
-		o.Credentials, err = googleoauth.CredentialsFromJSON(ctx, []byte(credentials), scopes...)
-		if err != nil {
-			return err
-		}
-	} else {
-		defaultTokenSource, err := googleoauth.DefaultTokenSource(ctx, scopes...)
-		if err != nil {
-			return err
-		}
-		o.Credentials = &googleoauth.Credentials{
-			TokenSource: defaultTokenSource,
-		}
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if o.Credentials, err = transport.Creds(ctx, clientOptions...); err != nil {
+		return err
 	}
 	return nil
 }
@@ -58,6 +60,15 @@ func (o *OAuth) Initialize(credentials string, log logger.Interface, scopes []st
 // GetAccessToken will return the access token as a string
 // TODO: unit test this
 func (o *OAuth) GetAccessToken() (string, error) {
+	return o.GetAccessTokenCtx(context.Background())
+}
+
+// GetAccessTokenCtx is GetAccessToken, returning early if the provided context is already done. The token
+// source itself is bound to the context used during initialization
+func (o *OAuth) GetAccessTokenCtx(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	token, err := o.Credentials.TokenSource.Token()
 	if err != nil {
 		return "", err
@@ -0,0 +1,158 @@
+package google
+
+import (
+	"net/http"
+
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+)
+
+// storageEmulatorHostEnv is the environment variable the storage client uses to send requests to an emulator
+const storageEmulatorHostEnv = "STORAGE_EMULATOR_HOST"
+
+// Service identifies the library an option applies to
+type Service string
+
+// Services that can be configured individually
+const (
+	ServiceCloudResourceManager Service = "cloudresourcemanager"
+	ServiceCloudBilling         Service = "cloudbilling"
+	ServiceCloudIdentity        Service = "cloudidentity"
+	ServiceCloudKMS             Service = "cloudkms"
+	ServiceCompute              Service = "compute"
+	ServiceDeploymentManager    Service = "deploymentmanager"
+	ServiceDNS                  Service = "dns"
+	ServiceIAM                  Service = "iam"
+	ServiceServiceUsage         Service = "serviceusage" // enables project services for cloud resource manager
+	ServiceStorage              Service = "storage"
+)
+
+// Option configures how all libraries authenticate with and connect to the google apis, see Initialize
+type Option func(*settings)
+
+// endpoint is an override of where a service's requests are sent
+type endpoint struct {
+	url      string
+	insecure bool
+}
+
+// WithCredentialsFile will authenticate using a service account or refresh token JSON credentials file
+func WithCredentialsFile(filename string) Option {
+	return withCredentialOption(option.WithCredentialsFile(filename))
+}
+
+// WithTokenSource will authenticate using tokens from the provided source
+func WithTokenSource(tokenSource oauth2.TokenSource) Option {
+	return withCredentialOption(option.WithTokenSource(tokenSource))
+}
+
+// WithImpersonatedServiceAccount will authenticate as the service account, impersonated by the base credentials,
+// optionally through a chain of delegates, each of which must be granted the token creator role on the next
+func WithImpersonatedServiceAccount(serviceAccountEmail string, delegates ...string) Option {
+	return withCredentialOption(option.ImpersonateCredentials(serviceAccountEmail, delegates...))
+}
+
+// WithQuotaProject will bill the api quota and usage of all requests to the project
+func WithQuotaProject(projectID string) Option {
+	return withClientOption(option.WithQuotaProject(projectID))
+}
+
+// WithEndpoint will send all requests to the endpoint instead of each api's default
+func WithEndpoint(endpoint string) Option {
+	return withClientOption(option.WithEndpoint(endpoint))
+}
+
+// WithUserAgent will identify all requests with the user agent
+func WithUserAgent(userAgent string) Option {
+	return withClientOption(option.WithUserAgent(userAgent))
+}
+
+// WithHTTPClient will make all http requests with the client, which is then responsible for authentication, so it
+// can't be combined with WithQuotaProject. Libraries using gRPC (iam and cloud kms) are unaffected.
+func WithHTTPClient(client *http.Client) Option {
+	return withClientOption(option.WithHTTPClient(client))
+}
+
+// WithTransport will send all http requests through the transport, underneath authentication, e.g. a
+// recorder.Recorder to record or replay them. Libraries using gRPC (iam and cloud kms) are unaffected.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(s *settings) {
+		s.transport = transport
+	}
+}
+
+// WithEventSink will send an event for each operation of every library to the sink, and what they'd otherwise log as
+// prose to it as message events, e.g. events.NewJSONSink(os.Stderr, events.LevelInfo) for json logging or
+// events.Silent() for none at all
+func WithEventSink(sink events.Sink) Option {
+	return func(s *settings) {
+		s.events = sink
+	}
+}
+
+// WithTracerProvider will record a span for each underlying api call of every library with a tracer from the
+// provider, e.g. an sdk provider exporting to a collector
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(s *settings) {
+		s.tracerProvider = tracerProvider
+	}
+}
+
+// WithMeterProvider will record call, error and latency metrics for the underlying api calls of every library with a
+// meter from the provider
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return func(s *settings) {
+		s.meterProvider = meterProvider
+	}
+}
+
+// WithDryRun will make a dry run of every library: their mutating methods still make their reads, but add the changes
+// they'd make to the plan instead of making them, to be reviewed with p.Changes() before applying them without it
+func WithDryRun(p *plan.Plan) Option {
+	return func(s *settings) {
+		s.dryRun = p
+	}
+}
+
+// WithConflictRetryPolicy will set how the libraries changing iam policies, cloud resource manager, cloud billing
+// and storage, retry an update conflicting with a concurrent change to the policy, from a fresh read of it. Each
+// library uses iampolicy.DefaultRetryPolicy() when one isn't set.
+func WithConflictRetryPolicy(policy *retry.Policy) Option {
+	return func(s *settings) {
+		s.conflictRetry = policy
+	}
+}
+
+// WithServiceEndpoint will send a service's requests to the endpoint instead of the api's default, taking
+// precedence over WithEndpoint. For http apis it's the base url including the api's path, e.g.
+// "http://localhost:8080/compute/v1/", and for gRPC apis (iam and cloud kms) it's a host:port.
+func WithServiceEndpoint(service Service, url string) Option {
+	return func(s *settings) {
+		s.endpoints[service] = endpoint{url: url}
+	}
+}
+
+// WithInsecureServiceEndpoint is WithServiceEndpoint for local emulators and fakes, sending the service's requests
+// without credentials and, for gRPC apis, without TLS
+func WithInsecureServiceEndpoint(service Service, url string) Option {
+	return func(s *settings) {
+		s.endpoints[service] = endpoint{url: url, insecure: true}
+	}
+}
+
+func withClientOption(clientOption option.ClientOption) Option {
+	return func(s *settings) {
+		s.clientOptions = append(s.clientOptions, clientOption)
+	}
+}
+
+func withCredentialOption(clientOption option.ClientOption) Option {
+	return func(s *settings) {
+		s.credentialOptions = append(s.credentialOptions, clientOption)
+	}
+}
@@ -0,0 +1,132 @@
+// Package plan is the library for dry runs of the google libraries. When a library has a Plan, its mutating methods
+// still make their reads, but add the changes they'd make to the plan instead of making them, so they can be reviewed
+// before being applied.
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Action is what a change does to its resource
+type Action string
+
+// Actions of changes
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a single change a mutating method would make. Before is the resource as it is, nil when it's to be
+// created or unknown, and After is the resource as it would be, nil when it's to be deleted or unknown. Both are the
+// api's types for the resource, or Roles for changes to the roles of an iam policy.
+type Change struct {
+	Service  string
+	Method   string
+	Project  string
+	Resource string
+	Action   Action
+	Before   interface{}
+	After    interface{}
+}
+
+// String will return a description of the change, e.g. "update dns.SetResourceRecordSets zone/name in project id"
+func (c *Change) String() string {
+	parts := []string{string(c.Action), fmt.Sprintf("%s.%s", c.Service, c.Method)}
+	if c.Resource != "" {
+		parts = append(parts, c.Resource)
+	}
+	if c.Project != "" {
+		parts = append(parts, fmt.Sprintf("in project %s", c.Project))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Plan is the changes planned by a dry run, in the order they were planned. The zero value is ready to use, and it's
+// safe for concurrent use.
+type Plan struct {
+	mutex   sync.Mutex
+	changes []Change
+}
+
+// New will return an empty plan
+func New() *Plan {
+	return &Plan{}
+}
+
+// Record will add the changes to the plan, returning whether it did, i.e. whether this is a dry run and the changes
+// shouldn't be made. A nil plan records nothing, so methods can always call it, e.g.
+//
+//	if d.DryRun.Record(changes...) {
+//		return nil
+//	}
+func (p *Plan) Record(changes ...Change) bool {
+	if p == nil {
+		return false
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.changes = append(p.changes, changes...)
+	return true
+}
+
+// Changes will return the changes planned so far, in order
+func (p *Plan) Changes() []Change {
+	if p == nil {
+		return nil
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]Change{}, p.changes...)
+}
+
+// Roles is the members granted each role of an iam policy
+type Roles map[string][]string
+
+// RolesChanges will return the change with Before and After set to the roles whose members differ between before and
+// after, regardless of their order, or no changes at all when none differ
+func RolesChanges(change Change, before Roles, after Roles) []Change {
+	changedBefore := Roles{}
+	changedAfter := Roles{}
+	for role := range merge(before, after) {
+		if !sameMembers(before[role], after[role]) {
+			changedBefore[role] = before[role]
+			changedAfter[role] = after[role]
+		}
+	}
+	if len(changedAfter) == 0 {
+		return nil
+	}
+	change.Before = changedBefore
+	change.After = changedAfter
+	return []Change{change}
+}
+
+func merge(roles ...Roles) Roles {
+	merged := Roles{}
+	for _, r := range roles {
+		for role, members := range r {
+			merged[role] = members
+		}
+	}
+	return merged
+}
+
+func sameMembers(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	p := New()
+	if !p.Record(Change{Action: ActionCreate, Resource: "one"}, Change{Action: ActionDelete, Resource: "two"}) {
+		t.Errorf("Expected plan.Record() to record to a plan")
+	}
+	changes := p.Changes()
+	if len(changes) != 2 || changes[0].Resource != "one" || changes[1].Resource != "two" {
+		t.Errorf("Expected the recorded changes in order from plan.Changes(), got: %v", changes)
+	}
+}
+
+func TestRecordNil(t *testing.T) {
+	var p *Plan
+	if p.Record(Change{Action: ActionCreate}) {
+		t.Errorf("Expected plan.Record() not to record to a nil plan")
+	}
+	if changes := p.Changes(); len(changes) != 0 {
+		t.Errorf("Expected no changes from plan.Changes() of a nil plan, got: %v", changes)
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	change := &Change{Service: "dns", Method: "SetResourceRecordSets", Project: "project", Resource: "zone/name", Action: ActionUpdate}
+	expected := "update dns.SetResourceRecordSets zone/name in project project"
+	if change.String() != expected {
+		t.Errorf("Expected %q from plan.Change.String(), got %q", expected, change.String())
+	}
+}
+
+func TestRolesChanges(t *testing.T) {
+	before := Roles{"role1": {"a", "b"}, "role2": {"a"}, "role3": {"c"}}
+	after := Roles{"role1": {"b", "a"}, "role2": {"a", "b"}, "role4": {"d"}}
+	changes := RolesChanges(Change{Resource: "projects/test", Action: ActionUpdate}, before, after)
+	if len(changes) != 1 || changes[0].Resource != "projects/test" {
+		t.Fatalf("Expected a single change from plan.RolesChanges(), got: %v", changes)
+	}
+	expectedBefore := Roles{"role2": {"a"}, "role3": {"c"}, "role4": nil}
+	expectedAfter := Roles{"role2": {"a", "b"}, "role3": nil, "role4": {"d"}}
+	if !reflect.DeepEqual(changes[0].Before, expectedBefore) || !reflect.DeepEqual(changes[0].After, expectedAfter) {
+		t.Errorf("Expected only the roles whose members differ from plan.RolesChanges(), got: %v => %v", changes[0].Before, changes[0].After)
+	}
+	if changes := RolesChanges(Change{}, before, before); len(changes) != 0 {
+		t.Errorf("Expected no changes from plan.RolesChanges() for the same roles, got: %v", changes)
+	}
+}
@@ -0,0 +1,364 @@
+// Package recorder is the library for recording api requests and responses to golden files and replaying them, so
+// that tests can exercise the real api clients, and how they serialize requests, without calling the real apis
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode is whether a recorder records or replays
+type Mode int
+
+const (
+	// Replay serves responses from the golden file, failing any request that wasn't recorded
+	Replay Mode = iota
+	// Record sends requests through to the apis, capturing them and their responses for the golden file
+	Record
+)
+
+// RecordEnv is the environment variable that, set to true or 1, makes ModeFromEnv return Record
+const RecordEnv = "GO_GOOGLE_LIB_RECORD"
+
+// redacted replaces the values of secrets in recorded interactions
+const redacted = "REDACTED"
+
+// DefaultRedactedHeaders are the headers whose values are always redacted
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Goog-Api-Key",
+}
+
+// DefaultRedactedFields are the json body fields, form fields and query parameters whose values are always redacted
+var DefaultRedactedFields = []string{
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"client_secret",
+	"private_key",
+	"private_key_id",
+	"privateKeyData",
+	"assertion",
+}
+
+// DefaultRedactedParameters are the query parameters whose values are always redacted, along with the redacted fields
+var DefaultRedactedParameters = []string{
+	"key",
+}
+
+// Interaction is a single recorded request and its response
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// Request is a recorded request
+type Request struct {
+	Method    string      `json:"method"`
+	URL       string      `json:"url"`
+	Header    http.Header `json:"header,omitempty"`
+	Body      string      `json:"body,omitempty"`
+	BodyBytes []byte      `json:"bodyBytes,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBytes  []byte      `json:"bodyBytes,omitempty"`
+}
+
+// golden is the format of a golden file
+type golden struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that, in Record mode, sends requests through to the apis and captures them, with
+// secrets redacted, to be saved to a golden file on Close. In Replay mode it serves the responses from the golden
+// file instead, each recorded interaction once and in order, so repeated identical requests, like polling an
+// operation, get the same sequence of responses they did when recorded. It's meant to be set as the base Transport
+// of the libraries, underneath authentication, and is safe for concurrent use.
+type Recorder struct {
+	// Transport is where requests are sent in Record mode, http.DefaultTransport when nil
+	Transport http.RoundTripper
+	// RedactHeaders are headers to redact in addition to DefaultRedactedHeaders
+	RedactHeaders []string
+	// RedactFields are json body fields, form fields and query parameters to redact in addition to
+	// DefaultRedactedFields
+	RedactFields []string
+	mode         Mode
+	filename     string
+	mutex        sync.Mutex
+	interactions []*Interaction
+	replayed     []bool
+}
+
+// New will return a recorder for a golden file, loading the file's interactions in Replay mode
+func New(filename string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		mode:     mode,
+		filename: filename,
+	}
+	if mode == Record {
+		return r, nil
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading golden file %s: %s", filename, err)
+	}
+	file := &golden{}
+	if err := json.Unmarshal(content, file); err != nil {
+		return nil, fmt.Errorf("error parsing golden file %s: %s", filename, err)
+	}
+	r.interactions = file.Interactions
+	r.replayed = make([]bool, len(file.Interactions))
+	return r, nil
+}
+
+// ModeFromEnv will return Record if the RecordEnv environment variable is set to true or 1, Replay otherwise
+func ModeFromEnv() Mode {
+	switch strings.ToLower(os.Getenv(RecordEnv)) {
+	case "true", "1":
+		return Record
+	}
+	return Replay
+}
+
+// Mode will return whether the recorder is recording or replaying
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip will record or replay a single request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recordedRequest, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == Replay {
+		return r.replay(req, recordedRequest)
+	}
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	recordedResponse := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     r.redactHeader(resp.Header),
+	}
+	recordedResponse.Body, recordedResponse.BodyBytes = r.redactBody(resp.Header.Get("Content-Type"), body)
+	r.mutex.Lock()
+	r.interactions = append(r.interactions, &Interaction{Request: recordedRequest, Response: recordedResponse})
+	r.mutex.Unlock()
+	return resp, nil
+}
+
+// Interactions will return the interactions recorded so far, or loaded from the golden file when replaying
+func (r *Recorder) Interactions() []*Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]*Interaction{}, r.interactions...)
+}
+
+// Close will save the recorded interactions to the golden file in Record mode, and is a no-op in Replay mode
+func (r *Recorder) Close() error {
+	if r.mode != Record {
+		return nil
+	}
+	r.mutex.Lock()
+	content, err := json.MarshalIndent(&golden{Interactions: r.interactions}, "", "  ")
+	r.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.filename), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.filename, append(content, '\n'), 0644)
+}
+
+// replay will serve the first interaction not yet replayed that matches the request
+func (r *Recorder) replay(req *http.Request, recordedRequest *Request) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || !matches(interaction.Request, recordedRequest) {
+			continue
+		}
+		r.replayed[i] = true
+		body := []byte(interaction.Response.Body)
+		if interaction.Response.BodyBytes != nil {
+			body = interaction.Response.BodyBytes
+		}
+		header := http.Header{}
+		for name, values := range interaction.Response.Header {
+			header[name] = append([]string{}, values...)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded response in %s for %s %s", r.filename, recordedRequest.Method, recordedRequest.URL)
+}
+
+// recordRequest will capture a request, with secrets redacted, leaving its body readable
+func (r *Recorder) recordRequest(req *http.Request) (*Request, error) {
+	recorded := &Request{
+		Method: req.Method,
+		URL:    r.redactURL(req.URL),
+		Header: r.redactHeader(req.Header),
+	}
+	if req.Body == nil {
+		return recorded, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	recorded.Body, recorded.BodyBytes = r.redactBody(req.Header.Get("Content-Type"), body)
+	return recorded, nil
+}
+
+// matches will compare requests by method, url and, when both are json, body
+func matches(recorded *Request, req *Request) bool {
+	if recorded.Method != req.Method || recorded.URL != req.URL {
+		return false
+	}
+	var recordedBody, reqBody interface{}
+	if json.Unmarshal([]byte(recorded.Body), &recordedBody) != nil || json.Unmarshal([]byte(req.Body), &reqBody) != nil {
+		return true
+	}
+	return reflect.DeepEqual(recordedBody, reqBody)
+}
+
+func (r *Recorder) redactURL(u *url.URL) string {
+	redactedURL := *u
+	query := redactedURL.Query()
+	changed := false
+	for name := range query {
+		if r.isRedactedField(name) || contains(DefaultRedactedParameters, name) {
+			query.Set(name, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		redactedURL.RawQuery = query.Encode()
+	}
+	return redactedURL.String()
+}
+
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	redactedHeader := http.Header{}
+	for name, values := range header {
+		redactedHeader[name] = append([]string{}, values...)
+	}
+	for _, name := range append(append([]string{}, DefaultRedactedHeaders...), r.RedactHeaders...) {
+		if redactedHeader.Get(name) != "" {
+			redactedHeader.Set(name, redacted)
+		}
+	}
+	return redactedHeader
+}
+
+// redactBody will return the body as a string, or as bytes if it isn't text, with the values of redacted fields
+// replaced in json and form bodies
+func (r *Recorder) redactBody(contentType string, body []byte) (string, []byte) {
+	if len(body) == 0 {
+		return "", nil
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			changed := false
+			for name := range form {
+				if r.isRedactedField(name) {
+					form.Set(name, redacted)
+					changed = true
+				}
+			}
+			if changed {
+				return form.Encode(), nil
+			}
+		}
+	}
+	var value interface{}
+	if json.Unmarshal(body, &value) == nil && r.redactJSON(value) {
+		if redactedBody, err := json.Marshal(value); err == nil {
+			return string(redactedBody), nil
+		}
+	}
+	if !utf8.Valid(body) {
+		return "", body
+	}
+	return string(body), nil
+}
+
+// redactJSON will replace the values of redacted fields anywhere in a decoded json value, returning whether any were
+func (r *Recorder) redactJSON(value interface{}) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if _, ok := field.(string); ok && r.isRedactedField(name) {
+				v[name] = redacted
+				changed = true
+				continue
+			}
+			changed = r.redactJSON(field) || changed
+		}
+	case []interface{}:
+		for _, item := range v {
+			changed = r.redactJSON(item) || changed
+		}
+	}
+	return changed
+}
+
+func (r *Recorder) isRedactedField(name string) bool {
+	return contains(DefaultRedactedFields, name) || contains(r.RedactFields, name)
+}
+
+func contains(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
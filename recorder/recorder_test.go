@@ -0,0 +1,124 @@
+package recorder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testSecret = "ya29.secret-access-token"
+
+func testServer() *httptest.Server {
+	count := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": testSecret,
+			"count":        count,
+		})
+	}))
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("Got unexpected error building request: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testSecret)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Got unexpected error during recorder.RoundTrip(): %s", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Got unexpected error reading response body: %s", err)
+	}
+	return string(body)
+}
+
+func TestRecordReplay(t *testing.T) {
+	server := testServer()
+	defer server.Close()
+	filename := filepath.Join(t.TempDir(), "testdata", "golden.json")
+	rec, err := New(filename, Record)
+	if err != nil {
+		t.Fatalf("Got unexpected error during recorder.New() in record mode: %s", err)
+	}
+	client := &http.Client{Transport: rec}
+	first := get(t, client, server.URL+"/poll?key=api-key")
+	second := get(t, client, server.URL+"/poll?key=api-key")
+	if !strings.Contains(first, testSecret) || !strings.Contains(second, `"count":2`) {
+		t.Errorf("Expected the responses in record mode to be unredacted and in order, got: %s and %s", first, second)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Got unexpected error during recorder.Close(): %s", err)
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Got unexpected error reading golden file: %s", err)
+	}
+	if strings.Contains(string(content), testSecret) || strings.Contains(string(content), "api-key") {
+		t.Errorf("Expected secrets to be redacted from the golden file, got: %s", content)
+	}
+
+	rec, err = New(filename, Replay)
+	if err != nil {
+		t.Fatalf("Got unexpected error during recorder.New() in replay mode: %s", err)
+	}
+	client = &http.Client{Transport: rec}
+	replayedFirst := get(t, client, server.URL+"/poll?key=api-key")
+	replayedSecond := get(t, client, server.URL+"/poll?key=api-key")
+	if !strings.Contains(replayedFirst, `"count":1`) || !strings.Contains(replayedSecond, `"count":2`) {
+		t.Errorf("Expected repeated requests to be replayed in order, got: %s and %s", replayedFirst, replayedSecond)
+	}
+	if strings.Contains(replayedFirst, testSecret) {
+		t.Errorf("Expected the replayed response to be the redacted recording, got: %s", replayedFirst)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/poll?key=api-key", nil)
+	if _, err := rec.RoundTrip(req); err == nil {
+		t.Errorf("Expected an error from recorder.RoundTrip() once all recorded responses were replayed")
+	}
+}
+
+func TestReplayMissingGoldenFile(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), Replay); err == nil {
+		t.Errorf("Expected an error from recorder.New() in replay mode without a golden file")
+	}
+}
+
+func TestReplayMatchesBody(t *testing.T) {
+	rec := &Recorder{
+		mode: Replay,
+		interactions: []*Interaction{
+			{
+				Request:  &Request{Method: http.MethodPost, URL: "https://example.com/changes", Body: `{"a":1,"b":2}`},
+				Response: &Response{StatusCode: http.StatusOK, Body: `{}`},
+			},
+		},
+		replayed: []bool{false},
+	}
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/changes", strings.NewReader(`{"a":1,"b":3}`))
+	if _, err := rec.RoundTrip(req); err == nil {
+		t.Errorf("Expected an error from recorder.RoundTrip() for a request with a different json body")
+	}
+	req, _ = http.NewRequest(http.MethodPost, "https://example.com/changes", strings.NewReader(`{"b":2,"a":1}`))
+	resp, err := rec.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Got unexpected error during recorder.RoundTrip() for an equivalent json body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the recorded status code from recorder.RoundTrip(), got: %d", resp.StatusCode)
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	if ModeFromEnv() != Replay {
+		t.Errorf("Expected recorder.ModeFromEnv() to default to Replay")
+	}
+}
@@ -0,0 +1,157 @@
+// Package retry is the library for retrying google api calls with exponential backoff
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	googleerrors "github.com/rockholla/go-google-lib/errors"
+)
+
+// Clock is the source of time for a retry policy, replaceable so that tests don't have to actually wait
+type Clock interface {
+	Now() time.Time
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// RealClock is the default implementation for Clock, using the system time
+type RealClock struct{}
+
+// Now returns the current system time
+func (c RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep waits for the duration, or returns early with the context error if the context is done first
+func (c RealClock) Sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// Policy describes how failed calls are retried. A nil *Policy is valid and makes every call exactly once.
+type Policy struct {
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between any two attempts
+	MaxBackoff time.Duration
+	// Multiplier is how much the wait grows after each retry
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, of each wait that is randomized
+	Jitter float64
+	// MaxAttempts is the most times a call is made in total, zero meaning no limit
+	MaxAttempts int
+	// MaxElapsed is the longest time to keep retrying for, measured from the first attempt, zero meaning no limit
+	MaxElapsed time.Duration
+	// Retryable decides whether an error should be retried, errors.IsRetryable when nil
+	Retryable func(err error) bool
+	// Clock is the source of time, RealClock when nil
+	Clock Clock
+}
+
+// DefaultPolicy will return the policy used by all libraries when none is provided: up to 5 attempts over at most
+// 2 minutes, starting at 1 second between attempts and doubling up to 30 seconds, for rate limiting and
+// temporary server errors. Calls that change something only retry rate limiting, see Mutating.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxAttempts:    5,
+		MaxElapsed:     2 * time.Minute,
+	}
+}
+
+// NoRetry will return a policy that makes every call exactly once
+func NoRetry() *Policy {
+	return &Policy{MaxAttempts: 1}
+}
+
+// WithRetryable will return a copy of the policy that retries an error when either the policy's existing
+// predicate or the additional one says to
+func (p *Policy) WithRetryable(retryable func(err error) bool) *Policy {
+	if p == nil {
+		return nil
+	}
+	copied := *p
+	existing := p.retryable
+	copied.Retryable = func(err error) bool {
+		return existing(err) || retryable(err)
+	}
+	return &copied
+}
+
+// Mutating will return a copy of the policy for calls that change something, e.g. creates, deletes and updates,
+// which might not be safe to make again once the server has acted on them. Only rate limiting errors, returned
+// before the server acts, are retried, and only when the policy would retry them.
+func (p *Policy) Mutating() *Policy {
+	if p == nil {
+		return nil
+	}
+	copied := *p
+	existing := p.retryable
+	copied.Retryable = func(err error) bool {
+		return googleerrors.IsRateLimited(err) && existing(err)
+	}
+	return &copied
+}
+
+// Do will call fn until it succeeds, returns an error that isn't retryable, or the policy's attempts or
+// elapsed time are exhausted, returning the last error
+func (p *Policy) Do(ctx context.Context, fn func() error) error {
+	if p == nil {
+		return fn()
+	}
+	clock := p.clock()
+	start := clock.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || (p.MaxAttempts > 0 && attempt >= p.MaxAttempts) || !p.retryable(err) {
+			return err
+		}
+		wait := p.Backoff(attempt)
+		if p.MaxElapsed > 0 && clock.Now().Add(wait).Sub(start) > p.MaxElapsed {
+			return err
+		}
+		if sleepErr := clock.Sleep(ctx, wait); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// Backoff will return how long to wait after the given attempt, starting at 1, has failed
+func (p *Policy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		backoff = backoff*(1-jitter) + backoff*jitter*rand.Float64()
+	}
+	return time.Duration(backoff)
+}
+
+func (p *Policy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return googleerrors.IsRetryable(err)
+}
+
+func (p *Policy) clock() Clock {
+	if p.Clock != nil {
+		return p.Clock
+	}
+	return RealClock{}
+}
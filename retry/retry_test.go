@@ -0,0 +1,185 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	googleapi "google.golang.org/api/googleapi"
+)
+
+// fakeClock advances only when slept on, recording each sleep
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+var errUnavailable = &googleapi.Error{Code: http.StatusServiceUnavailable}
+
+func failing(calls *int, failures int, err error) func() error {
+	return func() error {
+		*calls++
+		if *calls <= failures {
+			return err
+		}
+		return nil
+	}
+}
+
+func TestDoSucceedsAfterRetries(t *testing.T) {
+	clock := &fakeClock{}
+	policy := &Policy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second, Multiplier: 2, MaxAttempts: 5, Clock: clock}
+	calls := 0
+	if err := policy.Do(context.Background(), failing(&calls, 3, errUnavailable)); err != nil {
+		t.Errorf("Got unexpected error during retry.Do(): %s", err)
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 calls during retry.Do(), got %d", calls)
+	}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	if len(clock.sleeps) != len(expected) {
+		t.Fatalf("Expected sleeps %v during retry.Do(), got %v", expected, clock.sleeps)
+	}
+	for i := range expected {
+		if clock.sleeps[i] != expected[i] {
+			t.Errorf("Expected sleeps %v during retry.Do(), got %v", expected, clock.sleeps)
+			break
+		}
+	}
+}
+
+func TestDoMaxAttempts(t *testing.T) {
+	policy := &Policy{InitialBackoff: time.Second, MaxAttempts: 3, Clock: &fakeClock{}}
+	calls := 0
+	if err := policy.Do(context.Background(), failing(&calls, 10, errUnavailable)); err != errUnavailable {
+		t.Errorf("Expected the last error from retry.Do() after max attempts, got: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls during retry.Do(), got %d", calls)
+	}
+}
+
+func TestDoMaxElapsed(t *testing.T) {
+	clock := &fakeClock{}
+	policy := &Policy{InitialBackoff: 10 * time.Second, Multiplier: 1, MaxElapsed: 25 * time.Second, Clock: clock}
+	calls := 0
+	if err := policy.Do(context.Background(), failing(&calls, 10, errUnavailable)); err != errUnavailable {
+		t.Errorf("Expected the last error from retry.Do() after max elapsed, got: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls during retry.Do(), got %d", calls)
+	}
+	if len(clock.sleeps) != 2 {
+		t.Errorf("Expected 2 sleeps during retry.Do(), got %v", clock.sleeps)
+	}
+}
+
+func TestDoNotRetryable(t *testing.T) {
+	clock := &fakeClock{}
+	policy := &Policy{InitialBackoff: time.Second, MaxAttempts: 5, Clock: clock}
+	notFound := &googleapi.Error{Code: http.StatusNotFound}
+	calls := 0
+	if err := policy.Do(context.Background(), failing(&calls, 10, notFound)); err != notFound {
+		t.Errorf("Expected the not found error from retry.Do(), got: %v", err)
+	}
+	if calls != 1 || len(clock.sleeps) != 0 {
+		t.Errorf("Expected a single call and no sleeps during retry.Do(), got %d calls and sleeps %v", calls, clock.sleeps)
+	}
+}
+
+func TestDoCustomRetryable(t *testing.T) {
+	custom := errors.New("custom")
+	policy := &Policy{MaxAttempts: 5, Clock: &fakeClock{}, Retryable: func(err error) bool { return err == custom }}
+	calls := 0
+	if err := policy.Do(context.Background(), failing(&calls, 2, custom)); err != nil {
+		t.Errorf("Got unexpected error during retry.Do() with a custom retryable: %s", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls during retry.Do() with a custom retryable, got %d", calls)
+	}
+}
+
+func TestDoCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	policy := &Policy{InitialBackoff: time.Second, MaxAttempts: 5, Clock: &fakeClock{}}
+	calls := 0
+	if err := policy.Do(ctx, failing(&calls, 10, errUnavailable)); err != errUnavailable {
+		t.Errorf("Expected the last error from retry.Do() with a canceled context, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call during retry.Do() with a canceled context, got %d", calls)
+	}
+}
+
+func TestDoNilPolicy(t *testing.T) {
+	var policy *Policy
+	calls := 0
+	if err := policy.Do(context.Background(), failing(&calls, 10, errUnavailable)); err != errUnavailable {
+		t.Errorf("Expected the error from retry.Do() with a nil policy, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call during retry.Do() with a nil policy, got %d", calls)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := &Policy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := policy.Backoff(i + 1); got != want {
+			t.Errorf("Expected retry.Backoff(%d) to be %s, got %s", i+1, want, got)
+		}
+	}
+	policy.Jitter = 0.5
+	for attempt := 1; attempt <= 5; attempt++ {
+		got := policy.Backoff(attempt)
+		if got < expected[attempt-1]/2 || got > expected[attempt-1] {
+			t.Errorf("Expected retry.Backoff(%d) with jitter to be between %s and %s, got %s", attempt, expected[attempt-1]/2, expected[attempt-1], got)
+		}
+	}
+}
+
+func TestWithRetryable(t *testing.T) {
+	custom := errors.New("custom")
+	policy := NoRetry().WithRetryable(func(err error) bool { return err == custom })
+	if !policy.retryable(custom) || !policy.retryable(errUnavailable) {
+		t.Errorf("Expected retry.WithRetryable() to retry both the added and the default errors")
+	}
+	if policy.retryable(errors.New("other")) {
+		t.Errorf("Expected retry.WithRetryable() not to retry other errors")
+	}
+}
+
+func TestMutating(t *testing.T) {
+	policy := DefaultPolicy().Mutating()
+	if !policy.retryable(&googleapi.Error{Code: http.StatusTooManyRequests}) {
+		t.Errorf("Expected retry.Mutating() to retry rate limiting")
+	}
+	if policy.retryable(errUnavailable) {
+		t.Errorf("Expected retry.Mutating() not to retry server errors, the server may have acted on the call")
+	}
+	never := &Policy{Retryable: func(err error) bool { return false }}
+	if never.Mutating().retryable(&googleapi.Error{Code: http.StatusTooManyRequests}) {
+		t.Errorf("Expected retry.Mutating() not to retry what the policy doesn't")
+	}
+	var none *Policy
+	if none.Mutating() != nil {
+		t.Errorf("Expected retry.Mutating() of a nil policy to be nil")
+	}
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+
+	"cloud.google.com/go/iam"
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	pb "google.golang.org/genproto/googleapis/iam/v1"
+	"google.golang.org/genproto/googleapis/type/expr"
+)
+
+// policyUpdater will return the updater for the iam policies of buckets
+func (storage *Storage) policyUpdater() *iampolicy.Updater {
+	return &iampolicy.Updater{Retry: storage.ConflictRetry, DryRun: storage.DryRun != nil}
+}
+
+// updateBucketPolicy will update the policy of the bucket with the mutations, adding the change to the policy to a
+// dry run
+func (storage *Storage) updateBucketPolicy(ctx context.Context, method string, bucket string, mutations ...iampolicy.Mutation) (*iampolicy.Result, error) {
+	result, err := storage.policyUpdater().Update(ctx, &bucketPolicy{storage: storage, bucket: bucket}, mutations...)
+	if err != nil {
+		return nil, err
+	}
+	storage.DryRun.Record(result.Changes(plan.Change{Service: service, Method: method, Resource: bucket, Action: plan.ActionUpdate})...)
+	return result, nil
+}
+
+// bucketPolicy is the iam policy of a bucket. The storage client keeps the etag of a policy it reads to itself, so
+// the policy last read is kept to be set, with its etag, in place of one without it.
+type bucketPolicy struct {
+	storage *Storage
+	bucket  string
+	read    *iam.Policy3
+}
+
+func (b *bucketPolicy) GetPolicy(ctx context.Context) (*iampolicy.Policy, error) {
+	var policy *iam.Policy3
+	err := b.storage.Telemetry.Call(ctx, service, "BucketIAMPolicy", "", b.bucket, func(ctx context.Context) error {
+		return b.storage.Retry.Do(ctx, func() (err error) {
+			policy, err = b.storage.Client.Bucket(b.bucket).IAM().V3().Policy(ctx)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.read = policy
+	result := &iampolicy.Policy{Version: iampolicy.PolicyVersion}
+	for _, binding := range policy.Bindings {
+		converted := &iampolicy.Binding{Role: binding.Role, Members: binding.Members}
+		if binding.Condition != nil {
+			converted.Condition = &iampolicy.Condition{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+		}
+		result.Bindings = append(result.Bindings, converted)
+	}
+	return result, nil
+}
+
+func (b *bucketPolicy) SetPolicy(ctx context.Context, policy *iampolicy.Policy) error {
+	if b.read == nil {
+		b.read = &iam.Policy3{}
+	}
+	b.read.Bindings = nil
+	for _, binding := range policy.Bindings {
+		converted := &pb.Binding{Role: binding.Role, Members: binding.Members}
+		if binding.Condition != nil {
+			converted.Condition = &expr.Expr{Title: binding.Condition.Title, Description: binding.Condition.Description, Expression: binding.Condition.Expression}
+		}
+		b.read.Bindings = append(b.read.Bindings, converted)
+	}
+	return b.storage.Telemetry.Call(ctx, service, "BucketIAMSetPolicy", "", b.bucket, func(ctx context.Context) error {
+		return b.storage.Retry.Mutating().Do(ctx, func() error {
+			return b.storage.Client.Bucket(b.bucket).IAM().V3().SetPolicy(ctx, b.read)
+		})
+	})
+}
@@ -6,27 +6,71 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"os"
 
-	"cloud.google.com/go/iam"
 	api "cloud.google.com/go/storage"
+	"github.com/rockholla/go-google-lib/events"
+	"github.com/rockholla/go-google-lib/iampolicy"
+	"github.com/rockholla/go-google-lib/plan"
+	"github.com/rockholla/go-google-lib/retry"
+	"github.com/rockholla/go-google-lib/telemetry"
 	"github.com/rockholla/go-lib/logger"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
+// service is the name of the library in the events it emits
+const service = "storage"
+
 // Interface represents functionality for storage
 type Interface interface {
 	Initialize(credentials string, log logger.Interface) error
+	InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error
 	EnsureBucket(name string, projectID string, attrs *api.BucketAttrs) error
+	EnsureBucketCtx(ctx context.Context, name string, projectID string, attrs *api.BucketAttrs) error
 	EnsureObject(bucket string, path string, object *Object) error
+	EnsureObjectCtx(ctx context.Context, bucket string, path string, object *Object) error
 	GetObject(bucket string, path string) ([]byte, error)
+	GetObjectCtx(ctx context.Context, bucket string, path string) ([]byte, error)
 	GetServiceAccount(projectID string) (string, error)
+	GetServiceAccountCtx(ctx context.Context, projectID string) (string, error)
 	EnsureBucketRoles(bucket string, member string, roles []string) error
+	EnsureBucketRolesCtx(ctx context.Context, bucket string, member string, roles []string) error
+	RemoveBucketRoles(bucket string, member string, roles []string) ([]string, error)
+	RemoveBucketRolesCtx(ctx context.Context, bucket string, member string, roles []string) ([]string, error)
+	RemoveBucketMember(bucket string, member string) ([]string, error)
+	RemoveBucketMemberCtx(ctx context.Context, bucket string, member string) ([]string, error)
+	Close() error
 }
 
+// emulatorHostEnv is the environment variable the underlying client uses to send requests to a storage emulator
+const emulatorHostEnv = "STORAGE_EMULATOR_HOST"
+
 // Storage wraps google-provided apis for interacting with cloud.google.com/go/storage/*
 type Storage struct {
-	log    logger.Interface
-	Client *api.Client
+	log           logger.Interface
+	Client        *api.Client
+	Retry         *retry.Policy
+	ClientOptions []option.ClientOption
+	// Endpoint overrides where api requests are sent, e.g. to a local emulator or fake
+	Endpoint string
+	// Insecure sends api requests without credentials, for an Endpoint that doesn't expect them. It's implied when
+	// STORAGE_EMULATOR_HOST is set, in which case the underlying client sends requests to the emulator.
+	Insecure bool
+	// Transport is the base http transport api requests are sent through, underneath authentication, e.g. a
+	// recorder.Recorder to record or replay them
+	Transport http.RoundTripper
+	// Events receives an event for each operation, none are emitted when nil
+	Events events.Sink
+	// Telemetry records a span and metrics for each underlying api call, nothing is recorded when nil
+	Telemetry *telemetry.Telemetry
+	// DryRun receives the changes of mutating methods instead of them being made, their reads still being made, when
+	// set
+	DryRun *plan.Plan
+	// ConflictRetry is how iam policy updates that conflict with a concurrent change to the policy are retried, from a
+	// fresh read of it, iampolicy.DefaultRetryPolicy() when not set
+	ConflictRetry *retry.Policy
 }
 
 // Object is a storage object
@@ -37,30 +81,71 @@ type Object struct {
 
 // Initialize sets up necessary google-provided sdks and other local data
 func (storage *Storage) Initialize(credentials string, log logger.Interface) error {
+	return storage.InitializeCtx(context.Background(), credentials, log)
+}
+
+// InitializeCtx is Initialize, using the provided context to construct the underlying api clients
+func (storage *Storage) InitializeCtx(ctx context.Context, credentials string, log logger.Interface) error {
 	var err error
-	ctx := context.Background()
 	storage.log = log
-	if credentials != "" {
-		if storage.Client, err = api.NewClient(ctx, option.WithCredentialsJSON([]byte(credentials))); err != nil {
-			return err
-		}
-	} else {
-		if storage.Client, err = api.NewClient(ctx); err != nil {
+	if os.Getenv(emulatorHostEnv) != "" {
+		storage.Insecure = true
+	}
+	if storage.Retry == nil {
+		storage.Retry = retry.DefaultPolicy()
+	}
+	if storage.ConflictRetry == nil {
+		storage.ConflictRetry = iampolicy.DefaultRetryPolicy()
+	}
+	clientOptions := append([]option.ClientOption{}, storage.ClientOptions...)
+	if credentials != "" && !storage.Insecure {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
+	if storage.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(storage.Endpoint))
+	}
+	if storage.Insecure {
+		clientOptions = append(clientOptions, option.WithoutAuthentication())
+	}
+	if storage.Transport != nil {
+		transport, err := htransport.NewTransport(ctx, storage.Transport, append([]option.ClientOption{option.WithScopes(api.ScopeFullControl)}, clientOptions...)...)
+		if err != nil {
 			return err
 		}
+		clientOptions = append(clientOptions, option.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	if storage.Client, err = api.NewClient(ctx, clientOptions...); err != nil {
+		return err
 	}
 	return nil
 }
 
 // EnsureBucket will make sure that a bucket with a name exists in a project
 func (storage *Storage) EnsureBucket(name string, projectID string, attrs *api.BucketAttrs) error {
-	ctx := context.Background()
+	return storage.EnsureBucketCtx(context.Background(), name, projectID, attrs)
+}
+
+// EnsureBucketCtx is EnsureBucket, using the provided context for the underlying api calls
+func (storage *Storage) EnsureBucketCtx(ctx context.Context, name string, projectID string, attrs *api.BucketAttrs) (err error) {
+	defer events.Start(storage.Events, service, "EnsureBucket", events.ActionEnsure, projectID, name).Done(&err)
 	storage.log.InfoPart("Ensuring bucket gs://%s exists in project %s...", name, projectID)
 	bucketHandle := storage.Client.Bucket(name)
-	_, err := bucketHandle.Attrs(ctx)
+	err = storage.Telemetry.Call(ctx, service, "BucketAttrs", projectID, name, func(ctx context.Context) error {
+		return storage.Retry.Do(ctx, func() error {
+			_, err := bucketHandle.Attrs(ctx)
+			return err
+		})
+	})
 	if err == api.ErrBucketNotExist {
+		if storage.DryRun.Record(plan.Change{Service: service, Method: "EnsureBucket", Project: projectID, Resource: name, Action: plan.ActionCreate, After: attrs}) {
+			storage.log.InfoPart("would be created\n")
+			return nil
+		}
 		storage.log.InfoPart("creating\n")
-		if err := bucketHandle.Create(ctx, projectID, attrs); err != nil {
+		err := storage.Telemetry.Call(ctx, service, "BucketCreate", projectID, name, func(ctx context.Context) error {
+			return storage.Retry.Mutating().Do(ctx, func() error { return bucketHandle.Create(ctx, projectID, attrs) })
+		})
+		if err != nil {
 			return fmt.Errorf("Error creating bucket: %s", err)
 		}
 	} else {
@@ -71,27 +156,47 @@ func (storage *Storage) EnsureBucket(name string, projectID string, attrs *api.B
 
 // EnsureObject will make sure that an object exists and is updated with provided data in a bucket
 func (storage *Storage) EnsureObject(bucket string, path string, object *Object) error {
-	ctx := context.Background()
-	objectWriter := storage.Client.Bucket(bucket).Object(path).NewWriter(ctx)
-	objectWriter.ContentType = object.ContentType
-	errs := ""
-	if _, err := objectWriter.Write(object.Data); err != nil {
-		errs = err.Error()
-	}
-	if err := objectWriter.Close(); err != nil {
-		errs = fmt.Sprintf("%s %s", errs, err)
-	}
-	if errs != "" {
-		return errors.New(errs)
+	return storage.EnsureObjectCtx(context.Background(), bucket, path, object)
+}
+
+// EnsureObjectCtx is EnsureObject, using the provided context for the underlying api calls
+func (storage *Storage) EnsureObjectCtx(ctx context.Context, bucket string, path string, object *Object) (err error) {
+	defer events.Start(storage.Events, service, "EnsureObject", events.ActionUpdate, "", bucket+"/"+path).Done(&err)
+	if storage.DryRun != nil {
+		return storage.planObject(ctx, bucket, path, object)
 	}
-	return nil
+	return storage.Telemetry.Call(ctx, service, "ObjectWrite", "", bucket+"/"+path, func(ctx context.Context) error {
+		objectWriter := storage.Client.Bucket(bucket).Object(path).NewWriter(ctx)
+		objectWriter.ContentType = object.ContentType
+		errs := ""
+		if _, err := objectWriter.Write(object.Data); err != nil {
+			errs = err.Error()
+		}
+		if err := objectWriter.Close(); err != nil {
+			errs = fmt.Sprintf("%s %s", errs, err)
+		}
+		if errs != "" {
+			return errors.New(errs)
+		}
+		return nil
+	})
 }
 
 // GetObject will get a storage bucket object content bytes
 func (storage *Storage) GetObject(bucket string, path string) ([]byte, error) {
-	var content []byte
-	ctx := context.Background()
-	objectReader, err := storage.Client.Bucket(bucket).Object(path).NewReader(ctx)
+	return storage.GetObjectCtx(context.Background(), bucket, path)
+}
+
+// GetObjectCtx is GetObject, using the provided context for the underlying api calls
+func (storage *Storage) GetObjectCtx(ctx context.Context, bucket string, path string) (content []byte, err error) {
+	defer events.Start(storage.Events, service, "GetObject", events.ActionRead, "", bucket+"/"+path).Done(&err)
+	var objectReader *api.Reader
+	err = storage.Telemetry.Call(ctx, service, "ObjectNewReader", "", bucket+"/"+path, func(ctx context.Context) error {
+		return storage.Retry.Do(ctx, func() (err error) {
+			objectReader, err = storage.Client.Bucket(bucket).Object(path).NewReader(ctx)
+			return err
+		})
+	})
 	if err != nil {
 		return content, err
 	}
@@ -105,25 +210,109 @@ func (storage *Storage) GetObject(bucket string, path string) ([]byte, error) {
 
 // GetServiceAccount will return the storage service account for a project
 func (storage *Storage) GetServiceAccount(projectID string) (string, error) {
-	ctx := context.Background()
-	return storage.Client.ServiceAccount(ctx, projectID)
+	return storage.GetServiceAccountCtx(context.Background(), projectID)
+}
+
+// GetServiceAccountCtx is GetServiceAccount, using the provided context for the underlying api calls
+func (storage *Storage) GetServiceAccountCtx(ctx context.Context, projectID string) (serviceAccount string, err error) {
+	defer events.Start(storage.Events, service, "GetServiceAccount", events.ActionRead, projectID, "").Done(&err)
+	err = storage.Telemetry.Call(ctx, service, "ServiceAccount", projectID, "", func(ctx context.Context) error {
+		return storage.Retry.Do(ctx, func() (err error) {
+			serviceAccount, err = storage.Client.ServiceAccount(ctx, projectID)
+			return err
+		})
+	})
+	return serviceAccount, err
 }
 
 // EnsureBucketRoles makes sure that a particular member has the supplied roles on the bucket
 func (storage *Storage) EnsureBucketRoles(bucket string, member string, roles []string) error {
-	ctx := context.Background()
+	return storage.EnsureBucketRolesCtx(context.Background(), bucket, member, roles)
+}
+
+// EnsureBucketRolesCtx is EnsureBucketRoles, using the provided context for the underlying api calls
+func (storage *Storage) EnsureBucketRolesCtx(ctx context.Context, bucket string, member string, roles []string) (err error) {
+	defer events.Start(storage.Events, service, "EnsureBucketRoles", events.ActionUpdate, "", bucket).Done(&err)
 	storage.log.Info("Ensuring member %s has roles on gs://%s:", member, bucket)
-	bucketIAMHandle := storage.Client.Bucket(bucket).IAM()
-	policy, err := bucketIAMHandle.Policy(ctx)
-	if err != nil {
-		return err
+	mutations := []iampolicy.Mutation{}
+	for _, role := range roles {
+		storage.log.ListItem(role)
+		mutations = append(mutations, iampolicy.AddMember(role, member, nil))
 	}
+	_, err = storage.updateBucketPolicy(ctx, "EnsureBucketRoles", bucket, mutations...)
+	return err
+}
+
+// RemoveBucketRoles makes sure that a particular member is removed from the supplied roles on the bucket, returning
+// the roles it was removed from
+func (storage *Storage) RemoveBucketRoles(bucket string, member string, roles []string) ([]string, error) {
+	return storage.RemoveBucketRolesCtx(context.Background(), bucket, member, roles)
+}
+
+// RemoveBucketRolesCtx is RemoveBucketRoles, using the provided context for the underlying api calls
+func (storage *Storage) RemoveBucketRolesCtx(ctx context.Context, bucket string, member string, roles []string) (removed []string, err error) {
+	defer events.Start(storage.Events, service, "RemoveBucketRoles", events.ActionUpdate, "", bucket).Done(&err)
+	storage.log.Info("Ensuring roles for member %s are removed on gs://%s:", member, bucket)
+	mutations := []iampolicy.Mutation{}
 	for _, role := range roles {
 		storage.log.ListItem(role)
-		roleName := iam.RoleName(role)
-		if !policy.HasRole(member, roleName) {
-			policy.Add(member, roleName)
-		}
+		mutations = append(mutations, iampolicy.RemoveMember(role, member, nil))
+	}
+	result, err := storage.updateBucketPolicy(ctx, "RemoveBucketRoles", bucket, mutations...)
+	if err != nil {
+		return nil, err
+	}
+	return result.Removed(member), nil
+}
+
+// RemoveBucketMember makes sure that a particular member is removed from every role on the bucket, with or without a
+// condition, returning the roles it was removed from
+func (storage *Storage) RemoveBucketMember(bucket string, member string) ([]string, error) {
+	return storage.RemoveBucketMemberCtx(context.Background(), bucket, member)
+}
+
+// RemoveBucketMemberCtx is RemoveBucketMember, using the provided context for the underlying api calls
+func (storage *Storage) RemoveBucketMemberCtx(ctx context.Context, bucket string, member string) (removed []string, err error) {
+	defer events.Start(storage.Events, service, "RemoveBucketMember", events.ActionUpdate, "", bucket).Done(&err)
+	storage.log.Info("Ensuring member %s is removed from all roles on gs://%s:", member, bucket)
+	result, err := storage.updateBucketPolicy(ctx, "RemoveBucketMember", bucket, iampolicy.RemoveMemberFromAll(member))
+	if err != nil {
+		return nil, err
+	}
+	removed = result.Removed(member)
+	for _, role := range removed {
+		storage.log.ListItem(role)
+	}
+	return removed, nil
+}
+
+// planObject will add the change EnsureObject would make to the dry run, reading the object's attributes to determine
+// whether it would be created or updated
+func (storage *Storage) planObject(ctx context.Context, bucket string, path string, object *Object) error {
+	var attrs *api.ObjectAttrs
+	err := storage.Telemetry.Call(ctx, service, "ObjectAttrs", "", bucket+"/"+path, func(ctx context.Context) error {
+		return storage.Retry.Do(ctx, func() (err error) {
+			attrs, err = storage.Client.Bucket(bucket).Object(path).Attrs(ctx)
+			return err
+		})
+	})
+	change := plan.Change{Service: service, Method: "EnsureObject", Resource: bucket + "/" + path, Action: plan.ActionUpdate, After: object}
+	switch {
+	case err == api.ErrObjectNotExist:
+		change.Action = plan.ActionCreate
+	case err != nil:
+		return err
+	default:
+		change.Before = attrs
+	}
+	storage.DryRun.Record(change)
+	return nil
+}
+
+// Close will release the underlying api client's connections
+func (storage *Storage) Close() error {
+	if storage.Client == nil {
+		return nil
 	}
-	return bucketIAMHandle.SetPolicy(ctx, policy)
+	return storage.Client.Close()
 }
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"strings"
 	"testing"
 
@@ -219,3 +220,49 @@ func TestEnsureBucketRoles(t *testing.T) {
 		t.Errorf("Got unexpected error for storage.EnsureBucketRoles(): %s", err)
 	}
 }
+
+func TestRemoveBucketMember(t *testing.T) {
+	s := &Storage{}
+	err := s.Initialize("", loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during storage.Initialize() with blank credentials: %s", err)
+	}
+	mt := &mockTransport{}
+	iamJSON := `{
+  "kind": "storage#policy",
+  "resourceId": "projects/test-project/buckets/test-bucket",
+  "version": 1,
+  "etag": "CAE=",
+  "bindings": [
+    {"role": "roles/storage.objectViewer", "members": ["serviceAccount:test-sa@go-google-lib.tests", "user:other@go-google-lib.tests"]},
+    {"role": "roles/storage.objectAdmin", "members": ["serviceAccount:test-sa@go-google-lib.tests"]}
+  ]
+}`
+	mt.addResult(&http.Response{StatusCode: 200, Body: bodyReader(iamJSON)}, nil)
+	mt.addResult(&http.Response{StatusCode: 200, Body: bodyReader("{}")}, nil)
+	s.Client = mockClient(t, mt)
+	removed, err := s.RemoveBucketMember("test-bucket", "serviceAccount:test-sa@go-google-lib.tests")
+	if err != nil {
+		t.Fatalf("Got unexpected error for storage.RemoveBucketMember(): %s", err)
+	}
+	if len(removed) != 2 || removed[0] != "roles/storage.objectAdmin" || removed[1] != "roles/storage.objectViewer" {
+		t.Errorf("Expected storage.RemoveBucketMember() to report the roles the member was removed from, got %v", removed)
+	}
+	body := mt.gotJSONBody()
+	if bindings, ok := body["bindings"].([]interface{}); !ok || len(bindings) != 1 || body["etag"] != "CAE=" {
+		t.Errorf("Expected storage.RemoveBucketMember() to set the policy with only the other member's binding and the etag it was read with, got %v", body)
+	}
+}
+
+func TestInitializeEmulatorHost(t *testing.T) {
+	os.Setenv("STORAGE_EMULATOR_HOST", "localhost:9000")
+	defer os.Unsetenv("STORAGE_EMULATOR_HOST")
+	s := &Storage{}
+	err := s.Initialize(testCredentials, loggermock.GetLogMock())
+	if err != nil {
+		t.Errorf("Got unexpected error during storage.Initialize() with an emulator host: %s", err)
+	}
+	if !s.Insecure {
+		t.Errorf("Expected storage.Initialize() with an emulator host to send requests without credentials")
+	}
+}
@@ -0,0 +1,128 @@
+// Package telemetry is the library for tracing and metrics of the underlying google api calls, each call producing
+// an OpenTelemetry span and counting toward call, error and latency metrics. Without providers, nothing is recorded.
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/unit"
+)
+
+// InstrumentationName is the name of the tracer and meter the spans and metrics are recorded with
+const InstrumentationName = "github.com/rockholla/go-google-lib"
+
+// Names of the metrics recorded for calls
+const (
+	MetricCalls   = "google.api.calls"
+	MetricErrors  = "google.api.errors"
+	MetricLatency = "google.api.latency"
+)
+
+// Attributes of the spans and metrics, metrics only have the service and method
+const (
+	AttributeService  = attribute.Key("google.service")
+	AttributeMethod   = attribute.Key("google.method")
+	AttributeProject  = attribute.Key("google.project")
+	AttributeResource = attribute.Key("google.resource")
+)
+
+// Telemetry records a span and metrics for each api call. A nil Telemetry records nothing, so it's safe to use
+// without providers, and it's safe for concurrent use.
+type Telemetry struct {
+	tracer  trace.Tracer
+	calls   metric.Int64Counter
+	errors  metric.Int64Counter
+	latency metric.Float64ValueRecorder
+}
+
+// New will return telemetry recording to the providers, either of which can be nil to record no spans or no
+// metrics
+func New(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*Telemetry, error) {
+	if tracerProvider == nil {
+		tracerProvider = trace.NewNoopTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = metric.NoopMeterProvider{}
+	}
+	t := &Telemetry{tracer: tracerProvider.Tracer(InstrumentationName)}
+	meter := meterProvider.Meter(InstrumentationName)
+	var err error
+	if t.calls, err = meter.NewInt64Counter(MetricCalls, metric.WithDescription("Number of google api calls")); err != nil {
+		return nil, err
+	}
+	if t.errors, err = meter.NewInt64Counter(MetricErrors, metric.WithDescription("Number of google api calls that failed")); err != nil {
+		return nil, err
+	}
+	if t.latency, err = meter.NewFloat64ValueRecorder(MetricLatency,
+		metric.WithDescription("Latency of google api calls, including retries"),
+		metric.WithUnit(unit.Milliseconds),
+	); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Call will call fn, with the context of a span for the call of the service's method on the resource in the
+// project, recording the span and metrics once it returns
+func (t *Telemetry) Call(ctx context.Context, service string, method string, project string, resource string, fn func(ctx context.Context) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if t == nil {
+		return fn(ctx)
+	}
+	labels := []attribute.KeyValue{AttributeService.String(service), AttributeMethod.String(method)}
+	attributes := append([]attribute.KeyValue{}, labels...)
+	if project != "" {
+		attributes = append(attributes, AttributeProject.String(project))
+	}
+	if resource != "" {
+		attributes = append(attributes, AttributeResource.String(resource))
+	}
+	ctx, span := t.tracer.Start(ctx, service+"."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+	start := time.Now()
+	err := fn(ctx)
+	t.latency.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), labels...)
+	t.calls.Add(ctx, 1, labels...)
+	if err != nil {
+		t.errors.Add(ctx, 1, labels...)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
+
+// Do is Call for the default implementations of the Calls of each library, taking the project and resource from
+// the context, where they're put with WithResource
+func (t *Telemetry) Do(ctx context.Context, service string, method string, fn func(ctx context.Context) error) error {
+	target := resourceFrom(ctx)
+	return t.Call(ctx, service, method, target.project, target.resource, fn)
+}
+
+// WithResource will return a copy of the context carrying the project and resource of an api call, for Calls to
+// record their span with, since those are otherwise unknown to them
+func WithResource(ctx context.Context, project string, resource string) context.Context {
+	return context.WithValue(ctx, resourceKey{}, resourceValue{project: project, resource: resource})
+}
+
+type resourceKey struct{}
+
+type resourceValue struct {
+	project  string
+	resource string
+}
+
+// resourceFrom will return the project and resource put in the context with WithResource, empty when there are none
+func resourceFrom(ctx context.Context) resourceValue {
+	if ctx == nil {
+		return resourceValue{}
+	}
+	value, _ := ctx.Value(resourceKey{}).(resourceValue)
+	return value
+}
@@ -0,0 +1,123 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTelemetry(t *testing.T) (*Telemetry, *oteltest.SpanRecorder, *oteltest.MeterImpl) {
+	spanRecorder := &oteltest.SpanRecorder{}
+	meter, meterProvider := oteltest.NewMeterProvider()
+	tel, err := New(oteltest.NewTracerProvider(oteltest.WithSpanRecorder(spanRecorder)), meterProvider)
+	if err != nil {
+		t.Fatalf("Got unexpected error during telemetry.New(): %s", err)
+	}
+	return tel, spanRecorder, meter
+}
+
+func TestCall(t *testing.T) {
+	tel, spanRecorder, meter := newTestTelemetry(t)
+	err := tel.Call(context.Background(), "dns", "ChangesCreate", "project-1", "zone-1", func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Got unexpected error during telemetry.Call(): %s", err)
+	}
+	spans := spanRecorder.Completed()
+	if len(spans) != 1 {
+		t.Fatalf("Expected a single span from telemetry.Call(), got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "dns.ChangesCreate" {
+		t.Errorf("Got unexpected span name from telemetry.Call(): %s", span.Name())
+	}
+	attributes := span.Attributes()
+	for key, expected := range map[attribute.Key]string{
+		AttributeService:  "dns",
+		AttributeMethod:   "ChangesCreate",
+		AttributeProject:  "project-1",
+		AttributeResource: "zone-1",
+	} {
+		if value := attributes[key].AsString(); value != expected {
+			t.Errorf("Expected span attribute %s of %q from telemetry.Call(), got %q", key, expected, value)
+		}
+	}
+	if span.StatusCode() == codes.Error {
+		t.Errorf("Expected span from a successful telemetry.Call() to not have an error status")
+	}
+	measured := map[string]int{}
+	for _, measurement := range oteltest.AsStructs(meter.MeasurementBatches) {
+		measured[measurement.Name]++
+		if measurement.Labels[AttributeService].AsString() != "dns" || measurement.Labels[AttributeMethod].AsString() != "ChangesCreate" {
+			t.Errorf("Got unexpected labels for metric %s from telemetry.Call(): %v", measurement.Name, measurement.Labels)
+		}
+		if _, ok := measurement.Labels[AttributeResource]; ok {
+			t.Errorf("Expected no resource label for metric %s from telemetry.Call()", measurement.Name)
+		}
+	}
+	if measured[MetricCalls] != 1 || measured[MetricLatency] != 1 || measured[MetricErrors] != 0 {
+		t.Errorf("Got unexpected metrics from a successful telemetry.Call(): %v", measured)
+	}
+}
+
+func TestCallError(t *testing.T) {
+	tel, spanRecorder, meter := newTestTelemetry(t)
+	expected := errors.New("call failed")
+	err := tel.Call(context.Background(), "dns", "ChangesCreate", "", "", func(ctx context.Context) error {
+		return expected
+	})
+	if err != expected {
+		t.Errorf("Expected telemetry.Call() to return the error of the call, got: %v", err)
+	}
+	spans := spanRecorder.Completed()
+	if len(spans) != 1 || spans[0].StatusCode() != codes.Error || spans[0].StatusMessage() != expected.Error() {
+		t.Errorf("Expected a single span with an error status from a failed telemetry.Call()")
+	}
+	measured := map[string]int{}
+	for _, measurement := range oteltest.AsStructs(meter.MeasurementBatches) {
+		measured[measurement.Name]++
+	}
+	if measured[MetricCalls] != 1 || measured[MetricErrors] != 1 {
+		t.Errorf("Got unexpected metrics from a failed telemetry.Call(): %v", measured)
+	}
+}
+
+func TestCallNil(t *testing.T) {
+	var tel *Telemetry
+	called := false
+	err := tel.Call(nil, "dns", "ChangesCreate", "", "", func(ctx context.Context) error {
+		called = ctx != nil
+		return nil
+	})
+	if err != nil || !called {
+		t.Errorf("Expected a nil telemetry.Call() to call through with a context, got error: %v", err)
+	}
+}
+
+func TestDo(t *testing.T) {
+	tel, spanRecorder, _ := newTestTelemetry(t)
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	err := tel.Do(WithResource(ctx, "project-1", "zone-1"), "dns", "ChangesCreate", func(ctx context.Context) error {
+		if ctx.Value(key{}) != "value" {
+			t.Errorf("Expected telemetry.Do() to call with a context derived from the one provided")
+		}
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			t.Errorf("Expected telemetry.Do() to call with the context of its span, for the api request")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Got unexpected error during telemetry.Do(): %s", err)
+	}
+	spans := spanRecorder.Completed()
+	if len(spans) != 1 || spans[0].Attributes()[AttributeResource].AsString() != "zone-1" {
+		t.Errorf("Expected a single span for the resource of the context from telemetry.Do()")
+	}
+}